@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/internal/alerts"
+)
+
+var alertsStateFile string
+
+func init() {
+	alertsCmd.Flags().StringVar(&alertsStateFile, "state", "", "State file for cooldowns, overrides state_file in the config")
+	rootCmd.AddCommand(alertsCmd)
+}
+
+var alertsCmd = &cobra.Command{
+	Use:   "alerts CONFIG",
+	Short: "Run the price alert daemon",
+	Long: `Load alert rules from a YAML config and monitor prices via the live stream,
+falling back to polling. When a rule triggers, its webhook (Slack, Discord,
+or generic JSON), email, or shell command actions run. A rule fires when its
+condition starts to hold, and with a cooldown again each time it elapses
+while the condition holds. Last-fired times are persisted so restarts don't
+re-alert within a cooldown.
+
+Example config:
+
+  interval: 30s
+  state_file: ~/.config/gotick/alerts-state.json
+  rules:
+    - name: aapl-200
+      symbol: AAPL
+      condition: price_above   # price_below, change_percent_above, change_percent_below
+      value: 200
+      cooldown: 4h
+      actions:
+        - type: webhook
+          format: slack
+          url: https://hooks.slack.com/services/...
+        - type: exec
+          command: notify-send "gotick" "$GOTICK_MESSAGE"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := alerts.LoadConfig(args[0])
+		if err != nil {
+			return err
+		}
+		if alertsStateFile != "" {
+			cfg.StateFile = alertsStateFile
+		}
+		cfg.StateFile = expandHome(cfg.StateFile)
+
+		logger := log.New(cmd.ErrOrStderr(), "", log.LstdFlags)
+		return alerts.Run(cmd.Context(), cfg, logger)
+	},
+}
+
+// expandHome replaces a leading ~/ in path with the user's home directory
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
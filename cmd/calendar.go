@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var (
+	calendarFrom      string
+	calendarTo        string
+	calendarDays      int
+	calendarWatchlist string
+	calendarSymbols   []string
+	calendarSize      int
+)
+
+func init() {
+	calendarCmd.Flags().StringVar(&calendarFrom, "from", "", "Start date (YYYY-MM-DD), defaults to today")
+	calendarCmd.Flags().StringVar(&calendarTo, "to", "", "End date (YYYY-MM-DD), overrides --days")
+	calendarCmd.Flags().IntVarP(&calendarDays, "days", "d", 7, "Number of days from the start date")
+	calendarCmd.Flags().StringVarP(&calendarWatchlist, "watchlist", "w", "", "Only show symbols listed in this file (one per line, # for comments)")
+	calendarCmd.Flags().StringSliceVarP(&calendarSymbols, "symbols", "s", nil, "Only show these symbols (comma-separated)")
+	calendarCmd.Flags().IntVarP(&calendarSize, "size", "n", 100, "Maximum number of events to request")
+	rootCmd.AddCommand(calendarCmd)
+}
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar earnings|ipo|splits|dividends",
+	Short: "Print earnings, IPO, split, or dividend calendars",
+	Long: `Print upcoming corporate events for a date range.
+
+Earnings, IPO, and split calendars cover the whole market and can be narrowed
+with --watchlist or --symbols. Yahoo has no market-wide dividend calendar, so
+the dividends calendar requires a watchlist or symbols.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"earnings", "ipo", "splits", "dividends"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := buildCalendarParams(calendarFrom, calendarTo, calendarDays)
+		if err != nil {
+			return err
+		}
+		params.Size = calendarSize
+
+		watch, err := collectSymbols(calendarSymbols, calendarWatchlist)
+		if err != nil {
+			return err
+		}
+		inWatchlist := func(sym string) bool {
+			if len(watch) == 0 {
+				return true
+			}
+			for _, w := range watch {
+				if w == sym {
+					return true
+				}
+			}
+			return false
+		}
+
+		ctx := cmd.Context()
+		switch args[0] {
+		case "earnings":
+			all, err := yfinance.GetEarningsCalendar(ctx, params)
+			if err != nil {
+				return err
+			}
+			events := filterEvents(all, func(e yfinance.EarningsEvent) bool { return inWatchlist(e.Symbol) })
+			return writeList(cmd, events, earningsColumns, nil)
+		case "ipo":
+			all, err := yfinance.GetIPOCalendar(ctx, params)
+			if err != nil {
+				return err
+			}
+			events := filterEvents(all, func(e yfinance.IPOEvent) bool { return inWatchlist(e.Symbol) })
+			return writeList(cmd, events, ipoColumns, nil)
+		case "splits":
+			all, err := yfinance.GetSplitsCalendar(ctx, params)
+			if err != nil {
+				return err
+			}
+			events := filterEvents(all, func(e yfinance.SplitEvent) bool { return inWatchlist(e.Symbol) })
+			return writeList(cmd, events, splitColumns, nil)
+		case "dividends":
+			if len(watch) == 0 {
+				return fmt.Errorf("the dividends calendar requires --watchlist or --symbols")
+			}
+			events, err := yfinance.GetDividendsCalendar(ctx, watch, params)
+			if err != nil {
+				return err
+			}
+			return writeList(cmd, events, dividendColumns, nil)
+		default:
+			return fmt.Errorf("unknown calendar %q (want earnings, ipo, splits or dividends)", args[0])
+		}
+	},
+}
+
+// filterEvents returns the events for which keep reports true
+func filterEvents[T any](events []T, keep func(T) bool) []T {
+	result := make([]T, 0, len(events))
+	for _, e := range events {
+		if keep(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// buildCalendarParams converts date flags into CalendarParams
+func buildCalendarParams(from, to string, days int) (yfinance.CalendarParams, error) {
+	params := yfinance.CalendarParams{Start: time.Now()}
+	if from != "" {
+		t, err := time.Parse(time.DateOnly, from)
+		if err != nil {
+			return params, fmt.Errorf("invalid --from date %q: %w", from, err)
+		}
+		params.Start = t
+	}
+
+	if to != "" {
+		t, err := time.Parse(time.DateOnly, to)
+		if err != nil {
+			return params, fmt.Errorf("invalid --to date %q: %w", to, err)
+		}
+		params.End = t
+	} else {
+		if days <= 0 {
+			return params, fmt.Errorf("--days must be positive")
+		}
+		params.End = params.Start.AddDate(0, 0, days)
+	}
+
+	if params.End.Before(params.Start) {
+		return params, fmt.Errorf("--to must not be before --from")
+	}
+	return params, nil
+}
+
+var earningsColumns = []column[yfinance.EarningsEvent]{
+	{Header: "DATE", Left: true, Value: func(e yfinance.EarningsEvent) any { return formatDate(e.EarningsDate) }},
+	{Header: "SYMBOL", Left: true, Value: func(e yfinance.EarningsEvent) any { return e.Symbol }},
+	{Header: "COMPANY", Left: true, Value: func(e yfinance.EarningsEvent) any { return e.CompanyShortName }},
+	{Header: "EPS ESTIMATE", Value: func(e yfinance.EarningsEvent) any { return optionalValue(e.EpsEstimate, e.EpsEstimate) },
+		Text: func(e yfinance.EarningsEvent) string { return formatOptionalPrice(e.EpsEstimate) }},
+}
+
+var ipoColumns = []column[yfinance.IPOEvent]{
+	{Header: "DATE", Left: true, Value: func(e yfinance.IPOEvent) any { return formatDate(e.PricingDate) }},
+	{Header: "SYMBOL", Left: true, Value: func(e yfinance.IPOEvent) any { return e.Symbol }},
+	{Header: "COMPANY", Left: true, Value: func(e yfinance.IPOEvent) any { return e.CompanyName }},
+	{Header: "EXCHANGE", Left: true, Value: func(e yfinance.IPOEvent) any { return e.Exchange }},
+}
+
+var splitColumns = []column[yfinance.SplitEvent]{
+	{Header: "DATE", Left: true, Value: func(e yfinance.SplitEvent) any { return formatDate(e.SplitDate) }},
+	{Header: "SYMBOL", Left: true, Value: func(e yfinance.SplitEvent) any { return e.Symbol }},
+	{Header: "RATIO", Left: true, Value: func(e yfinance.SplitEvent) any { return e.SplitRatio }},
+}
+
+var dividendColumns = []column[yfinance.DividendEvent]{
+	{Header: "EX-DATE", Left: true, Value: func(e yfinance.DividendEvent) any { return formatDate(e.ExDividendDate) }},
+	{Header: "SYMBOL", Left: true, Value: func(e yfinance.DividendEvent) any { return e.Symbol }},
+	{Header: "PAY DATE", Left: true, Value: func(e yfinance.DividendEvent) any { return formatDate(e.DividendDate) }},
+}
+
+// formatDate renders a unix timestamp as a date, or "-" when unset
+func formatDate(ts int64) string {
+	if ts == 0 {
+		return "-"
+	}
+	return time.Unix(ts, 0).UTC().Format(time.DateOnly)
+}
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+	"github.com/amjadjibon/gotick/pkg/yfinance/columnar"
+)
+
+// Columnar file formats of the download command
+const (
+	formatParquet = "parquet"
+	formatArrow   = "arrow"
+)
+
+var (
+	downloadFile      string
+	downloadIndex     string
+	downloadOutputDir string
+	downloadFormat    string
+	downloadPeriod    string
+	downloadInterval  string
+	downloadStart     string
+	downloadEnd       string
+	downloadThreads   int
+	downloadActions   bool
+	downloadQuiet     bool
+	downloadStore     string
+	downloadRetries   int
+	downloadFailFast  bool
+)
+
+func init() {
+	downloadCmd.Flags().StringVar(&downloadFile, "file", "", "Read symbols from a file (one per line, # for comments)")
+	downloadCmd.Flags().StringVar(&downloadIndex, "index", "", "Add the members of an index: sp500, nasdaq100, or dow")
+	downloadCmd.Flags().StringVarP(&downloadOutputDir, "output-dir", "d", ".", "Directory for per-symbol output files")
+	downloadCmd.Flags().StringVarP(&downloadFormat, "format", "f", "csv", "File format: csv, json, parquet or arrow (--output is accepted as an alias)")
+	downloadCmd.Flags().StringVarP(&downloadPeriod, "period", "p", "1y", "History period (e.g. 5d, 1mo, 1y, max)")
+	downloadCmd.Flags().StringVarP(&downloadInterval, "interval", "i", "1d", "Bar interval (e.g. 1m, 1h, 1d, 1wk)")
+	downloadCmd.Flags().StringVar(&downloadStart, "start", "", "Start date (YYYY-MM-DD), overrides --period")
+	downloadCmd.Flags().StringVar(&downloadEnd, "end", "", "End date (YYYY-MM-DD), defaults to now when --start is set")
+	downloadCmd.Flags().IntVarP(&downloadThreads, "threads", "t", 5, "Number of concurrent downloads")
+	downloadCmd.Flags().BoolVar(&downloadActions, "actions", false, "Include dividend and split events")
+	downloadCmd.Flags().BoolVarP(&downloadQuiet, "quiet", "q", false, "Disable the progress bar")
+	downloadCmd.Flags().IntVar(&downloadRetries, "retries", 0, "Attempt each failed symbol this many more times")
+	downloadCmd.Flags().BoolVar(&downloadFailFast, "fail-fast", false, "Stop at the first symbol that fails")
+	downloadCmd.Flags().StringVar(&downloadStore, "store", "", "Keep bars in a Parquet directory and fetch only those missing from it")
+	rootCmd.AddCommand(downloadCmd)
+}
+
+var downloadCmd = &cobra.Command{
+	Use:   "download [SYMBOL...]",
+	Short: "Backfill history for many symbols into files",
+	Long: `Download historical OHLCV data for a list of symbols concurrently and write
+one file per symbol into an output directory. Symbols can be passed as
+arguments, read from --file, taken from an index's members with --index,
+or any mix of these. Parquet and Arrow files share one schema with symbol
+and interval columns, so they can be queried together, e.g.
+SELECT * FROM 'dir/*.parquet' in DuckDB.
+
+With --store, bars are also kept in a Parquet file per symbol in that
+directory, and later runs fetch only the bars after the newest stored one.
+--period, --start, and --end then set what is backfilled for new symbols,
+and --start and --end which stored bars are written.
+
+Failed symbols are reported at the end and cause a non-zero exit status;
+successfully downloaded symbols are still written. --retries attempts each
+failed symbol again, unless it is unknown or has no data, and --fail-fast
+stops at the first symbol that still fails.
+
+--format sets the file format. The global --output may be used instead,
+e.g. -o parquet; giving both with different formats is an error.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if downloadIndex != "" {
+			members, err := yfinance.GetIndexConstituents(cmd.Context(), downloadIndex)
+			if err != nil {
+				return err
+			}
+			args = append(args, members...)
+		}
+		symbols, err := collectSymbols(args, downloadFile)
+		if err != nil {
+			return err
+		}
+		if len(symbols) == 0 {
+			return fmt.Errorf("no symbols given (pass them as arguments, with --file, or with --index)")
+		}
+		format, err := downloadFileFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		hp, err := buildHistoryParams(downloadPeriod, downloadInterval, downloadStart, downloadEnd)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(downloadOutputDir, 0o755); err != nil { //nolint:gosec // G301: output directory is user-visible data
+			return err
+		}
+
+		params := yfinance.DownloadParams{
+			Period:   hp.Period,
+			Interval: hp.Interval,
+			Start:    hp.Start,
+			End:      hp.End,
+			Actions:  downloadActions,
+
+			MaxRetriesPerSymbol: downloadRetries,
+			FailFast:            downloadFailFast,
+		}
+
+		download := yfinance.Download
+		if downloadStore != "" {
+			store, err := columnar.NewParquetStore(expandHome(downloadStore))
+			if err != nil {
+				return err
+			}
+			download = func(ctx context.Context, params yfinance.DownloadParams) (*yfinance.DownloadResult, error) {
+				return yfinance.DownloadIncremental(ctx, store, params)
+			}
+		}
+
+		var bar *progressBar
+		if !downloadQuiet {
+			bar = newProgressBar(cmd.ErrOrStderr(), len(symbols))
+		}
+
+		failed := runDownloads(cmd.Context(), download, symbols, params, downloadThreads, func(sym string, data *yfinance.ChartData) error {
+			path := filepath.Join(downloadOutputDir, sym+"."+format)
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = f.Close() }()
+			switch format {
+			case formatParquet:
+				return columnar.WriteParquet(f, data)
+			case formatArrow:
+				return columnar.WriteArrow(f, data)
+			}
+			return writeHistory(f, data, format)
+		}, bar)
+
+		if bar != nil {
+			bar.Finish()
+		}
+
+		if len(failed) > 0 {
+			names := make([]string, 0, len(failed))
+			for sym := range failed {
+				names = append(names, sym)
+			}
+			sort.Strings(names)
+			for _, sym := range names {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", sym, failed[sym])
+			}
+			return fmt.Errorf("%d of %d symbols failed", len(failed), len(symbols))
+		}
+		return nil
+	},
+}
+
+// downloadFileFormat returns the file format chosen by --format, or by the
+// global --output when only that was given. The configured output format is
+// not used, since it names a terminal format such as table.
+func downloadFileFormat(cmd *cobra.Command) (string, error) {
+	format := downloadFormat
+	if cmd.Flags().Changed("output") {
+		if cmd.Flags().Changed("format") && outputFormat != downloadFormat {
+			return "", fmt.Errorf("--format %q and --output %q disagree; give only one", downloadFormat, outputFormat)
+		}
+		format = outputFormat
+	}
+	switch format {
+	case outputCSV, outputJSON, formatParquet, formatArrow:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q (want csv, json, parquet or arrow)", format)
+	}
+}
+
+// runDownloads downloads the symbols with download, at most threads in
+// flight, calling write for every successful result as it arrives. It
+// returns the per-symbol failures.
+func runDownloads(ctx context.Context, download func(context.Context, yfinance.DownloadParams) (*yfinance.DownloadResult, error), symbols []string, params yfinance.DownloadParams, threads int,
+	write func(string, *yfinance.ChartData) error, bar *progressBar,
+) map[string]error {
+	if threads <= 0 {
+		threads = 1
+	}
+
+	results := make(chan yfinance.SymbolResult)
+	params.Symbols, params.Threads, params.Results = symbols, threads, results
+
+	failed := make(map[string]error)
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		for res := range results {
+			err := res.Err
+			if err == nil {
+				err = write(res.Symbol, res.Data)
+			}
+			if err != nil {
+				failed[res.Symbol] = err
+			}
+			if bar != nil {
+				bar.Increment(res.Symbol)
+			}
+		}
+	}()
+
+	if res, err := download(ctx, params); res == nil {
+		// Results is not closed when the download fails to start
+		close(results)
+		<-written
+		for _, sym := range symbols {
+			failed[sym] = err
+		}
+		return failed
+	}
+	<-written
+	return failed
+}
+
+// collectSymbols merges symbols from arguments and an optional file,
+// upper-casing them and dropping duplicates while preserving order
+func collectSymbols(args []string, file string) ([]string, error) {
+	raw := append([]string{}, args...)
+
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if i := strings.IndexByte(line, '#'); i >= 0 {
+				line = line[:i]
+			}
+			raw = append(raw, strings.FieldsFunc(line, func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			})...)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool, len(raw))
+	symbols := make([]string, 0, len(raw))
+	for _, s := range raw {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		symbols = append(symbols, s)
+	}
+	return symbols, nil
+}
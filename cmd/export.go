@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/internal/exporter"
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+	"github.com/amjadjibon/gotick/pkg/yfinance/metrics"
+)
+
+var (
+	exportListen      string
+	exportSymbols     []string
+	exportFile        string
+	exportMinInterval time.Duration
+	exportStream      bool
+)
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportListen, "listen", "l", ":9100", "Address to serve /metrics on")
+	exportCmd.Flags().StringSliceVarP(&exportSymbols, "symbols", "s", nil, "Symbols to export (comma-separated)")
+	exportCmd.Flags().StringVar(&exportFile, "file", "", "Read symbols from a file (one per line, # for comments)")
+	exportCmd.Flags().DurationVar(&exportMinInterval, "min-interval", 15*time.Second, "Minimum time between upstream quote fetches")
+	exportCmd.Flags().BoolVar(&exportStream, "stream", false, "Keep prices current from the live stream instead of fetching on scrape")
+	rootCmd.AddCommand(exportCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Serve quote metrics for Prometheus",
+	Long: `Expose price, change, volume, market cap, and market state for a list of
+symbols as Prometheus gauges on /metrics. Quotes are refreshed when scraped
+(at most once per --min-interval), or continuously from the live stream with
+--stream.
+
+Yahoo Finance client metrics are served too: request counts and latency by
+endpoint, cache lookups, rate limiter waits, and stream messages
+(gotick_yfinance_*).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		symbols, err := collectSymbols(exportSymbols, exportFile)
+		if err != nil {
+			return err
+		}
+		if len(symbols) == 0 {
+			symbols = settings.Symbols
+		}
+		if len(symbols) == 0 {
+			return fmt.Errorf("no symbols given (use --symbols or --file, or configure symbols)")
+		}
+
+		logger := log.New(cmd.ErrOrStderr(), "", log.LstdFlags)
+		collector := exporter.NewCollector(symbols, exportMinInterval, logger)
+
+		// Report the health of the Yahoo client alongside the quotes
+		clientMetrics := metrics.NewCollector("gotick")
+		if err := installClient(append(clientOptions(), yfinance.WithObserver(clientMetrics))...); err != nil {
+			return err
+		}
+
+		registry := prometheus.NewRegistry()
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+		if err := registry.Register(clientMetrics); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		if exportStream {
+			go func() {
+				if err := collector.Stream(ctx); err != nil {
+					logger.Printf("exporter: stream stopped, falling back to polling: %v", err)
+				}
+			}()
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		srv := &http.Server{
+			Addr:              exportListen,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			<-ctx.Done()
+			_ = srv.Close()
+		}()
+
+		logger.Printf("serving metrics for %d symbols on %s/metrics", len(symbols), exportListen)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var (
+	historyPeriod   string
+	historyInterval string
+	historyStart    string
+	historyEnd      string
+	historyOutFile  string
+	historyPrePost  bool
+	historyTimezone string
+	historyAdjust   bool
+	historyBack     bool
+	historyRepair   bool
+	historySessions []string
+)
+
+func init() {
+	historyCmd.Flags().StringVarP(&historyPeriod, "period", "p", "1mo", "History period (e.g. 5d, 1mo, 1y, max)")
+	historyCmd.Flags().StringVarP(&historyInterval, "interval", "i", "1d", "Bar interval (e.g. 1m, 1h, 1d, 1wk)")
+	historyCmd.Flags().StringVar(&historyStart, "start", "", "Start date (YYYY-MM-DD), overrides --period")
+	historyCmd.Flags().StringVar(&historyEnd, "end", "", "End date (YYYY-MM-DD), defaults to now when --start is set")
+	historyCmd.Flags().StringVar(&historyOutFile, "out-file", "", "Write to file instead of stdout")
+	historyCmd.Flags().BoolVar(&historyPrePost, "prepost", false, "Include pre/post market bars")
+	historyCmd.Flags().BoolVar(&historyAdjust, "auto-adjust", false, "Adjust all prices for splits and dividends")
+	historyCmd.Flags().BoolVar(&historyBack, "back-adjust", false, "Adjust open, high, and low but keep the traded close")
+	historyCmd.Flags().BoolVar(&historyRepair, "repair", false, "Fix 100x-off prices, duplicate and empty bars, and missing adjusted closes")
+	historyCmd.Flags().StringSliceVar(&historySessions, "session", nil, "Keep only intraday bars of these sessions (pre, regular, post)")
+	historyCmd.Flags().StringVar(&historyTimezone, "timezone", "UTC", `Timezone of timestamps (IANA name, or "exchange" for the exchange's own)`)
+	rootCmd.AddCommand(historyCmd)
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history SYMBOL",
+	Short: "Fetch OHLCV history for a symbol",
+	Long: `Fetch historical OHLCV bars for a symbol and write them to stdout or a file.
+Bars are written as CSV unless --output selects another format.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := buildHistoryParams(historyPeriod, historyInterval, historyStart, historyEnd)
+		if err != nil {
+			return err
+		}
+		params.PrePost = historyPrePost
+		params.AutoAdjust, params.BackAdjust = historyAdjust, historyBack
+		params.Repair = historyRepair
+		for _, s := range historySessions {
+			params.Sessions = append(params.Sessions, yfinance.MarketSession(s))
+		}
+		if historyTimezone != "exchange" {
+			params.Timezone = historyTimezone
+		}
+		if err := params.Validate(); err != nil {
+			return err
+		}
+		format, err := resolveOutput(cmd, outputCSV)
+		if err != nil {
+			return err
+		}
+
+		ticker, err := yfinance.NewTicker(args[0])
+		if err != nil {
+			return err
+		}
+
+		data, err := ticker.History(cmd.Context(), params)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if historyOutFile != "" {
+			f, err := os.Create(historyOutFile)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = f.Close() }()
+			out = f
+		}
+
+		return writeHistory(out, data, format)
+	},
+}
+
+// buildHistoryParams converts command-line flags into validated HistoryParams
+func buildHistoryParams(period, interval, start, end string) (yfinance.HistoryParams, error) {
+	params := yfinance.HistoryParams{
+		Period:   yfinance.Period(period),
+		Interval: yfinance.Interval(interval),
+	}
+
+	if start != "" {
+		t, err := time.Parse(time.DateOnly, start)
+		if err != nil {
+			return params, fmt.Errorf("invalid --start date %q: %w", start, err)
+		}
+		params.Start = t
+		params.End = time.Now()
+	}
+	if end != "" {
+		if start == "" {
+			return params, fmt.Errorf("--end requires --start")
+		}
+		t, err := time.Parse(time.DateOnly, end)
+		if err != nil {
+			return params, fmt.Errorf("invalid --end date %q: %w", end, err)
+		}
+		params.End = t
+	}
+
+	return params, params.Validate()
+}
+
+// writeHistory writes chart data in the requested output format. JSON
+// includes the chart metadata; the other formats list the bars.
+func writeHistory(w io.Writer, data *yfinance.ChartData, format string) error {
+	return newListWriter(w, format, barColumns).Write(data.Bars, data)
+}
+
+// barColumns are the fields printed for OHLCV bars
+var barColumns = []column[yfinance.Bar]{
+	{Header: "TIMESTAMP", Left: true, Value: func(b yfinance.Bar) any { return b.Timestamp.Format(time.RFC3339) }},
+	{Header: "OPEN", Value: func(b yfinance.Bar) any { return b.Open }},
+	{Header: "HIGH", Value: func(b yfinance.Bar) any { return b.High }},
+	{Header: "LOW", Value: func(b yfinance.Bar) any { return b.Low }},
+	{Header: "CLOSE", Value: func(b yfinance.Bar) any { return b.Close }},
+	{Header: "ADJ CLOSE", Value: func(b yfinance.Bar) any { return b.AdjClose }},
+	{Header: "VOLUME", Value: func(b yfinance.Bar) any { return b.Volume }},
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/internal/mcpserver"
+)
+
+var (
+	mcpRate      float64
+	mcpBurst     int
+	mcpCacheSize int
+)
+
+func init() {
+	mcpCmd.Flags().Float64Var(&mcpRate, "rate", 2, "Upstream Yahoo requests per second")
+	mcpCmd.Flags().IntVar(&mcpBurst, "burst", 5, "Upstream request burst size")
+	mcpCmd.Flags().IntVar(&mcpCacheSize, "cache-size", 1000, "Maximum number of cached tool results")
+	rootCmd.AddCommand(mcpCmd)
+}
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve market data tools to AI agents over MCP",
+	Long: `Run a Model Context Protocol server on stdin/stdout exposing these tools:
+
+  get_quote       quotes for up to 50 symbols
+  get_history     OHLCV bars by period or date range
+  search          symbol search by name or ticker
+  get_financials  income statement, balance sheet, or cash flow
+
+Tool results are cached and upstream requests are rate limited. Register it
+with an MCP client as a stdio server running "gotick mcp".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srv := mcpserver.New(mcpserver.Options{
+			Version:           rootCmd.Version,
+			RequestsPerSecond: mcpRate,
+			Burst:             mcpBurst,
+			CacheSize:         mcpCacheSize,
+		})
+		return srv.Run(cmd.Context())
+	},
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var (
+	newsCount    int
+	newsFollow   bool
+	newsInterval time.Duration
+)
+
+func init() {
+	newsCmd.Flags().IntVarP(&newsCount, "count", "n", 10, "Number of headlines to fetch")
+	newsCmd.Flags().BoolVarP(&newsFollow, "follow", "F", false, "Keep polling and print new headlines as they appear")
+	newsCmd.Flags().DurationVar(&newsInterval, "interval", time.Minute, "Polling interval for --follow")
+	rootCmd.AddCommand(newsCmd)
+}
+
+var newsCmd = &cobra.Command{
+	Use:   "news [SYMBOL...]",
+	Short: "Print the latest headlines for symbols or the market",
+	Long: `Fetch the latest news headlines for the given symbols, or general market news
+when no symbols are given. With --follow the command keeps polling and prints
+only headlines it has not shown before. With --output ndjson every headline is
+written as a single-line JSON object, suitable for piping into jq; --follow
+always writes JSON output this way.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if newsFollow && newsInterval < time.Second {
+			return fmt.Errorf("--interval must be at least 1s")
+		}
+
+		symbols := make([]string, len(args))
+		for i, arg := range args {
+			symbols[i] = strings.ToUpper(arg)
+		}
+
+		format, err := resolveOutput(cmd, outputTable)
+		if err != nil {
+			return err
+		}
+		w := newListWriter(cmd.OutOrStdout(), format, newsColumns)
+
+		ctx := cmd.Context()
+		seen := make(map[string]bool)
+
+		for {
+			items, err := yfinance.GetNews(ctx, symbols, newsCount)
+			if err != nil {
+				if !newsFollow {
+					return err
+				}
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "news: %v\n", err)
+			}
+
+			fresh := make([]yfinance.NewsItem, 0, len(items))
+			for _, item := range items {
+				if seen[item.UUID] {
+					continue
+				}
+				seen[item.UUID] = true
+				fresh = append(fresh, item)
+			}
+			sort.SliceStable(fresh, func(i, j int) bool {
+				return fresh[i].PublishTime < fresh[j].PublishTime
+			})
+
+			if !newsFollow {
+				return w.Write(fresh, nil)
+			}
+			if len(fresh) > 0 {
+				if err := w.WriteRecords(fresh); err != nil {
+					return err
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(newsInterval):
+			}
+		}
+	},
+}
+
+// newsColumns are the fields printed for headlines
+var newsColumns = []column[yfinance.NewsItem]{
+	{Header: "TIME", Left: true, Value: func(item yfinance.NewsItem) any {
+		return time.Unix(item.PublishTime, 0).UTC().Format(time.RFC3339)
+	}, Text: func(item yfinance.NewsItem) string {
+		return time.Unix(item.PublishTime, 0).Local().Format("2006-01-02 15:04")
+	}},
+	{Header: "PUBLISHER", Left: true, Value: func(item yfinance.NewsItem) any { return item.Publisher }},
+	{Header: "TITLE", Left: true, Value: func(item yfinance.NewsItem) any { return item.Title },
+		Text: func(item yfinance.NewsItem) string { return truncate(item.Title, 80) }},
+	{Header: "LINK", Left: true, Value: func(item yfinance.NewsItem) any { return item.Link }},
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var (
+	optionsExpiration string
+	optionsList       bool
+	optionsCalls      bool
+	optionsPuts       bool
+	optionsGreeks     bool
+	optionsRate       float64
+	optionsMoneyness  string
+	optionsATMRange   float64
+	optionsMinStrike  float64
+	optionsMaxStrike  float64
+	optionsMinOI      int64
+	optionsMinVolume  int64
+)
+
+func init() {
+	optionsCmd.Flags().StringVarP(&optionsExpiration, "expiration", "e", "", "Expiration date (YYYY-MM-DD), defaults to the nearest")
+	optionsCmd.Flags().BoolVarP(&optionsList, "list", "l", false, "List available expiration dates and exit")
+	optionsCmd.Flags().BoolVar(&optionsCalls, "calls", false, "Show only calls")
+	optionsCmd.Flags().BoolVar(&optionsPuts, "puts", false, "Show only puts")
+	optionsCmd.Flags().BoolVarP(&optionsGreeks, "greeks", "g", false, "Include Black-Scholes Greeks")
+	optionsCmd.Flags().Float64Var(&optionsRate, "rate", 0.045, "Risk-free rate used for Greeks")
+	optionsCmd.Flags().StringVarP(&optionsMoneyness, "moneyness", "m", "all", "Filter contracts: all, itm, otm or atm")
+	optionsCmd.Flags().Float64Var(&optionsATMRange, "atm-range", 5, "Percent distance from spot counted as at-the-money")
+	optionsCmd.Flags().Float64Var(&optionsMinStrike, "min-strike", 0, "Lowest strike to show")
+	optionsCmd.Flags().Float64Var(&optionsMaxStrike, "max-strike", 0, "Highest strike to show, 0 for no limit")
+	optionsCmd.Flags().Int64Var(&optionsMinOI, "min-open-interest", 0, "Hide contracts with less open interest")
+	optionsCmd.Flags().Int64Var(&optionsMinVolume, "min-volume", 0, "Hide contracts with less volume")
+	rootCmd.AddCommand(optionsCmd)
+}
+
+var optionsCmd = &cobra.Command{
+	Use:   "options SYMBOL",
+	Short: "Print the option chain for a symbol",
+	Long: `Fetch the option chain for a symbol and expiration and print calls and puts
+in the format selected by --output. Greeks are calculated locally with Black-Scholes
+from each contract's implied volatility.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch optionsMoneyness {
+		case "all", "itm", "otm", "atm":
+		default:
+			return fmt.Errorf("unsupported moneyness %q (want all, itm, otm or atm)", optionsMoneyness)
+		}
+		format, err := resolveOutput(cmd, outputTable)
+		if err != nil {
+			return err
+		}
+
+		params := yfinance.OptionsParams{
+			MinStrike:       optionsMinStrike,
+			MaxStrike:       optionsMaxStrike,
+			CallsOnly:       optionsCalls && !optionsPuts,
+			PutsOnly:        optionsPuts && !optionsCalls,
+			MinOpenInterest: optionsMinOI,
+			MinVolume:       optionsMinVolume,
+		}
+		if optionsExpiration != "" {
+			t, err := time.Parse(time.DateOnly, optionsExpiration)
+			if err != nil {
+				return fmt.Errorf("invalid --expiration date %q: %w", optionsExpiration, err)
+			}
+			params.Expiration = t
+		}
+
+		ticker, err := yfinance.NewTicker(args[0])
+		if err != nil {
+			return err
+		}
+
+		chain, err := ticker.OptionsWithParams(cmd.Context(), params)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if optionsList {
+			for _, ts := range chain.ExpirationDates {
+				_, _ = fmt.Fprintln(out, time.Unix(ts, 0).UTC().Format(time.DateOnly))
+			}
+			return nil
+		}
+
+		full := chain.WithGreeks(optionsRate)
+		filtered := &yfinance.OptionChainWithGreeks{
+			Symbol:          full.Symbol,
+			UnderlyingPrice: full.UnderlyingPrice,
+			ExpirationDates: full.ExpirationDates,
+			Strikes:         full.Strikes,
+		}
+		filtered.Calls = filterMoneyness(full.Calls, chain.UnderlyingPrice)
+		filtered.Puts = filterMoneyness(full.Puts, chain.UnderlyingPrice)
+		if !optionsGreeks {
+			stripGreeks(filtered.Calls)
+			stripGreeks(filtered.Puts)
+		}
+
+		rows := make([]optionRow, 0, len(filtered.Calls)+len(filtered.Puts))
+		for _, o := range filtered.Calls {
+			rows = append(rows, optionRow{Type: "call", OptionWithGreeks: o})
+		}
+		for _, o := range filtered.Puts {
+			rows = append(rows, optionRow{Type: "put", OptionWithGreeks: o})
+		}
+
+		if format == outputTable {
+			_, _ = fmt.Fprintf(out, "%s  underlying %.2f\n\n", filtered.Symbol, filtered.UnderlyingPrice)
+		}
+		return newListWriter(out, format, optionColumns(optionsGreeks)).Write(rows, filtered)
+	},
+}
+
+// optionRow is a call or put contract in flat listings
+type optionRow struct {
+	Type string `json:"type"`
+	yfinance.OptionWithGreeks
+}
+
+// optionColumns returns the fields printed for option contracts, with the
+// Greeks appended when requested
+func optionColumns(greeks bool) []column[optionRow] {
+	cols := []column[optionRow]{
+		{Header: "TYPE", Left: true, Value: func(o optionRow) any { return o.Type }},
+		{Header: "CONTRACT", Left: true, Value: func(o optionRow) any { return o.ContractSymbol }},
+		{Header: "EXPIRATION", Value: func(o optionRow) any { return time.Unix(o.Expiration, 0).UTC().Format(time.DateOnly) }},
+		{Header: "STRIKE", Value: func(o optionRow) any { return o.Strike },
+			Text: func(o optionRow) string { return fmt.Sprintf("%.2f", o.Strike) }},
+		{Header: "LAST", Value: func(o optionRow) any { return o.LastPrice },
+			Text: func(o optionRow) string { return fmt.Sprintf("%.2f", o.LastPrice) }},
+		{Header: "BID", Value: func(o optionRow) any { return o.Bid },
+			Text: func(o optionRow) string { return fmt.Sprintf("%.2f", o.Bid) }},
+		{Header: "ASK", Value: func(o optionRow) any { return o.Ask },
+			Text: func(o optionRow) string { return fmt.Sprintf("%.2f", o.Ask) }},
+		{Header: "CHANGE%", Signed: true, Value: func(o optionRow) any { return o.PercentChange },
+			Text: func(o optionRow) string { return fmt.Sprintf("%+.2f%%", o.PercentChange) }},
+		{Header: "VOLUME", Value: func(o optionRow) any { return o.Volume }},
+		{Header: "OPEN INTEREST", Value: func(o optionRow) any { return o.OpenInterest }},
+		{Header: "IMPLIED VOLATILITY", Value: func(o optionRow) any { return o.ImpliedVolatility },
+			Text: func(o optionRow) string { return fmt.Sprintf("%.2f%%", o.ImpliedVolatility*100) }},
+		{Header: "ITM", Value: func(o optionRow) any { return o.InTheMoney }},
+	}
+	if !greeks {
+		return cols
+	}
+
+	greek := func(header string, f func(*yfinance.Greeks) float64, prec int) column[optionRow] {
+		return column[optionRow]{
+			Header: header,
+			Value: func(o optionRow) any {
+				if o.Greeks == nil {
+					return nil
+				}
+				return f(o.Greeks)
+			},
+			Text: func(o optionRow) string {
+				if o.Greeks == nil {
+					return "-"
+				}
+				return strconv.FormatFloat(f(o.Greeks), 'f', prec, 64)
+			},
+		}
+	}
+	return append(cols,
+		greek("DELTA", func(g *yfinance.Greeks) float64 { return g.Delta }, 3),
+		greek("GAMMA", func(g *yfinance.Greeks) float64 { return g.Gamma }, 4),
+		greek("THETA", func(g *yfinance.Greeks) float64 { return g.Theta }, 3),
+		greek("VEGA", func(g *yfinance.Greeks) float64 { return g.Vega }, 3),
+		greek("RHO", func(g *yfinance.Greeks) float64 { return g.Rho }, 3),
+	)
+}
+
+// filterMoneyness keeps the contracts matching the --moneyness flag
+func filterMoneyness(opts []yfinance.OptionWithGreeks, spot float64) []yfinance.OptionWithGreeks {
+	if optionsMoneyness == "all" {
+		return opts
+	}
+
+	result := make([]yfinance.OptionWithGreeks, 0, len(opts))
+	for _, o := range opts {
+		var keep bool
+		switch optionsMoneyness {
+		case "itm":
+			keep = o.InTheMoney
+		case "otm":
+			keep = !o.InTheMoney
+		case "atm":
+			keep = spot > 0 && math.Abs(o.Strike-spot)/spot*100 <= optionsATMRange
+		}
+		if keep {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// stripGreeks drops calculated Greeks so JSON output omits them
+func stripGreeks(opts []yfinance.OptionWithGreeks) {
+	for i := range opts {
+		opts[i].Greeks = nil
+	}
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+// Output formats accepted by --output
+const (
+	outputTable  = "table"
+	outputJSON   = "json"
+	outputCSV    = "csv"
+	outputNDJSON = "ndjson"
+)
+
+var (
+	outputFormat string
+	noColor      bool
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputTable, "Output format: table, json, csv or ndjson")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+}
+
+// resolveOutput returns the output format for cmd. When --output was not
+// given explicitly the configured format is used, then def.
+func resolveOutput(cmd *cobra.Command, def string) (string, error) {
+	format := outputFormat
+	if !cmd.Flags().Changed("output") {
+		format = def
+		if settings.Output != "" {
+			format = settings.Output
+		}
+	}
+	switch format {
+	case outputTable, outputJSON, outputCSV, outputNDJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported output %q (want table, json, csv or ndjson)", format)
+	}
+}
+
+// useColor reports whether ANSI colors should be written to out
+func useColor(out io.Writer) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// column describes one field of a rendered list
+type column[T any] struct {
+	Header string
+	Value  func(T) any    // Raw value used for CSV
+	Text   func(T) string // Display text for tables; defaults to Value formatted with %v
+	Left   bool           // Left-align in tables (numbers are right-aligned by default)
+	Signed bool           // Color green/red by the sign of the display text
+}
+
+// listWriter renders items in the selected output format
+type listWriter[T any] struct {
+	out           io.Writer
+	format        string
+	columns       []column[T]
+	color         bool
+	headerWritten bool
+}
+
+func newListWriter[T any](out io.Writer, format string, columns []column[T]) *listWriter[T] {
+	return &listWriter[T]{out: out, format: format, columns: columns, color: useColor(out)}
+}
+
+// writeList renders items with cols in the format chosen by --output. For
+// json, whole is encoded instead of items when it is non-nil, so commands can
+// emit richer documents than their tabular rows.
+func writeList[T any](cmd *cobra.Command, items []T, cols []column[T], whole any) error {
+	format, err := resolveOutput(cmd, outputTable)
+	if err != nil {
+		return err
+	}
+	return newListWriter(cmd.OutOrStdout(), format, cols).Write(items, whole)
+}
+
+// Write renders a complete list
+func (w *listWriter[T]) Write(items []T, whole any) error {
+	switch w.format {
+	case outputJSON:
+		if whole == nil {
+			whole = items
+		}
+		enc := json.NewEncoder(w.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(whole)
+	case outputTable:
+		return w.writeTable(items, true)
+	default:
+		return w.WriteRecords(items)
+	}
+}
+
+// WriteRecords renders items incrementally, for commands that emit results
+// over time. JSON is written one object per line, and tables and CSV omit
+// the header row after the first call.
+func (w *listWriter[T]) WriteRecords(items []T) error {
+	switch w.format {
+	case outputJSON, outputNDJSON:
+		enc := json.NewEncoder(w.out)
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputCSV:
+		cw := csv.NewWriter(w.out)
+		if !w.headerWritten {
+			if err := cw.Write(w.csvHeader()); err != nil {
+				return err
+			}
+			w.headerWritten = true
+		}
+		for _, item := range items {
+			if err := cw.Write(w.csvRecord(item)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		header := !w.headerWritten
+		w.headerWritten = true
+		return w.writeTable(items, header)
+	}
+}
+
+func (w *listWriter[T]) csvHeader() []string {
+	header := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		header[i] = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(c.Header)), " ", "_")
+		header[i] = strings.ReplaceAll(header[i], "%", "_percent")
+	}
+	return header
+}
+
+func (w *listWriter[T]) csvRecord(item T) []string {
+	record := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		record[i] = formatRaw(c.Value(item))
+	}
+	return record
+}
+
+// writeTable prints an aligned table. Widths are computed on the visible
+// text so colored cells stay aligned.
+func (w *listWriter[T]) writeTable(items []T, header bool) error {
+	cells := make([][]string, len(items))
+	widths := make([]int, len(w.columns))
+	for i, c := range w.columns {
+		widths[i] = utf8.RuneCountInString(c.Header)
+	}
+	for r, item := range items {
+		cells[r] = make([]string, len(w.columns))
+		for i, c := range w.columns {
+			var text string
+			if c.Text != nil {
+				text = c.Text(item)
+			} else {
+				text = formatRaw(c.Value(item))
+			}
+			cells[r][i] = text
+			if n := utf8.RuneCountInString(text); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var b strings.Builder
+	if header {
+		for i, c := range w.columns {
+			w.writeCell(&b, i, c.Header, widths[i], false)
+		}
+		b.WriteString("\n")
+	}
+	for _, row := range cells {
+		for i, text := range row {
+			w.writeCell(&b, i, text, widths[i], w.columns[i].Signed)
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w.out, b.String())
+	return err
+}
+
+func (w *listWriter[T]) writeCell(b *strings.Builder, i int, text string, width int, signed bool) {
+	if i > 0 {
+		b.WriteString("  ")
+	}
+	pad := strings.Repeat(" ", width-utf8.RuneCountInString(text))
+	if signed && w.color {
+		text = colorize(text)
+	}
+	if w.columns[i].Left {
+		b.WriteString(text)
+		if i < len(w.columns)-1 {
+			b.WriteString(pad)
+		}
+		return
+	}
+	b.WriteString(pad)
+	b.WriteString(text)
+}
+
+// colorize wraps signed numbers in green or red
+func colorize(text string) string {
+	value := strings.TrimSpace(text)
+	switch {
+	case strings.HasPrefix(value, "+") && strings.Trim(value, "+0.%") != "":
+		return "\033[32m" + text + "\033[0m"
+	case strings.HasPrefix(value, "-") && strings.Trim(value, "-0.%") != "":
+		return "\033[31m" + text + "\033[0m"
+	default:
+		return text
+	}
+}
+
+// formatRaw renders a raw column value for CSV and default table text
+func formatRaw(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case int:
+		return strconv.Itoa(x)
+	case bool:
+		return strconv.FormatBool(x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/portfolio"
+)
+
+var (
+	portfolioTransactions bool
+	portfolioPerformance  bool
+	portfolioStart        string
+	portfolioEnd          string
+	portfolioBenchmark    string
+	portfolioIncome       bool
+	portfolioMonths       int
+)
+
+func init() {
+	portfolioCmd.Flags().BoolVarP(&portfolioTransactions, "transactions", "t", false, "FILE is a transaction history or broker export instead of holdings")
+	portfolioCmd.Flags().BoolVar(&portfolioPerformance, "performance", false, "Report time- and money-weighted returns against a benchmark instead of current value")
+	portfolioCmd.Flags().StringVar(&portfolioStart, "start", "", "Performance window start (YYYY-MM-DD), default one year ago")
+	portfolioCmd.Flags().StringVar(&portfolioEnd, "end", "", "Performance window end (YYYY-MM-DD), default today")
+	portfolioCmd.Flags().StringVar(&portfolioBenchmark, "benchmark", portfolio.DefaultBenchmark, "Benchmark symbol for --performance")
+	portfolioCmd.Flags().BoolVar(&portfolioIncome, "income", false, "Project dividend income and upcoming payments instead of current value")
+	portfolioCmd.Flags().IntVar(&portfolioMonths, "months", 12, "Months of dividend income to project with --income")
+	portfolioCmd.MarkFlagsMutuallyExclusive("performance", "income")
+	rootCmd.AddCommand(portfolioCmd)
+}
+
+var portfolioCmd = &cobra.Command{
+	Use:   "portfolio FILE",
+	Short: "Value a portfolio of holdings",
+	Long: `Read holdings from a CSV, JSON, or YAML file and print current value, day and total
+P&L, allocation by sector and country, and projected annual dividend income.
+
+CSV files need a header with symbol and quantity columns and an optional
+cost_basis (average cost per share) column. YAML files use a positions list,
+with either a quantity and cost basis or individual lots:
+
+  positions:
+    - symbol: AAPL
+      quantity: 10
+      cost_basis: 150
+    - symbol: MSFT
+      lots:
+        - {date: 2023-01-05, quantity: 5, price: 240, fees: 1}
+
+With --transactions, FILE is a CSV of buys and sells: either a generic file
+with date, symbol, action (buy/sell), quantity, price, and fees columns, or an
+activity export from Fidelity, Schwab, Interactive Brokers, or Robinhood.
+Sells are matched first-in first-out to compute realized P&L.
+
+With --performance, daily history over the --start/--end window is used to
+report time-weighted and money-weighted returns, and alpha, beta, and
+tracking error against --benchmark. Holdings change on lot purchase and sale
+dates, so use lots or --transactions for accurate results.
+
+With --income, dividend rates, past payment dates, and announced ex-dividend
+dates are combined to project income per position and per month over the
+next --months, with yield on cost and a schedule of upcoming payments.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		load := portfolio.LoadFile
+		if portfolioTransactions {
+			load = portfolio.LoadTransactionsFile
+		}
+		p, err := load(args[0])
+		if err != nil {
+			return err
+		}
+		if portfolioPerformance {
+			return runPerformance(cmd, p)
+		}
+		if portfolioIncome {
+			return runIncome(cmd, p)
+		}
+
+		v, err := portfolio.Value(cmd.Context(), p)
+		if err != nil {
+			return err
+		}
+
+		format, err := resolveOutput(cmd, outputTable)
+		if err != nil {
+			return err
+		}
+		if len(v.Missing) > 0 {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "no quote for: %s\n", strings.Join(v.Missing, ", "))
+		}
+		if format != outputTable {
+			return newListWriter(cmd.OutOrStdout(), format, positionColumns).Write(v.Positions, v)
+		}
+		return writeValuation(cmd.OutOrStdout(), v)
+	},
+}
+
+// positionColumns are the fields printed for valued positions
+var positionColumns = []column[portfolio.PositionValue]{
+	{Header: "SYMBOL", Left: true, Value: func(p portfolio.PositionValue) any { return p.Symbol }},
+	{Header: "QUANTITY", Value: func(p portfolio.PositionValue) any { return p.Quantity }},
+	{Header: "PRICE", Value: func(p portfolio.PositionValue) any { return p.Price },
+		Text: func(p portfolio.PositionValue) string { return fmt.Sprintf("%.2f", p.Price) }},
+	{Header: "VALUE", Value: func(p portfolio.PositionValue) any { return p.MarketValue },
+		Text: func(p portfolio.PositionValue) string { return fmt.Sprintf("%.2f", p.MarketValue) }},
+	{Header: "DAY", Signed: true, Value: func(p portfolio.PositionValue) any { return p.DayChange },
+		Text: func(p portfolio.PositionValue) string { return fmt.Sprintf("%+.2f", p.DayChange) }},
+	{Header: "DAY%", Signed: true, Value: func(p portfolio.PositionValue) any { return p.DayChangePct },
+		Text: func(p portfolio.PositionValue) string { return fmt.Sprintf("%+.2f%%", p.DayChangePct) }},
+	{Header: "P&L", Signed: true, Value: func(p portfolio.PositionValue) any { return p.TotalPL },
+		Text: func(p portfolio.PositionValue) string { return fmt.Sprintf("%+.2f", p.TotalPL) }},
+	{Header: "P&L%", Signed: true, Value: func(p portfolio.PositionValue) any { return p.TotalPLPct },
+		Text: func(p portfolio.PositionValue) string { return fmt.Sprintf("%+.2f%%", p.TotalPLPct) }},
+	{Header: "REALIZED", Signed: true, Value: func(p portfolio.PositionValue) any { return p.RealizedPL },
+		Text: func(p portfolio.PositionValue) string { return fmt.Sprintf("%+.2f", p.RealizedPL) }},
+	{Header: "WEIGHT", Value: func(p portfolio.PositionValue) any { return p.Weight },
+		Text: func(p portfolio.PositionValue) string { return fmt.Sprintf("%.1f%%", p.Weight*100) }},
+	{Header: "DIVIDENDS", Value: func(p portfolio.PositionValue) any { return p.AnnualDividend },
+		Text: func(p portfolio.PositionValue) string { return fmt.Sprintf("%.2f", p.AnnualDividend) }},
+}
+
+// allocationWeight is one row of an allocation table
+type allocationWeight struct {
+	Name   string
+	Weight float64
+}
+
+// allocationColumns returns the columns of an allocation table titled header
+func allocationColumns(header string) []column[allocationWeight] {
+	return []column[allocationWeight]{
+		{Header: header, Left: true, Value: func(a allocationWeight) any { return a.Name }},
+		{Header: "WEIGHT", Value: func(a allocationWeight) any { return a.Weight },
+			Text: func(a allocationWeight) string { return fmt.Sprintf("%.1f%%", a.Weight*100) }},
+	}
+}
+
+// sortedAllocation returns the allocation map as rows by descending weight
+func sortedAllocation(m map[string]float64) []allocationWeight {
+	rows := make([]allocationWeight, 0, len(m))
+	for name, w := range m {
+		rows = append(rows, allocationWeight{Name: name, Weight: w})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Weight != rows[j].Weight {
+			return rows[i].Weight > rows[j].Weight
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+// writeValuation prints positions, totals, and sector and country allocation
+func writeValuation(out io.Writer, v *portfolio.Valuation) error {
+	if err := newListWriter(out, outputTable, positionColumns).Write(v.Positions, nil); err != nil {
+		return err
+	}
+
+	color := useColor(out)
+	signed := func(format string, x float64) string {
+		s := fmt.Sprintf(format, x)
+		if color {
+			s = colorize(s)
+		}
+		return s
+	}
+	_, _ = fmt.Fprintf(out, "\nTOTAL  value %.2f  day %s (%s)  P&L %s (%s)  realized %s  dividends %.2f\n",
+		v.MarketValue,
+		signed("%+.2f", v.DayChange), signed("%+.2f%%", v.DayChangePct),
+		signed("%+.2f", v.TotalPL), signed("%+.2f%%", v.TotalPLPct),
+		signed("%+.2f", v.RealizedPL),
+		v.AnnualDividends)
+
+	_, _ = fmt.Fprintln(out)
+	if err := newListWriter(out, outputTable, allocationColumns("SECTOR")).Write(sortedAllocation(v.SectorAllocation), nil); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out)
+	return newListWriter(out, outputTable, allocationColumns("COUNTRY")).Write(sortedAllocation(v.CountryAllocation), nil)
+}
+
+// runPerformance prints portfolio performance for the --start/--end window
+func runPerformance(cmd *cobra.Command, p *portfolio.Portfolio) error {
+	params := portfolio.PerformanceParams{Benchmark: strings.ToUpper(portfolioBenchmark)}
+	for _, d := range []struct {
+		flag, value string
+		dst         *time.Time
+	}{{"--start", portfolioStart, &params.Start}, {"--end", portfolioEnd, &params.End}} {
+		if d.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.DateOnly, d.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s date %q: %w", d.flag, d.value, err)
+		}
+		*d.dst = t
+	}
+
+	perf, err := portfolio.MeasurePerformance(cmd.Context(), p, params)
+	if err != nil {
+		return err
+	}
+
+	format, err := resolveOutput(cmd, outputTable)
+	if err != nil {
+		return err
+	}
+	if len(perf.Missing) > 0 {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "no history for: %s\n", strings.Join(perf.Missing, ", "))
+	}
+	if format != outputTable {
+		return newListWriter(cmd.OutOrStdout(), format, performanceColumns).Write(perf.Series, perf)
+	}
+	return writePerformance(cmd.OutOrStdout(), perf)
+}
+
+// performanceColumns are the fields printed for each day of the series
+var performanceColumns = []column[portfolio.PerformancePoint]{
+	{Header: "DATE", Left: true, Value: func(p portfolio.PerformancePoint) any { return p.Date.Format(time.DateOnly) }},
+	{Header: "VALUE", Value: func(p portfolio.PerformancePoint) any { return p.Value },
+		Text: func(p portfolio.PerformancePoint) string { return fmt.Sprintf("%.2f", p.Value) }},
+	{Header: "FLOW", Value: func(p portfolio.PerformancePoint) any { return p.Flow },
+		Text: func(p portfolio.PerformancePoint) string { return fmt.Sprintf("%.2f", p.Flow) }},
+	{Header: "RETURN", Signed: true, Value: func(p portfolio.PerformancePoint) any { return p.Return },
+		Text: func(p portfolio.PerformancePoint) string { return fmt.Sprintf("%+.2f%%", p.Return*100) }},
+}
+
+// writePerformance prints the performance summary
+func writePerformance(out io.Writer, perf *portfolio.Performance) error {
+	color := useColor(out)
+	pct := func(x float64) string {
+		s := fmt.Sprintf("%+.2f%%", x*100)
+		if color {
+			s = colorize(s)
+		}
+		return s
+	}
+	mwr := "n/a"
+	if perf.MWR != nil {
+		mwr = pct(*perf.MWR)
+	}
+
+	_, err := fmt.Fprintf(out, `Window            %s to %s
+Value             %.2f -> %.2f (net flows %.2f)
+Time-weighted     %s (%s annualized)
+Money-weighted    %s
+%-17s %s
+Alpha             %s annualized
+Beta              %.2f
+Tracking error    %.2f%%
+`,
+		perf.Start.Format(time.DateOnly), perf.End.Format(time.DateOnly),
+		perf.StartValue, perf.EndValue, perf.NetFlows,
+		pct(perf.TWR), pct(perf.AnnualizedTWR),
+		mwr,
+		perf.Benchmark, pct(perf.BenchmarkReturn),
+		pct(perf.Alpha),
+		perf.Beta,
+		perf.TrackingError*100)
+	return err
+}
+
+// runIncome prints projected dividend income
+func runIncome(cmd *cobra.Command, p *portfolio.Portfolio) error {
+	proj, err := portfolio.ProjectIncome(cmd.Context(), p, portfolioMonths)
+	if err != nil {
+		return err
+	}
+
+	format, err := resolveOutput(cmd, outputTable)
+	if err != nil {
+		return err
+	}
+	if format != outputTable {
+		return newListWriter(cmd.OutOrStdout(), format, paymentColumns).Write(proj.Payments, proj)
+	}
+	return writeIncome(cmd.OutOrStdout(), proj)
+}
+
+var incomeColumns = []column[portfolio.PositionIncome]{
+	{Header: "SYMBOL", Left: true, Value: func(p portfolio.PositionIncome) any { return p.Symbol }},
+	{Header: "QUANTITY", Value: func(p portfolio.PositionIncome) any { return p.Quantity }},
+	{Header: "RATE", Value: func(p portfolio.PositionIncome) any { return p.AnnualRate },
+		Text: func(p portfolio.PositionIncome) string { return fmt.Sprintf("%.4f", p.AnnualRate) }},
+	{Header: "FREQUENCY", Value: func(p portfolio.PositionIncome) any { return p.Frequency }},
+	{Header: "INCOME", Value: func(p portfolio.PositionIncome) any { return p.AnnualIncome },
+		Text: func(p portfolio.PositionIncome) string { return fmt.Sprintf("%.2f", p.AnnualIncome) }},
+	{Header: "YIELD", Value: func(p portfolio.PositionIncome) any { return p.CurrentYield },
+		Text: func(p portfolio.PositionIncome) string { return fmt.Sprintf("%.2f%%", p.CurrentYield*100) }},
+	{Header: "YIELD ON COST", Value: func(p portfolio.PositionIncome) any { return p.YieldOnCost },
+		Text: func(p portfolio.PositionIncome) string { return fmt.Sprintf("%.2f%%", p.YieldOnCost*100) }},
+}
+
+var monthlyIncomeColumns = []column[portfolio.MonthlyIncome]{
+	{Header: "MONTH", Left: true, Value: func(m portfolio.MonthlyIncome) any { return m.Month.Format("2006-01") }},
+	{Header: "INCOME", Value: func(m portfolio.MonthlyIncome) any { return m.Amount },
+		Text: func(m portfolio.MonthlyIncome) string { return fmt.Sprintf("%.2f", m.Amount) }},
+}
+
+// paymentColumns are the fields printed for projected dividend payments
+var paymentColumns = []column[portfolio.DividendPayment]{
+	{Header: "SYMBOL", Left: true, Value: func(p portfolio.DividendPayment) any { return p.Symbol }},
+	{Header: "EX DATE", Left: true, Value: func(p portfolio.DividendPayment) any { return p.ExDate.Format(time.DateOnly) }},
+	{Header: "PAY DATE", Left: true, Value: func(p portfolio.DividendPayment) any { return p.PayDate.Format(time.DateOnly) }},
+	{Header: "PER SHARE", Value: func(p portfolio.DividendPayment) any { return p.PerShare },
+		Text: func(p portfolio.DividendPayment) string { return fmt.Sprintf("%.4f", p.PerShare) }},
+	{Header: "AMOUNT", Value: func(p portfolio.DividendPayment) any { return p.Amount },
+		Text: func(p portfolio.DividendPayment) string { return fmt.Sprintf("%.2f", p.Amount) }},
+	{Header: "ESTIMATED", Value: func(p portfolio.DividendPayment) any { return p.Estimated }},
+}
+
+// writeIncome prints income by position, totals, income by month, and the
+// payment schedule
+func writeIncome(out io.Writer, proj *portfolio.IncomeProjection) error {
+	if err := newListWriter(out, outputTable, incomeColumns).Write(proj.Positions, nil); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "\nTOTAL  income %.2f/yr (%.2f/mo)  yield %.2f%%  yield on cost %.2f%%\n\n",
+		proj.AnnualIncome, proj.AnnualIncome/12, proj.CurrentYield*100, proj.YieldOnCost*100)
+
+	if err := newListWriter(out, outputTable, monthlyIncomeColumns).Write(proj.Monthly, nil); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out)
+	return newListWriter(out, outputTable, paymentColumns).Write(proj.Payments, nil)
+}
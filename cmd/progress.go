@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// progressBar renders a single-line text progress bar
+type progressBar struct {
+	w     io.Writer
+	total int
+	done  int
+	width int
+	mu    sync.Mutex
+}
+
+// newProgressBar creates a progress bar for total units of work
+func newProgressBar(w io.Writer, total int) *progressBar {
+	return &progressBar{w: w, total: total, width: 30}
+}
+
+// Increment advances the bar by one and redraws it with the given label
+func (p *progressBar) Increment(label string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done++
+	filled := p.width
+	if p.total > 0 {
+		filled = p.done * p.width / p.total
+	}
+	bar := strings.Repeat("#", filled) + strings.Repeat(".", p.width-filled)
+	_, _ = fmt.Fprintf(p.w, "\r[%s] %d/%d %-12s", bar, p.done, p.total, label)
+}
+
+// Finish terminates the progress line
+func (p *progressBar) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = fmt.Fprintln(p.w)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var quoteJSON bool
+
+func init() {
+	quoteCmd.Flags().BoolVar(&quoteJSON, "json", false, "Print quotes as JSON")
+	_ = quoteCmd.Flags().MarkDeprecated("json", "use --output json instead")
+	rootCmd.AddCommand(quoteCmd)
+}
+
+var quoteCmd = &cobra.Command{
+	Use:   "quote [SYMBOL...]",
+	Short: "Print quotes for one or more symbols",
+	Long: `Fetch real-time quotes for one or more symbols, or the configured default
+symbols, and print them in the format selected by --output. Pre-market and
+post-market prices are included when Yahoo Finance reports them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if quoteJSON {
+			if err := cmd.Flags().Set("output", outputJSON); err != nil {
+				return err
+			}
+		}
+		symbols, err := resolveSymbols(args)
+		if err != nil {
+			return err
+		}
+
+		quotes, err := yfinance.QuoteMultiple(cmd.Context(), symbols)
+		if err != nil {
+			return err
+		}
+		return writeList(cmd, quotes, quoteColumns, nil)
+	},
+}
+
+// quoteColumns are the fields printed for quotes
+var quoteColumns = []column[yfinance.Quote]{
+	{Header: "SYMBOL", Left: true, Value: func(q yfinance.Quote) any { return q.Symbol }},
+	{Header: "PRICE", Value: func(q yfinance.Quote) any { return q.RegularMarketPrice },
+		Text: func(q yfinance.Quote) string { return fmt.Sprintf("%.2f", q.RegularMarketPrice) }},
+	{Header: "CHANGE", Signed: true, Value: func(q yfinance.Quote) any { return q.RegularMarketChange },
+		Text: func(q yfinance.Quote) string { return fmt.Sprintf("%+.2f", q.RegularMarketChange) }},
+	{Header: "CHANGE%", Signed: true, Value: func(q yfinance.Quote) any { return q.RegularMarketChangePercent },
+		Text: func(q yfinance.Quote) string { return fmt.Sprintf("%+.2f%%", q.RegularMarketChangePercent) }},
+	{Header: "VOLUME", Value: func(q yfinance.Quote) any { return q.RegularMarketVolume }},
+	{Header: "STATE", Value: func(q yfinance.Quote) any { return q.MarketState }},
+	{Header: "PRE", Value: func(q yfinance.Quote) any { return optionalValue(q.PreMarketPrice, q.PreMarketPrice) },
+		Text: func(q yfinance.Quote) string { return formatOptionalPrice(q.PreMarketPrice) }},
+	{Header: "PRE%", Signed: true, Value: func(q yfinance.Quote) any { return optionalValue(q.PreMarketPrice, q.PreMarketChangePercent) },
+		Text: func(q yfinance.Quote) string {
+			return formatOptionalPercent(q.PreMarketPrice, q.PreMarketChangePercent)
+		}},
+	{Header: "POST", Value: func(q yfinance.Quote) any { return optionalValue(q.PostMarketPrice, q.PostMarketPrice) },
+		Text: func(q yfinance.Quote) string { return formatOptionalPrice(q.PostMarketPrice) }},
+	{Header: "POST%", Signed: true, Value: func(q yfinance.Quote) any { return optionalValue(q.PostMarketPrice, q.PostMarketChangePercent) },
+		Text: func(q yfinance.Quote) string {
+			return formatOptionalPercent(q.PostMarketPrice, q.PostMarketChangePercent)
+		}},
+}
+
+// optionalValue returns v, or nil when the price it belongs to is missing
+func optionalValue(price, v float64) any {
+	if price == 0 {
+		return nil
+	}
+	return v
+}
+
+// formatOptionalPrice renders a price, or "-" when Yahoo did not report one
+func formatOptionalPrice(price float64) string {
+	if price == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", price)
+}
+
+// formatOptionalPercent renders a change percent, or "-" when the price is missing
+func formatOptionalPercent(price, percent float64) string {
+	if price == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.2f%%", percent)
+}
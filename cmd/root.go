@@ -1,41 +1,143 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/amjadjibon/gotick/internal/config"
 	"github.com/amjadjibon/gotick/internal/tui"
+	"github.com/amjadjibon/gotick/pkg/portfolio"
+	"github.com/amjadjibon/gotick/pkg/yfinance"
 )
 
 var (
-	symbol    string
-	interval  string
-	timeRange string
+	symbol     string
+	interval   string
+	timeRange  string
+	configPath string
+	holdings   string
+	refresh    bool
+	debug      bool
+
+	// settings are loaded before any command runs
+	settings = config.Default()
 )
 
 func init() {
 	rootCmd.Flags().StringVarP(&symbol, "symbol", "s", "AAPL", "Stock symbol to display")
 	rootCmd.Flags().StringVarP(&interval, "interval", "i", "1d", "Chart interval (e.g. 1d, 1h, 5m)")
 	rootCmd.Flags().StringVarP(&timeRange, "range", "r", "1y", "Chart time range (e.g. 1y, 5d, 1mo)")
+	rootCmd.Flags().StringVarP(&holdings, "portfolio", "p", "", "Show holdings from a portfolio file instead of the market summary")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file (default $XDG_CONFIG_HOME/gotick/config.yaml, or $GOTICK_CONFIG)")
+	rootCmd.PersistentFlags().BoolVar(&refresh, "refresh", false, "Ignore cached responses and fetch fresh data")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Log Yahoo Finance requests, retries, and cache use to stderr")
 }
 
 var rootCmd = &cobra.Command{
 	Use:   "gotick",
 	Short: "Real-time terminal stock ticker",
 	Long: `A terminal-based stock ticker and dashboard using Yahoo Finance data.
-Displays real-time price, history chart, market summary, news, and analyst recommendations.`,
-	Run: func(cmd *cobra.Command, args []string) {
+Displays real-time price, history chart, market summary, news, and analyst recommendations.
+
+Settings such as default symbols, output format, response caching, proxy,
+and rate limits are read from the config file and GOTICK_* environment
+variables (GOTICK_PROXY, GOTICK_CACHE, GOTICK_CACHE_DIR, GOTICK_CACHE_REDIS,
+GOTICK_OUTPUT, GOTICK_SYMBOLS, GOTICK_RATE_LIMIT). Flags take precedence over
+both.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if refresh {
+			cmd.SetContext(yfinance.BypassCache(cmd.Context()))
+		}
+		return loadSettings()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sym := symbol
+		if !cmd.Flags().Changed("symbol") && len(settings.Symbols) > 0 {
+			sym = settings.Symbols[0]
+		}
+		var p *portfolio.Portfolio
+		if holdings != "" {
+			var err error
+			if p, err = portfolio.LoadFile(holdings); err != nil {
+				return err
+			}
+		}
 		tui.Run(tui.Options{
-			Symbol:   symbol,
-			Interval: interval,
-			Range:    timeRange,
+			Symbol:    sym,
+			Interval:  interval,
+			Range:     timeRange,
+			Portfolio: p,
 		})
+		return nil
 	},
 }
 
+// loadSettings reads the config file and installs a default Yahoo Finance
+// client built from it, so every command and the TUI share the settings
+func loadSettings() error {
+	path, required := configPath, configPath != ""
+	if path == "" {
+		path = config.DefaultPath()
+	}
+
+	cfg, err := config.Load(path, required)
+	if err != nil {
+		return err
+	}
+	settings = cfg
+
+	if opts := clientOptions(); len(opts) > 0 {
+		return installClient(opts...)
+	}
+	return nil
+}
+
+// clientOptions returns the Yahoo Finance client options for the settings
+// and global flags
+func clientOptions() []yfinance.ClientOption {
+	opts := settings.ClientOptions()
+	if debug {
+		handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})
+		opts = append(opts, yfinance.WithLogger(slog.New(handler)))
+	}
+	return opts
+}
+
+// installClient makes a client with opts the default for all commands
+func installClient(opts ...yfinance.ClientOption) error {
+	if err := yfinance.Configure(opts...); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	return nil
+}
+
+// resolveSymbols upper-cases args, falling back to the configured default
+// symbols when none are given
+func resolveSymbols(args []string) ([]string, error) {
+	if len(args) == 0 {
+		if len(settings.Symbols) == 0 {
+			return nil, fmt.Errorf("no symbols given and none configured")
+		}
+		return settings.Symbols, nil
+	}
+
+	symbols := make([]string, len(args))
+	for i, arg := range args {
+		symbols[i] = strings.ToUpper(arg)
+	}
+	return symbols, nil
+}
+
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
 	if err != nil {
 		os.Exit(1)
 	}
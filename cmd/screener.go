@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var (
+	screenerQuery    string
+	screenerSort     string
+	screenerAsc      bool
+	screenerSize     int
+	screenerOffset   int
+	screenerMinYield float64
+	screenerAll      bool
+)
+
+func init() {
+	screenerCmd.Flags().StringVarP(&screenerQuery, "query", "q", "", `Custom query as JSON or expression (e.g. "region=us and percentchange>5")`)
+	screenerCmd.Flags().StringVar(&screenerSort, "sort", "intradaymarketcap", "Sort field for --query")
+	screenerCmd.Flags().BoolVar(&screenerAsc, "asc", false, "Sort ascending for --query")
+	screenerCmd.Flags().IntVarP(&screenerSize, "size", "n", 25, "Number of results per page (at most 250)")
+	screenerCmd.Flags().IntVar(&screenerOffset, "offset", 0, "Number of results to skip")
+	screenerCmd.Flags().BoolVar(&screenerAll, "all", false, "Fetch every page of results, --size at a time")
+	screenerCmd.Flags().Float64Var(&screenerMinYield, "min-yield", 4, "Minimum dividend yield in percent for high-dividend")
+	screenerCmd.AddCommand(screenerFieldsCmd)
+	rootCmd.AddCommand(screenerCmd)
+}
+
+var screenerCmd = &cobra.Command{
+	Use:   "screener [gainers|losers|most-active|high-dividend|SAVED_SCREENER]",
+	Short: "Screen stocks with a preset or custom query",
+	Long: `Run a Yahoo Finance stock screener and print the matching quotes.
+
+Use one of the presets (gainers, losers, most-active, high-dividend), one of
+Yahoo's saved screeners by ID (such as day_gainers, undervalued_large_caps,
+or aggressive_small_caps; at most 250 results a page), or pass --query with
+either a raw JSON query or a compact expression of terms joined by "and",
+for example:
+
+  gotick screener --query "region=us and percentchange>5 and intradaymarketcap=1e9..1e11"
+
+Queries are checked against the field catalog before they are sent; list it
+with "gotick screener fields".`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: screenerPresets(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if screenerSize <= 0 {
+			return fmt.Errorf("--size must be positive")
+		}
+		if screenerOffset < 0 {
+			return fmt.Errorf("--offset cannot be negative")
+		}
+		format, err := resolveOutput(cmd, outputTable)
+		if err != nil {
+			return err
+		}
+
+		var preset string
+		if len(args) > 0 {
+			preset = args[0]
+		}
+
+		var result *yfinance.ScreenResult
+		var pager *yfinance.ScreenPager
+		if id := yfinance.PredefinedScreener(preset); slices.Contains(yfinance.PredefinedScreeners(), id) {
+			if screenerQuery != "" {
+				return fmt.Errorf("use either a saved screener or --query, not both")
+			}
+			switch {
+			case screenerAll && screenerOffset > 0:
+				return fmt.Errorf("--offset cannot be used with --all for saved screeners")
+			case screenerAll:
+				pager = yfinance.NewPredefinedScreenPager(nil, id, screenerSize)
+			default:
+				result, err = yfinance.ScreenPredefined(cmd.Context(), id, screenerOffset, screenerSize)
+			}
+		} else {
+			var criteria yfinance.ScreenCriteria
+			criteria, err = buildScreenCriteria(preset, screenerQuery)
+			if err != nil {
+				return err
+			}
+			criteria.Offset = screenerOffset
+			if screenerAll {
+				pager = yfinance.NewScreenPager(nil, criteria)
+			} else {
+				result, err = yfinance.Screen(cmd.Context(), criteria)
+			}
+		}
+		if pager != nil {
+			result, err = screenAll(cmd.Context(), pager)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := newListWriter(cmd.OutOrStdout(), format, screenColumns).Write(result.Quotes, result); err != nil {
+			return err
+		}
+		if format == outputTable {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "showing %d-%d of %d\n",
+				screenerOffset+min(1, len(result.Quotes)), screenerOffset+len(result.Quotes), result.Total)
+		}
+		return nil
+	},
+}
+
+var screenerFieldsCmd = &cobra.Command{
+	Use:   "fields [CATEGORY]",
+	Short: "List the fields screener queries can use",
+	Long: `List the fields --query and --sort can use, with the operators each
+supports and, for text fields, the values it allows. Pass a category such
+as valuation or esg to list only its fields.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fields := yfinance.ScreenFields()
+		if len(args) > 0 {
+			fields = slices.DeleteFunc(fields, func(f yfinance.ScreenField) bool {
+				return !strings.EqualFold(string(f.Category), args[0])
+			})
+			if len(fields) == 0 {
+				return fmt.Errorf("unknown field category %q", args[0])
+			}
+		}
+		return writeList(cmd, fields, screenFieldColumns, nil)
+	},
+}
+
+// screenFieldColumns are the fields printed for the screener field catalog
+var screenFieldColumns = []column[yfinance.ScreenField]{
+	{Header: "FIELD", Left: true, Value: func(f yfinance.ScreenField) any { return f.Name }},
+	{Header: "CATEGORY", Left: true, Value: func(f yfinance.ScreenField) any { return f.Category }},
+	{Header: "OPERATORS", Left: true, Value: func(f yfinance.ScreenField) any { return strings.Join(f.Operators, ",") }},
+	{Header: "DESCRIPTION", Left: true, Value: func(f yfinance.ScreenField) any { return f.Description }},
+}
+
+// screenAll fetches every page of pager into one result
+func screenAll(ctx context.Context, pager *yfinance.ScreenPager) (*yfinance.ScreenResult, error) {
+	result := &yfinance.ScreenResult{}
+	for pager.Next(ctx) {
+		page := pager.Page()
+		result.Title, result.Description = page.Title, page.Description
+		result.Quotes = append(result.Quotes, page.Quotes...)
+	}
+	result.Count, result.Total = len(result.Quotes), pager.Total()
+	return result, pager.Err()
+}
+
+// screenerPresets returns the preset names and saved screener IDs the
+// screener command accepts
+func screenerPresets() []string {
+	presets := []string{"gainers", "losers", "most-active", "high-dividend"}
+	for _, id := range yfinance.PredefinedScreeners() {
+		presets = append(presets, string(id))
+	}
+	return presets
+}
+
+// buildScreenCriteria resolves a preset name or custom query into screener criteria
+func buildScreenCriteria(preset, query string) (yfinance.ScreenCriteria, error) {
+	if preset != "" && query != "" {
+		return yfinance.ScreenCriteria{}, fmt.Errorf("use either a preset or --query, not both")
+	}
+
+	switch preset {
+	case "gainers":
+		return yfinance.GainersCriteria(screenerSize), nil
+	case "losers":
+		return yfinance.LosersCriteria(screenerSize), nil
+	case "most-active":
+		return yfinance.MostActiveCriteria(screenerSize), nil
+	case "high-dividend":
+		return yfinance.HighDividendCriteria(screenerMinYield, screenerSize), nil
+	case "":
+	default:
+		return yfinance.ScreenCriteria{}, fmt.Errorf("unknown preset %q (want gainers, losers, most-active, high-dividend, or a saved screener such as day_gainers)", preset)
+	}
+
+	if query == "" {
+		return yfinance.ScreenCriteria{}, fmt.Errorf("pass a preset or --query")
+	}
+
+	var q map[string]interface{}
+	if strings.HasPrefix(strings.TrimSpace(query), "{") {
+		if err := json.Unmarshal([]byte(query), &q); err != nil {
+			return yfinance.ScreenCriteria{}, fmt.Errorf("invalid JSON query: %w", err)
+		}
+	} else {
+		var err error
+		q, err = yfinance.ParseScreenQuery(query)
+		if err != nil {
+			return yfinance.ScreenCriteria{}, err
+		}
+	}
+
+	sortType := "DESC"
+	if screenerAsc {
+		sortType = "ASC"
+	}
+	return yfinance.ScreenCriteria{
+		Size:      screenerSize,
+		SortField: screenerSort,
+		SortType:  sortType,
+		Query:     q,
+	}, nil
+}
+
+// screenColumns are the fields printed for screener quotes
+var screenColumns = []column[yfinance.Quote]{
+	{Header: "SYMBOL", Left: true, Value: func(q yfinance.Quote) any { return q.Symbol }},
+	{Header: "NAME", Left: true, Value: func(q yfinance.Quote) any { return q.ShortName },
+		Text: func(q yfinance.Quote) string { return truncate(q.ShortName, 30) }},
+	{Header: "PRICE", Value: func(q yfinance.Quote) any { return q.RegularMarketPrice },
+		Text: func(q yfinance.Quote) string { return fmt.Sprintf("%.2f", q.RegularMarketPrice) }},
+	{Header: "CHANGE%", Signed: true, Value: func(q yfinance.Quote) any { return q.RegularMarketChangePercent },
+		Text: func(q yfinance.Quote) string { return fmt.Sprintf("%+.2f%%", q.RegularMarketChangePercent) }},
+	{Header: "VOLUME", Value: func(q yfinance.Quote) any { return q.RegularMarketVolume }},
+	{Header: "MARKET CAP", Value: func(q yfinance.Quote) any { return q.MarketCap },
+		Text: func(q yfinance.Quote) string { return formatMarketCap(q.MarketCap) }},
+	{Header: "DIVIDEND YIELD", Value: func(q yfinance.Quote) any { return q.DividendYield },
+		Text: func(q yfinance.Quote) string { return formatYield(q.DividendYield) }},
+}
+
+// formatYield renders a yield percent without a sign, or "-" when Yahoo did
+// not report one
+func formatYield(v float64) string {
+	if v == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", v)
+}
+
+// formatMarketCap renders a market cap with a T/B/M suffix
+func formatMarketCap(v int64) string {
+	f := float64(v)
+	switch {
+	case v == 0:
+		return "-"
+	case f >= 1e12:
+		return fmt.Sprintf("%.2fT", f/1e12)
+	case f >= 1e9:
+		return fmt.Sprintf("%.2fB", f/1e9)
+	case f >= 1e6:
+		return fmt.Sprintf("%.2fM", f/1e6)
+	default:
+		return strconv.FormatInt(v, 10)
+	}
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
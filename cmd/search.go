@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var (
+	searchType  string
+	searchCount int
+
+	lookupType  string
+	lookupCount int
+)
+
+func init() {
+	searchCmd.Flags().StringVar(&searchType, "type", "", "Only show results of this quote type (e.g. equity, etf, mutualfund, index, cryptocurrency)")
+	searchCmd.Flags().IntVarP(&searchCount, "count", "n", 10, "Maximum number of results")
+	rootCmd.AddCommand(searchCmd)
+
+	lookupCmd.Flags().StringVar(&lookupType, "type", "", "Lookup type (e.g. equity, etf, mutualfund, index, future, currency, cryptocurrency)")
+	lookupCmd.Flags().IntVarP(&lookupCount, "count", "n", 25, "Maximum number of results")
+	rootCmd.AddCommand(lookupCmd)
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search QUERY",
+	Short: "Search for symbols by company name or ticker",
+	Long: `Search Yahoo Finance for symbols matching a company name or partial ticker
+and print the symbol, name, exchange, and type of each match.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if searchCount <= 0 {
+			return fmt.Errorf("--count must be positive")
+		}
+
+		result, err := yfinance.Search(cmd.Context(), strings.Join(args, " "), yfinance.WithQuotesCount(searchCount))
+		if err != nil {
+			return err
+		}
+
+		quotes := result.Quotes
+		if searchType != "" {
+			quotes = quotes[:0:0]
+			for _, q := range result.Quotes {
+				if strings.EqualFold(q.QuoteType, searchType) {
+					quotes = append(quotes, q)
+				}
+			}
+		}
+
+		return writeList(cmd, quotes, searchColumns, nil)
+	},
+}
+
+var lookupCmd = &cobra.Command{
+	Use:   "lookup QUERY",
+	Short: "Look up symbols by type",
+	Long: `Look up Yahoo Finance symbols matching a query, optionally restricted to an
+instrument type, and print the symbol, name, exchange, and type of each match.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lookupCount <= 0 {
+			return fmt.Errorf("--count must be positive")
+		}
+
+		result, err := yfinance.Lookup(cmd.Context(), strings.Join(args, " "), strings.ToLower(lookupType))
+		if err != nil {
+			return err
+		}
+
+		items := result.Items
+		if len(items) > lookupCount {
+			items = items[:lookupCount]
+		}
+
+		return writeList(cmd, items, lookupColumns, nil)
+	},
+}
+
+// searchColumns are the fields printed for search matches, preferring
+// display names where Yahoo provides them
+var searchColumns = []column[yfinance.SearchQuote]{
+	{Header: "SYMBOL", Left: true, Value: func(q yfinance.SearchQuote) any { return q.Symbol }},
+	{Header: "NAME", Left: true, Value: func(q yfinance.SearchQuote) any { return firstNonEmpty(q.LongName, q.ShortName) }},
+	{Header: "EXCHANGE", Left: true, Value: func(q yfinance.SearchQuote) any { return firstNonEmpty(q.ExchDisp, q.Exchange) }},
+	{Header: "TYPE", Left: true, Value: func(q yfinance.SearchQuote) any { return firstNonEmpty(q.TypeDisp, q.QuoteType) }},
+}
+
+// lookupColumns are the fields printed for lookup matches
+var lookupColumns = []column[yfinance.LookupItem]{
+	{Header: "SYMBOL", Left: true, Value: func(item yfinance.LookupItem) any { return item.Symbol }},
+	{Header: "NAME", Left: true, Value: func(item yfinance.LookupItem) any { return item.Name }},
+	{Header: "EXCHANGE", Left: true, Value: func(item yfinance.LookupItem) any { return item.Exchange }},
+	{Header: "TYPE", Left: true, Value: func(item yfinance.LookupItem) any { return item.Type }},
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
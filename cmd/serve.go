@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/internal/server"
+)
+
+var (
+	serveListen    string
+	serveRate      float64
+	serveBurst     int
+	serveCacheSize int
+	serveQuiet     bool
+	serveGraphQL   bool
+)
+
+func init() {
+	serveCmd.Flags().StringVarP(&serveListen, "listen", "l", "127.0.0.1:8080", "Address to listen on")
+	serveCmd.Flags().Float64Var(&serveRate, "rate", 2, "Upstream Yahoo requests per second")
+	serveCmd.Flags().IntVar(&serveBurst, "burst", 5, "Upstream request burst size")
+	serveCmd.Flags().IntVar(&serveCacheSize, "cache-size", 1000, "Maximum number of cached responses")
+	serveCmd.Flags().BoolVarP(&serveQuiet, "quiet", "q", false, "Disable request logging")
+	serveCmd.Flags().BoolVar(&serveGraphQL, "graphql", false, "Also serve a GraphQL API on /graphql")
+	rootCmd.AddCommand(serveCmd)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a local REST API proxy for Yahoo Finance",
+	Long: `Serve Yahoo Finance data as JSON over HTTP so non-Go programs can use gotick
+as a local proxy. Responses are cached per endpoint and upstream requests
+are rate limited.
+
+Endpoints:
+  GET /quote/{symbol}          one symbol, or a comma-separated list
+  GET /history/{symbol}        ?period=&interval=&start=&end=&prepost=
+  GET /options/{symbol}        ?expiration=YYYY-MM-DD&greeks=true&rate=
+  GET /search                  ?q=&count=
+  GET /screener/{preset}       gainers, losers, most-active, high-dividend; ?size=&offset=&min_yield=
+
+With --graphql, POST /graphql (or GET ?query=) accepts queries such as:
+
+  { ticker(symbol: "AAPL") { quote { regularMarketPrice } history(period: "5d") { bars { close } } } }
+
+Ticker fields are quote, history(period, interval, start, end),
+options(expiration, rate), and financials(statement, quarterly); use
+tickers(symbols: [...]) to batch several symbols in one request.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := server.Options{
+			RequestsPerSecond: serveRate,
+			Burst:             serveBurst,
+			CacheSize:         serveCacheSize,
+			GraphQL:           serveGraphQL,
+		}
+		if !serveQuiet {
+			opts.Logger = log.New(cmd.ErrOrStderr(), "", log.LstdFlags)
+		}
+
+		handler, err := server.New(opts)
+		if err != nil {
+			return err
+		}
+		srv := &http.Server{
+			Addr:              serveListen,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx := cmd.Context()
+		go func() {
+			<-ctx.Done()
+			_ = srv.Close()
+		}()
+
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "listening on http://%s\n", serveListen)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
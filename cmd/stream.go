@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var (
+	streamReconnect bool
+	streamRetryWait time.Duration
+)
+
+func init() {
+	streamCmd.Flags().BoolVar(&streamReconnect, "reconnect", true, "Reconnect when the connection drops")
+	streamCmd.Flags().DurationVar(&streamRetryWait, "retry-wait", 5*time.Second, "Delay before reconnecting")
+	rootCmd.AddCommand(streamCmd)
+}
+
+var streamCmd = &cobra.Command{
+	Use:   "stream [SYMBOL...]",
+	Short: "Stream live ticks as newline-delimited JSON",
+	Long: `Connect to the Yahoo Finance WebSocket for the given symbols and write one
+JSON object per tick to stdout, suitable for piping into jq, message queue
+producers, or files. Use --output csv or table for other line formats.
+Connection errors are reported on stderr.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		symbols, err := resolveSymbols(args)
+		if err != nil {
+			return err
+		}
+
+		format, err := resolveOutput(cmd, outputNDJSON)
+		if err != nil {
+			return err
+		}
+		w := newListWriter(cmd.OutOrStdout(), format, tickColumns)
+
+		ctx := cmd.Context()
+		for {
+			err := streamTicks(ctx, symbols, w, cmd.ErrOrStderr())
+			if ctx.Err() != nil {
+				return nil
+			}
+			if !streamReconnect {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "stream: %v, reconnecting in %s\n", err, streamRetryWait)
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(streamRetryWait):
+			}
+		}
+	},
+}
+
+// streamTicks writes ticks for symbols to w until the connection drops or
+// ctx is done. Undecodable messages are reported to errOut and skipped.
+func streamTicks(ctx context.Context, symbols []string, w *listWriter[yfinance.StreamMessage], errOut io.Writer) error {
+	stream := yfinance.NewStream(symbols)
+	if err := stream.Connect(ctx); err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+
+	messages, errs := stream.Messages(), stream.Errors()
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				if lastErr != nil {
+					return lastErr
+				}
+				return fmt.Errorf("connection closed")
+			}
+			if msg.ID == "" {
+				continue
+			}
+			if err := w.WriteRecords([]yfinance.StreamMessage{msg}); err != nil {
+				return err
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			lastErr = err
+			_, _ = fmt.Fprintf(errOut, "stream: %v\n", err)
+		}
+	}
+}
+
+// tickColumns are the fields printed for stream ticks in table and CSV output
+var tickColumns = []column[yfinance.StreamMessage]{
+	{Header: "TIME", Left: true, Value: func(m yfinance.StreamMessage) any {
+		return time.UnixMilli(m.Time).UTC().Format(time.RFC3339)
+	}, Text: func(m yfinance.StreamMessage) string {
+		return time.UnixMilli(m.Time).Local().Format("15:04:05")
+	}},
+	{Header: "SYMBOL", Left: true, Value: func(m yfinance.StreamMessage) any { return m.ID },
+		Text: func(m yfinance.StreamMessage) string { return fmt.Sprintf("%-8s", m.ID) }},
+	{Header: "PRICE", Value: func(m yfinance.StreamMessage) any { return m.Price },
+		Text: func(m yfinance.StreamMessage) string { return fmt.Sprintf("%12.2f", m.Price) }},
+	{Header: "CHANGE", Signed: true, Value: func(m yfinance.StreamMessage) any { return m.Change },
+		Text: func(m yfinance.StreamMessage) string { return fmt.Sprintf("%+10.2f", m.Change) }},
+	{Header: "CHANGE%", Signed: true, Value: func(m yfinance.StreamMessage) any { return m.ChangePercent },
+		Text: func(m yfinance.StreamMessage) string { return fmt.Sprintf("%+8.2f%%", m.ChangePercent) }},
+	{Header: "VOLUME", Value: func(m yfinance.StreamMessage) any { return m.DayVolume },
+		Text: func(m yfinance.StreamMessage) string { return fmt.Sprintf("%14d", m.DayVolume) }},
+}
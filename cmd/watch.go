@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var (
+	watchInterval time.Duration
+	watchPoll     bool
+)
+
+func init() {
+	watchCmd.Flags().DurationVarP(&watchInterval, "interval", "n", 2*time.Second, "Screen refresh interval (also the polling interval)")
+	watchCmd.Flags().BoolVar(&watchPoll, "poll", false, "Poll quotes instead of using the live stream")
+	rootCmd.AddCommand(watchCmd)
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [SYMBOL...]",
+	Short: "Show a live one-line-per-symbol price table",
+	Long: `Print a continuously refreshing table of live prices, one line per symbol.
+Prices come from the WebSocket stream when it is available; if the stream
+cannot connect or drops, the command falls back to polling quotes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if watchInterval < 500*time.Millisecond {
+			return fmt.Errorf("--interval must be at least 500ms")
+		}
+		if format, err := resolveOutput(cmd, outputTable); err != nil {
+			return err
+		} else if format != outputTable {
+			return fmt.Errorf("watch only supports table output, use stream for %s", format)
+		}
+
+		symbols, err := resolveSymbols(args)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+		tape := newTickerTape(symbols)
+
+		quotes, err := yfinance.QuoteMultiple(ctx, symbols)
+		if err != nil {
+			return err
+		}
+		tape.applyQuotes(quotes)
+
+		var ticks <-chan yfinance.StreamMessage
+		var streamErrs <-chan error
+		if !watchPoll {
+			stream := yfinance.NewStream(symbols)
+			if err := stream.Connect(ctx); err == nil {
+				defer func() { _ = stream.Close() }()
+				ticks = stream.Messages()
+				streamErrs = stream.Errors()
+				tape.source = "stream"
+			}
+		}
+
+		refresh := time.NewTicker(watchInterval)
+		defer refresh.Stop()
+		tape.render(out)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case msg, ok := <-ticks:
+				if !ok {
+					// Stream dropped, keep going on polling
+					ticks = nil
+					tape.source = "poll"
+					continue
+				}
+				tape.applyTick(msg)
+			case err, ok := <-streamErrs:
+				if !ok {
+					streamErrs = nil
+					continue
+				}
+				tape.lastErr = err
+			case <-refresh.C:
+				if ticks == nil {
+					if quotes, err := yfinance.QuoteMultiple(ctx, symbols); err == nil {
+						tape.applyQuotes(quotes)
+					} else {
+						tape.lastErr = err
+					}
+				}
+				tape.render(out)
+			}
+		}
+	},
+}
+
+// tapeRow is the latest known state for one symbol
+type tapeRow struct {
+	symbol        string
+	name          string
+	price         float64
+	change        float64
+	changePercent float64
+	volume        int64
+	state         string
+	updated       time.Time
+}
+
+// tickerTape holds the rows shown by the watch command
+type tickerTape struct {
+	symbols []string
+	rows    map[string]*tapeRow
+	source  string
+	lastErr error
+}
+
+func newTickerTape(symbols []string) *tickerTape {
+	t := &tickerTape{symbols: symbols, rows: make(map[string]*tapeRow, len(symbols)), source: "poll"}
+	for _, s := range symbols {
+		t.rows[s] = &tapeRow{symbol: s}
+	}
+	return t
+}
+
+// applyQuotes refreshes rows from polled quotes
+func (t *tickerTape) applyQuotes(quotes []yfinance.Quote) {
+	t.lastErr = nil
+	for _, q := range quotes {
+		row, ok := t.rows[q.Symbol]
+		if !ok {
+			continue
+		}
+		row.name = q.ShortName
+		row.price = q.RegularMarketPrice
+		row.change = q.RegularMarketChange
+		row.changePercent = q.RegularMarketChangePercent
+		row.volume = q.RegularMarketVolume
+		row.state = q.MarketState
+		row.updated = time.Now()
+	}
+}
+
+// applyTick refreshes a row from a stream message
+func (t *tickerTape) applyTick(msg yfinance.StreamMessage) {
+	row, ok := t.rows[msg.ID]
+	if !ok || msg.Price == 0 {
+		return
+	}
+	row.price = msg.Price
+	row.change = msg.Change
+	row.changePercent = msg.ChangePercent
+	if msg.DayVolume > 0 {
+		row.volume = msg.DayVolume
+	}
+	row.updated = time.Now()
+}
+
+// render clears the screen and draws the table
+func (t *tickerTape) render(out io.Writer) {
+	_, _ = fmt.Fprint(out, "\033[H\033[2J")
+	_, _ = fmt.Fprintf(out, "gotick watch  %s  (%s, every %s)\n\n", time.Now().Format("15:04:05"), t.source, watchInterval)
+
+	rows := make([]*tapeRow, len(t.symbols))
+	for i, s := range t.symbols {
+		rows[i] = t.rows[s]
+	}
+	_ = newListWriter(out, outputTable, tapeColumns).Write(rows, nil)
+
+	if t.lastErr != nil {
+		_, _ = fmt.Fprintf(out, "\nlast refresh failed: %v\n", t.lastErr)
+	}
+}
+
+// tapeColumns are the fields shown by the watch command
+var tapeColumns = []column[*tapeRow]{
+	{Header: "SYMBOL", Left: true, Value: func(r *tapeRow) any { return r.symbol }},
+	{Header: "NAME", Left: true, Value: func(r *tapeRow) any { return truncate(r.name, 24) }},
+	{Header: "PRICE", Value: func(r *tapeRow) any { return fmt.Sprintf("%.2f", r.price) }},
+	{Header: "CHANGE", Signed: true, Value: func(r *tapeRow) any { return fmt.Sprintf("%+.2f", r.change) }},
+	{Header: "CHANGE%", Signed: true, Value: func(r *tapeRow) any { return fmt.Sprintf("%+.2f%%", r.changePercent) }},
+	{Header: "VOLUME", Value: func(r *tapeRow) any { return r.volume }},
+	{Header: "STATE", Value: func(r *tapeRow) any { return r.state }},
+	{Header: "UPDATED", Value: func(r *tapeRow) any {
+		if r.updated.IsZero() {
+			return "-"
+		}
+		return r.updated.Format("15:04:05")
+	}},
+}
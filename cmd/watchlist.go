@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/amjadjibon/gotick/internal/watchlist"
+)
+
+var (
+	watchlistFile   string
+	watchlistNote   string
+	watchlistTarget float64
+)
+
+func init() {
+	watchlistCmd.PersistentFlags().StringVar(&watchlistFile, "file", "", "Watchlists file (default $XDG_CONFIG_HOME/gotick/watchlists.yaml, or $GOTICK_WATCHLISTS)")
+	watchlistAddCmd.Flags().StringVar(&watchlistNote, "note", "", "Note to keep with the symbols")
+	watchlistAddCmd.Flags().Float64Var(&watchlistTarget, "target", 0, "Target price for the symbols")
+	watchlistCmd.AddCommand(watchlistShowCmd, watchlistAddCmd, watchlistRemoveCmd, watchlistRenameCmd, watchlistDeleteCmd)
+	rootCmd.AddCommand(watchlistCmd)
+}
+
+var watchlistCmd = &cobra.Command{
+	Use:   "watchlist",
+	Short: "Manage named watchlists",
+	Long: `List, show, and edit named watchlists of symbols. Each symbol can carry a
+note and a target price. Without a subcommand, the watchlist names are
+printed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := loadWatchlists()
+		if err != nil {
+			return err
+		}
+		for _, name := range store.Names() {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), name)
+		}
+		return nil
+	},
+}
+
+var watchlistShowCmd = &cobra.Command{
+	Use:   "show NAME",
+	Short: "Print a watchlist with live quotes",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := loadWatchlists()
+		if err != nil {
+			return err
+		}
+		list, err := store.Get(args[0])
+		if err != nil {
+			return err
+		}
+		items, err := list.Quotes(cmd.Context())
+		if err != nil {
+			return err
+		}
+		return writeList(cmd, items, watchlistColumns, list)
+	},
+}
+
+var watchlistAddCmd = &cobra.Command{
+	Use:   "add NAME SYMBOL...",
+	Short: "Add symbols to a watchlist, creating it if needed",
+	Long: `Add symbols to a watchlist, creating it if needed. Symbols already on the
+list keep their place, and --note and --target update them when given.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := loadWatchlists()
+		if err != nil {
+			return err
+		}
+		items := make([]watchlist.Item, len(args)-1)
+		for i, sym := range args[1:] {
+			items[i] = watchlist.Item{Symbol: sym, Note: watchlistNote, TargetPrice: watchlistTarget}
+		}
+		if err := store.Add(args[0], items...); err != nil {
+			return err
+		}
+		return store.Save()
+	},
+}
+
+var watchlistRemoveCmd = &cobra.Command{
+	Use:   "remove NAME SYMBOL...",
+	Short: "Remove symbols from a watchlist",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return editWatchlists(func(store *watchlist.Store) error { return store.Remove(args[0], args[1:]...) })
+	},
+}
+
+var watchlistRenameCmd = &cobra.Command{
+	Use:   "rename NAME NEW_NAME",
+	Short: "Rename a watchlist",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return editWatchlists(func(store *watchlist.Store) error { return store.Rename(args[0], args[1]) })
+	},
+}
+
+var watchlistDeleteCmd = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Delete a watchlist",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return editWatchlists(func(store *watchlist.Store) error { return store.Delete(args[0]) })
+	},
+}
+
+// watchlistColumns are the fields printed for a quoted watchlist
+var watchlistColumns = []column[watchlist.QuotedItem]{
+	{Header: "SYMBOL", Left: true, Value: func(i watchlist.QuotedItem) any { return i.Symbol }},
+	{Header: "PRICE", Value: func(i watchlist.QuotedItem) any { return quotedPrice(i) },
+		Text: func(i watchlist.QuotedItem) string { return formatOptionalPrice(quotedPrice(i)) }},
+	{Header: "CHANGE%", Signed: true, Value: func(i watchlist.QuotedItem) any { return optionalValue(quotedPrice(i), quotedChange(i)) },
+		Text: func(i watchlist.QuotedItem) string { return formatOptionalPercent(quotedPrice(i), quotedChange(i)) }},
+	{Header: "TARGET", Value: func(i watchlist.QuotedItem) any { return optionalValue(i.TargetPrice, i.TargetPrice) },
+		Text: func(i watchlist.QuotedItem) string { return formatOptionalPrice(i.TargetPrice) }},
+	{Header: "TO TARGET", Signed: true, Value: func(i watchlist.QuotedItem) any { return optionalValue(i.ToTarget, i.ToTarget*100) },
+		Text: func(i watchlist.QuotedItem) string { return formatOptionalPercent(i.ToTarget, i.ToTarget*100) }},
+	{Header: "NOTE", Left: true, Value: func(i watchlist.QuotedItem) any { return i.Note }},
+}
+
+// quotedPrice returns the item's price, or zero without a quote
+func quotedPrice(i watchlist.QuotedItem) float64 {
+	if i.Quote == nil {
+		return 0
+	}
+	return i.Quote.RegularMarketPrice
+}
+
+// quotedChange returns the item's change percent, or zero without a quote
+func quotedChange(i watchlist.QuotedItem) float64 {
+	if i.Quote == nil {
+		return 0
+	}
+	return i.Quote.RegularMarketChangePercent
+}
+
+// loadWatchlists opens the watchlists file from --file or the default path
+func loadWatchlists() (*watchlist.Store, error) {
+	path := expandHome(watchlistFile)
+	if path == "" {
+		path = watchlist.DefaultPath()
+	}
+	if path == "" {
+		return nil, fmt.Errorf("no watchlists file: pass --file or set %s", watchlist.EnvWatchlists)
+	}
+	return watchlist.Load(path)
+}
+
+// editWatchlists applies edit to the watchlists and saves them
+func editWatchlists(edit func(*watchlist.Store) error) error {
+	store, err := loadWatchlists()
+	if err != nil {
+		return err
+	}
+	if err := edit(store); err != nil {
+		return err
+	}
+	return store.Save()
+}
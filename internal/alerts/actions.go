@@ -0,0 +1,147 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const defaultMessage = `{{.Rule.Symbol}} {{.Rule.Condition}} {{.Rule.Value}}: price {{printf "%.2f" .Snapshot.Price}} ({{printf "%+.2f" .Snapshot.ChangePercent}}%)`
+
+// Notifier runs the actions of fired rules
+type Notifier struct {
+	SMTP       SMTPConfig
+	HTTPClient *http.Client
+}
+
+// Notify runs every action of the event's rule, returning the first error
+// after attempting all of them
+func (n *Notifier) Notify(ctx context.Context, ev Event) error {
+	msg, err := renderMessage(ev)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, a := range ev.Rule.Actions {
+		var err error
+		switch a.Type {
+		case ActionWebhook:
+			err = n.webhook(ctx, a, msg)
+		case ActionEmail:
+			err = n.email(a, ev, msg)
+		case ActionExec:
+			err = runCommand(ctx, a, ev, msg)
+		default:
+			err = fmt.Errorf("unknown action type %q", a.Type)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("alerts: rule %q: %s action: %w", ev.Rule.Name, a.Type, err)
+		}
+	}
+	return firstErr
+}
+
+// renderMessage expands the rule's message template, or the default one
+func renderMessage(ev Event) (string, error) {
+	text := ev.Rule.Message
+	if text == "" {
+		text = defaultMessage
+	}
+	tmpl, err := template.New(ev.Rule.Name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("alerts: rule %q: invalid message template: %w", ev.Rule.Name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ev); err != nil {
+		return "", fmt.Errorf("alerts: rule %q: %w", ev.Rule.Name, err)
+	}
+	return buf.String(), nil
+}
+
+// webhook posts the message in the payload shape expected by the target
+func (n *Notifier) webhook(ctx context.Context, a Action, msg string) error {
+	var payload any
+	switch strings.ToLower(a.Format) {
+	case "slack":
+		payload = map[string]string{"text": msg}
+	case "discord":
+		payload = map[string]string{"content": msg}
+	default:
+		payload = map[string]string{"message": msg}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// email sends the message through the configured SMTP server
+func (n *Notifier) email(a Action, ev Event, msg string) error {
+	subject := a.Subject
+	if subject == "" {
+		subject = "gotick alert: " + ev.Rule.Symbol
+	}
+
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "From: %s\r\n", n.SMTP.From)
+	_, _ = fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(a.To, ", "))
+	_, _ = fmt.Fprintf(&buf, "Subject: %s\r\n", subject)
+	_, _ = fmt.Fprintf(&buf, "Content-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n", msg)
+
+	var auth smtp.Auth
+	if n.SMTP.Username != "" {
+		auth = smtp.PlainAuth("", n.SMTP.Username, n.SMTP.Password, n.SMTP.Host)
+	}
+	addr := net.JoinHostPort(n.SMTP.Host, strconv.Itoa(n.SMTP.Port))
+	return smtp.SendMail(addr, auth, n.SMTP.From, a.To, buf.Bytes())
+}
+
+// runCommand runs the action's shell command with alert details in the environment
+func runCommand(ctx context.Context, a Action, ev Event, msg string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", a.Command) //nolint:gosec // G204: commands come from the user's own config
+	cmd.Env = append(os.Environ(),
+		"GOTICK_RULE="+ev.Rule.Name,
+		"GOTICK_SYMBOL="+ev.Snapshot.Symbol,
+		"GOTICK_PRICE="+strconv.FormatFloat(ev.Snapshot.Price, 'f', -1, 64),
+		"GOTICK_CHANGE_PERCENT="+strconv.FormatFloat(ev.Snapshot.ChangePercent, 'f', -1, 64),
+		"GOTICK_MESSAGE="+msg,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
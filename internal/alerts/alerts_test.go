@@ -0,0 +1,106 @@
+package alerts
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestEngineCooldown tests that rules respect their cooldown
+func TestEngineCooldown(t *testing.T) {
+	rules := []Rule{
+		{Name: "above", Symbol: "AAPL", Condition: PriceAbove, Value: 200, Cooldown: time.Hour},
+		{Name: "drop", Symbol: "AAPL", Condition: ChangePercentBelow, Value: -5},
+	}
+	e, err := NewEngine(rules, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	if got := e.Evaluate(Snapshot{Symbol: "AAPL", Price: 190, Time: now}); len(got) != 0 {
+		t.Errorf("Expected no events below threshold, got %d", len(got))
+	}
+	if got := e.Evaluate(Snapshot{Symbol: "AAPL", Price: 201, Time: now}); len(got) != 1 || got[0].Rule.Name != "above" {
+		t.Errorf("Expected above to fire, got %v", got)
+	}
+	if got := e.Evaluate(Snapshot{Symbol: "AAPL", Price: 202, Time: now.Add(time.Minute)}); len(got) != 0 {
+		t.Errorf("Expected cooldown to suppress, got %d events", len(got))
+	}
+	if got := e.Evaluate(Snapshot{Symbol: "AAPL", Price: 202, Time: now.Add(2 * time.Hour)}); len(got) != 1 {
+		t.Errorf("Expected rule to fire after cooldown, got %d events", len(got))
+	}
+	if got := e.Evaluate(Snapshot{Symbol: "MSFT", Price: 500, Time: now}); len(got) != 0 {
+		t.Errorf("Expected no events for other symbols, got %d", len(got))
+	}
+}
+
+// TestEngineEdgeTrigger tests that rules without a cooldown fire once each
+// time their condition starts to hold
+func TestEngineEdgeTrigger(t *testing.T) {
+	e, err := NewEngine([]Rule{{Name: "above", Symbol: "AAPL", Condition: PriceAbove, Value: 200}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	var fired int
+	for i, price := range []float64{201, 202, 203, 199, 204, 205} {
+		fired += len(e.Evaluate(Snapshot{Symbol: "AAPL", Price: price, Time: now.Add(time.Duration(i) * time.Second)}))
+	}
+	if fired != 2 {
+		t.Errorf("Expected rule to fire once per crossing, fired %d times", fired)
+	}
+}
+
+// TestEngineStatePersistence tests that last-fired times survive a restart
+func TestEngineStatePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "alerts.json")
+	rules := []Rule{{Name: "above", Symbol: "AAPL", Condition: PriceAbove, Value: 1, Cooldown: time.Hour}}
+
+	e, err := NewEngine(rules, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if len(e.Evaluate(Snapshot{Symbol: "AAPL", Price: 2, Time: now})) != 1 {
+		t.Fatal("Expected rule to fire")
+	}
+	if err := e.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := NewEngine(rules, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.Evaluate(Snapshot{Symbol: "AAPL", Price: 2, Time: now.Add(time.Minute)}); len(got) != 0 {
+		t.Errorf("Expected persisted cooldown to suppress, got %d events", len(got))
+	}
+}
+
+// TestConfigValidate tests rule validation
+func TestConfigValidate(t *testing.T) {
+	valid := Config{Rules: []Rule{{
+		Symbol: "aapl", Condition: PriceAbove, Value: 1,
+		Actions: []Action{{Type: ActionExec, Command: "true"}},
+	}}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if valid.Rules[0].Symbol != "AAPL" || valid.Rules[0].Name == "" {
+		t.Errorf("Expected normalized symbol and generated name, got %+v", valid.Rules[0])
+	}
+
+	bad := []Config{
+		{},
+		{Rules: []Rule{{Symbol: "AAPL", Condition: "sideways", Actions: []Action{{Type: ActionExec, Command: "true"}}}}},
+		{Rules: []Rule{{Symbol: "AAPL", Condition: PriceAbove}}},
+		{Rules: []Rule{{Symbol: "AAPL", Condition: PriceAbove, Actions: []Action{{Type: ActionEmail, To: []string{"a@b"}}}}}},
+	}
+	for i, c := range bad {
+		if err := c.Validate(); err == nil {
+			t.Errorf("config %d: expected error", i)
+		}
+	}
+}
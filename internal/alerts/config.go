@@ -0,0 +1,157 @@
+// Package alerts evaluates price alert rules and fires notification actions.
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Condition names a comparison between live data and a rule's threshold
+type Condition string
+
+// Supported alert conditions
+const (
+	PriceAbove         Condition = "price_above"
+	PriceBelow         Condition = "price_below"
+	ChangePercentAbove Condition = "change_percent_above"
+	ChangePercentBelow Condition = "change_percent_below"
+)
+
+// Action types
+const (
+	ActionWebhook = "webhook"
+	ActionEmail   = "email"
+	ActionExec    = "exec"
+)
+
+// Config is the alerts configuration file
+type Config struct {
+	Interval  time.Duration `yaml:"interval"`   // Polling interval when the stream is unavailable
+	StateFile string        `yaml:"state_file"` // Where last-fired times are persisted
+	SMTP      SMTPConfig    `yaml:"smtp"`
+	Rules     []Rule        `yaml:"rules"`
+}
+
+// SMTPConfig configures outgoing email
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+// Rule triggers actions when a symbol meets a condition
+type Rule struct {
+	Name      string        `yaml:"name"`
+	Symbol    string        `yaml:"symbol"`
+	Condition Condition     `yaml:"condition"`
+	Value     float64       `yaml:"value"`
+	Cooldown  time.Duration `yaml:"cooldown"` // Minimum time between firings; zero fires once per crossing
+	Message   string        `yaml:"message"`  // Optional text/template for notifications
+	Actions   []Action      `yaml:"actions"`
+}
+
+// Action describes what to do when a rule fires
+type Action struct {
+	Type    string   `yaml:"type"`
+	URL     string   `yaml:"url"`     // webhook
+	Format  string   `yaml:"format"`  // webhook: slack, discord, or generic
+	To      []string `yaml:"to"`      // email
+	Subject string   `yaml:"subject"` // email
+	Command string   `yaml:"command"` // exec, run with sh -c
+}
+
+// LoadConfig reads and validates a YAML alerts configuration
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is supplied by the user
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("alerts: failed to parse %s: %w", path, err)
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 30 * time.Second
+	}
+	if cfg.SMTP.Port == 0 {
+		cfg.SMTP.Port = 587
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Validate checks rules and actions for missing or unknown fields
+func (c *Config) Validate() error {
+	if len(c.Rules) == 0 {
+		return errors.New("alerts: no rules configured")
+	}
+
+	names := make(map[string]bool, len(c.Rules))
+	for i := range c.Rules {
+		r := &c.Rules[i]
+		r.Symbol = strings.ToUpper(strings.TrimSpace(r.Symbol))
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("%s-%s-%g", r.Symbol, r.Condition, r.Value)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("alerts: duplicate rule name %q", r.Name)
+		}
+		names[r.Name] = true
+
+		if r.Symbol == "" {
+			return fmt.Errorf("alerts: rule %q: missing symbol", r.Name)
+		}
+		switch r.Condition {
+		case PriceAbove, PriceBelow, ChangePercentAbove, ChangePercentBelow:
+		default:
+			return fmt.Errorf("alerts: rule %q: unknown condition %q", r.Name, r.Condition)
+		}
+		if len(r.Actions) == 0 {
+			return fmt.Errorf("alerts: rule %q: no actions", r.Name)
+		}
+
+		for _, a := range r.Actions {
+			switch a.Type {
+			case ActionWebhook:
+				if a.URL == "" {
+					return fmt.Errorf("alerts: rule %q: webhook action needs a url", r.Name)
+				}
+			case ActionEmail:
+				if len(a.To) == 0 {
+					return fmt.Errorf("alerts: rule %q: email action needs recipients", r.Name)
+				}
+				if c.SMTP.Host == "" {
+					return fmt.Errorf("alerts: rule %q: email action needs smtp.host", r.Name)
+				}
+			case ActionExec:
+				if a.Command == "" {
+					return fmt.Errorf("alerts: rule %q: exec action needs a command", r.Name)
+				}
+			default:
+				return fmt.Errorf("alerts: rule %q: unknown action type %q", r.Name, a.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// Symbols returns the distinct symbols referenced by the rules
+func (c *Config) Symbols() []string {
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, r := range c.Rules {
+		if !seen[r.Symbol] {
+			seen[r.Symbol] = true
+			symbols = append(symbols, r.Symbol)
+		}
+	}
+	return symbols
+}
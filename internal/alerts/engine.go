@@ -0,0 +1,133 @@
+package alerts
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Snapshot is the latest market data for a symbol
+type Snapshot struct {
+	Symbol        string
+	Price         float64
+	ChangePercent float64
+	Time          time.Time
+}
+
+// Event is a rule that fired for a snapshot
+type Event struct {
+	Rule     Rule
+	Snapshot Snapshot
+}
+
+// Engine evaluates rules against snapshots, enforcing cooldowns and
+// persisting the time each rule last fired
+type Engine struct {
+	rules     []Rule
+	stateFile string
+	lastFired map[string]time.Time
+	holding   map[string]bool // Rules whose condition held at the last snapshot
+	mu        sync.Mutex
+}
+
+// NewEngine creates an engine for the given rules, loading persisted state
+// from stateFile when it exists
+func NewEngine(rules []Rule, stateFile string) (*Engine, error) {
+	e := &Engine{
+		rules:     rules,
+		stateFile: stateFile,
+		lastFired: make(map[string]time.Time),
+		holding:   make(map[string]bool),
+	}
+
+	if stateFile == "" {
+		return e, nil
+	}
+	data, err := os.ReadFile(stateFile) //nolint:gosec // G304: path is supplied by the user
+	if errors.Is(err, os.ErrNotExist) {
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &e.lastFired); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Evaluate returns the rules triggered by s. A rule fires when its
+// condition starts to hold, and again after each cooldown while it keeps
+// holding; without a cooldown it waits for the condition to clear first.
+// It never fires before its cooldown has elapsed since it last fired.
+func (e *Engine) Evaluate(s Snapshot) []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var events []Event
+	for _, r := range e.rules {
+		if r.Symbol != s.Symbol {
+			continue
+		}
+		if !r.matches(s) {
+			e.holding[r.Name] = false
+			continue
+		}
+		held := e.holding[r.Name]
+		e.holding[r.Name] = true
+		if held && r.Cooldown == 0 {
+			continue
+		}
+		if last, ok := e.lastFired[r.Name]; ok && s.Time.Sub(last) < r.Cooldown {
+			continue
+		}
+		e.lastFired[r.Name] = s.Time
+		events = append(events, Event{Rule: r, Snapshot: s})
+	}
+	return events
+}
+
+// matches reports whether the rule's condition holds for s
+func (r Rule) matches(s Snapshot) bool {
+	if s.Price == 0 {
+		return false
+	}
+	switch r.Condition {
+	case PriceAbove:
+		return s.Price > r.Value
+	case PriceBelow:
+		return s.Price < r.Value
+	case ChangePercentAbove:
+		return s.ChangePercent > r.Value
+	case ChangePercentBelow:
+		return s.ChangePercent < r.Value
+	default:
+		return false
+	}
+}
+
+// Save writes the last-fired times to the state file atomically
+func (e *Engine) Save() error {
+	if e.stateFile == "" {
+		return nil
+	}
+
+	e.mu.Lock()
+	data, err := json.MarshalIndent(e.lastFired, "", "  ")
+	e.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(e.stateFile), 0o755); err != nil { //nolint:gosec // G301: state directory is user-owned
+		return err
+	}
+	tmp := e.stateFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, e.stateFile)
+}
@@ -0,0 +1,111 @@
+package alerts
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// actionQueue is how many fired events may wait for their actions before
+// further events are dropped
+const actionQueue = 64
+
+// Run monitors the configured symbols until ctx is done, firing actions for
+// triggered rules. Live prices come from the stream; when it cannot connect
+// or drops, quotes are polled every cfg.Interval. Actions run on a separate
+// worker so a slow webhook or command doesn't stall the stream.
+func Run(ctx context.Context, cfg *Config, logger *log.Logger) error {
+	engine, err := NewEngine(cfg.Rules, cfg.StateFile)
+	if err != nil {
+		return err
+	}
+	notifier := &Notifier{SMTP: cfg.SMTP}
+	symbols := cfg.Symbols()
+
+	fired := make(chan Event, actionQueue)
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for ev := range fired {
+			if err := notifier.Notify(ctx, ev); err != nil {
+				logger.Print(err)
+			}
+		}
+	})
+	defer func() {
+		close(fired)
+		wg.Wait()
+	}()
+
+	handle := func(s Snapshot) {
+		events := engine.Evaluate(s)
+		for _, ev := range events {
+			logger.Printf("rule %s fired: %s at %.2f", ev.Rule.Name, ev.Snapshot.Symbol, ev.Snapshot.Price)
+			select {
+			case fired <- ev:
+			default:
+				logger.Printf("alerts: action queue full, dropping actions for rule %s", ev.Rule.Name)
+			}
+		}
+		if len(events) > 0 {
+			if err := engine.Save(); err != nil {
+				logger.Printf("alerts: failed to save state: %v", err)
+			}
+		}
+	}
+
+	poll := func() {
+		quotes, err := yfinance.QuoteMultiple(ctx, symbols)
+		if err != nil {
+			logger.Printf("alerts: quote poll failed: %v", err)
+			return
+		}
+		now := time.Now()
+		for _, q := range quotes {
+			handle(Snapshot{Symbol: q.Symbol, Price: q.RegularMarketPrice, ChangePercent: q.RegularMarketChangePercent, Time: now})
+		}
+	}
+
+	// Seed with a poll so rules are checked even when the market is quiet
+	poll()
+
+	var ticks <-chan yfinance.StreamMessage
+	var streamErrs <-chan error
+	stream := yfinance.NewStream(symbols)
+	if err := stream.Connect(ctx); err == nil {
+		defer func() { _ = stream.Close() }()
+		ticks, streamErrs = stream.Messages(), stream.Errors()
+		logger.Printf("alerts: monitoring %d symbols via stream", len(symbols))
+	} else {
+		logger.Printf("alerts: stream unavailable (%v), polling every %s", err, cfg.Interval)
+	}
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-ticks:
+			if !ok {
+				ticks = nil
+				logger.Printf("alerts: stream closed, polling every %s", cfg.Interval)
+				continue
+			}
+			handle(Snapshot{Symbol: msg.ID, Price: msg.Price, ChangePercent: msg.ChangePercent, Time: time.Now()})
+		case err, ok := <-streamErrs:
+			if !ok {
+				streamErrs = nil
+				continue
+			}
+			logger.Printf("alerts: stream: %v", err)
+		case <-ticker.C:
+			if ticks == nil {
+				poll()
+			}
+		}
+	}
+}
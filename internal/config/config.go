@@ -0,0 +1,217 @@
+// Package config loads gotick settings shared by the CLI and the TUI.
+//
+// Settings come from a YAML file (by default $XDG_CONFIG_HOME/gotick/config.yaml)
+// and can be overridden with GOTICK_* environment variables. Command-line
+// flags take precedence over both.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// Environment variables that override file settings
+const (
+	EnvConfig      = "GOTICK_CONFIG"       // Path to the config file
+	EnvProxy       = "GOTICK_PROXY"        // HTTP proxy URL
+	EnvCacheDir    = "GOTICK_CACHE_DIR"    // Cache directory
+	EnvCache       = "GOTICK_CACHE"        // Cache Yahoo responses (true or false)
+	EnvCacheRedis  = "GOTICK_CACHE_REDIS"  // Redis URL for a shared cache
+	EnvCacheSQLite = "GOTICK_CACHE_SQLITE" // SQLite database to cache in
+	EnvOutput      = "GOTICK_OUTPUT"       // Default output format
+	EnvSymbols     = "GOTICK_SYMBOLS"      // Comma-separated default symbols
+	EnvRateLimit   = "GOTICK_RATE_LIMIT"   // Requests per second
+)
+
+// Config holds user settings
+type Config struct {
+	Symbols     []string  `yaml:"symbols"`      // Default symbols when a command is given none
+	Output      string    `yaml:"output"`       // Default --output format, empty for each command's own default
+	CacheDir    string    `yaml:"cache_dir"`    // Directory for on-disk caches
+	Cache       bool      `yaml:"cache"`        // Cache Yahoo responses under CacheDir
+	CacheRedis  string    `yaml:"cache_redis"`  // Redis URL to cache in instead, shared between instances
+	CacheSQLite string    `yaml:"cache_sqlite"` // SQLite database to cache in instead, keeping fetched bars and quotes
+	Proxy       string    `yaml:"proxy"`        // HTTP proxy URL for Yahoo requests
+	RateLimit   RateLimit `yaml:"rate_limit"`   // Client-side request rate limit
+}
+
+// RateLimit configures the client's token bucket
+type RateLimit struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"` // Zero disables rate limiting
+	Burst             int     `yaml:"burst"`
+
+	// Endpoints adds limits for single endpoints, keyed by name such as
+	// chart, quote, quoteSummary, or screener
+	Endpoints map[string]EndpointRateLimit `yaml:"endpoints"`
+}
+
+// EndpointRateLimit configures an endpoint's own token bucket
+type EndpointRateLimit struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+// Default returns the settings used when no config file exists
+func Default() *Config {
+	cfg := &Config{}
+	if dir, err := os.UserCacheDir(); err == nil {
+		cfg.CacheDir = filepath.Join(dir, "gotick")
+	}
+	return cfg
+}
+
+// DefaultPath returns the config file location, honoring GOTICK_CONFIG
+func DefaultPath() string {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gotick", "config.yaml")
+}
+
+// Load reads the config file at path over the defaults and applies
+// environment overrides. A missing file is not an error unless required is
+// set, so the default location is optional.
+func Load(path string, required bool) (*Config, error) {
+	cfg := Default()
+	if path != "" {
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path is supplied by the user
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("config: failed to parse %s: %w", path, err)
+			}
+		case errors.Is(err, fs.ErrNotExist) && !required:
+		default:
+			return nil, err
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// applyEnv overrides settings from GOTICK_* environment variables
+func (c *Config) applyEnv() error {
+	if v := os.Getenv(EnvProxy); v != "" {
+		c.Proxy = v
+	}
+	if v := os.Getenv(EnvCacheDir); v != "" {
+		c.CacheDir = v
+	}
+	if v := os.Getenv(EnvCache); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("config: invalid %s %q: %w", EnvCache, v, err)
+		}
+		c.Cache = enabled
+	}
+	if v := os.Getenv(EnvCacheRedis); v != "" {
+		c.CacheRedis = v
+	}
+	if v := os.Getenv(EnvCacheSQLite); v != "" {
+		c.CacheSQLite = v
+	}
+	if v := os.Getenv(EnvOutput); v != "" {
+		c.Output = v
+	}
+	if v := os.Getenv(EnvSymbols); v != "" {
+		c.Symbols = strings.Split(v, ",")
+	}
+	if v := os.Getenv(EnvRateLimit); v != "" {
+		rps, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("config: invalid %s %q: %w", EnvRateLimit, v, err)
+		}
+		c.RateLimit.RequestsPerSecond = rps
+	}
+	return nil
+}
+
+// Validate normalizes symbols and checks settings
+func (c *Config) Validate() error {
+	symbols := make([]string, 0, len(c.Symbols))
+	for _, s := range c.Symbols {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+			symbols = append(symbols, s)
+		}
+	}
+	c.Symbols = symbols
+
+	switch c.Output {
+	case "", "table", "json", "csv", "ndjson":
+	default:
+		return fmt.Errorf("config: unsupported output %q (want table, json, csv or ndjson)", c.Output)
+	}
+	if c.RateLimit.RequestsPerSecond < 0 {
+		return fmt.Errorf("config: rate_limit.requests_per_second cannot be negative")
+	}
+	if c.RateLimit.Burst < 0 {
+		return fmt.Errorf("config: rate_limit.burst cannot be negative")
+	}
+	for name, limit := range c.RateLimit.Endpoints {
+		if limit.RequestsPerSecond < 0 || limit.Burst < 0 {
+			return fmt.Errorf("config: rate_limit.endpoints.%s cannot be negative", name)
+		}
+	}
+	if c.CacheRedis != "" {
+		if u, err := url.Parse(c.CacheRedis); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+			return fmt.Errorf("config: cache_redis must be a redis:// or rediss:// URL, got %q", c.CacheRedis)
+		}
+	}
+	return nil
+}
+
+// ClientOptions returns yfinance client options for the settings
+func (c *Config) ClientOptions() []yfinance.ClientOption {
+	var opts []yfinance.ClientOption
+	if c.Proxy != "" {
+		opts = append(opts, yfinance.WithProxyURL(c.Proxy))
+	}
+	if c.RateLimit.RequestsPerSecond > 0 {
+		burst := max(c.RateLimit.Burst, 1)
+		opts = append(opts, yfinance.WithRateLimiter(c.RateLimit.RequestsPerSecond, burst))
+	}
+	if len(c.RateLimit.Endpoints) > 0 {
+		limits := make(map[string]yfinance.Rate, len(c.RateLimit.Endpoints))
+		for name, limit := range c.RateLimit.Endpoints {
+			limits[name] = yfinance.Rate{PerSecond: limit.RequestsPerSecond, Burst: limit.Burst}
+		}
+		opts = append(opts, yfinance.WithEndpointRateLimits(limits))
+	}
+	if c.CacheDir != "" {
+		opts = append(opts, yfinance.WithSessionFile(filepath.Join(c.CacheDir, "session.json")))
+	}
+	switch {
+	case !c.Cache:
+	case c.CacheRedis != "":
+		if cache, err := yfinance.NewRedisCache(yfinance.RedisCacheConfig{URL: c.CacheRedis}); err == nil {
+			opts = append(opts, yfinance.WithCache(cache))
+		}
+	case c.CacheSQLite != "":
+		if cache, err := yfinance.NewSQLiteCache(yfinance.SQLiteCacheConfig{Path: c.CacheSQLite}); err == nil {
+			opts = append(opts, yfinance.WithCache(cache))
+		}
+	case c.CacheDir != "":
+		cache := yfinance.DefaultCacheConfig()
+		cache.Type = yfinance.CacheTypeBoth
+		cache.Directory = filepath.Join(c.CacheDir, "http")
+		opts = append(opts, yfinance.WithCache(yfinance.NewCache(cache)))
+	}
+	return opts
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLoad tests file settings, environment overrides, and missing files
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `symbols: [aapl, " msft "]
+output: csv
+proxy: http://file-proxy:8080
+rate_limit:
+  requests_per_second: 2
+  burst: 5
+  endpoints:
+    chart: {requests_per_second: 1, burst: 2}
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(EnvProxy, "http://env-proxy:3128")
+	t.Setenv(EnvCacheDir, "/tmp/gotick-cache")
+	cfg, err := Load(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Symbols) != 2 || cfg.Symbols[0] != "AAPL" || cfg.Symbols[1] != "MSFT" {
+		t.Errorf("Expected normalized symbols, got %v", cfg.Symbols)
+	}
+	if cfg.Output != "csv" {
+		t.Errorf("Expected output csv, got %q", cfg.Output)
+	}
+	if cfg.Proxy != "http://env-proxy:3128" {
+		t.Errorf("Expected GOTICK_PROXY to override the file, got %q", cfg.Proxy)
+	}
+	if cfg.CacheDir != "/tmp/gotick-cache" {
+		t.Errorf("Expected GOTICK_CACHE_DIR, got %q", cfg.CacheDir)
+	}
+	if cfg.RateLimit.RequestsPerSecond != 2 || cfg.RateLimit.Burst != 5 {
+		t.Errorf("Unexpected rate limit %+v", cfg.RateLimit)
+	}
+	if cfg.RateLimit.Endpoints["chart"].RequestsPerSecond != 1 {
+		t.Errorf("Unexpected endpoint rate limits %+v", cfg.RateLimit.Endpoints)
+	}
+	if got := len(cfg.ClientOptions()); got != 4 {
+		t.Errorf("Expected proxy, rate limiter, endpoint limits, and session options, got %d", got)
+	}
+
+	t.Setenv(EnvCache, "true")
+	t.Setenv(EnvCacheDir, t.TempDir())
+	if cfg, err = Load(path, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(cfg.ClientOptions()); got != 5 {
+		t.Errorf("Expected a cache option with GOTICK_CACHE, got %d options", got)
+	}
+	t.Setenv(EnvCacheRedis, "localhost:6379")
+	if _, err := Load(path, true); err == nil {
+		t.Error("Expected error for a cache_redis without a redis:// scheme")
+	}
+	t.Setenv(EnvCacheRedis, "redis://localhost:6379/0")
+	if cfg, err = Load(path, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(cfg.ClientOptions()); got != 5 {
+		t.Errorf("Expected a Redis cache option, got %d options", got)
+	}
+	t.Setenv(EnvCache, "")
+	t.Setenv(EnvCacheRedis, "")
+
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	if _, err := Load(missing, false); err != nil {
+		t.Errorf("Expected optional missing file to load defaults, got %v", err)
+	}
+	if _, err := Load(missing, true); err == nil {
+		t.Error("Expected error for a required missing file")
+	}
+
+	t.Setenv(EnvOutput, "xml")
+	if _, err := Load(path, true); err == nil {
+		t.Error("Expected error for an unsupported output")
+	}
+}
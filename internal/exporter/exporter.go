@@ -0,0 +1,212 @@
+// Package exporter exposes Yahoo Finance quotes as Prometheus metrics.
+package exporter
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var marketStates = []string{"PREPRE", "PRE", "REGULAR", "POST", "POSTPOST", "CLOSED"}
+
+// fetchTimeout bounds the quote fetch a scrape triggers, keeping it under
+// Prometheus' default 10s scrape timeout so stale metrics are served instead
+const fetchTimeout = 8 * time.Second
+
+// Collector is a prometheus.Collector reporting quote gauges for a set of
+// symbols. Quotes are fetched on scrape, at most once per MinInterval, or
+// kept current from the live stream when Stream is running.
+type Collector struct {
+	symbols      []string
+	minInterval  time.Duration
+	fetchTimeout time.Duration
+	logger       *log.Logger
+
+	mu        sync.Mutex
+	quotes    map[string]yfinance.Quote
+	fetchedAt time.Time
+	streaming bool
+
+	price         *prometheus.Desc
+	change        *prometheus.Desc
+	changePercent *prometheus.Desc
+	volume        *prometheus.Desc
+	marketCap     *prometheus.Desc
+	marketState   *prometheus.Desc
+	updated       *prometheus.Desc
+	errors        prometheus.Counter
+	ticks         prometheus.Counter
+}
+
+// NewCollector creates a collector for symbols. minInterval bounds how
+// often scrapes trigger upstream quote requests.
+func NewCollector(symbols []string, minInterval time.Duration, logger *log.Logger) *Collector {
+	labels := []string{"symbol"}
+	return &Collector{
+		symbols:      symbols,
+		minInterval:  minInterval,
+		fetchTimeout: fetchTimeout,
+		logger:       logger,
+		quotes:       make(map[string]yfinance.Quote, len(symbols)),
+
+		price:         prometheus.NewDesc("gotick_quote_price", "Last regular market price.", []string{"symbol", "currency", "exchange"}, nil),
+		change:        prometheus.NewDesc("gotick_quote_change", "Price change since previous close.", labels, nil),
+		changePercent: prometheus.NewDesc("gotick_quote_change_percent", "Percent change since previous close.", labels, nil),
+		volume:        prometheus.NewDesc("gotick_quote_volume", "Regular market volume for the day.", labels, nil),
+		marketCap:     prometheus.NewDesc("gotick_quote_market_cap", "Market capitalization.", labels, nil),
+		marketState:   prometheus.NewDesc("gotick_quote_market_state", "Current market state (1 for the active state).", []string{"symbol", "state"}, nil),
+		updated:       prometheus.NewDesc("gotick_quote_updated_timestamp_seconds", "Unix time the quote was last updated.", labels, nil),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "gotick_quote_fetch_errors_total",
+			Help: "Number of failed upstream quote fetches.",
+		}),
+		ticks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "gotick_stream_messages_total",
+			Help: "Number of stream messages applied.",
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.price
+	ch <- c.change
+	ch <- c.changePercent
+	ch <- c.volume
+	ch <- c.marketCap
+	ch <- c.marketState
+	ch <- c.updated
+	c.errors.Describe(ch)
+	c.ticks.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.fetchTimeout)
+	c.refresh(ctx)
+	cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, sym := range c.symbols {
+		q, ok := c.quotes[sym]
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.price, prometheus.GaugeValue, q.RegularMarketPrice, sym, q.Currency, q.Exchange)
+		ch <- prometheus.MustNewConstMetric(c.change, prometheus.GaugeValue, q.RegularMarketChange, sym)
+		ch <- prometheus.MustNewConstMetric(c.changePercent, prometheus.GaugeValue, q.RegularMarketChangePercent, sym)
+		ch <- prometheus.MustNewConstMetric(c.volume, prometheus.GaugeValue, float64(q.RegularMarketVolume), sym)
+		ch <- prometheus.MustNewConstMetric(c.marketCap, prometheus.GaugeValue, float64(q.MarketCap), sym)
+		ch <- prometheus.MustNewConstMetric(c.updated, prometheus.GaugeValue, float64(q.RegularMarketTime), sym)
+		for _, state := range marketStates {
+			v := 0.0
+			if q.MarketState == state {
+				v = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.marketState, prometheus.GaugeValue, v, sym, state)
+		}
+	}
+	c.errors.Collect(ch)
+	c.ticks.Collect(ch)
+}
+
+// refresh fetches quotes when the cached ones are older than minInterval.
+// While streaming, prices stay current from ticks and only slower-moving
+// fields (market state, market cap) are refreshed.
+func (c *Collector) refresh(ctx context.Context) {
+	c.mu.Lock()
+	stale := time.Since(c.fetchedAt) >= c.minInterval
+	if c.streaming {
+		stale = time.Since(c.fetchedAt) >= 5*time.Minute
+	}
+	c.mu.Unlock()
+	if !stale {
+		return
+	}
+
+	quotes, err := yfinance.QuoteMultiple(ctx, c.symbols)
+	if err != nil {
+		c.errors.Inc()
+		if c.logger != nil {
+			c.logger.Printf("exporter: quote fetch failed: %v", err)
+		}
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, q := range quotes {
+		c.quotes[q.Symbol] = q
+	}
+	c.fetchedAt = time.Now()
+}
+
+// Stream keeps prices current from the WebSocket stream until ctx is done or
+// the connection drops, after which scrapes fall back to polling
+func (c *Collector) Stream(ctx context.Context) error {
+	stream := yfinance.NewStream(c.symbols)
+	if err := stream.Connect(ctx); err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+
+	c.mu.Lock()
+	c.streaming = true
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.streaming = false
+		c.mu.Unlock()
+	}()
+
+	messages, errs := stream.Messages(), stream.Errors()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return yfinance.ErrWebSocketClosed
+			}
+			c.apply(msg)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if c.logger != nil {
+				c.logger.Printf("exporter: stream: %v", err)
+			}
+		}
+	}
+}
+
+// apply updates the stored quote for a stream message
+func (c *Collector) apply(msg yfinance.StreamMessage) {
+	if msg.ID == "" || msg.Price == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	q := c.quotes[msg.ID]
+	q.Symbol = msg.ID
+	q.RegularMarketPrice = msg.Price
+	q.RegularMarketChange = msg.Change
+	q.RegularMarketChangePercent = msg.ChangePercent
+	if msg.DayVolume > 0 {
+		q.RegularMarketVolume = msg.DayVolume
+	}
+	if msg.Time > 0 {
+		q.RegularMarketTime = msg.Time / 1000
+	}
+	c.quotes[msg.ID] = q
+	c.ticks.Inc()
+}
@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+	"github.com/amjadjibon/gotick/pkg/yfinance/yfinancetest"
+)
+
+// useClient makes client the default yfinance client for the test
+func useClient(t *testing.T, client *yfinance.Client) {
+	t.Helper()
+	prev, _ := yfinance.DefaultClient()
+	yfinance.SetDefaultClient(client)
+	t.Cleanup(func() { yfinance.SetDefaultClient(prev) })
+}
+
+// gather collects c and returns its metric families by name
+func gather(t *testing.T, c *Collector) map[string]*dto.MetricFamily {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	byName := make(map[string]*dto.MetricFamily, len(families))
+	for _, f := range families {
+		byName[f.GetName()] = f
+	}
+	return byName
+}
+
+// TestCollector tests the quote gauges reported for fixture quotes
+func TestCollector(t *testing.T) {
+	tr := yfinancetest.NewTransport()
+	client, err := tr.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	useClient(t, client)
+
+	c := NewCollector([]string{"AAPL"}, time.Minute, nil)
+	families := gather(t, c)
+
+	price := families["gotick_quote_price"]
+	if price == nil || len(price.GetMetric()) != 1 || price.GetMetric()[0].GetGauge().GetValue() != 189.84 {
+		t.Fatalf("Expected the AAPL price gauge, got %v", price)
+	}
+	labels := make(map[string]string)
+	for _, l := range price.GetMetric()[0].GetLabel() {
+		labels[l.GetName()] = l.GetValue()
+	}
+	if labels["symbol"] != "AAPL" || labels["currency"] != "USD" || labels["exchange"] != "NMS" {
+		t.Errorf("Unexpected price labels %v", labels)
+	}
+	for _, m := range families["gotick_quote_market_state"].GetMetric() {
+		for _, l := range m.GetLabel() {
+			if l.GetName() == "state" && (l.GetValue() == "REGULAR") != (m.GetGauge().GetValue() == 1) {
+				t.Errorf("Unexpected market state %s = %v", l.GetValue(), m.GetGauge().GetValue())
+			}
+		}
+	}
+
+	// A second scrape within minInterval is served from the stored quotes
+	requests := len(tr.Requests())
+	gather(t, c)
+	if len(tr.Requests()) != requests {
+		t.Errorf("Expected no upstream request within the min interval")
+	}
+}
+
+// TestCollectorTimeout tests that a scrape doesn't wait on a hung fetch
+func TestCollectorTimeout(t *testing.T) {
+	hang := func(next http.RoundTripper) http.RoundTripper {
+		return yfinance.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+	}
+	client, err := yfinancetest.NewTransport().Client(yfinance.WithMiddleware(hang))
+	if err != nil {
+		t.Fatal(err)
+	}
+	useClient(t, client)
+
+	c := NewCollector([]string{"AAPL"}, time.Minute, nil)
+	c.fetchTimeout = 50 * time.Millisecond
+	start := time.Now()
+	families := gather(t, c)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the scrape to give up on the fetch, took %v", elapsed)
+	}
+	if f := families["gotick_quote_fetch_errors_total"]; f == nil || f.GetMetric()[0].GetCounter().GetValue() != 1 {
+		t.Errorf("Expected one fetch error, got %v", f)
+	}
+	if _, ok := families["gotick_quote_price"]; ok {
+		t.Error("Expected no price without a quote")
+	}
+}
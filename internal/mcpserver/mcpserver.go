@@ -0,0 +1,275 @@
+// Package mcpserver exposes the yfinance package as Model Context Protocol
+// tools, so AI agents can query market data through a rate-limited, cached
+// interface.
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// maxSymbols bounds the number of symbols in one get_quote call
+const maxSymbols = 50
+
+// Options configures the MCP server
+type Options struct {
+	Version           string  // Reported to clients
+	RequestsPerSecond float64 // Upstream Yahoo requests allowed per second
+	Burst             int     // Upstream burst size
+	CacheSize         int     // Maximum number of cached tool results
+}
+
+// Server serves Yahoo Finance tools over MCP with result caching and
+// upstream rate limiting
+type Server struct {
+	mcp     *mcp.Server
+	cache   *yfinance.Cache
+	limiter *yfinance.RateLimiter
+}
+
+// New creates a Server with the quote, history, search, and financials tools
+func New(opts Options) *Server {
+	if opts.Version == "" {
+		opts.Version = "dev"
+	}
+	if opts.RequestsPerSecond <= 0 {
+		opts.RequestsPerSecond = 2
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = 5
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = 1000
+	}
+
+	s := &Server{
+		mcp: mcp.NewServer(&mcp.Implementation{Name: "gotick", Version: opts.Version}, &mcp.ServerOptions{
+			Instructions: "Market data from Yahoo Finance. Prices may be delayed; results are cached briefly.",
+		}),
+		cache: yfinance.NewCache(yfinance.CacheConfig{
+			Type:       yfinance.CacheTypeMemory,
+			DefaultTTL: yfinance.TTLQuote,
+			MaxSize:    opts.CacheSize,
+		}),
+		limiter: yfinance.NewRateLimiter(opts.RequestsPerSecond, opts.Burst),
+	}
+
+	mcp.AddTool(s.mcp, &mcp.Tool{
+		Name:        "get_quote",
+		Description: "Get real-time quotes (price, change, volume, market cap, market state) for up to 50 ticker symbols.",
+	}, cached(s, "get_quote", yfinance.TTLQuote, getQuote))
+	mcp.AddTool(s.mcp, &mcp.Tool{
+		Name:        "get_history",
+		Description: "Get historical OHLCV bars for a ticker symbol by period or date range.",
+	}, cached(s, "get_history", yfinance.TTLHistory, getHistory))
+	mcp.AddTool(s.mcp, &mcp.Tool{
+		Name:        "search",
+		Description: "Search for ticker symbols by company name or partial ticker.",
+	}, cached(s, "search", yfinance.TTLSearch, search))
+	mcp.AddTool(s.mcp, &mcp.Tool{
+		Name:        "get_financials",
+		Description: "Get the income statement, balance sheet, or cash flow statement for a ticker symbol.",
+	}, cached(s, "get_financials", yfinance.TTLFinancials, getFinancials))
+
+	return s
+}
+
+// Run serves MCP over stdin/stdout until the client disconnects or ctx is done
+func (s *Server) Run(ctx context.Context) error {
+	return s.mcp.Run(ctx, &mcp.StdioTransport{})
+}
+
+// Connect serves a single session over t, for embedding and tests
+func (s *Server) Connect(ctx context.Context, t mcp.Transport) (*mcp.ServerSession, error) {
+	return s.mcp.Connect(ctx, t, nil)
+}
+
+// input is a tool's arguments, validated before any upstream request
+type input interface {
+	validate() error
+}
+
+// cached wraps fetch with input validation, result caching keyed by tool and
+// arguments, and rate limiting of the upstream calls made on cache misses.
+// Results must marshal to JSON objects.
+func cached[In input](s *Server, tool string, ttl time.Duration, fetch func(context.Context, In) (any, error)) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, in In) (*mcp.CallToolResult, any, error) {
+		if err := in.validate(); err != nil {
+			return nil, nil, err
+		}
+
+		args, err := json.Marshal(in)
+		if err != nil {
+			return nil, nil, err
+		}
+		key := tool + ":" + string(args)
+		if data, ok := s.cache.Get(key); ok {
+			return nil, json.RawMessage(data), nil
+		}
+
+		if err := s.limiter.Wait(ctx); err != nil {
+			return nil, nil, err
+		}
+		v, err := fetch(ctx, in)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		s.cache.Set(key, data, ttl)
+		return nil, json.RawMessage(data), nil
+	}
+}
+
+// QuoteInput are the get_quote arguments
+type QuoteInput struct {
+	Symbols []string `json:"symbols" jsonschema:"ticker symbols, e.g. AAPL or BTC-USD"`
+}
+
+func (in QuoteInput) validate() error {
+	if len(in.Symbols) == 0 {
+		return fmt.Errorf("symbols is required")
+	}
+	if len(in.Symbols) > maxSymbols {
+		return fmt.Errorf("at most %d symbols per call", maxSymbols)
+	}
+	return nil
+}
+
+func getQuote(ctx context.Context, in QuoteInput) (any, error) {
+	symbols := make([]string, len(in.Symbols))
+	for i, s := range in.Symbols {
+		symbols[i] = strings.ToUpper(strings.TrimSpace(s))
+	}
+	quotes, err := yfinance.QuoteMultiple(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"quotes": quotes}, nil
+}
+
+// HistoryInput are the get_history arguments
+type HistoryInput struct {
+	Symbol   string `json:"symbol" jsonschema:"ticker symbol"`
+	Period   string `json:"period,omitempty" jsonschema:"history period: 1d, 5d, 1mo, 3mo, 6mo, 1y, 2y, 5y, 10y, ytd or max (default 1mo)"`
+	Interval string `json:"interval,omitempty" jsonschema:"bar interval: 1m, 5m, 15m, 30m, 1h, 1d, 1wk or 1mo (default 1d)"`
+	Start    string `json:"start,omitempty" jsonschema:"start date YYYY-MM-DD, overrides period"`
+	End      string `json:"end,omitempty" jsonschema:"end date YYYY-MM-DD, defaults to today when start is set"`
+}
+
+func (in HistoryInput) validate() error {
+	_, err := in.params()
+	return err
+}
+
+// params converts the arguments into HistoryParams
+func (in HistoryInput) params() (yfinance.HistoryParams, error) {
+	params := yfinance.HistoryParams{
+		Period:   yfinance.Period(in.Period),
+		Interval: yfinance.Interval(in.Interval),
+	}
+	if in.Symbol == "" {
+		return params, fmt.Errorf("symbol is required")
+	}
+	if params.Period == "" {
+		params.Period = yfinance.Period1mo
+	}
+	if params.Interval == "" {
+		params.Interval = yfinance.Interval1d
+	}
+	if in.Start != "" {
+		t, err := time.Parse(time.DateOnly, in.Start)
+		if err != nil {
+			return params, fmt.Errorf("invalid start date %q: %w", in.Start, err)
+		}
+		params.Start, params.End = t, time.Now()
+	}
+	if in.End != "" {
+		t, err := time.Parse(time.DateOnly, in.End)
+		if err != nil {
+			return params, fmt.Errorf("invalid end date %q: %w", in.End, err)
+		}
+		params.End = t
+	}
+	return params, params.Validate()
+}
+
+func getHistory(ctx context.Context, in HistoryInput) (any, error) {
+	params, err := in.params()
+	if err != nil {
+		return nil, err
+	}
+	ticker, err := yfinance.NewTicker(in.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	return ticker.History(ctx, params)
+}
+
+// SearchInput are the search arguments
+type SearchInput struct {
+	Query string `json:"query" jsonschema:"company name or partial ticker"`
+	Count int    `json:"count,omitempty" jsonschema:"maximum number of matches (default 10)"`
+}
+
+func (in SearchInput) validate() error {
+	if strings.TrimSpace(in.Query) == "" {
+		return fmt.Errorf("query is required")
+	}
+	if in.Count < 0 || in.Count > 100 {
+		return fmt.Errorf("count must be between 1 and 100")
+	}
+	return nil
+}
+
+func search(ctx context.Context, in SearchInput) (any, error) {
+	count := in.Count
+	if count == 0 {
+		count = 10
+	}
+	return yfinance.Search(ctx, in.Query, yfinance.WithQuotesCount(count))
+}
+
+// FinancialsInput are the get_financials arguments
+type FinancialsInput struct {
+	Symbol    string `json:"symbol" jsonschema:"ticker symbol"`
+	Statement string `json:"statement" jsonschema:"income, balance or cashflow"`
+	Quarterly bool   `json:"quarterly,omitempty" jsonschema:"return quarterly instead of annual periods"`
+}
+
+func (in FinancialsInput) validate() error {
+	if in.Symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	switch in.Statement {
+	case "income", "balance", "cashflow":
+		return nil
+	default:
+		return fmt.Errorf("unknown statement %q (want income, balance or cashflow)", in.Statement)
+	}
+}
+
+func getFinancials(ctx context.Context, in FinancialsInput) (any, error) {
+	ticker, err := yfinance.NewTicker(in.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	switch in.Statement {
+	case "income":
+		return ticker.IncomeStatement(ctx, in.Quarterly)
+	case "balance":
+		return ticker.BalanceSheet(ctx, in.Quarterly)
+	default:
+		return ticker.CashFlow(ctx, in.Quarterly)
+	}
+}
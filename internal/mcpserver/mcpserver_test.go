@@ -0,0 +1,74 @@
+package mcpserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connect returns a client session talking to s in memory
+func connect(t *testing.T, s *Server) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := s.Connect(ctx, serverTransport); err != nil {
+		t.Fatal(err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// TestTools tests tool registration, input validation, and cached results
+func TestTools(t *testing.T) {
+	s := New(Options{})
+	session := connect(t, s)
+	ctx := context.Background()
+
+	tools, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, tool := range tools.Tools {
+		names[tool.Name] = true
+	}
+	for _, want := range []string{"get_quote", "get_history", "search", "get_financials"} {
+		if !names[want] {
+			t.Errorf("Expected tool %s to be registered", want)
+		}
+	}
+
+	invalid := []mcp.CallToolParams{
+		{Name: "get_quote", Arguments: map[string]any{"symbols": []string{}}},
+		{Name: "get_history", Arguments: map[string]any{"symbol": "AAPL", "period": "7y"}},
+		{Name: "get_financials", Arguments: map[string]any{"symbol": "AAPL", "statement": "equity"}},
+	}
+	for _, params := range invalid {
+		res, err := session.CallTool(ctx, &params)
+		if err != nil {
+			t.Fatalf("%s: unexpected protocol error: %v", params.Name, err)
+		}
+		if !res.IsError {
+			t.Errorf("%s: expected a tool error for %v", params.Name, params.Arguments)
+		}
+	}
+
+	s.cache.Set(`get_quote:{"symbols":["AAPL"]}`, []byte(`{"quotes":[{"symbol":"AAPL"}]}`), 0)
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "get_quote", Arguments: map[string]any{"symbols": []string{"AAPL"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || len(res.Content) == 0 {
+		t.Fatalf("Expected cached result, got %+v", res)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, `"AAPL"`) {
+		t.Errorf("Unexpected content %s", text)
+	}
+}
@@ -0,0 +1,143 @@
+// Package publish forwards live ticks and quote snapshots to MQTT or NATS
+// brokers.
+package publish
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/nats-io/nats.go"
+)
+
+// Publisher sends payloads to a message broker
+type Publisher interface {
+	// Publish sends payload on topic. Retained messages are kept by brokers
+	// that support it (MQTT) and delivered to new subscribers.
+	Publish(topic string, payload []byte, retain bool) error
+	// Separator is the broker's topic level separator
+	Separator() string
+	Close() error
+}
+
+// DialOptions configures the broker connection
+type DialOptions struct {
+	ClientID string
+	Username string
+	Password string
+	QoS      byte // MQTT quality of service, 0-2
+	Timeout  time.Duration
+}
+
+// Dial connects to the broker at rawURL. The scheme selects the protocol:
+// mqtt, mqtts, tcp, ssl, ws, and wss for MQTT; nats and tls for NATS.
+func Dial(rawURL string, opts DialOptions) (Publisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("publish: invalid broker URL %q: %w", rawURL, err)
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.QoS > 2 {
+		return nil, fmt.Errorf("publish: invalid QoS %d (want 0, 1 or 2)", opts.QoS)
+	}
+	if u.User != nil && opts.Username == "" {
+		opts.Username = u.User.Username()
+		opts.Password, _ = u.User.Password()
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "mqtt", "tcp":
+		return dialMQTT("tcp://"+u.Host, opts)
+	case "mqtts", "ssl":
+		return dialMQTT("ssl://"+u.Host, opts)
+	case "ws", "wss":
+		u.User = nil
+		return dialMQTT(u.String(), opts)
+	case "nats", "tls":
+		u.User = nil
+		return dialNATS(u.String(), opts)
+	default:
+		return nil, fmt.Errorf("publish: unsupported broker scheme %q (want mqtt, mqtts, ws, wss, nats or tls)", u.Scheme)
+	}
+}
+
+// mqttPublisher publishes to an MQTT broker
+type mqttPublisher struct {
+	client  mqtt.Client
+	qos     byte
+	timeout time.Duration
+}
+
+func dialMQTT(broker string, opts DialOptions) (*mqttPublisher, error) {
+	clientID := opts.ClientID
+	if clientID == "" {
+		clientID = fmt.Sprintf("gotick-%d", time.Now().UnixNano())
+	}
+	co := mqtt.NewClientOptions().
+		AddBroker(broker).
+		SetClientID(clientID).
+		SetUsername(opts.Username).
+		SetPassword(opts.Password).
+		SetConnectTimeout(opts.Timeout).
+		SetAutoReconnect(true)
+
+	client := mqtt.NewClient(co)
+	token := client.Connect()
+	if !token.WaitTimeout(opts.Timeout) {
+		return nil, fmt.Errorf("publish: timed out connecting to %s", broker)
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("publish: connecting to %s: %w", broker, err)
+	}
+	return &mqttPublisher{client: client, qos: opts.QoS, timeout: opts.Timeout}, nil
+}
+
+func (p *mqttPublisher) Publish(topic string, payload []byte, retain bool) error {
+	token := p.client.Publish(topic, p.qos, retain, payload)
+	if !token.WaitTimeout(p.timeout) {
+		return fmt.Errorf("publish: timed out publishing to %s", topic)
+	}
+	return token.Error()
+}
+
+func (p *mqttPublisher) Separator() string { return "/" }
+
+func (p *mqttPublisher) Close() error {
+	p.client.Disconnect(250)
+	return nil
+}
+
+// natsPublisher publishes to a NATS server
+type natsPublisher struct {
+	conn *nats.Conn
+}
+
+func dialNATS(server string, opts DialOptions) (*natsPublisher, error) {
+	natsOpts := []nats.Option{nats.Timeout(opts.Timeout), nats.MaxReconnects(-1)}
+	if opts.ClientID != "" {
+		natsOpts = append(natsOpts, nats.Name(opts.ClientID))
+	}
+	if opts.Username != "" {
+		natsOpts = append(natsOpts, nats.UserInfo(opts.Username, opts.Password))
+	}
+	conn, err := nats.Connect(server, natsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("publish: connecting to %s: %w", server, err)
+	}
+	return &natsPublisher{conn: conn}, nil
+}
+
+// Publish sends payload on the subject; NATS has no retained messages
+func (p *natsPublisher) Publish(subject string, payload []byte, _ bool) error {
+	return p.conn.Publish(subject, payload)
+}
+
+func (p *natsPublisher) Separator() string { return "." }
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
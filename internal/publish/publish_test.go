@@ -0,0 +1,59 @@
+package publish
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakePublisher records nothing and only reports its separator
+type fakePublisher struct{ sep string }
+
+func (f fakePublisher) Publish(string, []byte, bool) error { return nil }
+func (f fakePublisher) Separator() string                  { return f.sep }
+func (f fakePublisher) Close() error                       { return nil }
+
+// TestTopics tests topic construction and escaping per broker separator
+func TestTopics(t *testing.T) {
+	mqtt, nats := fakePublisher{"/"}, fakePublisher{"."}
+
+	tests := []struct {
+		pub    Publisher
+		prefix string
+		symbol string
+		tick   string
+		quote  string
+	}{
+		{mqtt, "quotes/", "aapl", "quotes/AAPL", "quotes/AAPL/quote"},
+		{mqtt, "home/", "BRK.B", "home/BRK.B", "home/BRK.B/quote"},
+		{nats, "quotes.", "BRK.B", "quotes.BRK_B", "quotes.BRK_B.quote"},
+		{nats, "quotes.", "^GSPC", "quotes.^GSPC", "quotes.^GSPC.quote"},
+		{mqtt, "q/", "A#B+C", "q/A_B_C", "q/A_B_C/quote"},
+	}
+	for _, tt := range tests {
+		if got := TickTopic(tt.pub, tt.prefix, tt.symbol); got != tt.tick {
+			t.Errorf("TickTopic(%q, %q) = %q, want %q", tt.prefix, tt.symbol, got, tt.tick)
+		}
+		if got := QuoteTopic(tt.pub, tt.prefix, tt.symbol); got != tt.quote {
+			t.Errorf("QuoteTopic(%q, %q) = %q, want %q", tt.prefix, tt.symbol, got, tt.quote)
+		}
+	}
+}
+
+// TestDialValidation tests broker URL and option validation
+func TestDialValidation(t *testing.T) {
+	tests := []struct {
+		url  string
+		opts DialOptions
+		want string
+	}{
+		{"kafka://localhost:9092", DialOptions{}, "unsupported broker scheme"},
+		{"mqtt://localhost:1883", DialOptions{QoS: 3}, "invalid QoS"},
+		{"://bad", DialOptions{}, "invalid broker URL"},
+	}
+	for _, tt := range tests {
+		_, err := Dial(tt.url, tt.opts)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Dial(%q) error = %v, want %q", tt.url, err, tt.want)
+		}
+	}
+}
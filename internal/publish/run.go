@@ -0,0 +1,125 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// Options configures what Run publishes
+type Options struct {
+	// Prefix is prepended to every topic, e.g. "quotes/". Defaults to
+	// "quotes" followed by the broker's separator.
+	Prefix string
+	// SnapshotInterval is how often full quotes are published
+	SnapshotInterval time.Duration
+	// Retain marks snapshots as retained so new subscribers get the latest
+	// quote immediately (MQTT only)
+	Retain bool
+}
+
+// TickTopic is the topic live ticks for symbol are published on
+func TickTopic(pub Publisher, prefix, symbol string) string {
+	return prefix + topicSymbol(pub, symbol)
+}
+
+// QuoteTopic is the topic quote snapshots for symbol are published on
+func QuoteTopic(pub Publisher, prefix, symbol string) string {
+	return prefix + topicSymbol(pub, symbol) + pub.Separator() + "quote"
+}
+
+// topicSymbol escapes characters that are topic separators or wildcards for
+// the broker, such as the dot in BRK.B on NATS
+func topicSymbol(pub Publisher, symbol string) string {
+	r := strings.NewReplacer(pub.Separator(), "_", "+", "_", "#", "_", "*", "_", ">", "_", " ", "_")
+	return r.Replace(strings.ToUpper(symbol))
+}
+
+// Run publishes live ticks for symbols until ctx is done, plus a quote
+// snapshot per symbol every opts.SnapshotInterval. When the stream cannot
+// connect or drops, snapshots continue and reconnection is retried on each
+// snapshot tick.
+func Run(ctx context.Context, pub Publisher, symbols []string, opts Options, logger *log.Logger) error {
+	if opts.Prefix == "" {
+		opts.Prefix = "quotes" + pub.Separator()
+	}
+	if opts.SnapshotInterval <= 0 {
+		opts.SnapshotInterval = time.Minute
+	}
+
+	send := func(topic string, v any, retain bool) {
+		payload, err := json.Marshal(v)
+		if err != nil {
+			logger.Printf("publish: encoding %s: %v", topic, err)
+			return
+		}
+		if err := pub.Publish(topic, payload, retain); err != nil {
+			logger.Printf("publish: %s: %v", topic, err)
+		}
+	}
+
+	snapshot := func() {
+		quotes, err := yfinance.QuoteMultiple(ctx, symbols)
+		if err != nil {
+			logger.Printf("publish: quote snapshot failed: %v", err)
+			return
+		}
+		for _, q := range quotes {
+			send(QuoteTopic(pub, opts.Prefix, q.Symbol), q, opts.Retain)
+		}
+	}
+
+	var stream *yfinance.Stream
+	var ticks <-chan yfinance.StreamMessage
+	var streamErrs <-chan error
+	connect := func() {
+		s := yfinance.NewStream(symbols)
+		if err := s.Connect(ctx); err != nil {
+			logger.Printf("publish: stream unavailable (%v), publishing snapshots every %s", err, opts.SnapshotInterval)
+			return
+		}
+		stream, ticks, streamErrs = s, s.Messages(), s.Errors()
+		logger.Printf("publish: streaming %d symbols", len(symbols))
+	}
+	defer func() {
+		if stream != nil {
+			_ = stream.Close()
+		}
+	}()
+
+	snapshot()
+	connect()
+
+	ticker := time.NewTicker(opts.SnapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-ticks:
+			if !ok {
+				_ = stream.Close()
+				stream, ticks, streamErrs = nil, nil, nil
+				logger.Printf("publish: stream closed, publishing snapshots every %s", opts.SnapshotInterval)
+				continue
+			}
+			send(TickTopic(pub, opts.Prefix, msg.ID), msg, false)
+		case err, ok := <-streamErrs:
+			if !ok {
+				streamErrs = nil
+				continue
+			}
+			logger.Printf("publish: stream: %v", err)
+		case <-ticker.C:
+			snapshot()
+			if stream == nil {
+				connect()
+			}
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// graphQLRequest is the body of a GraphQL POST
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// handleGraphQL executes GraphQL queries sent as a JSON POST body or as
+// GET query parameters
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if v := r.URL.Query().Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid variables: %w", err))
+				return
+			}
+		}
+	} else if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("missing query"))
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        r.Context(),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// newSchema builds the GraphQL schema:
+//
+//	ticker(symbol) { symbol quote history(...) options(...) financials(...) }
+//	tickers(symbols) { ... }
+func (s *Server) newSchema() (graphql.Schema, error) {
+	types := newTypeMapper()
+
+	tickerType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Ticker",
+		Fields: graphql.Fields{
+			"symbol": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"quote": &graphql.Field{
+				Type: types.output(reflect.TypeFor[yfinance.Quote]()),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					sym := sourceSymbol(p)
+					return s.fetch(p.Context, "quote:"+sym, yfinance.TTLQuote, func(ctx context.Context) (any, error) {
+						quotes, err := yfinance.QuoteMultiple(ctx, []string{sym})
+						if err != nil {
+							return nil, err
+						}
+						if len(quotes) == 0 {
+							return nil, yfinance.NewSymbolError(sym, yfinance.ErrNotFound)
+						}
+						return quotes[0], nil
+					})
+				},
+			},
+			"history": &graphql.Field{
+				Type: types.output(reflect.TypeFor[yfinance.ChartData]()),
+				Args: graphql.FieldConfigArgument{
+					"period":   &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: string(yfinance.Period1mo)},
+					"interval": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: string(yfinance.Interval1d)},
+					"start":    &graphql.ArgumentConfig{Type: graphql.String, Description: "YYYY-MM-DD, overrides period"},
+					"end":      &graphql.ArgumentConfig{Type: graphql.String, Description: "YYYY-MM-DD"},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					sym := sourceSymbol(p)
+					params, err := graphQLHistoryParams(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					key := fmt.Sprintf("history:%s:%v", sym, p.Args)
+					return s.fetch(p.Context, key, yfinance.TTLHistory, func(ctx context.Context) (any, error) {
+						ticker, err := yfinance.NewTicker(sym)
+						if err != nil {
+							return nil, err
+						}
+						return ticker.History(ctx, params)
+					})
+				},
+			},
+			"options": &graphql.Field{
+				Type: types.output(reflect.TypeFor[yfinance.OptionChainWithGreeks]()),
+				Args: graphql.FieldConfigArgument{
+					"expiration": &graphql.ArgumentConfig{Type: graphql.String, Description: "YYYY-MM-DD, defaults to the nearest"},
+					"rate":       &graphql.ArgumentConfig{Type: graphql.Float, DefaultValue: 0.045, Description: "Risk-free rate for Greeks"},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					sym := sourceSymbol(p)
+					var expiration string
+					if v, _ := p.Args["expiration"].(string); v != "" {
+						t, err := time.Parse(time.DateOnly, v)
+						if err != nil {
+							return nil, fmt.Errorf("invalid expiration date %q", v)
+						}
+						expiration = strconv.FormatInt(t.Unix(), 10)
+					}
+					rate, _ := p.Args["rate"].(float64)
+					key := fmt.Sprintf("options:%s:%s:%g", sym, expiration, rate)
+					return s.fetch(p.Context, key, yfinance.TTLOptions, func(ctx context.Context) (any, error) {
+						ticker, err := yfinance.NewTicker(sym)
+						if err != nil {
+							return nil, err
+						}
+						chain, err := ticker.Options(ctx, expiration)
+						if err != nil {
+							return nil, err
+						}
+						return chain.WithGreeks(rate), nil
+					})
+				},
+			},
+			"financials": &graphql.Field{
+				Type: types.output(reflect.TypeFor[yfinance.FinancialStatement]()),
+				Args: graphql.FieldConfigArgument{
+					"statement": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "income", Description: "income, balance or cashflow"},
+					"quarterly": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					sym := sourceSymbol(p)
+					statement, _ := p.Args["statement"].(string)
+					quarterly, _ := p.Args["quarterly"].(bool)
+					if statement != "income" && statement != "balance" && statement != "cashflow" {
+						return nil, fmt.Errorf("unknown statement %q (want income, balance or cashflow)", statement)
+					}
+					key := fmt.Sprintf("financials:%s:%s:%t", sym, statement, quarterly)
+					return s.fetch(p.Context, key, yfinance.TTLFinancials, func(ctx context.Context) (any, error) {
+						ticker, err := yfinance.NewTicker(sym)
+						if err != nil {
+							return nil, err
+						}
+						switch statement {
+						case "income":
+							return ticker.IncomeStatement(ctx, quarterly)
+						case "balance":
+							return ticker.BalanceSheet(ctx, quarterly)
+						default:
+							return ticker.CashFlow(ctx, quarterly)
+						}
+					})
+				},
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"ticker": &graphql.Field{
+				Type: tickerType,
+				Args: graphql.FieldConfigArgument{
+					"symbol": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					sym, _ := p.Args["symbol"].(string)
+					return map[string]any{"symbol": strings.ToUpper(sym)}, nil
+				},
+			},
+			"tickers": &graphql.Field{
+				Type: graphql.NewList(tickerType),
+				Args: graphql.FieldConfigArgument{
+					"symbols": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					symbols, _ := p.Args["symbols"].([]any)
+					result := make([]any, len(symbols))
+					for i, sym := range symbols {
+						result[i] = map[string]any{"symbol": strings.ToUpper(fmt.Sprint(sym))}
+					}
+					return result, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// fetch returns a cached resolver result, calling load under the rate
+// limiter on a miss. Results are returned as generic JSON values so the
+// field names match the REST API.
+func (s *Server) fetch(ctx context.Context, key string, ttl time.Duration, load func(context.Context) (any, error)) (any, error) {
+	key = "graphql:" + key
+	data, ok := s.cache.Get(key)
+	if !ok {
+		if err := s.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		v, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+		s.cache.Set(key, data, ttl)
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// sourceSymbol returns the symbol of the Ticker being resolved
+func sourceSymbol(p graphql.ResolveParams) string {
+	src, _ := p.Source.(map[string]any)
+	sym, _ := src["symbol"].(string)
+	return sym
+}
+
+// graphQLHistoryParams converts history arguments into HistoryParams
+func graphQLHistoryParams(args map[string]any) (yfinance.HistoryParams, error) {
+	period, _ := args["period"].(string)
+	interval, _ := args["interval"].(string)
+	params := yfinance.HistoryParams{
+		Period:   yfinance.Period(period),
+		Interval: yfinance.Interval(interval),
+	}
+	if v, _ := args["start"].(string); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return params, fmt.Errorf("invalid start date %q", v)
+		}
+		params.Start, params.End = t, time.Now()
+	}
+	if v, _ := args["end"].(string); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return params, fmt.Errorf("invalid end date %q", v)
+		}
+		params.End = t
+	}
+	return params, params.Validate()
+}
+
+// jsonScalar passes arbitrary JSON values (maps, raw values) through as-is
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "Arbitrary JSON value",
+	Serialize:   func(v any) any { return v },
+	ParseValue:  func(v any) any { return v },
+	ParseLiteral: func(v ast.Value) any {
+		return v.GetValue()
+	},
+})
+
+var invalidNameChars = regexp.MustCompile(`[^_0-9A-Za-z]`)
+
+// typeMapper derives GraphQL output types from Go structs by their JSON
+// tags, so the schema follows the yfinance types without a parallel
+// hand-written definition
+type typeMapper struct {
+	objects map[reflect.Type]*graphql.Object
+}
+
+func newTypeMapper() *typeMapper {
+	return &typeMapper{objects: make(map[reflect.Type]*graphql.Object)}
+}
+
+// output returns the GraphQL type for t
+func (m *typeMapper) output(t reflect.Type) graphql.Output {
+	if t == reflect.TypeFor[time.Time]() {
+		return graphql.String
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return m.output(t.Elem())
+	case reflect.String:
+		return graphql.String
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return graphql.Int
+	case reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		// GraphQL Int is 32-bit; volumes and market caps overflow it
+		return graphql.Float
+	case reflect.Slice, reflect.Array:
+		return graphql.NewList(m.output(t.Elem()))
+	case reflect.Struct:
+		return m.object(t)
+	default:
+		return jsonScalar
+	}
+}
+
+// object returns the GraphQL object for struct type t, flattening embedded
+// structs the way encoding/json does
+func (m *typeMapper) object(t reflect.Type) *graphql.Object {
+	if obj, ok := m.objects[t]; ok {
+		return obj
+	}
+	obj := graphql.NewObject(graphql.ObjectConfig{
+		Name: invalidNameChars.ReplaceAllString(t.Name(), "_"),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphql.Fields{}
+			m.addFields(fields, t)
+			return fields
+		}),
+	})
+	m.objects[t] = obj
+	return obj
+}
+
+func (m *typeMapper) addFields(fields graphql.Fields, t reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			m.addFields(fields, f.Type)
+			continue
+		}
+		if tag == "" {
+			tag = f.Name
+		}
+
+		name := invalidNameChars.ReplaceAllString(tag, "_")
+		if name[0] >= '0' && name[0] <= '9' {
+			name = "_" + name
+		}
+		key := tag
+		fields[name] = &graphql.Field{
+			Type: m.output(f.Type),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				src, _ := p.Source.(map[string]any)
+				return src[key], nil
+			},
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestGraphQL tests schema construction and resolving cached ticker fields
+func TestGraphQL(t *testing.T) {
+	s, err := New(Options{GraphQL: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.cache.Set("graphql:quote:AAPL", []byte(`{"symbol":"AAPL","regularMarketPrice":190.5}`), 0)
+
+	body := `{"query":"{ ticker(symbol: \"aapl\") { symbol quote { regularMarketPrice } } }"}`
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	want := `{"data":{"ticker":{"quote":{"regularMarketPrice":190.5},"symbol":"AAPL"}}}`
+	if got := strings.TrimSpace(rec.Body.String()); got != want {
+		t.Errorf("Unexpected response\n got: %s\nwant: %s", got, want)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/graphql?query="+`{ticker(symbol:"AAPL"){financials(statement:"equity"){annual{date}}}}`, nil)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if !strings.Contains(rec.Body.String(), "unknown statement") {
+		t.Errorf("Expected statement validation error, got %s", rec.Body)
+	}
+}
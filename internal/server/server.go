@@ -0,0 +1,317 @@
+// Package server exposes the yfinance package over a small JSON REST API.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// Options configures the REST server
+type Options struct {
+	RequestsPerSecond float64 // Upstream Yahoo requests allowed per second
+	Burst             int     // Upstream burst size
+	CacheSize         int     // Maximum number of cached responses
+	GraphQL           bool    // Also serve the GraphQL API on /graphql
+	Logger            *log.Logger
+}
+
+// Server serves Yahoo Finance data as JSON with response caching and
+// upstream rate limiting
+type Server struct {
+	mux     *http.ServeMux
+	cache   *yfinance.Cache
+	limiter *yfinance.RateLimiter
+	logger  *log.Logger
+	schema  graphql.Schema
+}
+
+// New creates a Server with the given options
+func New(opts Options) (*Server, error) {
+	if opts.RequestsPerSecond <= 0 {
+		opts.RequestsPerSecond = 2
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = 5
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = 1000
+	}
+
+	s := &Server{
+		mux: http.NewServeMux(),
+		cache: yfinance.NewCache(yfinance.CacheConfig{
+			Type:       yfinance.CacheTypeMemory,
+			DefaultTTL: yfinance.TTLQuote,
+			MaxSize:    opts.CacheSize,
+		}),
+		limiter: yfinance.NewRateLimiter(opts.RequestsPerSecond, opts.Burst),
+		logger:  opts.Logger,
+	}
+
+	s.mux.HandleFunc("GET /quote/{symbol}", s.cached(yfinance.TTLQuote, s.handleQuote))
+	s.mux.HandleFunc("GET /history/{symbol}", s.cached(yfinance.TTLHistory, s.handleHistory))
+	s.mux.HandleFunc("GET /options/{symbol}", s.cached(yfinance.TTLOptions, s.handleOptions))
+	s.mux.HandleFunc("GET /search", s.cached(yfinance.TTLSearch, s.handleSearch))
+	s.mux.HandleFunc("GET /screener/{preset}", s.cached(yfinance.TTLQuote, s.handleScreener))
+	s.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if opts.GraphQL {
+		schema, err := s.newSchema()
+		if err != nil {
+			return nil, fmt.Errorf("server: building GraphQL schema: %w", err)
+		}
+		s.schema = schema
+		s.mux.HandleFunc("GET /graphql", s.handleGraphQL)
+		s.mux.HandleFunc("POST /graphql", s.handleGraphQL)
+	}
+
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	s.mux.ServeHTTP(w, r)
+	if s.logger != nil {
+		s.logger.Printf("%s %s %s", r.Method, r.URL.RequestURI(), time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// handlerFunc produces a JSON-serializable response for a request
+type handlerFunc func(ctx context.Context, r *http.Request) (any, error)
+
+// cached wraps h with response caching keyed by path and query, and
+// rate limits the upstream calls made on cache misses
+func (s *Server) cached(ttl time.Duration, h handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path + "?" + r.URL.Query().Encode()
+		if data, ok := s.cache.Get(key); ok {
+			writeBody(w, http.StatusOK, "HIT", data)
+			return
+		}
+
+		if err := s.limiter.Wait(r.Context()); err != nil {
+			writeError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+
+		v, err := h(r.Context(), r)
+		if err != nil {
+			writeError(w, statusFor(err), err)
+			return
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		s.cache.Set(key, data, ttl)
+		writeBody(w, http.StatusOK, "MISS", data)
+	}
+}
+
+func (s *Server) handleQuote(ctx context.Context, r *http.Request) (any, error) {
+	symbols := strings.Split(strings.ToUpper(r.PathValue("symbol")), ",")
+	quotes, err := yfinance.QuoteMultiple(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+	if len(symbols) == 1 {
+		if len(quotes) == 0 {
+			return nil, yfinance.NewSymbolError(symbols[0], yfinance.ErrNotFound)
+		}
+		return quotes[0], nil
+	}
+	return quotes, nil
+}
+
+func (s *Server) handleHistory(ctx context.Context, r *http.Request) (any, error) {
+	q := r.URL.Query()
+	params := yfinance.HistoryParams{
+		Period:   yfinance.Period(q.Get("period")),
+		Interval: yfinance.Interval(q.Get("interval")),
+		PrePost:  q.Get("prepost") == "true",
+	}
+	if params.Period == "" {
+		params.Period = yfinance.Period1mo
+	}
+	if params.Interval == "" {
+		params.Interval = yfinance.Interval1d
+	}
+	if v := q.Get("start"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return nil, badRequest("invalid start date %q", v)
+		}
+		params.Start = t
+		params.End = time.Now()
+	}
+	if v := q.Get("end"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return nil, badRequest("invalid end date %q", v)
+		}
+		if params.Start.IsZero() {
+			return nil, badRequest("end date requires a start date")
+		}
+		if !t.After(params.Start) {
+			return nil, badRequest("end date %q must be after start date", v)
+		}
+		params.End = t
+	}
+
+	ticker, err := yfinance.NewTicker(r.PathValue("symbol"))
+	if err != nil {
+		return nil, err
+	}
+	return ticker.History(ctx, params)
+}
+
+func (s *Server) handleOptions(ctx context.Context, r *http.Request) (any, error) {
+	var expiration string
+	if v := r.URL.Query().Get("expiration"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return nil, badRequest("invalid expiration date %q", v)
+		}
+		expiration = strconv.FormatInt(t.Unix(), 10)
+	}
+
+	ticker, err := yfinance.NewTicker(r.PathValue("symbol"))
+	if err != nil {
+		return nil, err
+	}
+	chain, err := ticker.Options(ctx, expiration)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.URL.Query().Get("greeks") == "true" {
+		rate := 0.045
+		if v := r.URL.Query().Get("rate"); v != "" {
+			if rate, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, badRequest("invalid rate %q", v)
+			}
+		}
+		return chain.WithGreeks(rate), nil
+	}
+	return chain, nil
+}
+
+func (s *Server) handleSearch(ctx context.Context, r *http.Request) (any, error) {
+	q := r.URL.Query()
+	query := q.Get("q")
+	if query == "" {
+		return nil, badRequest("missing q parameter")
+	}
+	count, err := intParam(q.Get("count"), 10)
+	if err != nil {
+		return nil, err
+	}
+	return yfinance.Search(ctx, query, yfinance.WithQuotesCount(count))
+}
+
+func (s *Server) handleScreener(ctx context.Context, r *http.Request) (any, error) {
+	q := r.URL.Query()
+	size, err := intParam(q.Get("size"), 25)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := intParam(q.Get("offset"), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var criteria yfinance.ScreenCriteria
+	switch preset := r.PathValue("preset"); preset {
+	case "gainers":
+		criteria = yfinance.GainersCriteria(size)
+	case "losers":
+		criteria = yfinance.LosersCriteria(size)
+	case "most-active":
+		criteria = yfinance.MostActiveCriteria(size)
+	case "high-dividend":
+		minYield := 4.0
+		if v := q.Get("min_yield"); v != "" {
+			if minYield, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, badRequest("invalid min_yield %q", v)
+			}
+		}
+		criteria = yfinance.HighDividendCriteria(minYield, size)
+	default:
+		return nil, &httpError{status: http.StatusNotFound, msg: fmt.Sprintf("unknown screener preset %q", preset)}
+	}
+	criteria.Offset = offset
+
+	return yfinance.Screen(ctx, criteria)
+}
+
+// httpError carries an explicit HTTP status for request validation failures
+type httpError struct {
+	status int
+	msg    string
+}
+
+func (e *httpError) Error() string { return e.msg }
+
+func badRequest(format string, args ...any) error {
+	return &httpError{status: http.StatusBadRequest, msg: fmt.Sprintf(format, args...)}
+}
+
+// intParam parses a non-negative integer query parameter, returning def when empty
+func intParam(v string, def int) (int, error) {
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, badRequest("invalid integer %q", v)
+	}
+	return n, nil
+}
+
+// statusFor maps package errors onto HTTP status codes
+func statusFor(err error) int {
+	var he *httpError
+	switch {
+	case errors.As(err, &he):
+		return he.status
+	case errors.Is(err, yfinance.ErrInvalidSymbol),
+		errors.Is(err, yfinance.ErrInvalidInterval),
+		errors.Is(err, yfinance.ErrInvalidPeriod):
+		return http.StatusBadRequest
+	case errors.Is(err, yfinance.ErrNotFound), errors.Is(err, yfinance.ErrNoData):
+		return http.StatusNotFound
+	case errors.Is(err, yfinance.ErrRateLimited):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func writeBody(w http.ResponseWriter, status int, cacheStatus string, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Cache", cacheStatus)
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
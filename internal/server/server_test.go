@@ -0,0 +1,70 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+	"github.com/amjadjibon/gotick/pkg/yfinance/yfinancetest"
+)
+
+// TestHistoryDates tests start and end date handling of the history endpoint
+func TestHistoryDates(t *testing.T) {
+	tr := yfinancetest.NewTransport()
+	client, err := tr.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev, _ := yfinance.DefaultClient()
+	yfinance.SetDefaultClient(client)
+	t.Cleanup(func() { yfinance.SetDefaultClient(prev) })
+
+	s, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query   string
+		status  int
+		period1 string
+		period2 string
+	}{
+		{"?start=2024-01-02", http.StatusOK, "1704153600", ""},
+		{"?start=2024-01-02&end=2024-02-01", http.StatusOK, "1704153600", "1706745600"},
+		{"?end=2024-02-01", http.StatusBadRequest, "", ""},
+		{"?start=2024-02-01&end=2024-01-02", http.StatusBadRequest, "", ""},
+		{"?start=01/02/2024", http.StatusBadRequest, "", ""},
+		{"?start=2024-01-02&end=tomorrow", http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		before := len(tr.Requests())
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/history/AAPL"+tt.query, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected %d, got %d: %s", tt.query, tt.status, rec.Code, rec.Body)
+			continue
+		}
+		if tt.status != http.StatusOK {
+			if len(tr.Requests()) != before {
+				t.Errorf("%s: expected no upstream request", tt.query)
+			}
+			continue
+		}
+
+		var chart *http.Request
+		for _, req := range tr.Requests()[before:] {
+			if yfinance.EndpointName(req.URL) == "chart" {
+				chart = req
+			}
+		}
+		if chart == nil {
+			t.Fatalf("%s: expected a chart request", tt.query)
+		}
+		q := chart.URL.Query()
+		if q.Get("period1") != tt.period1 || (tt.period2 != "" && q.Get("period2") != tt.period2) {
+			t.Errorf("%s: expected period1=%s period2=%s, got %v", tt.query, tt.period1, tt.period2, q)
+		}
+	}
+}
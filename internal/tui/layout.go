@@ -10,6 +10,10 @@ import (
 )
 
 func createLayout(t terminalapi.Terminal, app *App) *container.Container {
+	summaryTitle := " Market Summary "
+	if app.portfolio != nil {
+		summaryTitle = " Portfolio "
+	}
 	c, err := container.New(
 		t,
 		container.Border(linestyle.Light),
@@ -48,7 +52,7 @@ func createLayout(t terminalapi.Terminal, app *App) *container.Container {
 							container.Top(
 								container.PlaceWidget(app.marketText),
 								container.Border(linestyle.Light),
-								container.BorderTitle(" Market Summary "),
+								container.BorderTitle(summaryTitle),
 							),
 							container.Bottom(
 								container.PlaceWidget(app.newsText),
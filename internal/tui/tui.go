@@ -15,12 +15,15 @@ import (
 	"github.com/mum4k/termdash/widgets/linechart"
 	"github.com/mum4k/termdash/widgets/text"
 	"github.com/mum4k/termdash/widgets/textinput"
+
+	"github.com/amjadjibon/gotick/pkg/portfolio"
 )
 
 type Options struct {
-	Symbol   string
-	Interval string
-	Range    string
+	Symbol    string
+	Interval  string
+	Range     string
+	Portfolio *portfolio.Portfolio // When set, replaces the market summary
 }
 
 // Valid ranges and intervals for Yahoo Finance
@@ -80,6 +83,7 @@ type App struct {
 	currentRange    string
 	rangeIdx        int
 	intervalIdx     int
+	portfolio       *portfolio.Portfolio
 
 	input        *textinput.TextInput
 	lc           *linechart.LineChart
@@ -96,6 +100,7 @@ func Run(opts Options) {
 		currentSymbol:   opts.Symbol,
 		currentInterval: opts.Interval,
 		currentRange:    opts.Range,
+		portfolio:       opts.Portfolio,
 	}
 
 	if app.currentSymbol == "" {
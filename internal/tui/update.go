@@ -8,6 +8,7 @@ import (
 	"github.com/mum4k/termdash/widgets/linechart"
 	"github.com/mum4k/termdash/widgets/text"
 
+	"github.com/amjadjibon/gotick/pkg/portfolio"
 	"github.com/amjadjibon/gotick/pkg/yfinance"
 )
 
@@ -20,7 +21,11 @@ func (app *App) updateDashboard() {
 
 	app.updateQuote(t)
 	app.updateChart(t)
-	app.updateMarketSummary()
+	if app.portfolio != nil {
+		app.updatePortfolio()
+	} else {
+		app.updateMarketSummary()
+	}
 	app.updateNews(t)
 	app.updateRecommendations(t)
 }
@@ -180,6 +185,34 @@ func (app *App) updateMarketSummary() {
 	}
 }
 
+func (app *App) updatePortfolio() {
+	v, err := portfolio.Value(app.ctx, app.portfolio)
+	if err != nil {
+		_ = app.marketText.Write(fmt.Sprintf("Error: %v", err), text.WriteReplace())
+		return
+	}
+
+	signed := func(x float64) cell.Color {
+		if x < 0 {
+			return cell.ColorRed
+		}
+		return cell.ColorGreen
+	}
+
+	app.marketText.Reset()
+	_ = app.marketText.Write(fmt.Sprintf("%-8s %10s %7s %9s\n", "SYMBOL", "VALUE", "WEIGHT", "P&L%"))
+	for _, pv := range v.Positions {
+		_ = app.marketText.Write(fmt.Sprintf("%-8s %10.2f %6.1f%% ", pv.Symbol, pv.MarketValue, pv.Weight*100))
+		_ = app.marketText.Write(fmt.Sprintf("%+8.2f%%\n", pv.TotalPLPct), text.WriteCellOpts(cell.FgColor(signed(pv.TotalPLPct))))
+	}
+	_ = app.marketText.Write(fmt.Sprintf("\nValue %.2f  Day ", v.MarketValue))
+	_ = app.marketText.Write(fmt.Sprintf("%+.2f (%+.2f%%)\n", v.DayChange, v.DayChangePct), text.WriteCellOpts(cell.FgColor(signed(v.DayChange))))
+	_ = app.marketText.Write("P&L ")
+	_ = app.marketText.Write(fmt.Sprintf("%+.2f (%+.2f%%)", v.TotalPL, v.TotalPLPct), text.WriteCellOpts(cell.FgColor(signed(v.TotalPL))))
+	_ = app.marketText.Write("  Realized ")
+	_ = app.marketText.Write(fmt.Sprintf("%+.2f\n", v.RealizedPL), text.WriteCellOpts(cell.FgColor(signed(v.RealizedPL))))
+}
+
 func (app *App) updateNews(t *yfinance.Ticker) {
 	news, err := t.News(app.ctx, 5)
 	if err != nil {
@@ -0,0 +1,328 @@
+// Package watchlist keeps named lists of symbols, with notes and target
+// prices, shared by the CLI and the TUI.
+//
+// Lists are stored in a YAML file, by default
+// $XDG_CONFIG_HOME/gotick/watchlists.yaml.
+package watchlist
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// EnvWatchlists overrides the default watchlists file
+const EnvWatchlists = "GOTICK_WATCHLISTS"
+
+var (
+	// ErrNotFound is returned for a watchlist that does not exist
+	ErrNotFound = errors.New("watchlist: not found")
+	// ErrExists is returned when creating or renaming onto an existing name
+	ErrExists = errors.New("watchlist: already exists")
+)
+
+// Item is a watched symbol
+type Item struct {
+	Symbol      string    `json:"symbol" yaml:"symbol"`
+	Note        string    `json:"note,omitempty" yaml:"note,omitempty"`
+	TargetPrice float64   `json:"targetPrice,omitempty" yaml:"target_price,omitempty"` // Zero when unset
+	Added       time.Time `json:"added,omitzero" yaml:"added,omitempty"`
+}
+
+// Watchlist is a named list of symbols
+type Watchlist struct {
+	Name  string `json:"name" yaml:"name"`
+	Items []Item `json:"items" yaml:"items"`
+}
+
+// Symbols returns the watched symbols in list order
+func (w *Watchlist) Symbols() []string {
+	symbols := make([]string, len(w.Items))
+	for i, item := range w.Items {
+		symbols[i] = item.Symbol
+	}
+	return symbols
+}
+
+// QuotedItem is a watched symbol with its latest quote
+type QuotedItem struct {
+	Item
+	Quote *yfinance.Quote `json:"quote,omitempty"` // Nil when Yahoo returned none
+	// ToTarget is the fractional move from the price to the target price,
+	// positive when the target is above; zero without a target or quote
+	ToTarget float64 `json:"toTarget,omitempty"`
+}
+
+// Quotes fetches quotes for every item in one batch with Tickers
+func (w *Watchlist) Quotes(ctx context.Context) ([]QuotedItem, error) {
+	items := make([]QuotedItem, len(w.Items))
+	if len(w.Items) == 0 {
+		return items, nil
+	}
+	tickers, err := yfinance.NewTickers(w.Symbols()...)
+	if err != nil {
+		return nil, err
+	}
+	quotes, err := tickers.Quotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range w.Items {
+		items[i] = QuotedItem{Item: item, Quote: quotes[item.Symbol]}
+		if q := items[i].Quote; q != nil && item.TargetPrice > 0 && q.RegularMarketPrice > 0 {
+			items[i].ToTarget = item.TargetPrice/q.RegularMarketPrice - 1
+		}
+	}
+	return items, nil
+}
+
+// Store holds the watchlists of one file. Its methods are safe for
+// concurrent use; changes are kept in memory until Save.
+type Store struct {
+	path  string
+	mu    sync.Mutex
+	lists []Watchlist
+}
+
+// file is the on-disk layout
+type file struct {
+	Watchlists []Watchlist `yaml:"watchlists"`
+}
+
+// DefaultPath returns the watchlists file location, honoring
+// GOTICK_WATCHLISTS
+func DefaultPath() string {
+	if path := os.Getenv(EnvWatchlists); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gotick", "watchlists.yaml")
+}
+
+// Load reads the watchlists at path. A missing file is an empty store,
+// created on the first Save.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is supplied by the user
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("watchlist: failed to parse %s: %w", path, err)
+	}
+	for _, w := range f.Watchlists {
+		name, err := validName(w.Name)
+		if err != nil {
+			return nil, err
+		}
+		if s.find(name) >= 0 {
+			return nil, fmt.Errorf("watchlist: %s: duplicate list %q", path, name)
+		}
+		list := Watchlist{Name: name}
+		list.upsert(w.Items)
+		s.lists = append(s.lists, list)
+	}
+	return s, nil
+}
+
+// Path returns the file the store loads from and saves to
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Save writes the watchlists to the store's file, creating its directory
+func (s *Store) Save() error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	s.mu.Lock()
+	err := enc.Encode(file{Watchlists: s.lists})
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil { //nolint:gosec // G301: config directory is user-owned
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Names returns the watchlist names in the order they were created
+func (s *Store) Names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, len(s.lists))
+	for i, w := range s.lists {
+		names[i] = w.Name
+	}
+	return names
+}
+
+// Get returns a copy of the named watchlist
+func (s *Store) Get(name string) (*Watchlist, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(name)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+	return &Watchlist{Name: s.lists[i].Name, Items: slices.Clone(s.lists[i].Items)}, nil
+}
+
+// Create adds an empty watchlist
+func (s *Store) Create(name string) error {
+	name, err := validName(name)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.find(name) >= 0 {
+		return fmt.Errorf("%w: %q", ErrExists, name)
+	}
+	s.lists = append(s.lists, Watchlist{Name: name})
+	return nil
+}
+
+// Rename changes a watchlist's name
+func (s *Store) Rename(name, to string) error {
+	to, err := validName(to)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+	if j := s.find(to); j >= 0 && j != i {
+		return fmt.Errorf("%w: %q", ErrExists, to)
+	}
+	s.lists[i].Name = to
+	return nil
+}
+
+// Delete removes a watchlist
+func (s *Store) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+	s.lists = slices.Delete(s.lists, i, i+1)
+	return nil
+}
+
+// Add puts items on a watchlist, creating the list if needed. An item
+// already on the list is updated in place, keeping its note, target, and
+// added time where the new item leaves them zero.
+func (s *Store) Add(name string, items ...Item) error {
+	name, err := validName(name)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].Symbol = strings.ToUpper(strings.TrimSpace(items[i].Symbol))
+		if items[i].Symbol == "" {
+			return fmt.Errorf("watchlist: %q: %w", name, yfinance.ErrInvalidSymbol)
+		}
+		if items[i].TargetPrice < 0 {
+			return fmt.Errorf("watchlist: %q: %s: target price cannot be negative", name, items[i].Symbol)
+		}
+		if items[i].Added.IsZero() {
+			items[i].Added = time.Now().UTC().Truncate(time.Second)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(name)
+	if i < 0 {
+		s.lists = append(s.lists, Watchlist{Name: name})
+		i = len(s.lists) - 1
+	}
+	s.lists[i].upsert(items)
+	return nil
+}
+
+// Remove takes symbols off a watchlist. Symbols not on it are ignored.
+func (s *Store) Remove(name string, symbols ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+	s.lists[i].Items = slices.DeleteFunc(s.lists[i].Items, func(item Item) bool {
+		return slices.ContainsFunc(symbols, func(sym string) bool { return strings.EqualFold(strings.TrimSpace(sym), item.Symbol) })
+	})
+	return nil
+}
+
+// find returns the index of the named list, matched case-insensitively,
+// or -1
+func (s *Store) find(name string) int {
+	name = strings.TrimSpace(name)
+	return slices.IndexFunc(s.lists, func(w Watchlist) bool { return strings.EqualFold(w.Name, name) })
+}
+
+// upsert appends new symbols and merges repeated ones into their item
+func (w *Watchlist) upsert(items []Item) {
+	for _, item := range items {
+		item.Symbol = strings.ToUpper(strings.TrimSpace(item.Symbol))
+		if item.Symbol == "" {
+			continue
+		}
+		i := slices.IndexFunc(w.Items, func(existing Item) bool { return existing.Symbol == item.Symbol })
+		if i < 0 {
+			w.Items = append(w.Items, item)
+			continue
+		}
+		existing := &w.Items[i]
+		if item.Note != "" {
+			existing.Note = item.Note
+		}
+		if item.TargetPrice != 0 {
+			existing.TargetPrice = item.TargetPrice
+		}
+		if existing.Added.IsZero() {
+			existing.Added = item.Added
+		}
+	}
+}
+
+// validName trims a watchlist name and rejects empty ones
+func validName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("watchlist: name cannot be empty")
+	}
+	return name, nil
+}
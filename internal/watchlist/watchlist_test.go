@@ -0,0 +1,97 @@
+package watchlist
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestStore tests editing watchlists and reading them back from disk
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gotick", "watchlists.yaml")
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Names()) != 0 {
+		t.Fatalf("Expected no lists, got %v", s.Names())
+	}
+
+	if err := s.Add("tech", Item{Symbol: " aapl ", Note: "core", TargetPrice: 250}, Item{Symbol: "msft"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Add("TECH", Item{Symbol: "AAPL", TargetPrice: 300}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Create("Tech"); !errors.Is(err, ErrExists) {
+		t.Errorf("Expected ErrExists, got %v", err)
+	}
+	if err := s.Create("energy"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Rename("energy", "tech"); !errors.Is(err, ErrExists) {
+		t.Errorf("Expected ErrExists renaming onto tech, got %v", err)
+	}
+	if err := s.Add("tech", Item{Symbol: "X", TargetPrice: -1}); err == nil {
+		t.Error("Expected error for a negative target")
+	}
+	if err := s.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := loaded.Names(); !reflect.DeepEqual(got, []string{"tech", "energy"}) {
+		t.Errorf("Expected [tech energy], got %v", got)
+	}
+	tech, err := loaded.Get("tech")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := tech.Symbols(); !reflect.DeepEqual(got, []string{"AAPL", "MSFT"}) {
+		t.Errorf("Expected [AAPL MSFT], got %v", got)
+	}
+	if aapl := tech.Items[0]; aapl.Note != "core" || aapl.TargetPrice != 300 || aapl.Added.IsZero() {
+		t.Errorf("Unexpected merged item: %+v", aapl)
+	}
+
+	// Get returns a copy
+	tech.Items[0].Note = "changed"
+	if again, _ := loaded.Get("tech"); again.Items[0].Note != "core" {
+		t.Error("Expected Get to return a copy")
+	}
+
+	if err := loaded.Remove("tech", "msft", "NOPE"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := loaded.Delete("energy"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := loaded.Delete("energy"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if tech, _ := loaded.Get("tech"); len(loaded.Names()) != 1 || !reflect.DeepEqual(tech.Symbols(), []string{"AAPL"}) {
+		t.Errorf("Unexpected lists after removal: %v %v", loaded.Names(), tech.Symbols())
+	}
+}
+
+// TestLoadInvalid tests that malformed files are rejected
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watchlists.yaml")
+	for _, content := range []string{
+		"watchlists: [",
+		"watchlists:\n  - name: ''\n",
+		"watchlists:\n  - name: a\n  - name: A\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("Expected error loading %q", content)
+		}
+	}
+}
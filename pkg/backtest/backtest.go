@@ -0,0 +1,320 @@
+// Package backtest simulates trading strategies over historical bars from
+// the yfinance package.
+//
+// A Strategy sees each bar in order and submits orders through a Context.
+// Orders fill on the next bar, so strategies cannot trade on prices they
+// have not seen yet:
+//
+//	data, _ := ticker.History(ctx, yfinance.HistoryParams{Period: yfinance.Period5y})
+//	report, err := backtest.Run(data, &backtest.SMACross{Fast: 20, Slow: 50}, backtest.Config{
+//		InitialCapital: 10000,
+//		CommissionRate: 0.001,
+//		Slippage:       0.0005,
+//	})
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// ErrNoBars is returned when there is no data to run over
+var ErrNoBars = errors.New("backtest: no bars")
+
+// Strategy decides what to trade on each bar
+type Strategy interface {
+	// OnBar is called once per bar, oldest first, after orders from the
+	// previous bar have filled
+	OnBar(c *Context, bar yfinance.Bar)
+}
+
+// StrategyFunc adapts a function to the Strategy interface
+type StrategyFunc func(c *Context, bar yfinance.Bar)
+
+// OnBar calls f
+func (f StrategyFunc) OnBar(c *Context, bar yfinance.Bar) { f(c, bar) }
+
+// Config sets the simulated account and trading costs
+type Config struct {
+	InitialCapital float64 // Starting cash, default 10000
+	Commission     float64 // Fixed cost per order
+	CommissionRate float64 // Cost per order as a fraction of traded value
+	Slippage       float64 // Adverse price move per fill, as a fraction of price
+	AllowShort     bool    // Allow selling more than is held
+}
+
+func (cfg *Config) validate() error {
+	if cfg.InitialCapital == 0 {
+		cfg.InitialCapital = 10000
+	}
+	if cfg.InitialCapital < 0 || cfg.Commission < 0 || cfg.CommissionRate < 0 || cfg.Slippage < 0 {
+		return fmt.Errorf("backtest: capital, commission, and slippage cannot be negative")
+	}
+	return nil
+}
+
+// Run simulates strategy over data's bars and reports the results. A
+// position still open at the end is valued at the last close.
+func Run(data *yfinance.ChartData, strategy Strategy, cfg Config) (*Report, error) {
+	if data == nil || len(data.Bars) == 0 {
+		return nil, ErrNoBars
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	c := &Context{Symbol: data.Symbol, bars: data.Bars, cfg: cfg, cash: cfg.InitialCapital}
+	equity := make([]EquityPoint, 0, len(data.Bars))
+	for i, bar := range data.Bars {
+		c.index = i
+		c.fillPending(bar)
+		strategy.OnBar(c, bar)
+		equity = append(equity, EquityPoint{Time: bar.Timestamp, Equity: c.Equity(), Cash: c.cash, Position: c.position})
+	}
+
+	return newReport(c, equity), nil
+}
+
+// RunAll runs a fresh strategy from newStrategy over each symbol of a
+// Download result. Symbols without bars are skipped.
+func RunAll(res *yfinance.DownloadResult, newStrategy func() Strategy, cfg Config) (map[string]*Report, error) {
+	reports := make(map[string]*Report, len(res.Data))
+	for sym, data := range res.Data {
+		report, err := Run(data, newStrategy(), cfg)
+		if errors.Is(err, ErrNoBars) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("backtest: %s: %w", sym, err)
+		}
+		reports[sym] = report
+	}
+	return reports, nil
+}
+
+// Side is the direction of an order
+type Side string
+
+const (
+	Buy  Side = "buy"
+	Sell Side = "sell"
+)
+
+// OrderType controls the fill price of an order
+type OrderType string
+
+const (
+	// Market orders fill at the next bar's open
+	Market OrderType = "market"
+	// Limit orders fill at Price or better if the next bar reaches it
+	Limit OrderType = "limit"
+	// Stop orders become market orders if the next bar trades through Price
+	Stop OrderType = "stop"
+)
+
+// Order is an instruction to trade. Orders are good for one bar: those not
+// filled on the bar after submission are cancelled.
+type Order struct {
+	Side     Side
+	Type     OrderType // Default Market
+	Quantity float64
+	Price    float64 // Limit or stop price
+}
+
+// Context is a strategy's view of the simulation
+type Context struct {
+	Symbol string
+
+	bars  []yfinance.Bar
+	index int
+	cfg   Config
+
+	cash           float64
+	position       float64 // Signed quantity; negative when short
+	avgPrice       float64
+	entryTime      time.Time
+	entryCost      float64 // Commission paid on the open position
+	pending        []Order
+	trades         []Trade
+	commissionPaid float64
+	rejected       int
+}
+
+// Bars returns the bars up to and including the current one
+func (c *Context) Bars() []yfinance.Bar { return c.bars[:c.index+1] }
+
+// Index returns the position of the current bar
+func (c *Context) Index() int { return c.index }
+
+// Cash returns the available cash
+func (c *Context) Cash() float64 { return c.cash }
+
+// Position returns the quantity held; negative when short
+func (c *Context) Position() float64 { return c.position }
+
+// Equity returns cash plus the position valued at the current close
+func (c *Context) Equity() float64 {
+	return c.cash + c.position*c.bars[c.index].Close
+}
+
+// Submit queues an order to fill on the next bar
+func (c *Context) Submit(o Order) {
+	if o.Quantity <= 0 {
+		return
+	}
+	if o.Type == "" {
+		o.Type = Market
+	}
+	c.pending = append(c.pending, o)
+}
+
+// Buy submits a market order to buy quantity
+func (c *Context) Buy(quantity float64) { c.Submit(Order{Side: Buy, Quantity: quantity}) }
+
+// Sell submits a market order to sell quantity
+func (c *Context) Sell(quantity float64) { c.Submit(Order{Side: Sell, Quantity: quantity}) }
+
+// Close submits a market order that flattens the position
+func (c *Context) Close() {
+	switch {
+	case c.position > 0:
+		c.Sell(c.position)
+	case c.position < 0:
+		c.Buy(-c.position)
+	}
+}
+
+// TargetPercent submits a market order that moves the position to fraction
+// of equity at the current close, e.g. 1 for fully invested or -0.5 for half
+// short
+func (c *Context) TargetPercent(fraction float64) {
+	price := c.bars[c.index].Close
+	if price <= 0 {
+		return
+	}
+	diff := fraction*c.Equity()/price - c.position
+	if diff > 0 {
+		c.Buy(diff)
+	} else if diff < 0 {
+		c.Sell(-diff)
+	}
+}
+
+// fillPending executes orders queued on the previous bar against bar
+func (c *Context) fillPending(bar yfinance.Bar) {
+	orders := c.pending
+	c.pending = nil
+	for _, o := range orders {
+		if price, ok := fillPrice(o, bar); ok {
+			c.fill(o, price, bar.Timestamp)
+		}
+	}
+}
+
+// fillPrice returns the price o fills at on bar, before slippage
+func fillPrice(o Order, bar yfinance.Bar) (float64, bool) {
+	switch o.Type {
+	case Limit:
+		if o.Side == Buy && bar.Low <= o.Price {
+			return math.Min(bar.Open, o.Price), true
+		}
+		if o.Side == Sell && bar.High >= o.Price {
+			return math.Max(bar.Open, o.Price), true
+		}
+		return 0, false
+	case Stop:
+		if o.Side == Buy && bar.High >= o.Price {
+			return math.Max(bar.Open, o.Price), true
+		}
+		if o.Side == Sell && bar.Low <= o.Price {
+			return math.Min(bar.Open, o.Price), true
+		}
+		return 0, false
+	default:
+		return bar.Open, bar.Open > 0
+	}
+}
+
+// fill applies slippage and commission, updates cash and the position, and
+// records a trade for any quantity that closes the existing position.
+// Sells beyond the position are cut back unless shorting is allowed, and
+// buys are cut back to what the cash covers.
+func (c *Context) fill(o Order, price float64, t time.Time) {
+	qty := o.Quantity
+	if o.Side == Buy {
+		price *= 1 + c.cfg.Slippage
+		if affordable := (c.cash - c.cfg.Commission) / (price * (1 + c.cfg.CommissionRate)); qty > affordable {
+			qty = affordable
+		}
+	} else {
+		price *= 1 - c.cfg.Slippage
+		if !c.cfg.AllowShort && qty > c.position {
+			qty = c.position
+		}
+	}
+	if qty <= 1e-9 {
+		c.rejected++
+		return
+	}
+
+	commission := c.cfg.Commission + c.cfg.CommissionRate*price*qty
+	c.commissionPaid += commission
+	signed := qty
+	if o.Side == Sell {
+		signed = -qty
+	}
+	c.cash -= signed*price + commission
+
+	// Opening or adding to a position
+	if c.position == 0 || (c.position > 0) == (signed > 0) {
+		held := math.Abs(c.position)
+		if held == 0 {
+			c.entryTime = t
+		}
+		c.avgPrice = (c.avgPrice*held + price*qty) / (held + qty)
+		c.entryCost += commission
+		c.position += signed
+		return
+	}
+
+	// Reducing, closing, or reversing
+	held := math.Abs(c.position)
+	closed := math.Min(qty, held)
+	direction := 1.0
+	side := Long
+	if c.position < 0 {
+		direction, side = -1, Short
+	}
+	entryCost := c.entryCost * closed / held
+	exitCost := commission * closed / qty
+	trade := Trade{
+		Side:       side,
+		EntryTime:  c.entryTime,
+		ExitTime:   t,
+		Quantity:   closed,
+		EntryPrice: c.avgPrice,
+		ExitPrice:  price,
+		Commission: entryCost + exitCost,
+	}
+	trade.PL = (price-c.avgPrice)*closed*direction - trade.Commission
+	if basis := c.avgPrice * closed; basis > 0 {
+		trade.Return = trade.PL / basis
+	}
+	c.trades = append(c.trades, trade)
+	c.entryCost -= entryCost
+	c.position += signed
+
+	switch {
+	case math.Abs(c.position) <= 1e-9:
+		c.position, c.avgPrice, c.entryCost = 0, 0, 0
+	case qty > held:
+		// Reversed: the remainder opens a new position at this price
+		c.avgPrice = price
+		c.entryTime = t
+		c.entryCost = commission - exitCost
+	}
+}
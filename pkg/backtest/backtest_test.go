@@ -0,0 +1,123 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// series builds daily bars that open at each price and close one higher
+func series(opens ...float64) *yfinance.ChartData {
+	data := &yfinance.ChartData{Symbol: "TEST"}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, o := range opens {
+		data.Bars = append(data.Bars, yfinance.Bar{
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      o,
+			High:      o + 2,
+			Low:       o - 2,
+			Close:     o + 1,
+		})
+	}
+	return data
+}
+
+// TestRunFills tests next-bar fills, slippage, commission, and trade P&L
+func TestRunFills(t *testing.T) {
+	strategy := StrategyFunc(func(c *Context, _ yfinance.Bar) {
+		switch c.Index() {
+		case 0:
+			c.Buy(10)
+		case 2:
+			c.Close()
+		}
+	})
+
+	report, err := Run(series(100, 101, 102, 103), strategy, Config{Commission: 1, Slippage: 0.01})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Trades) != 1 {
+		t.Fatalf("Expected 1 trade, got %d", len(report.Trades))
+	}
+	trade := report.Trades[0]
+	if !approx(trade.EntryPrice, 102.01) || !approx(trade.ExitPrice, 101.97) {
+		t.Errorf("Unexpected fill prices: %+v", trade)
+	}
+	if !approx(trade.PL, -2.4) || !approx(trade.Commission, 2) {
+		t.Errorf("Expected P&L -2.4 after 2 commission, got %+v", trade)
+	}
+	if !approx(report.FinalEquity, 9997.6) || report.OpenPosition != 0 {
+		t.Errorf("Unexpected final equity %f, position %f", report.FinalEquity, report.OpenPosition)
+	}
+	if report.Losses != 1 || report.WinRate != 0 {
+		t.Errorf("Unexpected trade stats: %d losses, win rate %f", report.Losses, report.WinRate)
+	}
+	// Holding 10 shares bought at 102.01 with the bar closing at 102
+	if !approx(report.Equity[1].Equity, 10000-1020.1-1+1020) {
+		t.Errorf("Unexpected equity after fill: %+v", report.Equity[1])
+	}
+}
+
+// TestRunOrders tests limit orders, cash limits, and short reversals
+func TestRunOrders(t *testing.T) {
+	strategy := StrategyFunc(func(c *Context, _ yfinance.Bar) {
+		switch c.Index() {
+		case 0:
+			c.Submit(Order{Side: Buy, Type: Limit, Quantity: 1, Price: 50}) // Never reached
+		case 1:
+			c.Buy(1000) // More than the cash covers
+		case 2:
+			c.Sell(2 * c.Position())
+		}
+	})
+
+	report, err := Run(series(100, 100, 100, 90, 90), strategy, Config{InitialCapital: 1000, AllowShort: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Trades) != 1 || !approx(report.Trades[0].Quantity, 10) {
+		t.Fatalf("Expected one closed trade of 10 shares, got %+v", report.Trades)
+	}
+	if !approx(report.OpenPosition, -10) {
+		t.Errorf("Expected a 10 share short after reversing, got %f", report.OpenPosition)
+	}
+	// Short 10 at 90, last close 91
+	if !approx(report.FinalEquity, 1000-100-10) {
+		t.Errorf("Unexpected final equity %f", report.FinalEquity)
+	}
+	if report.MaxDrawdown <= 0 {
+		t.Errorf("Expected a drawdown, got %f", report.MaxDrawdown)
+	}
+}
+
+// TestSMACross tests the crossover strategy enters an uptrend and exits a
+// downtrend
+func TestSMACross(t *testing.T) {
+	var opens []float64
+	for i := 0; i < 30; i++ {
+		opens = append(opens, 100+float64(i))
+	}
+	for i := 0; i < 30; i++ {
+		opens = append(opens, 130-float64(i)*2)
+	}
+
+	report, err := Run(series(opens...), &SMACross{Fast: 3, Slow: 10}, Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Trades) != 1 || report.Trades[0].PL <= 0 || report.OpenPosition != 0 {
+		t.Errorf("Expected one profitable closed trade, got %+v (open %f)", report.Trades, report.OpenPosition)
+	}
+
+	if _, err := Run(&yfinance.ChartData{}, &SMACross{}, Config{}); err != ErrNoBars {
+		t.Errorf("Expected ErrNoBars, got %v", err)
+	}
+}
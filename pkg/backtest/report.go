@@ -0,0 +1,162 @@
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+// PositionSide is the direction of a trade
+type PositionSide string
+
+const (
+	Long  PositionSide = "long"
+	Short PositionSide = "short"
+)
+
+// Trade is a closed round trip
+type Trade struct {
+	Side       PositionSide `json:"side"`
+	EntryTime  time.Time    `json:"entryTime"`
+	ExitTime   time.Time    `json:"exitTime"`
+	Quantity   float64      `json:"quantity"`
+	EntryPrice float64      `json:"entryPrice"` // Average, after slippage
+	ExitPrice  float64      `json:"exitPrice"`
+	Commission float64      `json:"commission"`
+	PL         float64      `json:"pl"`     // Net of commission
+	Return     float64      `json:"return"` // PL over entry value
+}
+
+// EquityPoint is the account at the close of one bar
+type EquityPoint struct {
+	Time     time.Time `json:"time"`
+	Equity   float64   `json:"equity"`
+	Cash     float64   `json:"cash"`
+	Position float64   `json:"position"`
+	Drawdown float64   `json:"drawdown"` // Fraction below the running peak
+}
+
+// Report summarizes a backtest
+type Report struct {
+	Symbol         string  `json:"symbol"`
+	InitialCapital float64 `json:"initialCapital"`
+	FinalEquity    float64 `json:"finalEquity"`
+	TotalReturn    float64 `json:"totalReturn"`
+	CAGR           float64 `json:"cagr"`
+	Volatility     float64 `json:"volatility"` // Annualized, of per-bar returns
+	Sharpe         float64 `json:"sharpe"`     // Annualized, with a zero risk-free rate
+
+	MaxDrawdown       float64   `json:"maxDrawdown"`
+	MaxDrawdownPeak   time.Time `json:"maxDrawdownPeak"`
+	MaxDrawdownTrough time.Time `json:"maxDrawdownTrough"`
+
+	Trades       []Trade `json:"trades"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	WinRate      float64 `json:"winRate"`
+	AvgWin       float64 `json:"avgWin"`
+	AvgLoss      float64 `json:"avgLoss"`
+	ProfitFactor float64 `json:"profitFactor"` // Gross profit over gross loss; 0 without losses
+	Commission   float64 `json:"commission"`
+	Rejected     int     `json:"rejected"`     // Orders that could not fill for lack of cash or position
+	OpenPosition float64 `json:"openPosition"` // Still held at the end
+
+	Equity []EquityPoint `json:"equity"`
+}
+
+// newReport computes statistics from a finished simulation
+func newReport(c *Context, equity []EquityPoint) *Report {
+	r := &Report{
+		Symbol:         c.Symbol,
+		InitialCapital: c.cfg.InitialCapital,
+		FinalEquity:    equity[len(equity)-1].Equity,
+		Trades:         c.trades,
+		Commission:     c.commissionPaid,
+		Rejected:       c.rejected,
+		OpenPosition:   c.position,
+		Equity:         equity,
+	}
+	if r.Trades == nil {
+		r.Trades = []Trade{}
+	}
+	r.TotalReturn = r.FinalEquity/r.InitialCapital - 1
+
+	// Drawdown
+	peak, peakTime := r.InitialCapital, equity[0].Time
+	for i := range equity {
+		if equity[i].Equity > peak {
+			peak, peakTime = equity[i].Equity, equity[i].Time
+		}
+		if peak > 0 {
+			equity[i].Drawdown = (peak - equity[i].Equity) / peak
+		}
+		if equity[i].Drawdown > r.MaxDrawdown {
+			r.MaxDrawdown = equity[i].Drawdown
+			r.MaxDrawdownPeak, r.MaxDrawdownTrough = peakTime, equity[i].Time
+		}
+	}
+
+	// Annualized return and risk, with bars per year taken from the data's
+	// span so any interval works
+	years := equity[len(equity)-1].Time.Sub(equity[0].Time).Hours() / 24 / 365.25
+	if years > 0 && r.FinalEquity > 0 {
+		r.CAGR = math.Pow(r.FinalEquity/r.InitialCapital, 1/years) - 1
+	}
+	if len(equity) > 2 && years > 0 {
+		returns := make([]float64, 0, len(equity)-1)
+		for i := 1; i < len(equity); i++ {
+			if prev := equity[i-1].Equity; prev > 0 {
+				returns = append(returns, equity[i].Equity/prev-1)
+			}
+		}
+		barsPerYear := float64(len(equity)-1) / years
+		mean, std := meanStd(returns)
+		r.Volatility = std * math.Sqrt(barsPerYear)
+		if std > 0 {
+			r.Sharpe = mean / std * math.Sqrt(barsPerYear)
+		}
+	}
+
+	// Trade statistics
+	var grossProfit, grossLoss float64
+	for _, t := range r.Trades {
+		if t.PL > 0 {
+			r.Wins++
+			grossProfit += t.PL
+		} else {
+			r.Losses++
+			grossLoss -= t.PL
+		}
+	}
+	if n := len(r.Trades); n > 0 {
+		r.WinRate = float64(r.Wins) / float64(n)
+	}
+	if r.Wins > 0 {
+		r.AvgWin = grossProfit / float64(r.Wins)
+	}
+	if r.Losses > 0 {
+		r.AvgLoss = -grossLoss / float64(r.Losses)
+	}
+	if grossLoss > 0 {
+		r.ProfitFactor = grossProfit / grossLoss
+	}
+	return r
+}
+
+// meanStd returns the mean and sample standard deviation of xs
+func meanStd(xs []float64) (mean, std float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	if len(xs) < 2 {
+		return mean, 0
+	}
+	var ss float64
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(ss / float64(len(xs)-1))
+}
@@ -0,0 +1,34 @@
+package backtest
+
+import "github.com/amjadjibon/gotick/pkg/yfinance"
+
+// SMACross is a long-only moving average crossover: fully invested while the
+// fast simple moving average of closes is above the slow one, flat otherwise
+type SMACross struct {
+	Fast int
+	Slow int
+}
+
+// OnBar implements Strategy
+func (s *SMACross) OnBar(c *Context, _ yfinance.Bar) {
+	bars := c.Bars()
+	if s.Fast <= 0 || s.Slow <= s.Fast || len(bars) < s.Slow {
+		return
+	}
+	fast, slow := sma(bars, s.Fast), sma(bars, s.Slow)
+	switch {
+	case fast > slow && c.Position() == 0:
+		c.TargetPercent(1)
+	case fast < slow && c.Position() > 0:
+		c.Close()
+	}
+}
+
+// sma returns the average close of the last n bars
+func sma(bars []yfinance.Bar, n int) float64 {
+	var sum float64
+	for _, b := range bars[len(bars)-n:] {
+		sum += b.Close
+	}
+	return sum / float64(n)
+}
@@ -0,0 +1,398 @@
+// Package paper simulates a brokerage account that fills orders against
+// live prices from the yfinance WebSocket stream, for trying strategies
+// without real money.
+//
+// A Broker takes market and limit orders and fills them as ticks arrive,
+// tracking cash, positions, and P&L:
+//
+//	broker, _ := paper.NewBroker(paper.Config{InitialCash: 10000})
+//	stream := yfinance.NewStream([]string{"AAPL"})
+//	_ = stream.Connect(ctx)
+//	go broker.Run(ctx, stream)
+//	order, err := broker.Submit(paper.Order{Symbol: "AAPL", Side: paper.Buy, Quantity: 10})
+package paper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// epsilon absorbs floating point error in fractional quantities
+const epsilon = 1e-9
+
+var (
+	// ErrUnknownOrder is returned when cancelling an order that does not exist
+	ErrUnknownOrder = errors.New("paper: unknown order")
+	// ErrOrderClosed is returned when cancelling an order that is no longer open
+	ErrOrderClosed = errors.New("paper: order is not open")
+)
+
+// Side is the direction of an order
+type Side string
+
+const (
+	Buy  Side = "buy"
+	Sell Side = "sell"
+)
+
+// OrderType controls when and at what price an order fills
+type OrderType string
+
+const (
+	// Market orders fill at the next available price: the ask for buys and
+	// the bid for sells, or the last price when the stream has no quotes
+	Market OrderType = "market"
+	// Limit orders fill once the available price reaches LimitPrice or better
+	Limit OrderType = "limit"
+)
+
+// OrderStatus is the state of an order
+type OrderStatus string
+
+const (
+	Open      OrderStatus = "open"
+	Filled    OrderStatus = "filled"
+	Cancelled OrderStatus = "cancelled"
+	Rejected  OrderStatus = "rejected" // See Order.Reason
+)
+
+// Config sets the simulated account and trading costs
+type Config struct {
+	InitialCash    float64 // Starting cash, default 10000
+	Commission     float64 // Fixed cost per fill
+	CommissionRate float64 // Cost per fill as a fraction of traded value
+	Slippage       float64 // Adverse price move on market fills, as a fraction of price
+	AllowShort     bool    // Allow selling more than is held
+}
+
+// Order is an instruction to trade. Orders stay open until they fill, are
+// rejected, or are cancelled.
+type Order struct {
+	ID         string      `json:"id"`
+	Symbol     string      `json:"symbol"`
+	Side       Side        `json:"side"`
+	Type       OrderType   `json:"type"` // Default Market
+	Quantity   float64     `json:"quantity"`
+	LimitPrice float64     `json:"limitPrice,omitempty"`
+	Status     OrderStatus `json:"status"`
+	Reason     string      `json:"reason,omitempty"` // Why the order was rejected
+	Submitted  time.Time   `json:"submitted"`
+	FilledAt   time.Time   `json:"filledAt,omitzero"`
+	FillPrice  float64     `json:"fillPrice,omitempty"` // After slippage
+	Commission float64     `json:"commission,omitempty"`
+}
+
+// Position is a holding of one symbol, valued at its last price
+type Position struct {
+	Symbol       string  `json:"symbol"`
+	Quantity     float64 `json:"quantity"` // Negative when short
+	AvgPrice     float64 `json:"avgPrice"`
+	LastPrice    float64 `json:"lastPrice"`
+	MarketValue  float64 `json:"marketValue"`
+	UnrealizedPL float64 `json:"unrealizedPL"`
+	RealizedPL   float64 `json:"realizedPL"` // Before commissions
+}
+
+// Account is a snapshot of the simulated account
+type Account struct {
+	Cash         float64    `json:"cash"`
+	MarketValue  float64    `json:"marketValue"` // Of all positions, negative for shorts
+	Equity       float64    `json:"equity"`      // Cash plus MarketValue
+	RealizedPL   float64    `json:"realizedPL"`  // Before commissions
+	UnrealizedPL float64    `json:"unrealizedPL"`
+	Commission   float64    `json:"commission"` // Paid so far
+	Positions    []Position `json:"positions"`  // Open positions, by symbol
+	OpenOrders   []Order    `json:"openOrders"`
+}
+
+// tick is the latest price of a symbol
+type tick struct {
+	price, bid, ask float64
+	time            time.Time
+}
+
+// holding is a position's running state
+type holding struct {
+	quantity float64
+	avgPrice float64
+	realized float64
+}
+
+// Broker is a simulated broker. Its methods are safe for concurrent use,
+// so a strategy can submit orders while Run feeds it prices.
+type Broker struct {
+	cfg Config
+
+	mu         sync.Mutex
+	cash       float64
+	commission float64
+	holdings   map[string]*holding
+	ticks      map[string]tick
+	orders     []*Order
+	nextID     int
+}
+
+// NewBroker creates a broker with an account funded per cfg
+func NewBroker(cfg Config) (*Broker, error) {
+	if cfg.InitialCash == 0 {
+		cfg.InitialCash = 10000
+	}
+	if cfg.InitialCash < 0 || cfg.Commission < 0 || cfg.CommissionRate < 0 || cfg.Slippage < 0 {
+		return nil, errors.New("paper: cash, commission, and slippage cannot be negative")
+	}
+	return &Broker{
+		cfg:      cfg,
+		cash:     cfg.InitialCash,
+		holdings: make(map[string]*holding),
+		ticks:    make(map[string]tick),
+	}, nil
+}
+
+// Submit places an order and returns it with its ID and status. A market
+// order fills at once if the symbol has a price, otherwise on its first
+// tick; a limit order fills on the first tick at or better than its limit.
+func (b *Broker) Submit(o Order) (Order, error) {
+	o.Symbol = strings.ToUpper(strings.TrimSpace(o.Symbol))
+	if o.Type == "" {
+		o.Type = Market
+	}
+	switch {
+	case o.Symbol == "":
+		return Order{}, yfinance.ErrInvalidSymbol
+	case o.Side != Buy && o.Side != Sell:
+		return Order{}, fmt.Errorf("paper: unknown side %q", o.Side)
+	case o.Type != Market && o.Type != Limit:
+		return Order{}, fmt.Errorf("paper: unknown order type %q", o.Type)
+	case o.Quantity <= 0:
+		return Order{}, errors.New("paper: quantity must be positive")
+	case o.Type == Limit && o.LimitPrice <= 0:
+		return Order{}, errors.New("paper: limit orders need a positive limit price")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	o.ID = strconv.Itoa(b.nextID)
+	o.Status = Open
+	o.Submitted = time.Now()
+	o.Reason, o.FilledAt, o.FillPrice, o.Commission = "", time.Time{}, 0, 0
+	order := &o
+	b.orders = append(b.orders, order)
+
+	if t, ok := b.ticks[o.Symbol]; ok {
+		b.tryFill(order, t)
+	}
+	return *order, nil
+}
+
+// Cancel cancels an open order
+func (b *Broker) Cancel(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := slices.IndexFunc(b.orders, func(o *Order) bool { return o.ID == id })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownOrder, id)
+	}
+	if b.orders[i].Status != Open {
+		return fmt.Errorf("%w: %s is %s", ErrOrderClosed, id, b.orders[i].Status)
+	}
+	b.orders[i].Status = Cancelled
+	return nil
+}
+
+// Update records a tick and fills the symbol's open orders that it reaches,
+// oldest first
+func (b *Broker) Update(msg yfinance.StreamMessage) {
+	if msg.ID == "" || msg.Price <= 0 {
+		return
+	}
+	t := tick{price: msg.Price, bid: msg.Bid, ask: msg.Ask, time: time.Now()}
+	if msg.Time > 0 {
+		t.time = time.UnixMilli(msg.Time)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	symbol := strings.ToUpper(msg.ID)
+	b.ticks[symbol] = t
+	for _, o := range b.orders {
+		if o.Status == Open && o.Symbol == symbol {
+			b.tryFill(o, t)
+		}
+	}
+}
+
+// Run feeds the broker ticks from a connected stream until ctx is done or
+// the stream closes. Undecodable messages are skipped; when the connection
+// drops, Run returns the error that ended it.
+func (b *Broker) Run(ctx context.Context, stream *yfinance.Stream) error {
+	messages, errs := stream.Messages(), stream.Errors()
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				if lastErr != nil {
+					return lastErr
+				}
+				return errors.New("paper: stream closed")
+			}
+			b.Update(msg)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			lastErr = err
+		}
+	}
+}
+
+// Order returns the order with id
+func (b *Broker) Order(id string) (Order, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := slices.IndexFunc(b.orders, func(o *Order) bool { return o.ID == id })
+	if i < 0 {
+		return Order{}, false
+	}
+	return *b.orders[i], true
+}
+
+// Orders returns every order submitted, oldest first
+func (b *Broker) Orders() []Order {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	orders := make([]Order, len(b.orders))
+	for i, o := range b.orders {
+		orders[i] = *o
+	}
+	return orders
+}
+
+// Account returns the account valued at the latest prices
+func (b *Broker) Account() Account {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	a := Account{Cash: b.cash, Commission: b.commission, Positions: []Position{}, OpenOrders: []Order{}}
+	for sym, h := range b.holdings {
+		a.RealizedPL += h.realized
+		if h.quantity == 0 {
+			continue
+		}
+		p := Position{Symbol: sym, Quantity: h.quantity, AvgPrice: h.avgPrice, RealizedPL: h.realized}
+		p.LastPrice = b.ticks[sym].price
+		p.MarketValue = p.Quantity * p.LastPrice
+		p.UnrealizedPL = (p.LastPrice - p.AvgPrice) * p.Quantity
+		a.MarketValue += p.MarketValue
+		a.UnrealizedPL += p.UnrealizedPL
+		a.Positions = append(a.Positions, p)
+	}
+	slices.SortFunc(a.Positions, func(x, y Position) int { return strings.Compare(x.Symbol, y.Symbol) })
+	for _, o := range b.orders {
+		if o.Status == Open {
+			a.OpenOrders = append(a.OpenOrders, *o)
+		}
+	}
+	a.Equity = a.Cash + a.MarketValue
+	return a
+}
+
+// tryFill fills o against t if its price is reachable. Buys the cash cannot
+// cover and sells beyond the position without AllowShort are rejected.
+func (b *Broker) tryFill(o *Order, t tick) {
+	price, ok := fillPrice(o, t)
+	if !ok {
+		return
+	}
+	if o.Type == Market {
+		if o.Side == Buy {
+			price *= 1 + b.cfg.Slippage
+		} else {
+			price *= 1 - b.cfg.Slippage
+		}
+	}
+
+	h := b.holdings[o.Symbol]
+	if h == nil {
+		h = &holding{}
+		b.holdings[o.Symbol] = h
+	}
+	commission := b.cfg.Commission + b.cfg.CommissionRate*price*o.Quantity
+	signed := o.Quantity
+	if o.Side == Sell {
+		signed = -o.Quantity
+		if !b.cfg.AllowShort && o.Quantity > h.quantity+epsilon {
+			o.Status, o.Reason = Rejected, fmt.Sprintf("sell of %g exceeds the %g held", o.Quantity, h.quantity)
+			return
+		}
+	} else if cost := price*o.Quantity + commission; cost > b.cash+epsilon {
+		o.Status, o.Reason = Rejected, fmt.Sprintf("cost %.2f exceeds cash %.2f", cost, b.cash)
+		return
+	}
+
+	b.cash -= signed*price + commission
+	b.commission += commission
+	h.apply(signed, price)
+	o.Status, o.FilledAt, o.FillPrice, o.Commission = Filled, t.time, price, commission
+}
+
+// fillPrice returns the price o fills at on t, before slippage
+func fillPrice(o *Order, t tick) (float64, bool) {
+	price := t.price
+	if o.Side == Buy && t.ask > 0 {
+		price = t.ask
+	}
+	if o.Side == Sell && t.bid > 0 {
+		price = t.bid
+	}
+	switch {
+	case o.Type == Market:
+		return price, true
+	case o.Side == Buy && price <= o.LimitPrice:
+		return price, true
+	case o.Side == Sell && price >= o.LimitPrice:
+		return price, true
+	default:
+		return 0, false
+	}
+}
+
+// apply adds a signed fill to the holding, realizing P&L on the quantity
+// that reduces it. A fill that reverses the position opens the remainder
+// at price.
+func (h *holding) apply(signed, price float64) {
+	if h.quantity == 0 || (h.quantity > 0) == (signed > 0) {
+		held := math.Abs(h.quantity)
+		h.avgPrice = (h.avgPrice*held + price*math.Abs(signed)) / (held + math.Abs(signed))
+		h.quantity += signed
+		return
+	}
+
+	closed := math.Min(math.Abs(signed), math.Abs(h.quantity))
+	direction := 1.0
+	if h.quantity < 0 {
+		direction = -1
+	}
+	h.realized += (price - h.avgPrice) * closed * direction
+	h.quantity += signed
+
+	switch {
+	case math.Abs(h.quantity) <= epsilon:
+		h.quantity, h.avgPrice = 0, 0
+	case (h.quantity > 0) != (direction > 0):
+		h.avgPrice = price
+	}
+}
@@ -0,0 +1,138 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func tickAt(symbol string, price float64) yfinance.StreamMessage {
+	return yfinance.StreamMessage{ID: symbol, Price: price, Time: 1700000000000}
+}
+
+// TestBrokerFills tests market and limit fills, cash, and P&L
+func TestBrokerFills(t *testing.T) {
+	b, err := NewBroker(Config{InitialCash: 1000, Commission: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No price yet: the market order waits for the first tick
+	buy, err := b.Submit(Order{Symbol: "aapl", Side: Buy, Quantity: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buy.Status != Open || buy.Symbol != "AAPL" || buy.Type != Market {
+		t.Fatalf("Unexpected pending order: %+v", buy)
+	}
+	b.Update(yfinance.StreamMessage{ID: "AAPL", Price: 100, Bid: 99.5, Ask: 100.5, Time: 1700000000000})
+	if o, _ := b.Order(buy.ID); o.Status != Filled || o.FillPrice != 100.5 || o.FilledAt.UnixMilli() != 1700000000000 {
+		t.Fatalf("Expected a fill at the ask, got %+v", o)
+	}
+
+	limit, _ := b.Submit(Order{Symbol: "AAPL", Side: Sell, Type: Limit, Quantity: 2, LimitPrice: 110})
+	if limit.Status != Open {
+		t.Fatalf("Expected the limit to rest, got %+v", limit)
+	}
+	b.Update(tickAt("AAPL", 105))
+	b.Update(tickAt("MSFT", 200))
+	if o, _ := b.Order(limit.ID); o.Status != Open {
+		t.Fatalf("Expected the limit to stay open below its price, got %+v", o)
+	}
+	b.Update(tickAt("AAPL", 112))
+	if o, _ := b.Order(limit.ID); o.Status != Filled || o.FillPrice != 112 {
+		t.Fatalf("Expected the limit to fill at 112, got %+v", o)
+	}
+
+	a := b.Account()
+	// 1000 - 5*100.5 - 1 + 2*112 - 1
+	if !approx(a.Cash, 719.5) || !approx(a.Commission, 2) {
+		t.Errorf("Expected cash 719.5 and commission 2, got %f and %f", a.Cash, a.Commission)
+	}
+	if len(a.Positions) != 1 || a.Positions[0].Quantity != 3 || !approx(a.Positions[0].AvgPrice, 100.5) {
+		t.Fatalf("Unexpected positions: %+v", a.Positions)
+	}
+	if !approx(a.RealizedPL, 23) || !approx(a.UnrealizedPL, 34.5) || !approx(a.Equity, 719.5+336) {
+		t.Errorf("Unexpected P&L: realized %f unrealized %f equity %f", a.RealizedPL, a.UnrealizedPL, a.Equity)
+	}
+
+	// Rejections and cancels
+	if o, _ := b.Submit(Order{Symbol: "AAPL", Side: Sell, Quantity: 4}); o.Status != Rejected || o.Reason == "" {
+		t.Errorf("Expected selling more than held to be rejected, got %+v", o)
+	}
+	if o, _ := b.Submit(Order{Symbol: "MSFT", Side: Buy, Quantity: 10}); o.Status != Rejected {
+		t.Errorf("Expected a buy beyond cash to be rejected, got %+v", o)
+	}
+	resting, _ := b.Submit(Order{Symbol: "AAPL", Side: Buy, Type: Limit, Quantity: 1, LimitPrice: 90})
+	if len(b.Account().OpenOrders) != 1 {
+		t.Errorf("Expected one open order, got %+v", b.Account().OpenOrders)
+	}
+	if err := b.Cancel(resting.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Cancel(resting.ID); !errors.Is(err, ErrOrderClosed) {
+		t.Errorf("Expected ErrOrderClosed, got %v", err)
+	}
+	if err := b.Cancel("nope"); !errors.Is(err, ErrUnknownOrder) {
+		t.Errorf("Expected ErrUnknownOrder, got %v", err)
+	}
+	b.Update(tickAt("AAPL", 80))
+	if o, _ := b.Order(resting.ID); o.Status != Cancelled {
+		t.Errorf("Expected the cancelled order not to fill, got %+v", o)
+	}
+
+	if _, err := b.Submit(Order{Symbol: "AAPL", Side: Buy, Type: Limit, Quantity: 1}); err == nil {
+		t.Error("Expected an error for a limit order without a price")
+	}
+	if _, err := b.Submit(Order{Symbol: "AAPL", Side: "hold", Quantity: 1}); err == nil {
+		t.Error("Expected an error for an unknown side")
+	}
+}
+
+// TestBrokerShort tests selling short and reversing with slippage
+func TestBrokerShort(t *testing.T) {
+	b, _ := NewBroker(Config{InitialCash: 1000, Slippage: 0.01, AllowShort: true})
+	b.Update(tickAt("X", 100))
+
+	short, _ := b.Submit(Order{Symbol: "X", Side: Sell, Quantity: 2})
+	if short.Status != Filled || !approx(short.FillPrice, 99) {
+		t.Fatalf("Expected a short fill at 99, got %+v", short)
+	}
+	b.Update(tickAt("X", 50))
+	if a := b.Account(); !approx(a.Positions[0].Quantity, -2) || !approx(a.UnrealizedPL, 98) {
+		t.Fatalf("Unexpected short position: %+v", a.Positions)
+	}
+
+	// Buying 3 covers the short and opens a long of 1
+	cover, _ := b.Submit(Order{Symbol: "X", Side: Buy, Quantity: 3})
+	a := b.Account()
+	if cover.Status != Filled || !approx(cover.FillPrice, 50.5) {
+		t.Fatalf("Expected a cover at 50.5, got %+v", cover)
+	}
+	if !approx(a.RealizedPL, 97) || !approx(a.Positions[0].Quantity, 1) || !approx(a.Positions[0].AvgPrice, 50.5) {
+		t.Errorf("Unexpected account after reversing: %+v", a)
+	}
+	if !approx(a.Cash, 1000+198-151.5) {
+		t.Errorf("Expected cash %f, got %f", 1000+198-151.5, a.Cash)
+	}
+}
+
+// TestBrokerRun tests that Run stops with its context
+func TestBrokerRun(t *testing.T) {
+	b, _ := NewBroker(Config{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.Run(ctx, yfinance.NewStream(nil)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if _, err := NewBroker(Config{Slippage: -1}); err == nil {
+		t.Error("Expected an error for negative slippage")
+	}
+}
@@ -0,0 +1,267 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoTransactions is returned when an export contains no buys or sells
+var ErrNoTransactions = errors.New("portfolio: no buy or sell transactions")
+
+// transactionColumns maps normalized header names from generic CSVs and
+// broker exports (Fidelity, Schwab, Interactive Brokers flex queries,
+// Robinhood) to transaction fields. Fee columns are summed.
+var transactionColumns = map[string]string{
+	"date":             "date",
+	"run date":         "date",
+	"trade date":       "date",
+	"tradedate":        "date",
+	"activity date":    "date",
+	"transaction date": "date",
+	"symbol":           "symbol",
+	"ticker":           "symbol",
+	"instrument":       "symbol",
+	"action":           "action",
+	"type":             "action",
+	"side":             "action",
+	"buy/sell":         "action",
+	"trans code":       "action",
+	"transaction type": "action",
+	"quantity":         "quantity",
+	"shares":           "quantity",
+	"qty":              "quantity",
+	"price":            "price",
+	"price ($)":        "price",
+	"tradeprice":       "price",
+	"trade price":      "price",
+	"fees":             "fees",
+	"fee":              "fees",
+	"fees ($)":         "fees",
+	"commission":       "fees",
+	"commission ($)":   "fees",
+	"commissions":      "fees",
+	"fees & comm":      "fees",
+	"ibcommission":     "fees",
+	"currency":         "currency",
+	"currencyprimary":  "currency",
+}
+
+// transactionDateLayouts are the date formats found in broker exports
+var transactionDateLayouts = []string{
+	time.DateOnly,
+	"01/02/2006",
+	"1/2/2006",
+	"20060102",
+	time.DateTime,
+	"2006-01-02T15:04:05Z07:00",
+	"01/02/06",
+}
+
+// LoadTransactionsFile reads a transaction history CSV and replays it into a
+// portfolio with FromTransactions
+func LoadTransactionsFile(path string) (*Portfolio, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is supplied by the user
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	txs, err := ReadTransactions(f)
+	if err != nil {
+		return nil, err
+	}
+	return FromTransactions(txs)
+}
+
+// ReadTransactions parses buys and sells from a generic transaction CSV or a
+// broker export. The header row is located automatically, so account
+// preambles and trailing disclaimers are skipped. It needs date, symbol, and
+// quantity columns; the side comes from an action column (e.g. "YOU BOUGHT",
+// "Buy", "SLD", "Reinvest Shares") or, without one, from the quantity's sign.
+// Rows that are not trades, such as dividends and transfers, are ignored.
+func ReadTransactions(r io.Reader) ([]Transaction, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+
+	var cols map[string][]int
+	var txs []Transaction
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("portfolio: line %d: %w", line, err)
+		}
+
+		if cols == nil {
+			cols = transactionHeader(record)
+			continue
+		}
+
+		tx, ok, err := parseTransaction(record, cols)
+		if err != nil {
+			return nil, fmt.Errorf("portfolio: line %d: %w", line, err)
+		}
+		if ok {
+			txs = append(txs, tx)
+		}
+	}
+
+	if cols == nil {
+		return nil, fmt.Errorf("portfolio: no header with date, symbol, and quantity columns found")
+	}
+	if len(txs) == 0 {
+		return nil, ErrNoTransactions
+	}
+	return txs, nil
+}
+
+// transactionHeader maps fields to column indexes if record is a header row
+// with at least date, symbol, and quantity columns, or returns nil
+func transactionHeader(record []string) map[string][]int {
+	cols := make(map[string][]int)
+	for i, name := range record {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := transactionColumns[name]; ok {
+			cols[field] = append(cols[field], i)
+		}
+	}
+	for _, required := range []string{"date", "symbol", "quantity"} {
+		if len(cols[required]) == 0 {
+			return nil
+		}
+	}
+	return cols
+}
+
+// parseTransaction converts a data row. ok is false for rows that are not
+// buys or sells.
+func parseTransaction(record []string, cols map[string][]int) (tx Transaction, ok bool, err error) {
+	field := func(name string) string {
+		if idx := cols[name]; len(idx) > 0 && idx[0] < len(record) {
+			return strings.TrimSpace(record[idx[0]])
+		}
+		return ""
+	}
+
+	tx.Symbol = strings.ToUpper(field("symbol"))
+	if tx.Symbol == "" || field("date") == "" {
+		return tx, false, nil
+	}
+
+	qty, err := parseAmount(field("quantity"))
+	if err != nil {
+		return tx, false, fmt.Errorf("invalid quantity: %w", err)
+	}
+	if qty == 0 {
+		return tx, false, nil
+	}
+
+	if _, hasAction := cols["action"]; hasAction {
+		if tx.Action, ok = parseAction(field("action")); !ok {
+			return tx, false, nil
+		}
+	} else if qty < 0 {
+		tx.Action = Sell
+	} else {
+		tx.Action = Buy
+	}
+	tx.Quantity = qty
+	if qty < 0 {
+		tx.Quantity = -qty
+	}
+
+	if tx.Date, err = parseTransactionDate(field("date")); err != nil {
+		return tx, false, err
+	}
+	if tx.Price, err = parseAmount(field("price")); err != nil {
+		return tx, false, fmt.Errorf("invalid price: %w", err)
+	}
+	if tx.Price < 0 {
+		tx.Price = -tx.Price
+	}
+	for _, i := range cols["fees"] {
+		if i >= len(record) {
+			continue
+		}
+		fee, err := parseAmount(record[i])
+		if err != nil {
+			return tx, false, fmt.Errorf("invalid fees: %w", err)
+		}
+		if fee < 0 {
+			fee = -fee
+		}
+		tx.Fees += fee
+	}
+	tx.Currency = strings.ToUpper(field("currency"))
+	return tx, true, nil
+}
+
+// parseAction recognizes buy and sell wording used by brokers
+func parseAction(s string) (Action, bool) {
+	s = strings.ToUpper(s)
+	if strings.Contains(s, "BOUGHT") || strings.Contains(s, "REINVEST") {
+		return Buy, true
+	}
+	if strings.Contains(s, "SOLD") {
+		return Sell, true
+	}
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_' || r == '/'
+	}) {
+		switch word {
+		case "BUY", "BOT", "B":
+			return Buy, true
+		case "SELL", "SLD", "S":
+			return Sell, true
+		}
+	}
+	return "", false
+}
+
+// parseTransactionDate parses the first date in s, ignoring suffixes such
+// as Schwab's "as of" dates
+func parseTransactionDate(s string) (time.Time, error) {
+	if i := strings.Index(s, " as of "); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	for _, layout := range transactionDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// parseAmount parses numbers with currency symbols, thousands separators,
+// and accounting-style negative parentheses. Empty and "--" are zero.
+func parseAmount(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "--" {
+		return 0, nil
+	}
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	s = strings.NewReplacer("$", "", ",", "", " ", "").Replace(s)
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		v = -v
+	}
+	return v, nil
+}
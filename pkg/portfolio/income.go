@@ -0,0 +1,260 @@
+package portfolio
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// IncomeData is the market data an income projection is built from
+type IncomeData struct {
+	Quotes    map[string]*yfinance.Quote
+	Dividends map[string][]yfinance.Dividend    // Past dividends, by ex-dividend date
+	Upcoming  map[string]yfinance.DividendEvent // Announced ex-dividend and payment dates
+}
+
+// DividendPayment is one projected dividend on a position
+type DividendPayment struct {
+	Symbol    string    `json:"symbol"`
+	ExDate    time.Time `json:"exDate"`
+	PayDate   time.Time `json:"payDate"`
+	PerShare  float64   `json:"perShare"`
+	Quantity  float64   `json:"quantity"`
+	Amount    float64   `json:"amount"`
+	Estimated bool      `json:"estimated"` // Dates extrapolated from past payments rather than announced
+}
+
+// PositionIncome is a position's projected dividend income
+type PositionIncome struct {
+	Symbol       string  `json:"symbol"`
+	Quantity     float64 `json:"quantity"`
+	AnnualRate   float64 `json:"annualRate"` // Per share
+	Frequency    int     `json:"frequency"`  // Payments per year
+	AnnualIncome float64 `json:"annualIncome"`
+	CurrentYield float64 `json:"currentYield"` // Annual rate over current price
+	YieldOnCost  float64 `json:"yieldOnCost"`  // Annual rate over cost basis
+}
+
+// MonthlyIncome is the projected income paid in one month
+type MonthlyIncome struct {
+	Month  time.Time `json:"month"`
+	Amount float64   `json:"amount"`
+}
+
+// IncomeProjection is projected dividend income for a portfolio
+type IncomeProjection struct {
+	Positions    []PositionIncome  `json:"positions"`
+	AnnualIncome float64           `json:"annualIncome"`
+	CurrentYield float64           `json:"currentYield"`
+	YieldOnCost  float64           `json:"yieldOnCost"`
+	Monthly      []MonthlyIncome   `json:"monthly"`
+	Payments     []DividendPayment `json:"payments"` // Upcoming payments in ex-date order
+}
+
+// ProjectIncome fetches dividend rates, two years of dividend history, and
+// announced dividend dates, and projects income for the next months
+func ProjectIncome(ctx context.Context, p *Portfolio, months int) (*IncomeProjection, error) {
+	symbols := p.Symbols()
+	if len(symbols) == 0 {
+		return EvaluateIncome(p, IncomeData{}, time.Now(), months), nil
+	}
+
+	quotes, err := yfinance.DownloadQuotes(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+	data := IncomeData{
+		Quotes:    quotes,
+		Dividends: make(map[string][]yfinance.Dividend, len(symbols)),
+		Upcoming:  make(map[string]yfinance.DividendEvent, len(symbols)),
+	}
+
+	// Dividend history and calendars are best-effort: funds and symbols
+	// without dividends fall back to the quote's rate
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, sym := range symbols {
+		wg.Add(1)
+		go func(sym string) {
+			defer wg.Done()
+			ticker, err := yfinance.NewTicker(sym)
+			if err != nil {
+				return
+			}
+			divs, err := ticker.Dividends(ctx, yfinance.HistoryParams{Period: yfinance.Period2y})
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			data.Dividends[sym] = divs
+			mu.Unlock()
+		}(sym)
+	}
+	wg.Wait()
+
+	now := time.Now()
+	events, err := yfinance.GetDividendsCalendar(ctx, symbols, yfinance.CalendarParams{Start: now, End: now.AddDate(1, 0, 0)})
+	if err == nil {
+		for _, ev := range events {
+			data.Upcoming[ev.Symbol] = ev
+		}
+	}
+
+	return EvaluateIncome(p, data, now, months), nil
+}
+
+// EvaluateIncome projects dividend income from already-fetched data over the
+// months starting at from. Annual rates come from the quote's forward rate,
+// or the trailing twelve months of dividends; payment frequency and dates
+// are inferred from past dividends, with announced dates taking precedence.
+func EvaluateIncome(p *Portfolio, data IncomeData, from time.Time, months int) *IncomeProjection {
+	if months <= 0 {
+		months = 12
+	}
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, months, 0)
+
+	proj := &IncomeProjection{Positions: []PositionIncome{}, Payments: []DividendPayment{}}
+	var cost, value float64
+
+	for _, pos := range p.Positions {
+		history := sortedDividends(data.Dividends[pos.Symbol])
+		trailing := trailingDividends(history, from)
+
+		pi := PositionIncome{Symbol: pos.Symbol, Quantity: pos.Quantity, Frequency: frequency(len(trailing))}
+		if q := data.Quotes[pos.Symbol]; q != nil {
+			pi.AnnualRate = q.DividendRate
+			if q.RegularMarketPrice > 0 {
+				value += pos.Quantity * q.RegularMarketPrice
+			}
+		}
+		if pi.AnnualRate == 0 {
+			for _, d := range trailing {
+				pi.AnnualRate += d.Amount
+			}
+		}
+		cost += pos.Cost()
+
+		if pi.AnnualRate > 0 {
+			pi.AnnualIncome = pos.Quantity * pi.AnnualRate
+			if q := data.Quotes[pos.Symbol]; q != nil && q.RegularMarketPrice > 0 {
+				pi.CurrentYield = pi.AnnualRate / q.RegularMarketPrice
+			}
+			if pos.CostBasis > 0 {
+				pi.YieldOnCost = pi.AnnualRate / pos.CostBasis
+			}
+			if pi.Frequency == 0 {
+				pi.Frequency = 4
+			}
+			upcoming, announced := data.Upcoming[pos.Symbol]
+			proj.Payments = append(proj.Payments, schedule(pos, pi, history, upcoming, announced, from, end)...)
+		}
+
+		proj.AnnualIncome += pi.AnnualIncome
+		proj.Positions = append(proj.Positions, pi)
+	}
+
+	if value > 0 {
+		proj.CurrentYield = proj.AnnualIncome / value
+	}
+	if cost > 0 {
+		proj.YieldOnCost = proj.AnnualIncome / cost
+	}
+
+	sort.SliceStable(proj.Payments, func(i, j int) bool {
+		return proj.Payments[i].ExDate.Before(proj.Payments[j].ExDate)
+	})
+	sort.SliceStable(proj.Positions, func(i, j int) bool {
+		return proj.Positions[i].AnnualIncome > proj.Positions[j].AnnualIncome
+	})
+
+	for m := start; m.Before(end); m = m.AddDate(0, 1, 0) {
+		mi := MonthlyIncome{Month: m}
+		next := m.AddDate(0, 1, 0)
+		for _, pay := range proj.Payments {
+			if !pay.PayDate.Before(m) && pay.PayDate.Before(next) {
+				mi.Amount += pay.Amount
+			}
+		}
+		proj.Monthly = append(proj.Monthly, mi)
+	}
+	return proj
+}
+
+// schedule projects a position's payments with ex-dates in [from, end)
+func schedule(pos Position, pi PositionIncome, history []yfinance.Dividend, upcoming yfinance.DividendEvent, announced bool, from, end time.Time) []DividendPayment {
+	step := 12 / pi.Frequency
+	perShare := pi.AnnualRate / float64(pi.Frequency)
+
+	var next time.Time
+	var lag time.Duration
+	estimated := true
+	switch {
+	case announced && upcoming.ExDividendDate > 0:
+		next = time.Unix(upcoming.ExDividendDate, 0).UTC()
+		if upcoming.DividendDate > upcoming.ExDividendDate {
+			lag = time.Unix(upcoming.DividendDate, 0).Sub(next)
+		}
+		estimated = false
+	case len(history) > 0:
+		next = history[len(history)-1].Date.UTC()
+	default:
+		// No dates known: spread payments evenly from the start
+		next = from
+	}
+	for next.Before(from) {
+		next = next.AddDate(0, step, 0)
+		estimated = true
+	}
+
+	var payments []DividendPayment
+	for ; next.Before(end); next = next.AddDate(0, step, 0) {
+		payments = append(payments, DividendPayment{
+			Symbol:    pos.Symbol,
+			ExDate:    next,
+			PayDate:   next.Add(lag),
+			PerShare:  perShare,
+			Quantity:  pos.Quantity,
+			Amount:    perShare * pos.Quantity,
+			Estimated: estimated,
+		})
+		// Only the announced payment is exact
+		estimated = true
+	}
+	return payments
+}
+
+// sortedDividends returns dividends in date order
+func sortedDividends(divs []yfinance.Dividend) []yfinance.Dividend {
+	sorted := make([]yfinance.Dividend, len(divs))
+	copy(sorted, divs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+	return sorted
+}
+
+// trailingDividends returns the dividends in the year before from
+func trailingDividends(history []yfinance.Dividend, from time.Time) []yfinance.Dividend {
+	yearAgo := from.AddDate(-1, 0, 0)
+	var trailing []yfinance.Dividend
+	for _, d := range history {
+		if d.Date.After(yearAgo) && !d.Date.After(from) {
+			trailing = append(trailing, d)
+		}
+	}
+	return trailing
+}
+
+// frequency rounds a count of payments in a year to a common schedule
+func frequency(n int) int {
+	switch {
+	case n >= 10:
+		return 12
+	case n >= 3:
+		return 4
+	default:
+		return n
+	}
+}
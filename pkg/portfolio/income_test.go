@@ -0,0 +1,72 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// TestEvaluateIncome tests rates, yields, and the projected payment schedule
+func TestEvaluateIncome(t *testing.T) {
+	p := &Portfolio{Positions: []Position{
+		{Symbol: "AAPL", Quantity: 10, CostBasis: 100},
+		{Symbol: "KO", Quantity: 20, CostBasis: 50},
+		{Symbol: "TSLA", Quantity: 1, CostBasis: 200},
+	}}
+	data := IncomeData{
+		Quotes: map[string]*yfinance.Quote{
+			"AAPL": {RegularMarketPrice: 200, DividendRate: 1},
+			"KO":   {RegularMarketPrice: 60},
+			"TSLA": {RegularMarketPrice: 250},
+		},
+		Dividends: map[string][]yfinance.Dividend{
+			"AAPL": {
+				{Date: date("2024-11-08"), Amount: 0.25},
+				{Date: date("2024-02-09"), Amount: 0.24},
+				{Date: date("2024-08-12"), Amount: 0.25},
+				{Date: date("2024-05-10"), Amount: 0.25},
+			},
+			"KO": {
+				{Date: date("2024-03-14"), Amount: 0.485},
+				{Date: date("2024-06-14"), Amount: 0.485},
+				{Date: date("2024-09-13"), Amount: 0.485},
+				{Date: date("2024-11-29"), Amount: 0.485},
+			},
+		},
+		Upcoming: map[string]yfinance.DividendEvent{
+			"KO": {Symbol: "KO", ExDividendDate: date("2025-03-14").Unix(), DividendDate: date("2025-04-01").Unix()},
+		},
+	}
+
+	proj := EvaluateIncome(p, data, date("2024-12-01"), 12)
+
+	if !approx(proj.AnnualIncome, 10+20*1.94) {
+		t.Errorf("Expected annual income %f, got %f", 10+20*1.94, proj.AnnualIncome)
+	}
+	aapl := proj.Positions[1]
+	if aapl.Symbol != "AAPL" || aapl.Frequency != 4 || !approx(aapl.YieldOnCost, 0.01) || !approx(aapl.CurrentYield, 0.005) {
+		t.Errorf("Unexpected AAPL income: %+v", aapl)
+	}
+
+	var aaplPays, koPays []DividendPayment
+	for _, pay := range proj.Payments {
+		switch pay.Symbol {
+		case "AAPL":
+			aaplPays = append(aaplPays, pay)
+		case "KO":
+			koPays = append(koPays, pay)
+		case "TSLA":
+			t.Errorf("Unexpected payment for non-dividend stock: %+v", pay)
+		}
+	}
+	if len(aaplPays) != 4 || !aaplPays[0].ExDate.Equal(date("2025-02-08")) || !approx(aaplPays[0].Amount, 2.5) || !aaplPays[0].Estimated {
+		t.Errorf("Unexpected AAPL schedule: %+v", aaplPays)
+	}
+	if len(koPays) != 3 || koPays[0].Estimated || !koPays[0].PayDate.Equal(date("2025-04-01")) || !koPays[1].Estimated {
+		t.Errorf("Unexpected KO schedule: %+v", koPays)
+	}
+
+	if len(proj.Monthly) != 12 || !proj.Monthly[2].Month.Equal(date("2025-02-01")) || !approx(proj.Monthly[2].Amount, 2.5) {
+		t.Errorf("Unexpected monthly income: %+v", proj.Monthly)
+	}
+}
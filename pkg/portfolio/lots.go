@@ -0,0 +1,177 @@
+package portfolio
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// epsilon absorbs floating point error when matching fractional shares
+const epsilon = 1e-9
+
+// Action is the side of a transaction
+type Action string
+
+const (
+	Buy  Action = "buy"
+	Sell Action = "sell"
+)
+
+// Transaction is a single buy or sell
+type Transaction struct {
+	Date     time.Time `json:"date" yaml:"date"`
+	Symbol   string    `json:"symbol" yaml:"symbol"`
+	Action   Action    `json:"action" yaml:"action"`
+	Quantity float64   `json:"quantity" yaml:"quantity"` // Always positive
+	Price    float64   `json:"price" yaml:"price"`       // Per share, before fees
+	Fees     float64   `json:"fees,omitempty" yaml:"fees,omitempty"`
+	Currency string    `json:"currency,omitempty" yaml:"currency,omitempty"`
+}
+
+// Lot is a purchase of shares that is still held
+type Lot struct {
+	Date     time.Time `json:"date" yaml:"date"`
+	Quantity float64   `json:"quantity" yaml:"quantity"`
+	Price    float64   `json:"price" yaml:"price"` // Per share, before fees
+	Fees     float64   `json:"fees,omitempty" yaml:"fees,omitempty"`
+}
+
+// Cost returns the total amount paid for the lot, including fees
+func (l Lot) Cost() float64 {
+	return l.Quantity*l.Price + l.Fees
+}
+
+// Sale is the part of a sell transaction matched against one lot
+type Sale struct {
+	Symbol   string    `json:"symbol" yaml:"symbol"`
+	Opened   time.Time `json:"opened" yaml:"opened"` // Purchase date of the matched lot
+	Closed   time.Time `json:"closed" yaml:"closed"`
+	Quantity float64   `json:"quantity" yaml:"quantity"`
+	Proceeds float64   `json:"proceeds" yaml:"proceeds"` // Net of fees
+	Cost     float64   `json:"cost" yaml:"cost"`         // Including purchase fees
+	PL       float64   `json:"pl" yaml:"pl"`
+}
+
+// applyLots sets the position's quantity and average cost from its lots
+func (p *Position) applyLots() {
+	if len(p.Lots) == 0 {
+		return
+	}
+	var qty, cost float64
+	for _, l := range p.Lots {
+		qty += l.Quantity
+		cost += l.Cost()
+	}
+	p.Quantity = qty
+	p.CostBasis = 0
+	if qty != 0 {
+		p.CostBasis = cost / qty
+	}
+}
+
+// FromTransactions builds a portfolio by replaying transactions in date
+// order. Sells are matched first-in first-out against open lots, and each
+// match is recorded in Sales. Selling more than is held is an error.
+func FromTransactions(txs []Transaction) (*Portfolio, error) {
+	if len(txs) == 0 {
+		return nil, ErrNoPositions
+	}
+
+	sorted := make([]Transaction, len(txs))
+	copy(sorted, txs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	p := &Portfolio{}
+	bySymbol := make(map[string]*Position)
+	var order []string
+
+	for i, tx := range sorted {
+		tx.Symbol = strings.ToUpper(strings.TrimSpace(tx.Symbol))
+		if tx.Symbol == "" {
+			return nil, fmt.Errorf("portfolio: transaction %d: symbol is required", i+1)
+		}
+		if tx.Quantity <= 0 {
+			return nil, fmt.Errorf("portfolio: transaction %d (%s): quantity must be positive", i+1, tx.Symbol)
+		}
+
+		pos, ok := bySymbol[tx.Symbol]
+		if !ok {
+			pos = &Position{Symbol: tx.Symbol, Currency: strings.ToUpper(tx.Currency)}
+			bySymbol[tx.Symbol] = pos
+			order = append(order, tx.Symbol)
+		}
+
+		switch tx.Action {
+		case Buy:
+			pos.Lots = append(pos.Lots, Lot{Date: tx.Date, Quantity: tx.Quantity, Price: tx.Price, Fees: math.Abs(tx.Fees)})
+		case Sell:
+			sales, err := pos.sell(tx)
+			if err != nil {
+				return nil, err
+			}
+			p.Sales = append(p.Sales, sales...)
+		default:
+			return nil, fmt.Errorf("portfolio: transaction %d (%s): unknown action %q", i+1, tx.Symbol, tx.Action)
+		}
+	}
+
+	for _, sym := range order {
+		pos := bySymbol[sym]
+		if len(pos.Lots) == 0 {
+			continue
+		}
+		pos.applyLots()
+		p.Positions = append(p.Positions, *pos)
+	}
+	return p, nil
+}
+
+// sell removes tx.Quantity shares from the oldest lots first
+func (p *Position) sell(tx Transaction) ([]Sale, error) {
+	var sales []Sale
+	remaining := tx.Quantity
+	fees := math.Abs(tx.Fees)
+
+	for remaining > epsilon && len(p.Lots) > 0 {
+		lot := &p.Lots[0]
+		take := math.Min(remaining, lot.Quantity)
+		lotFees := lot.Fees * take / lot.Quantity
+
+		sale := Sale{
+			Symbol:   p.Symbol,
+			Opened:   lot.Date,
+			Closed:   tx.Date,
+			Quantity: take,
+			Proceeds: take*tx.Price - fees*take/tx.Quantity,
+			Cost:     take*lot.Price + lotFees,
+		}
+		sale.PL = sale.Proceeds - sale.Cost
+		sales = append(sales, sale)
+
+		lot.Quantity -= take
+		lot.Fees -= lotFees
+		remaining -= take
+		if lot.Quantity <= epsilon {
+			p.Lots = p.Lots[1:]
+		}
+	}
+
+	if remaining > epsilon {
+		return nil, fmt.Errorf("portfolio: %s: selling %g shares on %s exceeds the %g held",
+			p.Symbol, tx.Quantity, tx.Date.Format(time.DateOnly), tx.Quantity-remaining)
+	}
+	return sales, nil
+}
+
+// RealizedPL returns the total profit or loss of sales, by symbol
+func (p *Portfolio) RealizedPL() map[string]float64 {
+	pl := make(map[string]float64)
+	for _, s := range p.Sales {
+		pl[s.Symbol] += s.PL
+	}
+	return pl
+}
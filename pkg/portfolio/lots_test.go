@@ -0,0 +1,135 @@
+package portfolio
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, _ := time.Parse(time.DateOnly, s)
+	return t
+}
+
+// TestFromTransactions tests FIFO lot matching and realized P&L
+func TestFromTransactions(t *testing.T) {
+	txs := []Transaction{
+		{Date: date("2024-03-01"), Symbol: "AAPL", Action: Sell, Quantity: 15, Price: 200, Fees: 3},
+		{Date: date("2024-01-01"), Symbol: "aapl", Action: Buy, Quantity: 10, Price: 100, Fees: 10},
+		{Date: date("2024-02-01"), Symbol: "AAPL", Action: Buy, Quantity: 10, Price: 150},
+		{Date: date("2024-01-15"), Symbol: "MSFT", Action: Buy, Quantity: 2, Price: 300},
+		{Date: date("2024-04-01"), Symbol: "MSFT", Action: Sell, Quantity: 2, Price: 250},
+	}
+
+	p, err := FromTransactions(txs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(p.Positions) != 1 || p.Positions[0].Symbol != "AAPL" {
+		t.Fatalf("Expected only AAPL to remain, got %+v", p.Positions)
+	}
+	pos := p.Positions[0]
+	if !approx(pos.Quantity, 5) || !approx(pos.CostBasis, 150) || len(pos.Lots) != 1 {
+		t.Errorf("Unexpected remaining position: %+v", pos)
+	}
+
+	if len(p.Sales) != 3 {
+		t.Fatalf("Expected 3 sales, got %d", len(p.Sales))
+	}
+	// First lot: 10 shares, proceeds 2000 - 2 fees, cost 1000 + 10 fees
+	if !approx(p.Sales[0].PL, 988) || !p.Sales[0].Opened.Equal(date("2024-01-01")) {
+		t.Errorf("Unexpected first sale: %+v", p.Sales[0])
+	}
+	// Second lot: 5 shares, proceeds 1000 - 1 fee, cost 750
+	if !approx(p.Sales[1].PL, 249) {
+		t.Errorf("Unexpected second sale: %+v", p.Sales[1])
+	}
+	realized := p.RealizedPL()
+	if !approx(realized["AAPL"], 1237) || !approx(realized["MSFT"], -100) {
+		t.Errorf("Unexpected realized P&L: %v", realized)
+	}
+
+	_, err = FromTransactions([]Transaction{{Date: date("2024-01-01"), Symbol: "AAPL", Action: Sell, Quantity: 1, Price: 1}})
+	if err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("Expected oversell error, got %v", err)
+	}
+}
+
+// TestReadTransactions tests parsing generic and broker transaction exports
+func TestReadTransactions(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Transaction
+	}{
+		{
+			name: "fidelity",
+			input: `
+Brokerage
+
+Run Date,Action,Symbol,Security Description,Security Type,Quantity,Price ($),Commission ($),Fees ($),Accrued Interest ($),Amount ($),Settlement Date
+02/01/2024, YOU SOLD APPLE INC (AAPL) (Cash), AAPL, APPLE INC,Cash,-5,190.5,,0.02,,952.48,02/05/2024
+01/02/2024, YOU BOUGHT APPLE INC (AAPL) (Cash), AAPL, APPLE INC,Cash,10,185,,,,-1850,01/04/2024
+01/03/2024, DIVIDEND RECEIVED VANGUARD (VTI) (Cash), VTI, VANGUARD,Cash,,,,,,12.3,
+
+"The data and information in this spreadsheet is provided to you solely for your use"`,
+			want: []Transaction{
+				{Date: date("2024-02-01"), Symbol: "AAPL", Action: Sell, Quantity: 5, Price: 190.5, Fees: 0.02},
+				{Date: date("2024-01-02"), Symbol: "AAPL", Action: Buy, Quantity: 10, Price: 185},
+			},
+		},
+		{
+			name: "schwab",
+			input: `"Date","Action","Symbol","Description","Quantity","Price","Fees & Comm","Amount"
+"03/04/2024 as of 03/01/2024","Buy","MSFT","MICROSOFT CORP","2","$410.00","$1.00","-$821.00"
+"03/05/2024","Reinvest Shares","MSFT","MICROSOFT CORP","0.01","$400.00","","-$4.00"
+"03/06/2024","Qualified Dividend","MSFT","MICROSOFT CORP","","","","$1.50"`,
+			want: []Transaction{
+				{Date: date("2024-03-04"), Symbol: "MSFT", Action: Buy, Quantity: 2, Price: 410, Fees: 1},
+				{Date: date("2024-03-05"), Symbol: "MSFT", Action: Buy, Quantity: 0.01, Price: 400},
+			},
+		},
+		{
+			name: "ibkr",
+			input: `Symbol,TradeDate,Quantity,TradePrice,IBCommission,CurrencyPrimary,Buy/Sell
+SAP,20240110,20,150.2,-1.25,EUR,BUY
+SAP,20240210,-5,160,-1.25,EUR,SELL`,
+			want: []Transaction{
+				{Date: date("2024-01-10"), Symbol: "SAP", Action: Buy, Quantity: 20, Price: 150.2, Fees: 1.25, Currency: "EUR"},
+				{Date: date("2024-02-10"), Symbol: "SAP", Action: Sell, Quantity: 5, Price: 160, Fees: 1.25, Currency: "EUR"},
+			},
+		},
+		{
+			name: "generic signed quantity",
+			input: `date,symbol,quantity,price
+2024-05-01,vti,3,250
+2024-06-01,vti,-1,"1,260.00"`,
+			want: []Transaction{
+				{Date: date("2024-05-01"), Symbol: "VTI", Action: Buy, Quantity: 3, Price: 250},
+				{Date: date("2024-06-01"), Symbol: "VTI", Action: Sell, Quantity: 1, Price: 1260},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadTransactions(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d transactions, got %d: %+v", len(tt.want), len(got), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Transaction %d:\n got %+v\nwant %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := ReadTransactions(strings.NewReader("symbol,quantity\nAAPL,1\n")); err == nil {
+		t.Error("Expected error for missing date column")
+	}
+}
@@ -0,0 +1,342 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// DefaultBenchmark is the symbol performance is compared against by default
+const DefaultBenchmark = "^GSPC"
+
+// tradingDays annualizes daily statistics
+const tradingDays = 252
+
+// PerformanceParams selects the window and benchmark for performance
+type PerformanceParams struct {
+	Start     time.Time // Defaults to one year before End
+	End       time.Time // Defaults to now
+	Benchmark string    // Defaults to DefaultBenchmark
+}
+
+func (pp *PerformanceParams) setDefaults() {
+	if pp.End.IsZero() {
+		pp.End = time.Now()
+	}
+	if pp.Start.IsZero() {
+		pp.Start = pp.End.AddDate(-1, 0, 0)
+	}
+	if pp.Benchmark == "" {
+		pp.Benchmark = DefaultBenchmark
+	}
+}
+
+// PerformancePoint is the portfolio on one trading day
+type PerformancePoint struct {
+	Date   time.Time `json:"date"`
+	Value  float64   `json:"value"`
+	Flow   float64   `json:"flow"`   // Net cash invested that day; negative for sales
+	Return float64   `json:"return"` // Time-weighted return for the day
+}
+
+// Performance is portfolio performance over a window compared to a benchmark
+type Performance struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	StartValue float64   `json:"startValue"`
+	EndValue   float64   `json:"endValue"`
+	NetFlows   float64   `json:"netFlows"` // Purchases minus sale proceeds within the window
+
+	TWR           float64  `json:"twr"`           // Time-weighted return over the window
+	AnnualizedTWR float64  `json:"annualizedTWR"` // TWR compounded to a yearly rate
+	MWR           *float64 `json:"mwr"`           // Money-weighted (internal) return over the window; nil when it has no solution
+
+	Benchmark       string  `json:"benchmark"`
+	BenchmarkReturn float64 `json:"benchmarkReturn"`
+	Alpha           float64 `json:"alpha"`         // Annualized, relative to the benchmark
+	Beta            float64 `json:"beta"`          // Sensitivity of daily returns to the benchmark
+	TrackingError   float64 `json:"trackingError"` // Annualized volatility of excess returns
+
+	Series  []PerformancePoint `json:"series"`
+	Missing []string           `json:"missing,omitempty"` // Symbols without history, valued at zero
+}
+
+// MeasurePerformance downloads daily history for the portfolio's symbols and
+// the benchmark, and computes performance with EvaluatePerformance
+func MeasurePerformance(ctx context.Context, p *Portfolio, params PerformanceParams) (*Performance, error) {
+	params.setDefaults()
+
+	symbols := append(p.heldSymbols(), params.Benchmark)
+	res, err := yfinance.Download(ctx, yfinance.DownloadParams{
+		Symbols:  symbols,
+		Interval: yfinance.Interval1d,
+		Start:    params.Start,
+		End:      params.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err, ok := res.Errors[params.Benchmark]; ok {
+		return nil, fmt.Errorf("portfolio: benchmark %s: %w", params.Benchmark, err)
+	}
+	return EvaluatePerformance(p, res.Data, params)
+}
+
+// EvaluatePerformance computes returns from already-fetched daily histories,
+// keyed by symbol. Holdings over time come from lot purchase dates and sales;
+// positions without lots are treated as held for the whole window. Values
+// use closing prices, so dividends are not included.
+func EvaluatePerformance(p *Portfolio, histories map[string]*yfinance.ChartData, params PerformanceParams) (*Performance, error) {
+	params.setDefaults()
+	start, end := day(params.Start), day(params.End)
+
+	dates, closes := alignCloses(histories, start, end)
+	if len(dates) < 2 {
+		return nil, errors.New("portfolio: not enough price history in the window")
+	}
+
+	perf := &Performance{Start: dates[0], End: dates[len(dates)-1], Benchmark: params.Benchmark}
+	for _, sym := range p.heldSymbols() {
+		if closes[sym] == nil {
+			perf.Missing = append(perf.Missing, sym)
+		}
+	}
+	perf.Series = p.valueSeries(dates, closes)
+
+	growth := 1.0
+	var portfolioReturns, benchmarkReturns []float64
+	bench := closes[params.Benchmark]
+
+	for i := 1; i < len(dates); i++ {
+		pt := &perf.Series[i]
+		perf.NetFlows += pt.Flow
+		if prev := perf.Series[i-1].Value; prev > 0 {
+			pt.Return = (pt.Value-pt.Flow)/prev - 1
+			growth *= 1 + pt.Return
+			if bench != nil && bench[i-1] > 0 {
+				portfolioReturns = append(portfolioReturns, pt.Return)
+				benchmarkReturns = append(benchmarkReturns, bench[i]/bench[i-1]-1)
+			}
+		}
+	}
+
+	perf.StartValue = perf.Series[0].Value
+	perf.EndValue = perf.Series[len(dates)-1].Value
+	perf.TWR = growth - 1
+	if years := perf.End.Sub(perf.Start).Hours() / 24 / 365; years > 0 {
+		perf.AnnualizedTWR = math.Pow(growth, 1/years) - 1
+	}
+	if rate, ok := irr(perf.Series); ok {
+		mwr := math.Pow(1+rate, perf.End.Sub(perf.Start).Hours()/24) - 1
+		perf.MWR = &mwr
+	}
+
+	if bench != nil && bench[0] > 0 {
+		perf.BenchmarkReturn = bench[len(bench)-1]/bench[0] - 1
+	}
+	if len(portfolioReturns) > 1 {
+		perf.Beta = yfinance.Beta(portfolioReturns, benchmarkReturns)
+		perf.Alpha = (yfinance.Mean(portfolioReturns) - perf.Beta*yfinance.Mean(benchmarkReturns)) * tradingDays
+		perf.TrackingError = yfinance.TrackingError(portfolioReturns, benchmarkReturns, tradingDays)
+	}
+	return perf, nil
+}
+
+// ValueHistory downloads daily history for the portfolio's symbols and
+// values the holdings at each close in [start, end], as EvaluateValueHistory
+func ValueHistory(ctx context.Context, p *Portfolio, start, end time.Time) ([]PerformancePoint, error) {
+	params := PerformanceParams{Start: start, End: end}
+	params.setDefaults()
+
+	res, err := yfinance.Download(ctx, yfinance.DownloadParams{
+		Symbols:  p.heldSymbols(),
+		Interval: yfinance.Interval1d,
+		Start:    params.Start,
+		End:      params.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return EvaluateValueHistory(p, res.Data, params.Start, params.End)
+}
+
+// EvaluateValueHistory values the holdings at each trading day's close
+// from already-fetched daily histories, keyed by symbol. Holdings over time
+// follow lots and sales as in EvaluatePerformance; Return is left zero.
+func EvaluateValueHistory(p *Portfolio, histories map[string]*yfinance.ChartData, start, end time.Time) ([]PerformancePoint, error) {
+	dates, closes := alignCloses(histories, day(start), day(end))
+	if len(dates) == 0 {
+		return nil, errors.New("portfolio: no price history in the window")
+	}
+	return p.valueSeries(dates, closes), nil
+}
+
+// valueSeries values the holdings and totals cash flows on each date
+func (p *Portfolio) valueSeries(dates []time.Time, closes map[string][]float64) []PerformancePoint {
+	spans := p.holdingSpans()
+	series := make([]PerformancePoint, len(dates))
+	for i, d := range dates {
+		pt := PerformancePoint{Date: d}
+		for _, s := range spans {
+			if c := closes[s.Symbol]; c != nil && s.heldOn(d) {
+				pt.Value += s.Quantity * c[i]
+			}
+			if i == 0 {
+				continue
+			}
+			// Flows on non-trading days land on the next trading day
+			if between(s.Opened, dates[i-1], d) {
+				pt.Flow += s.Cost
+			}
+			if between(s.Closed, dates[i-1], d) {
+				pt.Flow -= s.Proceeds
+			}
+		}
+		series[i] = pt
+	}
+	return series
+}
+
+// holdingSpan is a quantity of a symbol held between two dates
+type holdingSpan struct {
+	Symbol   string
+	Quantity float64
+	Opened   time.Time // Zero when held before any window
+	Closed   time.Time // Zero while still held
+	Cost     float64
+	Proceeds float64
+}
+
+// heldOn reports whether the span is held at the close of d
+func (s holdingSpan) heldOn(d time.Time) bool {
+	return !s.Opened.After(d) && (s.Closed.IsZero() || d.Before(s.Closed))
+}
+
+// holdingSpans returns open lots, positions without lots, and sales as spans
+func (p *Portfolio) holdingSpans() []holdingSpan {
+	var spans []holdingSpan
+	for _, pos := range p.Positions {
+		if len(pos.Lots) == 0 {
+			spans = append(spans, holdingSpan{Symbol: pos.Symbol, Quantity: pos.Quantity})
+			continue
+		}
+		for _, l := range pos.Lots {
+			spans = append(spans, holdingSpan{Symbol: pos.Symbol, Quantity: l.Quantity, Opened: day(l.Date), Cost: l.Cost()})
+		}
+	}
+	for _, s := range p.Sales {
+		spans = append(spans, holdingSpan{
+			Symbol:   s.Symbol,
+			Quantity: s.Quantity,
+			Opened:   day(s.Opened),
+			Closed:   day(s.Closed),
+			Cost:     s.Cost,
+			Proceeds: s.Proceeds,
+		})
+	}
+	return spans
+}
+
+// heldSymbols returns the symbols of positions and sales
+func (p *Portfolio) heldSymbols() []string {
+	symbols := p.Symbols()
+	seen := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		seen[s] = true
+	}
+	for _, s := range p.Sales {
+		if !seen[s.Symbol] {
+			seen[s.Symbol] = true
+			symbols = append(symbols, s.Symbol)
+		}
+	}
+	return symbols
+}
+
+// between reports whether t is after prev and not after d
+func between(t, prev, d time.Time) bool {
+	return t.After(prev) && !t.After(d)
+}
+
+// day truncates t to its calendar date in UTC
+func day(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// alignCloses puts closing prices on the trading dates within [start, end]
+// that every symbol with a bar in the window has, aligned by
+// yfinance.NewPanel. Symbols without bars in the window are left out.
+func alignCloses(histories map[string]*yfinance.ChartData, start, end time.Time) ([]time.Time, map[string][]float64) {
+	charts := make(map[string]*yfinance.ChartData, len(histories))
+	for sym, h := range histories {
+		if h != nil && slices.ContainsFunc(h.Bars, func(b yfinance.Bar) bool {
+			d := day(b.Timestamp)
+			return b.Close > 0 && !d.Before(start) && !d.After(end)
+		}) {
+			charts[sym] = h
+		}
+	}
+
+	panel := yfinance.NewPanel(charts, yfinance.PanelOptions{Join: yfinance.JoinInner}).Slice(start, end)
+	closes := make(map[string][]float64, len(panel.Symbols))
+	for _, sym := range panel.Symbols {
+		closes[sym] = panel.Column(sym, yfinance.FieldClose)
+	}
+	return panel.Index, closes
+}
+
+// irr solves for the daily rate that discounts the series' cash flows to
+// zero: the starting value and each day's purchases are paid in, sale
+// proceeds and the ending value are paid out. It reports false if there is
+// no solution between -50% and 100% a day.
+func irr(series []PerformancePoint) (float64, bool) {
+	if len(series) < 2 {
+		return 0, false
+	}
+	t0 := series[0].Date
+	npv := func(rate float64) float64 {
+		var sum float64
+		for i, pt := range series {
+			flow := -pt.Flow
+			if i == 0 {
+				flow = -pt.Value
+			}
+			if i == len(series)-1 {
+				flow += pt.Value
+			}
+			days := pt.Date.Sub(t0).Hours() / 24
+			sum += flow / math.Pow(1+rate, days)
+		}
+		return sum
+	}
+
+	lo, hi := -0.5, 1.0
+	fLo, fHi := npv(lo), npv(hi)
+	if math.IsNaN(fLo) || math.IsNaN(fHi) || fLo*fHi > 0 {
+		return 0, false
+	}
+	for range 200 {
+		mid := (lo + hi) / 2
+		fMid := npv(mid)
+		if math.IsNaN(fMid) {
+			return 0, false
+		}
+		if math.Abs(fMid) < 1e-9 {
+			return mid, true
+		}
+		if fLo*fMid < 0 {
+			hi = mid
+		} else {
+			lo, fLo = mid, fMid
+		}
+	}
+	return (lo + hi) / 2, true
+}
@@ -0,0 +1,115 @@
+package portfolio
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// chart builds daily closes starting on 2024-01-01
+func chart(closes ...float64) *yfinance.ChartData {
+	data := &yfinance.ChartData{}
+	for i, c := range closes {
+		data.Bars = append(data.Bars, yfinance.Bar{Timestamp: date("2024-01-01").AddDate(0, 0, i).Add(14 * time.Hour), Close: c})
+	}
+	return data
+}
+
+// TestEvaluatePerformance tests time-weighted returns around a purchase and
+// benchmark statistics
+func TestEvaluatePerformance(t *testing.T) {
+	p := &Portfolio{Positions: []Position{{
+		Symbol: "X",
+		Lots: []Lot{
+			{Date: date("2023-12-01"), Quantity: 1, Price: 90},
+			{Date: date("2024-01-03"), Quantity: 1, Price: 121},
+		},
+	}}}
+	histories := map[string]*yfinance.ChartData{
+		"X":     chart(100, 110, 121, 121),
+		"^GSPC": chart(100, 105, 110.25, 110.25),
+	}
+
+	perf, err := EvaluatePerformance(p, histories, PerformanceParams{Start: date("2024-01-01"), End: date("2024-01-04")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !approx(perf.StartValue, 100) || !approx(perf.EndValue, 242) || !approx(perf.NetFlows, 121) {
+		t.Errorf("Unexpected values: start %f end %f flows %f", perf.StartValue, perf.EndValue, perf.NetFlows)
+	}
+	if !approx(perf.TWR, 0.21) {
+		t.Errorf("Expected TWR 0.21, got %f", perf.TWR)
+	}
+	if !approx(perf.BenchmarkReturn, 0.1025) {
+		t.Errorf("Expected benchmark return 0.1025, got %f", perf.BenchmarkReturn)
+	}
+	if !approx(perf.Beta, 2) || math.Abs(perf.Alpha) > 1e-9 {
+		t.Errorf("Expected beta 2 and alpha 0, got %f and %f", perf.Beta, perf.Alpha)
+	}
+	if perf.MWR == nil || *perf.MWR <= 0 || perf.TrackingError <= 0 {
+		t.Errorf("Expected positive MWR and tracking error, got %v and %f", perf.MWR, perf.TrackingError)
+	}
+}
+
+// TestEvaluateValueHistory tests daily values around a purchase and a sale
+func TestEvaluateValueHistory(t *testing.T) {
+	p := &Portfolio{
+		Positions: []Position{{Symbol: "X", Lots: []Lot{{Date: date("2024-01-02"), Quantity: 2, Price: 10}}}},
+		Sales:     []Sale{{Symbol: "Y", Opened: date("2023-06-01"), Closed: date("2024-01-03"), Quantity: 1, Proceeds: 50}},
+	}
+	histories := map[string]*yfinance.ChartData{
+		"X": chart(10, 11, 12),
+		"Y": chart(40, 45, 50),
+	}
+
+	series, err := EvaluateValueHistory(p, histories, date("2024-01-01"), date("2024-01-03"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []struct{ value, flow float64 }{{40, 0}, {67, 20}, {24, -50}}
+	if len(series) != len(want) {
+		t.Fatalf("Expected %d points, got %d", len(want), len(series))
+	}
+	for i, w := range want {
+		if !approx(series[i].Value, w.value) || !approx(series[i].Flow, w.flow) {
+			t.Errorf("Day %d: expected value %f flow %f, got %+v", i, w.value, w.flow, series[i])
+		}
+	}
+
+	if _, err := EvaluateValueHistory(p, nil, date("2024-01-01"), date("2024-01-03")); err == nil {
+		t.Error("Expected error without history")
+	}
+}
+
+// TestIRR tests the money-weighted return solver
+func TestIRR(t *testing.T) {
+	series := []PerformancePoint{
+		{Date: date("2023-01-01"), Value: 100},
+		{Date: date("2024-01-01"), Value: 110},
+	}
+	if rate, ok := irr(series); !ok || math.Abs(math.Pow(1+rate, 365)-1-0.10) > 1e-6 {
+		t.Errorf("Expected 10%% over the year, got %f, %v", math.Pow(1+rate, 365)-1, ok)
+	}
+
+	// Doubling the investment after a year doesn't change the rate
+	series = []PerformancePoint{
+		{Date: date("2023-01-01"), Value: 100},
+		{Date: date("2024-01-01"), Value: 210, Flow: 100},
+		{Date: date("2024-12-31"), Value: 231},
+	}
+	if rate, ok := irr(series); !ok || math.Abs(math.Pow(1+rate, 365)-1-0.10) > 1e-6 {
+		t.Errorf("Expected 10%% a year, got %f, %v", math.Pow(1+rate, 365)-1, ok)
+	}
+
+	// Losing everything overnight is beyond the solver's range
+	series = []PerformancePoint{
+		{Date: date("2024-01-01"), Value: 100},
+		{Date: date("2024-01-02"), Value: 0},
+	}
+	if rate, ok := irr(series); ok {
+		t.Errorf("Expected no solution, got %f", rate)
+	}
+}
@@ -0,0 +1,341 @@
+// Package portfolio tracks holdings and values them with Yahoo Finance data.
+package portfolio
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// ErrNoPositions is returned when a holdings file contains no positions
+var ErrNoPositions = errors.New("portfolio: no positions")
+
+// Position is a holding of a single symbol
+type Position struct {
+	Symbol    string  `json:"symbol" yaml:"symbol"`
+	Quantity  float64 `json:"quantity" yaml:"quantity"`
+	CostBasis float64 `json:"costBasis" yaml:"cost_basis"` // Average cost per share
+	Currency  string  `json:"currency,omitempty" yaml:"currency,omitempty"`
+	Lots      []Lot   `json:"lots,omitempty" yaml:"lots,omitempty"` // When set, determine Quantity and CostBasis
+}
+
+// Cost returns the total amount paid for the position
+func (p Position) Cost() float64 {
+	return p.Quantity * p.CostBasis
+}
+
+// Portfolio is a collection of positions
+type Portfolio struct {
+	Name      string     `json:"name,omitempty" yaml:"name,omitempty"`
+	Positions []Position `json:"positions" yaml:"positions"`
+	Sales     []Sale     `json:"sales,omitempty" yaml:"sales,omitempty"` // Closed lots, for realized P&L
+}
+
+// Symbols returns the distinct symbols held, in position order
+func (p *Portfolio) Symbols() []string {
+	seen := make(map[string]bool, len(p.Positions))
+	symbols := make([]string, 0, len(p.Positions))
+	for _, pos := range p.Positions {
+		if !seen[pos.Symbol] {
+			seen[pos.Symbol] = true
+			symbols = append(symbols, pos.Symbol)
+		}
+	}
+	return symbols
+}
+
+// LoadFile reads a portfolio from a .csv, .json, .yaml, or .yml file
+func LoadFile(path string) (*Portfolio, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is supplied by the user
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReadCSV(f)
+	case ".json":
+		return ReadJSON(f)
+	case ".yaml", ".yml":
+		return ReadYAML(f)
+	default:
+		return nil, fmt.Errorf("portfolio: unsupported file type %q (want .csv, .json, .yaml or .yml)", filepath.Ext(path))
+	}
+}
+
+// ReadCSV parses positions from CSV with a header row. Recognized columns are
+// symbol, quantity (or shares), cost_basis (or cost, avg_cost), and currency.
+func ReadCSV(r io.Reader) (*Portfolio, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNoPositions
+		}
+		return nil, fmt.Errorf("portfolio: failed to read CSV header: %w", err)
+	}
+
+	cols := map[string]int{}
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "symbol", "ticker":
+			cols["symbol"] = i
+		case "quantity", "shares", "qty":
+			cols["quantity"] = i
+		case "cost_basis", "cost", "avg_cost", "price":
+			cols["cost"] = i
+		case "currency":
+			cols["currency"] = i
+		}
+	}
+	for _, required := range []string{"symbol", "quantity"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("portfolio: CSV is missing a %s column", required)
+		}
+	}
+
+	p := &Portfolio{}
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("portfolio: line %d: %w", line, err)
+		}
+
+		pos := Position{Symbol: strings.ToUpper(strings.TrimSpace(record[cols["symbol"]]))}
+		if pos.Quantity, err = strconv.ParseFloat(strings.TrimSpace(record[cols["quantity"]]), 64); err != nil {
+			return nil, fmt.Errorf("portfolio: line %d: invalid quantity: %w", line, err)
+		}
+		if i, ok := cols["cost"]; ok && strings.TrimSpace(record[i]) != "" {
+			if pos.CostBasis, err = strconv.ParseFloat(strings.TrimSpace(record[i]), 64); err != nil {
+				return nil, fmt.Errorf("portfolio: line %d: invalid cost basis: %w", line, err)
+			}
+		}
+		if i, ok := cols["currency"]; ok {
+			pos.Currency = strings.ToUpper(strings.TrimSpace(record[i]))
+		}
+		p.Positions = append(p.Positions, pos)
+	}
+
+	return p, p.Validate()
+}
+
+// ReadYAML parses a portfolio from YAML with a top-level positions list.
+// Positions may list lots instead of a quantity and cost basis.
+func ReadYAML(r io.Reader) (*Portfolio, error) {
+	p := &Portfolio{}
+	if err := yaml.NewDecoder(r).Decode(p); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNoPositions
+		}
+		return nil, fmt.Errorf("portfolio: failed to parse YAML: %w", err)
+	}
+	return p, p.normalize()
+}
+
+// ReadJSON parses a portfolio in the shape WriteJSON produces. Like YAML,
+// positions may list lots instead of a quantity and cost basis.
+func ReadJSON(r io.Reader) (*Portfolio, error) {
+	p := &Portfolio{}
+	if err := json.NewDecoder(r).Decode(p); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNoPositions
+		}
+		return nil, fmt.Errorf("portfolio: failed to parse JSON: %w", err)
+	}
+	return p, p.normalize()
+}
+
+// normalize upper-cases symbols and currencies, applies lots, and validates
+func (p *Portfolio) normalize() error {
+	for i := range p.Positions {
+		p.Positions[i].Symbol = strings.ToUpper(strings.TrimSpace(p.Positions[i].Symbol))
+		p.Positions[i].Currency = strings.ToUpper(p.Positions[i].Currency)
+		p.Positions[i].applyLots()
+	}
+	return p.Validate()
+}
+
+// Validate checks that the portfolio has positions with symbols and quantities
+func (p *Portfolio) Validate() error {
+	if len(p.Positions) == 0 {
+		return ErrNoPositions
+	}
+	for i, pos := range p.Positions {
+		if pos.Symbol == "" {
+			return fmt.Errorf("portfolio: position %d: %w", i+1, yfinance.ErrInvalidSymbol)
+		}
+		if pos.Quantity == 0 {
+			return fmt.Errorf("portfolio: position %d (%s): quantity cannot be zero", i+1, pos.Symbol)
+		}
+	}
+	return nil
+}
+
+// PositionValue is a position priced at current market data
+type PositionValue struct {
+	Position
+	Name           string  `json:"name,omitempty"`
+	Sector         string  `json:"sector"`
+	Country        string  `json:"country"`
+	Price          float64 `json:"price"`
+	MarketValue    float64 `json:"marketValue"`
+	DayChange      float64 `json:"dayChange"`
+	DayChangePct   float64 `json:"dayChangePercent"`
+	TotalPL        float64 `json:"totalPL"` // Unrealized
+	TotalPLPct     float64 `json:"totalPLPercent"`
+	RealizedPL     float64 `json:"realizedPL"`
+	Weight         float64 `json:"weight"` // Fraction of total market value
+	AnnualDividend float64 `json:"annualDividend"`
+}
+
+// Valuation summarizes a priced portfolio
+type Valuation struct {
+	Positions         []PositionValue    `json:"positions"`
+	MarketValue       float64            `json:"marketValue"`
+	Cost              float64            `json:"cost"`
+	DayChange         float64            `json:"dayChange"`
+	DayChangePct      float64            `json:"dayChangePercent"`
+	TotalPL           float64            `json:"totalPL"` // Unrealized
+	TotalPLPct        float64            `json:"totalPLPercent"`
+	RealizedPL        float64            `json:"realizedPL"` // Including symbols no longer held
+	AnnualDividends   float64            `json:"annualDividends"`
+	SectorAllocation  map[string]float64 `json:"sectorAllocation"`  // Fraction of market value by sector
+	CountryAllocation map[string]float64 `json:"countryAllocation"` // Fraction of market value by country
+	Missing           []string           `json:"missing,omitempty"` // Symbols without a quote
+}
+
+// Profile classifies a symbol for allocation
+type Profile struct {
+	Sector  string `json:"sector,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// Value prices the portfolio with live quotes and looks up each symbol's
+// sector and country
+func Value(ctx context.Context, p *Portfolio) (*Valuation, error) {
+	symbols := p.Symbols()
+	if len(symbols) == 0 {
+		return Evaluate(p, nil, nil), nil
+	}
+	quotes, err := yfinance.DownloadQuotes(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]Profile, len(symbols))
+	if infos, err := yfinance.DownloadInfo(ctx, symbols, yfinance.ModuleAssetProfile); err == nil {
+		for sym, info := range infos {
+			if info != nil && info.AssetProfile != nil {
+				profiles[sym] = Profile{Sector: info.AssetProfile.Sector, Country: info.AssetProfile.Country}
+			}
+		}
+	}
+
+	return Evaluate(p, quotes, profiles), nil
+}
+
+// Evaluate prices the portfolio from already-fetched quotes and profiles.
+// Positions without a quote are listed in Valuation.Missing and excluded
+// from totals.
+func Evaluate(p *Portfolio, quotes map[string]*yfinance.Quote, profiles map[string]Profile) *Valuation {
+	v := &Valuation{
+		SectorAllocation:  make(map[string]float64),
+		CountryAllocation: make(map[string]float64),
+	}
+	realized := p.RealizedPL()
+	for _, pl := range realized {
+		v.RealizedPL += pl
+	}
+
+	for _, pos := range p.Positions {
+		q, ok := quotes[pos.Symbol]
+		if !ok || q == nil {
+			v.Missing = append(v.Missing, pos.Symbol)
+			continue
+		}
+
+		pv := PositionValue{
+			Position:       pos,
+			Name:           q.ShortName,
+			Sector:         profiles[pos.Symbol].Sector,
+			Country:        profiles[pos.Symbol].Country,
+			Price:          q.RegularMarketPrice,
+			MarketValue:    pos.Quantity * q.RegularMarketPrice,
+			DayChange:      pos.Quantity * q.RegularMarketChange,
+			DayChangePct:   q.RegularMarketChangePercent,
+			AnnualDividend: pos.Quantity * q.DividendRate,
+			RealizedPL:     realized[pos.Symbol],
+		}
+		if pv.Sector == "" {
+			pv.Sector = classify(q.QuoteType)
+		}
+		if pv.Country == "" {
+			pv.Country = "Unknown"
+		}
+		if pos.CostBasis > 0 {
+			pv.TotalPL = pv.MarketValue - pos.Cost()
+			pv.TotalPLPct = pv.TotalPL / pos.Cost() * 100
+		}
+
+		v.MarketValue += pv.MarketValue
+		v.Cost += pos.Cost()
+		v.DayChange += pv.DayChange
+		v.TotalPL += pv.TotalPL
+		v.AnnualDividends += pv.AnnualDividend
+		v.Positions = append(v.Positions, pv)
+	}
+
+	if v.MarketValue != 0 {
+		for i := range v.Positions {
+			v.Positions[i].Weight = v.Positions[i].MarketValue / v.MarketValue
+			v.SectorAllocation[v.Positions[i].Sector] += v.Positions[i].Weight
+			v.CountryAllocation[v.Positions[i].Country] += v.Positions[i].Weight
+		}
+	}
+	if prev := v.MarketValue - v.DayChange; prev != 0 {
+		v.DayChangePct = v.DayChange / prev * 100
+	}
+	if v.Cost != 0 {
+		v.TotalPLPct = v.TotalPL / v.Cost * 100
+	}
+
+	sort.SliceStable(v.Positions, func(i, j int) bool {
+		return v.Positions[i].MarketValue > v.Positions[j].MarketValue
+	})
+	return v
+}
+
+// classify names an allocation bucket for instruments without a sector
+func classify(quoteType string) string {
+	switch strings.ToUpper(quoteType) {
+	case yfinance.QuoteTypeETF:
+		return "ETF"
+	case yfinance.QuoteTypeMutualFund:
+		return "Mutual Fund"
+	case yfinance.QuoteTypeCrypto:
+		return "Crypto"
+	case yfinance.QuoteTypeCurrency:
+		return "Cash"
+	default:
+		return "Other"
+	}
+}
@@ -0,0 +1,147 @@
+package portfolio
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// TestReadCSV tests parsing holdings from CSV
+func TestReadCSV(t *testing.T) {
+	input := `Symbol,Shares,Avg_Cost
+# long-term holdings
+aapl,10,150
+MSFT, 5, 300.5
+`
+	p, err := ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Positions) != 2 {
+		t.Fatalf("Expected 2 positions, got %d", len(p.Positions))
+	}
+	if p.Positions[0].Symbol != "AAPL" || p.Positions[0].Quantity != 10 || p.Positions[0].CostBasis != 150 {
+		t.Errorf("Unexpected first position: %+v", p.Positions[0])
+	}
+	if p.Positions[1].CostBasis != 300.5 {
+		t.Errorf("Expected cost basis 300.5, got %f", p.Positions[1].CostBasis)
+	}
+
+	if _, err := ReadCSV(strings.NewReader("symbol,cost\nAAPL,1\n")); err == nil {
+		t.Error("Expected error for missing quantity column")
+	}
+	if _, err := ReadCSV(strings.NewReader("symbol,quantity\n")); !errors.Is(err, ErrNoPositions) {
+		t.Errorf("Expected ErrNoPositions, got %v", err)
+	}
+}
+
+// TestReadYAML tests parsing holdings from YAML
+func TestReadYAML(t *testing.T) {
+	input := `
+name: retirement
+positions:
+  - symbol: vti
+    quantity: 20
+    cost_basis: 200
+`
+	p, err := ReadYAML(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name != "retirement" || len(p.Positions) != 1 || p.Positions[0].Symbol != "VTI" {
+		t.Errorf("Unexpected portfolio: %+v", p)
+	}
+}
+
+// TestSaveRoundTrip tests that saved portfolios read back unchanged
+func TestSaveRoundTrip(t *testing.T) {
+	p := &Portfolio{
+		Name: "taxable",
+		Positions: []Position{
+			{Symbol: "AAPL", Quantity: 10, CostBasis: 150.25, Currency: "USD"},
+			{Symbol: "VOD.L", Quantity: 0.5, CostBasis: 70},
+		},
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"p.csv", "p.json", "p.yaml"} {
+		path := filepath.Join(dir, name)
+		if err := SaveFile(path, p); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		got, err := LoadFile(path)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if !reflect.DeepEqual(got.Positions, p.Positions) {
+			t.Errorf("%s: expected %+v, got %+v", name, p.Positions, got.Positions)
+		}
+		if name != "p.csv" && got.Name != p.Name {
+			t.Errorf("%s: expected name %q, got %q", name, p.Name, got.Name)
+		}
+	}
+
+	if err := SaveFile(filepath.Join(dir, "p.txt"), p); err == nil {
+		t.Error("Expected error for unsupported file type")
+	}
+
+	got, err := ReadJSON(strings.NewReader(`{"positions": [{"symbol": " msft ", "lots": [{"quantity": 2, "price": 100, "fees": 2}]}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pos := got.Positions[0]; pos.Symbol != "MSFT" || pos.Quantity != 2 || !approx(pos.CostBasis, 101) {
+		t.Errorf("Unexpected position from lots: %+v", pos)
+	}
+}
+
+// TestEvaluate tests valuation, P&L, weights, and allocation
+func TestEvaluate(t *testing.T) {
+	p := &Portfolio{Positions: []Position{
+		{Symbol: "AAPL", Quantity: 10, CostBasis: 100},
+		{Symbol: "VTI", Quantity: 5, CostBasis: 200},
+		{Symbol: "GONE", Quantity: 1, CostBasis: 1},
+	}}
+	quotes := map[string]*yfinance.Quote{
+		"AAPL": {Symbol: "AAPL", RegularMarketPrice: 150, RegularMarketChange: 3, DividendRate: 1},
+		"VTI":  {Symbol: "VTI", QuoteType: "ETF", RegularMarketPrice: 100, RegularMarketChange: -2},
+	}
+	profiles := map[string]Profile{"AAPL": {Sector: "Technology", Country: "United States"}}
+
+	v := Evaluate(p, quotes, profiles)
+
+	if !approx(v.MarketValue, 2000) {
+		t.Errorf("Expected market value 2000, got %f", v.MarketValue)
+	}
+	if !approx(v.Cost, 2000) {
+		t.Errorf("Expected cost 2000, got %f", v.Cost)
+	}
+	if !approx(v.TotalPL, 0) {
+		t.Errorf("Expected total P&L 0, got %f", v.TotalPL)
+	}
+	if !approx(v.DayChange, 20) {
+		t.Errorf("Expected day change 20, got %f", v.DayChange)
+	}
+	if !approx(v.AnnualDividends, 10) {
+		t.Errorf("Expected dividends 10, got %f", v.AnnualDividends)
+	}
+	if !approx(v.SectorAllocation["Technology"], 0.75) || !approx(v.SectorAllocation["ETF"], 0.25) {
+		t.Errorf("Unexpected allocation: %v", v.SectorAllocation)
+	}
+	if !approx(v.CountryAllocation["United States"], 0.75) || !approx(v.CountryAllocation["Unknown"], 0.25) {
+		t.Errorf("Unexpected country allocation: %v", v.CountryAllocation)
+	}
+	if len(v.Missing) != 1 || v.Missing[0] != "GONE" {
+		t.Errorf("Expected GONE to be missing, got %v", v.Missing)
+	}
+	if v.Positions[0].Symbol != "AAPL" || !approx(v.Positions[0].TotalPLPct, 50) {
+		t.Errorf("Unexpected first position: %+v", v.Positions[0])
+	}
+}
@@ -0,0 +1,79 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SaveFile writes the portfolio to a .csv, .json, .yaml, or .yml file,
+// replacing it if it exists. CSV keeps only each position's quantity,
+// average cost, and currency; the other formats keep lots and sales too.
+func SaveFile(path string, p *Portfolio) error {
+	var write func(io.Writer, *Portfolio) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		write = WriteCSV
+	case ".json":
+		write = WriteJSON
+	case ".yaml", ".yml":
+		write = WriteYAML
+	default:
+		return fmt.Errorf("portfolio: unsupported file type %q (want .csv, .json, .yaml or .yml)", filepath.Ext(path))
+	}
+
+	f, err := os.Create(path) //nolint:gosec // G304: path is supplied by the user
+	if err != nil {
+		return err
+	}
+	if err := write(f, p); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteCSV writes positions with the columns ReadCSV reads back
+func WriteCSV(w io.Writer, p *Portfolio) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"symbol", "quantity", "cost_basis", "currency"}); err != nil {
+		return err
+	}
+	for _, pos := range p.Positions {
+		record := []string{
+			pos.Symbol,
+			strconv.FormatFloat(pos.Quantity, 'f', -1, 64),
+			strconv.FormatFloat(pos.CostBasis, 'f', -1, 64),
+			pos.Currency,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the portfolio as indented JSON
+func WriteJSON(w io.Writer, p *Portfolio) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// WriteYAML writes the portfolio as YAML
+func WriteYAML(w io.Writer, p *Portfolio) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(p); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -18,6 +18,9 @@ type Action struct {
 
 // Actions fetches all corporate actions (dividends and splits) for the ticker
 func (t *Ticker) Actions(ctx context.Context, params HistoryParams) ([]Action, error) {
+	ctx, span := t.startSpan(ctx, "Actions")
+	defer span.End()
+
 	if params.Period == "" {
 		params.Period = PeriodMax
 	}
@@ -74,11 +77,14 @@ func (t *Ticker) SplitHistory(ctx context.Context) ([]Split, error) {
 
 // CapitalGains fetches capital gains distributions (for mutual funds)
 func (t *Ticker) CapitalGains(ctx context.Context, params HistoryParams) ([]CapitalGain, error) {
+	ctx, span := t.startSpan(ctx, "CapitalGains")
+	defer span.End()
+
 	if params.Period == "" {
 		params.Period = PeriodMax
 	}
 
-	endpoint := ChartURL + "/" + t.Symbol
+	endpoint := t.client.endpoints.Chart + "/" + t.Symbol
 	queryParams := map[string][]string{
 		"range":    {string(params.Period)},
 		"interval": {string(Interval1d)},
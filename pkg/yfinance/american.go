@@ -0,0 +1,87 @@
+package yfinance
+
+import "math"
+
+// defaultTreeSteps is the binomial tree depth used when steps is not
+// positive, accurate to about a cent for typical equity options
+const defaultTreeSteps = 200
+
+// AmericanPrice prices an option that may be exercised early with a
+// Cox-Ross-Rubinstein binomial tree of the given number of steps. q is the
+// continuous dividend yield; the other parameters are those of
+// CalculateGreeks. Early exercise matters for puts, and for calls on
+// dividend payers.
+func AmericanPrice(s, k, r, q, t, sigma float64, isCall bool, steps int) float64 {
+	if t <= 0 || sigma <= 0 {
+		return intrinsic(s, k, isCall)
+	}
+	price, _, _ := binomialTree(s, k, r, q, t, sigma, isCall, steps)
+	return price
+}
+
+// AmericanGreeks calculates the Greeks of an American option from the same
+// binomial tree as AmericanPrice. Delta, gamma, and theta are read off the
+// tree's first steps; vega and rho come from repricing with volatility and
+// rate bumped by one point. Units match CalculateGreeks. It returns nil
+// for non-positive t or sigma.
+func AmericanGreeks(s, k, r, q, t, sigma float64, isCall bool, steps int) *Greeks {
+	if t <= 0 || sigma <= 0 {
+		return nil
+	}
+	if steps < 3 {
+		steps = defaultTreeSteps
+	}
+
+	price, step1, step2 := binomialTree(s, k, r, q, t, sigma, isCall, steps)
+	dt := t / float64(steps)
+	u := math.Exp(sigma * math.Sqrt(dt))
+	d := 1 / u
+
+	g := &Greeks{}
+	g.Delta = (step1[1] - step1[0]) / (s*u - s*d)
+	upDelta := (step2[2] - step2[1]) / (s*u*u - s)
+	downDelta := (step2[1] - step2[0]) / (s - s*d*d)
+	g.Gamma = (upDelta - downDelta) / ((s*u*u - s*d*d) / 2)
+	// The middle node two steps in has the same spot, 2dt later
+	g.Theta = (step2[1] - price) / (2 * dt) / 365
+
+	const bump = 0.01
+	// Keep the lower volatility positive for very low volatilities
+	lo, hi := math.Max(sigma-bump, sigma/2), sigma+bump
+	g.Vega = (AmericanPrice(s, k, r, q, t, hi, isCall, steps) - AmericanPrice(s, k, r, q, t, lo, isCall, steps)) / (hi - lo) / 100
+	g.Rho = (AmericanPrice(s, k, r+bump, q, t, sigma, isCall, steps) -
+		AmericanPrice(s, k, r-bump, q, t, sigma, isCall, steps)) / (2 * bump) / 100
+	return g
+}
+
+// binomialTree prices an American option, returning also the option's
+// values at the nodes one and two steps in, lowest spot first
+func binomialTree(s, k, r, q, t, sigma float64, isCall bool, steps int) (price float64, step1, step2 []float64) {
+	if steps < 3 {
+		steps = defaultTreeSteps
+	}
+	dt := t / float64(steps)
+	u := math.Exp(sigma * math.Sqrt(dt))
+	d := 1 / u
+	p := (math.Exp((r-q)*dt) - d) / (u - d)
+	discount := math.Exp(-r * dt)
+
+	// values[j] is the option's value after j up moves
+	values := make([]float64, steps+1)
+	for j := range values {
+		values[j] = intrinsic(s*math.Pow(u, float64(2*j-steps)), k, isCall)
+	}
+	for i := steps - 1; i >= 0; i-- {
+		for j := 0; j <= i; j++ {
+			hold := discount * (p*values[j+1] + (1-p)*values[j])
+			values[j] = math.Max(hold, intrinsic(s*math.Pow(u, float64(2*j-i)), k, isCall))
+		}
+		switch i {
+		case 2:
+			step2 = append([]float64(nil), values[:3]...)
+		case 1:
+			step1 = append([]float64(nil), values[:2]...)
+		}
+	}
+	return values[0], step1, step2
+}
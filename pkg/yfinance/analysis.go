@@ -1,10 +1,14 @@
 package yfinance
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // RecommendationTrend represents analyst recommendation trends
@@ -81,6 +85,66 @@ type EarningsHistoryItem struct {
 	SurprisePercent float64 `json:"surprisePercent"`
 }
 
+// AnalystAction is one firm's rating change or reiteration
+type AnalystAction struct {
+	Date             time.Time `json:"date"`
+	Firm             string    `json:"firm"`
+	FromGrade        string    `json:"fromGrade,omitempty"`
+	ToGrade          string    `json:"toGrade"`
+	Action           string    `json:"action"` // "up", "down", "main", "init", or "reit"
+	PriceTarget      float64   `json:"priceTarget,omitempty"`
+	PriorPriceTarget float64   `json:"priorPriceTarget,omitempty"`
+}
+
+// RevisionMomentum counts analyst revisions over a window
+type RevisionMomentum struct {
+	Upgrades     int `json:"upgrades"`
+	Downgrades   int `json:"downgrades"`
+	TargetRaises int `json:"targetRaises"`
+	TargetCuts   int `json:"targetCuts"`
+	Net          int `json:"net"` // Upgrades and raises less downgrades and cuts
+}
+
+// AnalystView combines a ticker's analyst ratings, recommendation trend,
+// and price targets
+type AnalystView struct {
+	Symbol string `json:"symbol"`
+	// Consensus is the mean rating this month, from 1 (strong buy) to 5
+	// (strong sell); zero without ratings
+	Consensus       float64               `json:"consensus"`
+	ConsensusKey    string                `json:"consensusKey"` // e.g. "buy" or "hold"
+	Recommendations []RecommendationTrend `json:"recommendations"`
+	PriceTarget     PriceTarget           `json:"priceTarget"`
+	Actions         []AnalystAction       `json:"actions"` // Newest first
+	Momentum30d     RevisionMomentum      `json:"momentum30d"`
+	Momentum90d     RevisionMomentum      `json:"momentum90d"`
+}
+
+// Earnings is the revenue and earnings summary shown on Yahoo's earnings
+// tab
+type Earnings struct {
+	Currency               string           `json:"currency"`
+	Yearly                 []EarningsPeriod `json:"yearly"`    // Oldest first
+	Quarterly              []EarningsPeriod `json:"quarterly"` // Oldest first
+	QuarterlyEPS           []EPSQuarter     `json:"quarterlyEps"`
+	CurrentQuarter         string           `json:"currentQuarter"` // e.g. "2Q2025"
+	CurrentQuarterEstimate float64          `json:"currentQuarterEstimate"`
+}
+
+// EarningsPeriod is the revenue and net income of a fiscal year or quarter
+type EarningsPeriod struct {
+	Period   string  `json:"period"` // e.g. "2024" or "3Q2024"
+	Revenue  float64 `json:"revenue"`
+	Earnings float64 `json:"earnings"`
+}
+
+// EPSQuarter is the reported and expected EPS of a quarter
+type EPSQuarter struct {
+	Quarter  string  `json:"quarter"` // e.g. "3Q2024"
+	Actual   float64 `json:"actual"`
+	Estimate float64 `json:"estimate"`
+}
+
 // GrowthEstimate represents growth estimates
 type GrowthEstimate struct {
 	Period   string  `json:"period"`
@@ -92,7 +156,10 @@ type GrowthEstimate struct {
 
 // Recommendations fetches analyst recommendation trends
 func (t *Ticker) Recommendations(ctx context.Context) ([]RecommendationTrend, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "Recommendations")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleRecommendationTrend)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -111,7 +178,7 @@ func (t *Ticker) Recommendations(ctx context.Context) ([]RecommendationTrend, er
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse recommendations: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "recommendations", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -123,7 +190,10 @@ func (t *Ticker) Recommendations(ctx context.Context) ([]RecommendationTrend, er
 
 // AnalystPriceTargets fetches analyst price targets
 func (t *Ticker) AnalystPriceTargets(ctx context.Context) (*PriceTarget, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "AnalystPriceTargets")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleFinancialData)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -147,7 +217,7 @@ func (t *Ticker) AnalystPriceTargets(ctx context.Context) (*PriceTarget, error)
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse price targets: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "price targets", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -165,15 +235,71 @@ func (t *Ticker) AnalystPriceTargets(ctx context.Context) (*PriceTarget, error)
 	}, nil
 }
 
-// RawValue represents a Yahoo Finance value with raw and formatted versions
+// RawValue represents a Yahoo Finance value with raw and formatted versions.
+// Valid is false when Yahoo sent no value, telling a missing value apart
+// from a real zero.
 type RawValue struct {
-	Raw float64 `json:"raw"`
-	Fmt string  `json:"fmt"`
+	Raw   float64 `json:"raw"`
+	Fmt   string  `json:"fmt"`
+	Valid bool    `json:"-"`
+}
+
+// UnmarshalJSON accepts every shape Yahoo uses for a value: a
+// {"raw": ..., "fmt": ...} object, an empty object, a bare number, a
+// numeric string, or null
+func (v *RawValue) UnmarshalJSON(data []byte) error {
+	*v = RawValue{}
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		return nil
+	case data[0] == '{':
+		var obj struct {
+			Raw json.RawMessage `json:"raw"`
+			Fmt string          `json:"fmt"`
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		v.Fmt = obj.Fmt
+		if len(obj.Raw) == 0 || bytes.Equal(obj.Raw, []byte("null")) {
+			return nil
+		}
+		v.Raw, v.Valid = rawNumber(obj.Raw)
+		return nil
+	case data[0] == '"':
+		if err := json.Unmarshal(data, &v.Fmt); err != nil {
+			return err
+		}
+		v.Raw, v.Valid = rawNumber(data)
+		return nil
+	default:
+		if err := json.Unmarshal(data, &v.Raw); err != nil {
+			return err
+		}
+		v.Valid = true
+		return nil
+	}
+}
+
+// rawNumber parses a JSON number or numeric string
+func rawNumber(data []byte) (float64, bool) {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		f, err := strconv.ParseFloat(strings.TrimSuffix(strings.ReplaceAll(s, ",", ""), "%"), 64)
+		return f, err == nil
+	}
+	var f float64
+	err := json.Unmarshal(data, &f)
+	return f, err == nil
 }
 
 // EarningsEstimates fetches earnings estimates for upcoming periods
 func (t *Ticker) EarningsEstimates(ctx context.Context) ([]EarningsEstimate, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "EarningsEstimates")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleEarningsTrend)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -203,7 +329,7 @@ func (t *Ticker) EarningsEstimates(ctx context.Context) ([]EarningsEstimate, err
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse earnings estimates: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "earnings estimates", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -229,7 +355,10 @@ func (t *Ticker) EarningsEstimates(ctx context.Context) ([]EarningsEstimate, err
 
 // RevenueEstimates fetches revenue estimates for upcoming periods
 func (t *Ticker) RevenueEstimates(ctx context.Context) ([]RevenueEstimate, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "RevenueEstimates")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleEarningsTrend)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -259,7 +388,7 @@ func (t *Ticker) RevenueEstimates(ctx context.Context) ([]RevenueEstimate, error
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse revenue estimates: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "revenue estimates", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -285,7 +414,10 @@ func (t *Ticker) RevenueEstimates(ctx context.Context) ([]RevenueEstimate, error
 
 // EPSTrends fetches EPS trend data
 func (t *Ticker) EPSTrends(ctx context.Context) ([]EPSTrend, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "EPSTrends")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleEarningsTrend)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -314,7 +446,7 @@ func (t *Ticker) EPSTrends(ctx context.Context) ([]EPSTrend, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse EPS trends: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "EPS trends", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -339,7 +471,10 @@ func (t *Ticker) EPSTrends(ctx context.Context) ([]EPSTrend, error) {
 
 // EPSRevisions fetches EPS revision data
 func (t *Ticker) EPSRevisions(ctx context.Context) ([]EPSRevision, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "EPSRevisions")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleEarningsTrend)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -367,7 +502,7 @@ func (t *Ticker) EPSRevisions(ctx context.Context) ([]EPSRevision, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse EPS revisions: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "EPS revisions", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -391,7 +526,10 @@ func (t *Ticker) EPSRevisions(ctx context.Context) ([]EPSRevision, error) {
 
 // EarningsHistoryData fetches historical earnings data
 func (t *Ticker) EarningsHistoryData(ctx context.Context) ([]EarningsHistoryItem, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "EarningsHistoryData")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleEarningsHistory)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -416,7 +554,7 @@ func (t *Ticker) EarningsHistoryData(ctx context.Context) ([]EarningsHistoryItem
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse earnings history: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "earnings history", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -437,9 +575,233 @@ func (t *Ticker) EarningsHistoryData(ctx context.Context) ([]EarningsHistoryItem
 	return history, nil
 }
 
+// Earnings fetches the ticker's yearly and quarterly revenue and earnings,
+// and its reported against expected EPS for recent quarters
+func (t *Ticker) Earnings(ctx context.Context) (*Earnings, error) {
+	ctx, span := t.startSpan(ctx, "Earnings")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
+	params := buildModulesParams(ModuleEarnings)
+
+	data, err := t.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		QuoteSummary struct {
+			Result []struct {
+				Earnings *struct {
+					EarningsChart struct {
+						Quarterly []struct {
+							Date     string   `json:"date"`
+							Actual   RawValue `json:"actual"`
+							Estimate RawValue `json:"estimate"`
+						} `json:"quarterly"`
+						CurrentQuarterEstimate     RawValue `json:"currentQuarterEstimate"`
+						CurrentQuarterEstimateDate string   `json:"currentQuarterEstimateDate"`
+						CurrentQuarterEstimateYear int      `json:"currentQuarterEstimateYear"`
+					} `json:"earningsChart"`
+					FinancialsChart struct {
+						Yearly []struct {
+							Date     int      `json:"date"`
+							Revenue  RawValue `json:"revenue"`
+							Earnings RawValue `json:"earnings"`
+						} `json:"yearly"`
+						Quarterly []struct {
+							Date     string   `json:"date"`
+							Revenue  RawValue `json:"revenue"`
+							Earnings RawValue `json:"earnings"`
+						} `json:"quarterly"`
+					} `json:"financialsChart"`
+					FinancialCurrency string `json:"financialCurrency"`
+				} `json:"earnings"`
+			} `json:"result"`
+		} `json:"quoteSummary"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "earnings", err))
+	}
+
+	if len(response.QuoteSummary.Result) == 0 || response.QuoteSummary.Result[0].Earnings == nil {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	raw := response.QuoteSummary.Result[0].Earnings
+	earnings := &Earnings{
+		Currency:               raw.FinancialCurrency,
+		CurrentQuarterEstimate: raw.EarningsChart.CurrentQuarterEstimate.Raw,
+	}
+	if chart := raw.EarningsChart; chart.CurrentQuarterEstimateDate != "" {
+		earnings.CurrentQuarter = fmt.Sprintf("%s%d", chart.CurrentQuarterEstimateDate, chart.CurrentQuarterEstimateYear)
+	}
+	for _, y := range raw.FinancialsChart.Yearly {
+		earnings.Yearly = append(earnings.Yearly, EarningsPeriod{
+			Period:   strconv.Itoa(y.Date),
+			Revenue:  y.Revenue.Raw,
+			Earnings: y.Earnings.Raw,
+		})
+	}
+	for _, q := range raw.FinancialsChart.Quarterly {
+		earnings.Quarterly = append(earnings.Quarterly, EarningsPeriod{
+			Period:   q.Date,
+			Revenue:  q.Revenue.Raw,
+			Earnings: q.Earnings.Raw,
+		})
+	}
+	for _, q := range raw.EarningsChart.Quarterly {
+		earnings.QuarterlyEPS = append(earnings.QuarterlyEPS, EPSQuarter{
+			Quarter:  q.Date,
+			Actual:   q.Actual.Raw,
+			Estimate: q.Estimate.Raw,
+		})
+	}
+
+	return earnings, nil
+}
+
+// AnalystActions fetches the ticker's rating changes, recommendation trend,
+// and price targets in one request, with the consensus rating and the
+// revisions of the last 30 and 90 days
+func (t *Ticker) AnalystActions(ctx context.Context) (*AnalystView, error) {
+	ctx, span := t.startSpan(ctx, "AnalystActions")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
+	params := buildModulesParams(ModuleUpgradeDowngradeHistory, ModuleRecommendationTrend, ModuleFinancialData)
+
+	data, err := t.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		QuoteSummary struct {
+			Result []struct {
+				UpgradeDowngradeHistory struct {
+					History []struct {
+						EpochGradeDate     int64   `json:"epochGradeDate"`
+						Firm               string  `json:"firm"`
+						ToGrade            string  `json:"toGrade"`
+						FromGrade          string  `json:"fromGrade"`
+						Action             string  `json:"action"`
+						CurrentPriceTarget float64 `json:"currentPriceTarget"`
+						PriorPriceTarget   float64 `json:"priorPriceTarget"`
+					} `json:"history"`
+				} `json:"upgradeDowngradeHistory"`
+				RecommendationTrend struct {
+					Trend []RecommendationTrend `json:"trend"`
+				} `json:"recommendationTrend"`
+				FinancialData struct {
+					CurrentPrice            RawValue `json:"currentPrice"`
+					TargetLowPrice          RawValue `json:"targetLowPrice"`
+					TargetHighPrice         RawValue `json:"targetHighPrice"`
+					TargetMeanPrice         RawValue `json:"targetMeanPrice"`
+					TargetMedianPrice       RawValue `json:"targetMedianPrice"`
+					NumberOfAnalystOpinions RawValue `json:"numberOfAnalystOpinions"`
+					RecommendationMean      RawValue `json:"recommendationMean"`
+					RecommendationKey       string   `json:"recommendationKey"`
+				} `json:"financialData"`
+			} `json:"result"`
+		} `json:"quoteSummary"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "analyst actions", err))
+	}
+
+	if len(response.QuoteSummary.Result) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	result := response.QuoteSummary.Result[0]
+	fd := result.FinancialData
+	view := &AnalystView{
+		Symbol:          t.Symbol,
+		ConsensusKey:    fd.RecommendationKey,
+		Recommendations: result.RecommendationTrend.Trend,
+		PriceTarget: PriceTarget{
+			Current:     fd.CurrentPrice.Raw,
+			Low:         fd.TargetLowPrice.Raw,
+			High:        fd.TargetHighPrice.Raw,
+			Mean:        fd.TargetMeanPrice.Raw,
+			Median:      fd.TargetMedianPrice.Raw,
+			NumAnalysts: int(fd.NumberOfAnalystOpinions.Raw),
+		},
+	}
+	for _, h := range result.UpgradeDowngradeHistory.History {
+		view.Actions = append(view.Actions, AnalystAction{
+			Date:             time.Unix(h.EpochGradeDate, 0).UTC(),
+			Firm:             h.Firm,
+			FromGrade:        h.FromGrade,
+			ToGrade:          h.ToGrade,
+			Action:           h.Action,
+			PriceTarget:      h.CurrentPriceTarget,
+			PriorPriceTarget: h.PriorPriceTarget,
+		})
+	}
+	sort.SliceStable(view.Actions, func(i, j int) bool { return view.Actions[i].Date.After(view.Actions[j].Date) })
+
+	view.Consensus = consensusRating(view.Recommendations)
+	if view.Consensus == 0 {
+		view.Consensus = fd.RecommendationMean.Raw
+	}
+	now := time.Now()
+	view.Momentum30d = revisionMomentum(view.Actions, now.AddDate(0, 0, -30))
+	view.Momentum90d = revisionMomentum(view.Actions, now.AddDate(0, 0, -90))
+	return view, nil
+}
+
+// consensusRating returns the mean rating of the current month's trend,
+// from 1 (strong buy) to 5 (strong sell), or zero without ratings
+func consensusRating(trend []RecommendationTrend) float64 {
+	for _, r := range trend {
+		if r.Period != "0m" {
+			continue
+		}
+		total := r.StrongBuy + r.Buy + r.Hold + r.Sell + r.StrongSell
+		if total == 0 {
+			return 0
+		}
+		weighted := r.StrongBuy + 2*r.Buy + 3*r.Hold + 4*r.Sell + 5*r.StrongSell
+		return float64(weighted) / float64(total)
+	}
+	return 0
+}
+
+// revisionMomentum counts the revisions among actions dated since
+func revisionMomentum(actions []AnalystAction, since time.Time) RevisionMomentum {
+	var m RevisionMomentum
+	for _, a := range actions {
+		if a.Date.Before(since) {
+			continue
+		}
+		switch a.Action {
+		case "up":
+			m.Upgrades++
+		case "down":
+			m.Downgrades++
+		}
+		switch {
+		case a.PriorPriceTarget == 0 || a.PriceTarget == 0:
+		case a.PriceTarget > a.PriorPriceTarget:
+			m.TargetRaises++
+		case a.PriceTarget < a.PriorPriceTarget:
+			m.TargetCuts++
+		}
+	}
+	m.Net = m.Upgrades + m.TargetRaises - m.Downgrades - m.TargetCuts
+	return m
+}
+
 // GrowthEstimates fetches growth estimates
 func (t *Ticker) GrowthEstimates(ctx context.Context) ([]GrowthEstimate, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "GrowthEstimates")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleEarningsTrend)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -461,7 +823,7 @@ func (t *Ticker) GrowthEstimates(ctx context.Context) ([]GrowthEstimate, error)
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse growth estimates: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "growth estimates", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
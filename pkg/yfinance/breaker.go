@@ -0,0 +1,181 @@
+package yfinance
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitBreakerConfig configures per-endpoint circuit breaking. After
+// FailureThreshold consecutive failures on an endpoint, requests to it fail
+// fast with a CircuitOpenError for CoolDown. Then one trial request is let
+// through: success closes the circuit, failure opens it again.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures that open the circuit
+	CoolDown         time.Duration // How long the circuit stays open
+	FailureStatuses  []int         // Statuses counted as failures; network errors always are
+}
+
+// DefaultCircuitBreakerConfig returns the default circuit breaker settings
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 5,
+		CoolDown:         30 * time.Second,
+		FailureStatuses:  []int{429, 500, 502, 503, 504},
+	}
+}
+
+// WithCircuitBreaker enables per-endpoint circuit breaking. Endpoints are
+// grouped by Endpoints.Name, so one failing symbol's chart requests also stop
+// other chart requests, while quotes keep flowing.
+func WithCircuitBreaker(config CircuitBreakerConfig) ClientOption {
+	return func(c *Client) {
+		defaults := DefaultCircuitBreakerConfig()
+		if config.FailureThreshold <= 0 {
+			config.FailureThreshold = defaults.FailureThreshold
+		}
+		if config.CoolDown <= 0 {
+			config.CoolDown = defaults.CoolDown
+		}
+		if config.FailureStatuses == nil {
+			config.FailureStatuses = defaults.FailureStatuses
+		}
+		c.breaker = &circuitBreaker{config: config, circuits: make(map[string]*circuit)}
+	}
+}
+
+// CircuitOpenError is returned without sending a request while an
+// endpoint's circuit is open
+type CircuitOpenError struct {
+	Endpoint string    // Endpoint name, see Endpoints.Name
+	RetryAt  time.Time // When a trial request will be allowed
+}
+
+// Error implements the error interface
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("yfinance: circuit open for %s endpoint until %s", e.Endpoint, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrCircuitOpen
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState is the state of an endpoint's circuit
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // Requests flow normally
+	CircuitOpen     CircuitState = "open"      // Requests fail fast
+	CircuitHalfOpen CircuitState = "half-open" // One trial request is in flight
+)
+
+// circuit tracks one endpoint
+type circuit struct {
+	failures  int
+	openUntil time.Time
+	trial     bool // A half-open trial request is in flight
+}
+
+// circuitBreaker holds the circuits of all endpoints
+type circuitBreaker struct {
+	config   CircuitBreakerConfig
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// allow returns a CircuitOpenError unless a request to endpoint may be sent
+func (b *circuitBreaker) allow(endpoint string, now time.Time) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb := b.circuits[endpoint]
+	if cb == nil || cb.failures < b.config.FailureThreshold {
+		return nil
+	}
+	if now.Before(cb.openUntil) || cb.trial {
+		retryAt := cb.openUntil
+		if cb.trial {
+			retryAt = now.Add(b.config.CoolDown)
+		}
+		return &CircuitOpenError{Endpoint: endpoint, RetryAt: retryAt}
+	}
+	cb.trial = true
+	return nil
+}
+
+// record updates endpoint's circuit with a request's outcome
+func (b *circuitBreaker) record(endpoint string, resp *http.Response, err error, now time.Time) {
+	if b == nil {
+		return
+	}
+	failed := b.failure(resp, err)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb := b.circuits[endpoint]
+	if !failed {
+		delete(b.circuits, endpoint)
+		return
+	}
+	if cb == nil {
+		cb = &circuit{}
+		b.circuits[endpoint] = cb
+	}
+	cb.failures++
+	cb.trial = false
+	if cb.failures >= b.config.FailureThreshold {
+		cb.openUntil = now.Add(b.config.CoolDown)
+	}
+}
+
+// release ends a trial request that finished without an outcome, such as
+// when its context was canceled
+func (b *circuitBreaker) release(endpoint string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if cb := b.circuits[endpoint]; cb != nil {
+		cb.trial = false
+	}
+}
+
+// failure reports whether a request's outcome counts as a failure
+func (b *circuitBreaker) failure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return shouldRetry(resp.StatusCode, b.config.FailureStatuses)
+}
+
+// state returns endpoint's circuit state
+func (b *circuitBreaker) state(endpoint string, now time.Time) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb := b.circuits[endpoint]
+	switch {
+	case cb == nil || cb.failures < b.config.FailureThreshold:
+		return CircuitClosed
+	case cb.trial || !now.Before(cb.openUntil):
+		return CircuitHalfOpen
+	default:
+		return CircuitOpen
+	}
+}
+
+// CircuitState returns the state of an endpoint's circuit, by name as given
+// by Endpoints.Name. It is always closed without WithCircuitBreaker.
+func (c *Client) CircuitState(endpoint string) CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.state(endpoint, time.Now())
+}
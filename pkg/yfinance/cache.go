@@ -1,12 +1,19 @@
 package yfinance
 
 import (
+	"container/list"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -28,7 +35,11 @@ type CacheConfig struct {
 	Type       CacheType
 	Directory  string        // For disk cache
 	DefaultTTL time.Duration // Default TTL for cache entries
-	MaxSize    int           // Maximum number of entries in memory cache
+	MaxSize    int           // Maximum number of entries in memory cache, 0 for no limit
+	MaxBytes   int64         // Maximum total size of memory cache entries, 0 for no limit
+
+	SweepInterval time.Duration // How often the disk cache is swept, 0 to never sweep in the background
+	MaxDiskBytes  int64         // Size the disk cache is trimmed to on each sweep, 0 for no limit
 }
 
 // DefaultCacheConfig returns the default cache configuration
@@ -39,39 +50,109 @@ func DefaultCacheConfig() CacheConfig {
 		Directory:  filepath.Join(homeDir, ".yfinance_cache"),
 		DefaultTTL: 5 * time.Minute,
 		MaxSize:    1000,
+		MaxBytes:   64 << 20,
+
+		SweepInterval: 10 * time.Minute,
+		MaxDiskBytes:  512 << 20,
 	}
 }
 
+// CacheStore is a backend for cached API responses. Implementations must be
+// safe for concurrent use. Errors are treated as cache misses, so a failing
+// backend degrades to uncached requests.
+type CacheStore interface {
+	// Get returns the data stored under key if it has not expired
+	Get(key string) ([]byte, bool)
+	// Set stores data under key for ttl, or the store's default TTL if zero
+	Set(key string, data []byte, ttl time.Duration)
+	// Delete removes key
+	Delete(key string)
+	// Clear removes every entry
+	Clear()
+}
+
+var _ CacheStore = (*Cache)(nil)
+
 // cacheEntry represents a cached item
 type cacheEntry struct {
 	Data      []byte    `json:"data"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
-// Cache provides caching functionality for API responses
+// memoryEntry is an element of the memory cache's LRU list
+type memoryEntry struct {
+	key string
+	cacheEntry
+}
+
+// size is the number of bytes an entry counts towards MaxBytes
+func (e *memoryEntry) size() int64 {
+	return int64(len(e.key) + len(e.Data))
+}
+
+// CacheStats are counters of a cache's activity and its current size
+type CacheStats struct {
+	Hits      uint64 // Lookups answered from memory or disk
+	Misses    uint64 // Lookups that found nothing fresh
+	Evictions uint64 // Memory entries dropped to stay within MaxSize or MaxBytes
+	Swept     uint64 // Disk files removed as expired, corrupt, or over MaxDiskBytes
+	Entries   int    // Entries in memory
+	Bytes     int64  // Size of the entries in memory
+}
+
+// Cache provides caching functionality for API responses. The memory cache
+// evicts the least recently used entries once it holds MaxSize entries or
+// MaxBytes bytes. The disk cache is swept every SweepInterval by a
+// background janitor, stopped by Close.
 type Cache struct {
 	config  CacheConfig
-	memory  map[string]*cacheEntry
+	memory  map[string]*list.Element
+	lru     *list.List // Most recently used first
+	bytes   int64
+	stats   CacheStats
 	mu      sync.RWMutex
 	enabled bool
+
+	stop      chan struct{}
+	closeOnce sync.Once
 }
 
 // NewCache creates a new cache with the given configuration
 func NewCache(config CacheConfig) *Cache {
 	c := &Cache{
 		config:  config,
-		memory:  make(map[string]*cacheEntry),
+		memory:  make(map[string]*list.Element),
+		lru:     list.New(),
 		enabled: true,
 	}
 
 	// Create disk cache directory if needed
-	if config.Type == CacheTypeDisk || config.Type == CacheTypeBoth {
+	if c.onDisk() {
 		_ = os.MkdirAll(config.Directory, 0o755) //nolint:gosec // G301: 0755 permissions acceptable for user cache dir
+		if config.SweepInterval > 0 {
+			c.stop = make(chan struct{})
+			go c.janitor(config.SweepInterval)
+		}
 	}
 
 	return c
 }
 
+// Close stops the disk cache janitor. The cache remains usable.
+func (c *Cache) Close() error {
+	c.closeOnce.Do(func() {
+		if c.stop != nil {
+			close(c.stop)
+		}
+	})
+	return nil
+}
+
+// onDisk reports whether the cache keeps entries on disk
+func (c *Cache) onDisk() bool {
+	return c.config.Type == CacheTypeDisk || c.config.Type == CacheTypeBoth
+}
+
 // defaultCache is the global cache instance
 var (
 	defaultCache     *Cache
@@ -104,6 +185,11 @@ func EnableCache(enabled bool) {
 
 // generateKey creates a cache key from the given parameters
 func (c *Cache) generateKey(prefix string, params ...interface{}) string {
+	return hashKey(prefix, params...)
+}
+
+// hashKey hashes a prefix and parameters into a fixed-length key
+func hashKey(prefix string, params ...interface{}) string {
 	data := fmt.Sprintf("%s:%v", prefix, params)
 	hash := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(hash[:])
@@ -117,29 +203,38 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 
 	// Try memory cache first
 	if c.config.Type == CacheTypeMemory || c.config.Type == CacheTypeBoth {
-		c.mu.RLock()
-		entry, ok := c.memory[key]
-		c.mu.RUnlock()
-
-		if ok && time.Now().Before(entry.ExpiresAt) {
-			return entry.Data, true
+		c.mu.Lock()
+		if el, ok := c.memory[key]; ok {
+			entry := el.Value.(*memoryEntry)
+			if time.Now().Before(entry.ExpiresAt) {
+				c.lru.MoveToFront(el)
+				c.stats.Hits++
+				c.mu.Unlock()
+				return entry.Data, true
+			}
+			c.remove(el)
 		}
+		c.mu.Unlock()
 	}
 
 	// Try disk cache
 	if c.config.Type == CacheTypeDisk || c.config.Type == CacheTypeBoth {
-		data, ok := c.getFromDisk(key)
+		entry, ok := c.getFromDisk(key)
 		if ok {
+			c.mu.Lock()
+			c.stats.Hits++
 			// Populate memory cache
 			if c.config.Type == CacheTypeBoth {
-				c.mu.Lock()
-				c.memory[key] = &cacheEntry{Data: data, ExpiresAt: time.Now().Add(c.config.DefaultTTL)}
-				c.mu.Unlock()
+				c.store(key, entry)
 			}
-			return data, true
+			c.mu.Unlock()
+			return entry.Data, true
 		}
 	}
 
+	c.mu.Lock()
+	c.stats.Misses++
+	c.mu.Unlock()
 	return nil, false
 }
 
@@ -161,11 +256,7 @@ func (c *Cache) Set(key string, data []byte, ttl time.Duration) {
 	// Store in memory
 	if c.config.Type == CacheTypeMemory || c.config.Type == CacheTypeBoth {
 		c.mu.Lock()
-		// Evict if at max size
-		if len(c.memory) >= c.config.MaxSize {
-			c.evictOldest()
-		}
-		c.memory[key] = entry
+		c.store(key, *entry)
 		c.mu.Unlock()
 	}
 
@@ -178,7 +269,9 @@ func (c *Cache) Set(key string, data []byte, ttl time.Duration) {
 // Delete removes a value from the cache
 func (c *Cache) Delete(key string) {
 	c.mu.Lock()
-	delete(c.memory, key)
+	if el, ok := c.memory[key]; ok {
+		c.remove(el)
+	}
 	c.mu.Unlock()
 
 	if c.config.Type == CacheTypeDisk || c.config.Type == CacheTypeBoth {
@@ -189,7 +282,9 @@ func (c *Cache) Delete(key string) {
 // Clear removes all entries from the cache
 func (c *Cache) Clear() {
 	c.mu.Lock()
-	c.memory = make(map[string]*cacheEntry)
+	c.memory = make(map[string]*list.Element)
+	c.lru.Init()
+	c.bytes = 0
 	c.mu.Unlock()
 
 	if c.config.Type == CacheTypeDisk || c.config.Type == CacheTypeBoth {
@@ -198,58 +293,212 @@ func (c *Cache) Clear() {
 	}
 }
 
-// evictOldest removes the oldest entry from memory cache
-func (c *Cache) evictOldest() {
-	var oldestKey string
-	var oldestTime time.Time
+// Stats returns the cache's counters and current memory size
+func (c *Cache) Stats() CacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stats := c.stats
+	stats.Entries = len(c.memory)
+	stats.Bytes = c.bytes
+	return stats
+}
 
-	for key, entry := range c.memory {
-		if oldestKey == "" || entry.ExpiresAt.Before(oldestTime) {
-			oldestKey = key
-			oldestTime = entry.ExpiresAt
-		}
+// store puts an entry at the front of the memory cache, evicting the least
+// recently used entries to make room; callers must hold c.mu
+func (c *Cache) store(key string, entry cacheEntry) {
+	if el, ok := c.memory[key]; ok {
+		c.remove(el)
 	}
-
-	if oldestKey != "" {
-		delete(c.memory, oldestKey)
+	e := &memoryEntry{key: key, cacheEntry: entry}
+	if c.config.MaxBytes > 0 && e.size() > c.config.MaxBytes {
+		return
 	}
+	for c.lru.Len() > 0 && ((c.config.MaxSize > 0 && c.lru.Len() >= c.config.MaxSize) ||
+		(c.config.MaxBytes > 0 && c.bytes+e.size() > c.config.MaxBytes)) {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+	c.memory[key] = c.lru.PushFront(e)
+	c.bytes += e.size()
 }
 
-// getFromDisk retrieves a value from disk cache
-func (c *Cache) getFromDisk(key string) ([]byte, bool) {
-	path := filepath.Join(c.config.Directory, key+".json")
+// remove drops an element from the memory cache; callers must hold c.mu
+func (c *Cache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*memoryEntry)
+	delete(c.memory, e.key)
+	c.bytes -= e.size()
+}
+
+// getFromDisk retrieves an entry from disk cache
+func (c *Cache) getFromDisk(key string) (cacheEntry, bool) {
+	path := c.diskPath(key)
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is sanitized (cache directory)
 	if err != nil {
-		return nil, false
+		return cacheEntry{}, false
 	}
 
 	var entry cacheEntry
 	if err := json.Unmarshal(data, &entry); err != nil {
-		return nil, false
+		// A corrupt file would otherwise be read and rejected on every lookup
+		c.removeFromDisk(path)
+		return cacheEntry{}, false
 	}
 
 	if time.Now().After(entry.ExpiresAt) {
 		_ = os.Remove(path)
-		return nil, false
+		return cacheEntry{}, false
 	}
 
-	return entry.Data, true
+	return entry, true
 }
 
-// saveToDisk saves a value to disk cache
+// saveToDisk saves a value to disk cache. It writes a temporary file and
+// renames it into place, so readers never see a partly written entry.
 func (c *Cache) saveToDisk(key string, entry *cacheEntry) {
-	path := filepath.Join(c.config.Directory, key+".json")
+	path := c.diskPath(key)
 	data, err := json.Marshal(entry)
 	if err != nil {
 		return
 	}
-	_ = os.WriteFile(path, data, 0o644) //nolint:gosec // G306: 0644 permissions acceptable for cache files
+	f, err := os.CreateTemp(c.config.Directory, diskName(key)+".*"+tempSuffix)
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0o644) //nolint:gosec // G302: 0644 permissions acceptable for cache files
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
 }
 
 // deleteFromDisk removes a value from disk cache
 func (c *Cache) deleteFromDisk(key string) {
-	path := filepath.Join(c.config.Directory, key+".json")
-	_ = os.Remove(path)
+	_ = os.Remove(c.diskPath(key))
+}
+
+// diskPath returns the file an entry for key is stored in
+func (c *Cache) diskPath(key string) string {
+	return filepath.Join(c.config.Directory, diskName(key)+".json")
+}
+
+// diskName maps key to a file name that is valid on every platform. Keys
+// such as "quote:<hash>" contain characters Windows does not allow in file
+// names, so anything other than letters, digits, '-' and '.' becomes '_'.
+func diskName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// tempSuffix marks disk cache files that are still being written
+const tempSuffix = ".tmp"
+
+// staleTempAge is how old a temporary file must be for a sweep to treat it
+// as left behind by an interrupted write
+const staleTempAge = time.Minute
+
+// janitor sweeps the disk cache every interval until Close
+func (c *Cache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			_ = c.Sweep()
+		}
+	}
+}
+
+// Sweep removes expired and corrupt entries from the disk cache, along with
+// temporary files left by interrupted writes, then removes the least
+// recently written entries until the rest fit in MaxDiskBytes
+func (c *Cache) Sweep() error {
+	if !c.onDisk() {
+		return nil
+	}
+	entries, err := os.ReadDir(c.config.Directory)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	type diskFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []diskFile
+	var total int64
+	now := time.Now()
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		path := filepath.Join(c.config.Directory, e.Name())
+		switch {
+		case strings.HasSuffix(e.Name(), tempSuffix):
+			if now.Sub(info.ModTime()) > staleTempAge {
+				c.removeFromDisk(path)
+			}
+			continue
+		case !strings.HasSuffix(e.Name(), ".json"):
+			continue
+		}
+
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path is in the cache directory
+		if err != nil {
+			continue
+		}
+		var entry struct {
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+		if json.Unmarshal(data, &entry) != nil || now.After(entry.ExpiresAt) {
+			c.removeFromDisk(path)
+			continue
+		}
+		files = append(files, diskFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+	}
+
+	if c.config.MaxDiskBytes <= 0 || total <= c.config.MaxDiskBytes {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files {
+		if total <= c.config.MaxDiskBytes {
+			break
+		}
+		c.removeFromDisk(f.path)
+		total -= f.size
+	}
+	return nil
+}
+
+// removeFromDisk removes a disk cache file, counting it as swept
+func (c *Cache) removeFromDisk(path string) {
+	if os.Remove(path) != nil {
+		return
+	}
+	c.mu.Lock()
+	c.stats.Swept++
+	c.mu.Unlock()
 }
 
 // CacheKey generates a cache key for API requests
@@ -258,6 +507,38 @@ func CacheKey(endpoint string, params map[string]string) string {
 	return cache.generateKey(endpoint, params)
 }
 
+// DataType classifies responses so each kind can be cached for its own TTL
+type DataType string
+
+// Data types of cached responses
+const (
+	DataQuote      DataType = "quote"
+	DataHistory    DataType = "history"
+	DataInfo       DataType = "info"
+	DataHolders    DataType = "holders"
+	DataAnalysis   DataType = "analysis"
+	DataSearch     DataType = "search"
+	DataNews       DataType = "news"
+	DataOptions    DataType = "options"
+	DataFinancials DataType = "financials"
+	DataOther      DataType = "other" // Cached for the store's default TTL
+)
+
+// DefaultTTLs returns the TTL of each data type
+func DefaultTTLs() map[DataType]time.Duration {
+	return map[DataType]time.Duration{
+		DataQuote:      TTLQuote,
+		DataHistory:    TTLHistory,
+		DataInfo:       TTLInfo,
+		DataHolders:    TTLHolders,
+		DataAnalysis:   TTLAnalysis,
+		DataSearch:     TTLSearch,
+		DataNews:       TTLNews,
+		DataOptions:    TTLOptions,
+		DataFinancials: TTLFinancials,
+	}
+}
+
 // TTL constants for different data types
 const (
 	TTLQuote      = 1 * time.Minute  // Quotes are short-lived
@@ -270,3 +551,122 @@ const (
 	TTLOptions    = 5 * time.Minute  // Options data is time-sensitive
 	TTLFinancials = 24 * time.Hour   // Financial statements are quarterly
 )
+
+// WithCache caches successful GET responses in store, keyed by data type,
+// endpoint, and query parameters. Each entry lives for its data type's TTL
+// from DefaultTTLs unless changed with WithCacheTTL: quotes and intraday
+// charts for TTLQuote, daily charts for TTLHistory, and so on.
+func WithCache(store CacheStore) ClientOption {
+	return func(c *Client) {
+		c.cache = store
+	}
+}
+
+// WithCacheTTL sets how long responses of a data type are cached. A
+// negative ttl disables caching for the type.
+func WithCacheTTL(dataType DataType, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if c.cacheTTLs == nil {
+			c.cacheTTLs = DefaultTTLs()
+		}
+		c.cacheTTLs[dataType] = ttl
+	}
+}
+
+// bypassCacheKey marks contexts whose requests skip cached responses
+type bypassCacheKey struct{}
+
+// BypassCache returns a context whose requests always go to Yahoo, for a
+// forced refresh. Their responses still replace cached entries.
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// cacheBypassed reports whether ctx comes from BypassCache
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(bool)
+	return bypass
+}
+
+// cacheKey keys a request by data type, endpoint, and parameters, leaving
+// out the crumb since it changes between sessions. The data type prefix
+// lets shared stores such as Redis be inspected or flushed by kind.
+func cacheKey(dataType DataType, endpoint string, params url.Values) string {
+	query := make(url.Values, len(params))
+	for k, v := range params {
+		if k != "crumb" {
+			query[k] = v
+		}
+	}
+	return string(dataType) + ":" + hashKey(endpoint, query.Encode())
+}
+
+// cacheTTL returns how long responses of dataType may be cached. Zero means
+// the store's default TTL.
+func (c *Client) cacheTTL(dataType DataType) time.Duration {
+	if c.cacheTTLs != nil {
+		return c.cacheTTLs[dataType]
+	}
+	return DefaultTTLs()[dataType]
+}
+
+// cacheDataType classifies a response by endpoint and parameters
+func (c *Client) cacheDataType(endpoint string, params url.Values) DataType {
+	switch {
+	case strings.HasPrefix(endpoint, c.endpoints.Chart):
+		// Intraday bars keep changing during the session
+		if i := params.Get("interval"); strings.HasSuffix(i, "m") || strings.HasSuffix(i, "h") {
+			return DataQuote
+		}
+		return DataHistory
+	case strings.HasPrefix(endpoint, c.endpoints.QuoteSummary):
+		// A response is as short-lived as its shortest-lived module
+		dataType := DataInfo
+		for _, m := range strings.Split(params.Get("modules"), ",") {
+			if t := moduleDataType(m); c.cacheTTL(t) < c.cacheTTL(dataType) {
+				dataType = t
+			}
+		}
+		return dataType
+	case strings.HasPrefix(endpoint, c.endpoints.Quote), strings.HasPrefix(endpoint, c.endpoints.QuoteFallback),
+		strings.HasPrefix(endpoint, c.endpoints.MarketSummary), strings.HasPrefix(endpoint, c.endpoints.MarketTime):
+		return DataQuote
+	case strings.HasPrefix(endpoint, c.endpoints.Options):
+		return DataOptions
+	case strings.HasPrefix(endpoint, c.endpoints.Fundamentals):
+		return DataFinancials
+	case strings.HasPrefix(endpoint, c.endpoints.Search):
+		if n := params.Get("newsCount"); n != "" && n != "0" {
+			return DataNews
+		}
+		return DataSearch
+	case strings.HasPrefix(endpoint, c.endpoints.Lookup):
+		return DataSearch
+	case strings.HasPrefix(endpoint, c.endpoints.News):
+		return DataNews
+	case strings.HasPrefix(endpoint, c.endpoints.Calendar):
+		return DataAnalysis
+	case strings.HasPrefix(endpoint, c.endpoints.Sector), strings.HasPrefix(endpoint, c.endpoints.Industry),
+		strings.HasPrefix(endpoint, c.endpoints.Recommendations):
+		return DataInfo
+	}
+	return DataOther
+}
+
+// moduleDataType returns the data type of a quoteSummary module
+func moduleDataType(module string) DataType {
+	switch module {
+	case ModulePrice, ModuleSummaryDetail, ModuleFinancialData:
+		return DataQuote
+	case ModuleUpgradeDowngradeHistory, ModuleRecommendationTrend, ModuleEarnings, ModuleEarningsHistory,
+		ModuleEarningsTrend, ModuleCalendarEvents, ModuleIndustryTrend, ModuleIndexTrend, ModuleSectorTrend:
+		return DataAnalysis
+	case ModuleInstitutionOwnership, ModuleFundOwnership, ModuleMajorDirectHolders, ModuleMajorHoldersBreakdown,
+		ModuleInsiderTransactions, ModuleInsiderHolders, ModuleNetSharePurchaseActivity:
+		return DataHolders
+	case ModuleIncomeStatementHistory, ModuleIncomeStatementHistoryQuarterly, ModuleBalanceSheetHistory,
+		ModuleBalanceSheetHistoryQuarterly, ModuleCashFlowStatementHistory, ModuleCashFlowStatementHistoryQuarterly:
+		return DataFinancials
+	}
+	return DataInfo
+}
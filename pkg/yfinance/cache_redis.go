@@ -0,0 +1,151 @@
+package yfinance
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCacheConfig configures a RedisCache
+type RedisCacheConfig struct {
+	// URL is a redis:// or rediss:// connection URL. When set it takes
+	// precedence over Addr, Username, Password, DB, and TLS.
+	URL string
+
+	Addr     string // host:port, default localhost:6379
+	Username string
+	Password string
+	DB       int
+	TLS      *tls.Config // Enables TLS when set
+
+	PoolSize     int           // Default 10 per CPU
+	DialTimeout  time.Duration // Default 5s
+	ReadTimeout  time.Duration // Default 3s
+	WriteTimeout time.Duration // Default ReadTimeout
+
+	Timeout    time.Duration // Bound on each cache operation, default 2s
+	KeyPrefix  string        // Namespace for keys, default "yfinance:"
+	DefaultTTL time.Duration // TTL when Set is given none, default 5m
+}
+
+// RedisCache is a CacheStore backed by Redis, so several processes can share
+// cached responses. Entries expire through Redis TTLs.
+type RedisCache struct {
+	client  *redis.Client
+	prefix  string
+	ttl     time.Duration
+	timeout time.Duration
+}
+
+var _ CacheStore = (*RedisCache)(nil)
+
+// NewRedisCache connects to Redis with the given configuration. Connections
+// are made lazily; use Ping to check the server is reachable.
+func NewRedisCache(config RedisCacheConfig) (*RedisCache, error) {
+	opts := &redis.Options{
+		Addr:      config.Addr,
+		Username:  config.Username,
+		Password:  config.Password,
+		DB:        config.DB,
+		TLSConfig: config.TLS,
+	}
+	if config.URL != "" {
+		var err error
+		if opts, err = redis.ParseURL(config.URL); err != nil {
+			return nil, fmt.Errorf("redis cache: %w", err)
+		}
+	}
+	opts.PoolSize = config.PoolSize
+	opts.DialTimeout = config.DialTimeout
+	opts.ReadTimeout = config.ReadTimeout
+	opts.WriteTimeout = config.WriteTimeout
+
+	c := &RedisCache{
+		client:  redis.NewClient(opts),
+		prefix:  config.KeyPrefix,
+		ttl:     config.DefaultTTL,
+		timeout: config.Timeout,
+	}
+	if c.prefix == "" {
+		c.prefix = "yfinance:"
+	}
+	if c.ttl <= 0 {
+		c.ttl = 5 * time.Minute
+	}
+	if c.timeout <= 0 {
+		c.timeout = 2 * time.Second
+	}
+	return c, nil
+}
+
+// Ping checks that the server is reachable
+func (c *RedisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// Close closes the connection pool
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
+
+// Get retrieves a value from the cache
+func (c *RedisCache) Get(key string) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set stores a value in the cache
+func (c *RedisCache) Set(key string, data []byte, ttl time.Duration) {
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_ = c.client.Set(ctx, c.prefix+key, data, ttl).Err()
+}
+
+// Delete removes a value from the cache
+func (c *RedisCache) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_ = c.client.Del(ctx, c.prefix+key).Err()
+}
+
+// Clear removes every key under the cache's prefix, leaving the rest of the
+// database alone
+func (c *RedisCache) Clear() {
+	_ = c.ClearType(context.Background(), "")
+}
+
+// ClearType removes the entries of one data type, or all entries if
+// dataType is empty
+func (c *RedisCache) ClearType(ctx context.Context, dataType DataType) error {
+	match := c.prefix + "*"
+	if dataType != "" {
+		match = c.prefix + string(dataType) + ":*"
+	}
+
+	var errs []error
+	iter := c.client.Scan(ctx, 0, match, 500).Iterator()
+	for iter.Next(ctx) {
+		if err := c.client.Del(ctx, iter.Val()).Err(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
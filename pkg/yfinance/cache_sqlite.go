@@ -0,0 +1,335 @@
+package yfinance
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // Pure Go driver registered as "sqlite"
+)
+
+// SQLiteCacheConfig configures a SQLiteCache
+type SQLiteCacheConfig struct {
+	Path       string        // Database file, created with its directory if missing
+	Timeout    time.Duration // Bound on each cache operation, default 2s
+	DefaultTTL time.Duration // TTL when Set is given none, default 5m
+}
+
+// SQLiteCache is a CacheStore backed by a SQLite database file. Besides
+// cached responses it keeps every chart bar and quote passing through it in
+// tables, so previously fetched data can be queried offline with Bars and
+// Quotes, and history extended incrementally with SyncHistory. Unlike
+// responses, stored bars and quotes do not expire.
+type SQLiteCache struct {
+	db      *sql.DB
+	ttl     time.Duration
+	timeout time.Duration
+}
+
+var _ CacheStore = (*SQLiteCache)(nil)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS responses (
+	key        TEXT PRIMARY KEY,
+	data       BLOB NOT NULL,
+	expires_at INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS bars (
+	symbol    TEXT NOT NULL,
+	interval  TEXT NOT NULL,
+	ts        INTEGER NOT NULL,
+	open      REAL NOT NULL,
+	high      REAL NOT NULL,
+	low       REAL NOT NULL,
+	close     REAL NOT NULL,
+	adj_close REAL NOT NULL,
+	volume    INTEGER NOT NULL,
+	PRIMARY KEY (symbol, interval, ts)
+);
+CREATE TABLE IF NOT EXISTS quotes (
+	symbol TEXT NOT NULL,
+	ts     INTEGER NOT NULL,
+	price  REAL NOT NULL,
+	data   TEXT NOT NULL,
+	PRIMARY KEY (symbol, ts)
+);`
+
+// NewSQLiteCache opens or creates the database at config.Path
+func NewSQLiteCache(config SQLiteCacheConfig) (*SQLiteCache, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("sqlite cache: no path")
+	}
+	if dir := filepath.Dir(config.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("sqlite cache: %w", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", "file:"+config.Path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("sqlite cache: %w", err)
+	}
+	// SQLite has a single writer; one connection avoids busy errors
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("sqlite cache: %w", err)
+	}
+
+	c := &SQLiteCache{db: db, ttl: config.DefaultTTL, timeout: config.Timeout}
+	if c.ttl <= 0 {
+		c.ttl = 5 * time.Minute
+	}
+	if c.timeout <= 0 {
+		c.timeout = 2 * time.Second
+	}
+	return c, nil
+}
+
+// Close closes the database
+func (c *SQLiteCache) Close() error {
+	return c.db.Close()
+}
+
+// Get retrieves a value from the cache
+func (c *SQLiteCache) Get(key string) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var data []byte
+	err := c.db.QueryRowContext(ctx, `SELECT data FROM responses WHERE key = ? AND expires_at > ?`,
+		key, time.Now().UnixMilli()).Scan(&data)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set stores a value in the cache. Chart and quote responses are also
+// added to the bar and quote tables.
+func (c *SQLiteCache) Set(key string, data []byte, ttl time.Duration) {
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_, _ = c.db.ExecContext(ctx, `INSERT OR REPLACE INTO responses (key, data, expires_at) VALUES (?, ?, ?)`,
+		key, data, time.Now().Add(ttl).UnixMilli())
+	if strings.HasPrefix(key, string(DataHistory)+":") || strings.HasPrefix(key, string(DataQuote)+":") {
+		_ = c.index(ctx, data)
+	}
+}
+
+// Delete removes a value from the cache
+func (c *SQLiteCache) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_, _ = c.db.ExecContext(ctx, `DELETE FROM responses WHERE key = ?`, key)
+}
+
+// Clear removes every cached response. Stored bars and quotes are kept.
+func (c *SQLiteCache) Clear() {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_, _ = c.db.ExecContext(ctx, `DELETE FROM responses`)
+}
+
+// index stores the bars of a chart response or the quotes of a quote
+// response. Other responses are ignored.
+func (c *SQLiteCache) index(ctx context.Context, data []byte) error {
+	var response struct {
+		chartResponse
+		QuoteResponse struct {
+			Result []Quote `json:"result"`
+		} `json:"quoteResponse"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+	for i := range response.Chart.Result {
+		result := &response.Chart.Result[i]
+		if result.Meta.Symbol == "" || result.Meta.DataGranularity == "" {
+			continue
+		}
+		if err := c.SaveBars(ctx, result.Meta.Symbol, Interval(result.Meta.DataGranularity), result.bars(time.UTC)); err != nil {
+			return err
+		}
+	}
+	for i := range response.QuoteResponse.Result {
+		if err := c.SaveQuote(ctx, &response.QuoteResponse.Result[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveBars stores bars of symbol at interval, replacing stored bars with
+// the same timestamps
+func (c *SQLiteCache) SaveBars(ctx context.Context, symbol string, interval Interval, bars []Bar) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite cache: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO bars
+		(symbol, interval, ts, open, high, low, close, adj_close, volume) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("sqlite cache: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	symbol = strings.ToUpper(symbol)
+	for _, b := range bars {
+		if _, err := stmt.ExecContext(ctx, symbol, string(interval), b.Timestamp.Unix(),
+			b.Open, b.High, b.Low, b.Close, b.AdjClose, b.Volume); err != nil {
+			return fmt.Errorf("sqlite cache: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite cache: %w", err)
+	}
+	return nil
+}
+
+// Bars returns the stored bars of symbol at interval from start up to but
+// not including end, oldest first. A zero start or end leaves that side
+// unbounded.
+func (c *SQLiteCache) Bars(ctx context.Context, symbol string, interval Interval, start, end time.Time) ([]Bar, error) {
+	from, to := timeRange(start, end)
+	rows, err := c.db.QueryContext(ctx, `SELECT ts, open, high, low, close, adj_close, volume FROM bars
+		WHERE symbol = ? AND interval = ? AND ts >= ? AND ts < ? ORDER BY ts`,
+		strings.ToUpper(symbol), string(interval), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite cache: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var bars []Bar
+	for rows.Next() {
+		var b Bar
+		var ts int64
+		if err := rows.Scan(&ts, &b.Open, &b.High, &b.Low, &b.Close, &b.AdjClose, &b.Volume); err != nil {
+			return nil, fmt.Errorf("sqlite cache: %w", err)
+		}
+		b.Timestamp = time.Unix(ts, 0)
+		bars = append(bars, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite cache: %w", err)
+	}
+	return bars, nil
+}
+
+// FirstBarTime returns the timestamp of the oldest stored bar of symbol at
+// interval, or false if there is none
+func (c *SQLiteCache) FirstBarTime(ctx context.Context, symbol string, interval Interval) (time.Time, bool, error) {
+	return c.barTime(ctx, "MIN", symbol, interval)
+}
+
+// LastBarTime returns the timestamp of the newest stored bar of symbol at
+// interval, or false if there is none
+func (c *SQLiteCache) LastBarTime(ctx context.Context, symbol string, interval Interval) (time.Time, bool, error) {
+	return c.barTime(ctx, "MAX", symbol, interval)
+}
+
+// barTime returns the timestamp picked by the aggregate agg, MIN or MAX,
+// over the stored bars of symbol at interval
+func (c *SQLiteCache) barTime(ctx context.Context, agg, symbol string, interval Interval) (time.Time, bool, error) {
+	var ts sql.NullInt64
+	err := c.db.QueryRowContext(ctx, `SELECT `+agg+`(ts) FROM bars WHERE symbol = ? AND interval = ?`, //nolint:gosec // G202: agg is MIN or MAX
+		strings.ToUpper(symbol), string(interval)).Scan(&ts)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("sqlite cache: %w", err)
+	}
+	if !ts.Valid {
+		return time.Time{}, false, nil
+	}
+	return time.Unix(ts.Int64, 0), true, nil
+}
+
+// SaveQuote stores a quote snapshot, keyed by symbol and regular market time
+func (c *SQLiteCache) SaveQuote(ctx context.Context, quote *Quote) error {
+	if quote.Symbol == "" {
+		return nil
+	}
+	data, err := json.Marshal(quote)
+	if err != nil {
+		return fmt.Errorf("sqlite cache: %w", err)
+	}
+	ts := quote.RegularMarketTime
+	if ts == 0 {
+		ts = time.Now().Unix()
+	}
+	_, err = c.db.ExecContext(ctx, `INSERT OR REPLACE INTO quotes (symbol, ts, price, data) VALUES (?, ?, ?, ?)`,
+		strings.ToUpper(quote.Symbol), ts, quote.RegularMarketPrice, data)
+	if err != nil {
+		return fmt.Errorf("sqlite cache: %w", err)
+	}
+	return nil
+}
+
+// Quotes returns the stored quote snapshots of symbol from start up to but
+// not including end, oldest first. A zero start or end leaves that side
+// unbounded.
+func (c *SQLiteCache) Quotes(ctx context.Context, symbol string, start, end time.Time) ([]Quote, error) {
+	from, to := timeRange(start, end)
+	rows, err := c.db.QueryContext(ctx, `SELECT data FROM quotes WHERE symbol = ? AND ts >= ? AND ts < ? ORDER BY ts`,
+		strings.ToUpper(symbol), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite cache: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var quotes []Quote
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("sqlite cache: %w", err)
+		}
+		var q Quote
+		if err := json.Unmarshal(data, &q); err != nil {
+			return nil, fmt.Errorf("sqlite cache: %w", err)
+		}
+		quotes = append(quotes, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite cache: %w", err)
+	}
+	return quotes, nil
+}
+
+// SyncHistory brings the stored bars of ticker at interval up to date and
+// returns all of them. Only bars from the newest stored one onwards are
+// fetched, the newest being refetched since it may have been incomplete.
+// Without stored bars the full history is fetched, or as much as Yahoo
+// serves for intraday intervals.
+func (c *SQLiteCache) SyncHistory(ctx context.Context, ticker *Ticker, interval Interval) ([]Bar, error) {
+	if interval == "" {
+		interval = Interval1d
+	}
+	if _, err := syncBars(ctx, c, ticker, HistoryParams{Interval: interval}); err != nil {
+		return nil, err
+	}
+	return c.Bars(ctx, ticker.Symbol, interval, time.Time{}, time.Time{})
+}
+
+// timeRange converts start and end to unix seconds, unbounded when zero
+func timeRange(start, end time.Time) (from, to int64) {
+	from, to = -1<<63, 1<<63-1
+	if !start.IsZero() {
+		from = start.Unix()
+	}
+	if !end.IsZero() {
+		to = end.Unix()
+	}
+	return from, to
+}
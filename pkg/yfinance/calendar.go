@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -21,7 +22,7 @@ func GetEarningsCalendar(ctx context.Context, params CalendarParams) ([]Earnings
 // GetEarningsCalendarWithClient fetches earnings calendar using a specific client
 func GetEarningsCalendarWithClient(ctx context.Context, client *Client, params CalendarParams) ([]EarningsEvent, error) {
 	queryParams := buildCalendarParams(params, "earnings")
-	data, err := client.Get(ctx, CalendarURL, queryParams)
+	data, err := client.Get(ctx, client.endpoints.Calendar, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +41,7 @@ func GetEarningsCalendarWithClient(ctx context.Context, client *Client, params C
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, parseError(client.endpoints.Calendar, queryParams, data, "response", err)
 	}
 
 	var events []EarningsEvent
@@ -56,6 +57,113 @@ func GetEarningsCalendarWithClient(ctx context.Context, client *Client, params C
 	return events, nil
 }
 
+// EarningsDates fetches the ticker's most recent earnings announcements,
+// newest first, including scheduled ones. limit defaults to 12 and is at
+// most 100.
+func (t *Ticker) EarningsDates(ctx context.Context, limit int) ([]EarningsDate, error) {
+	ctx, span := t.startSpan(ctx, "EarningsDates")
+	defer span.End()
+
+	if limit <= 0 {
+		limit = 12
+	}
+	limit = min(limit, 100)
+
+	body := map[string]interface{}{
+		"size": limit,
+		"query": map[string]interface{}{
+			"operator": "and",
+			"operands": []interface{}{
+				map[string]interface{}{"operator": "eq", "operands": []interface{}{"ticker", t.Symbol}},
+				map[string]interface{}{"operator": "or", "operands": []interface{}{
+					map[string]interface{}{"operator": "eq", "operands": []interface{}{"eventtype", "EAD"}},
+					map[string]interface{}{"operator": "eq", "operands": []interface{}{"eventtype", "ERA"}},
+				}},
+			},
+		},
+		"sortField":     "startdatetime",
+		"sortType":      "DESC",
+		"entityIdType":  "earnings",
+		"includeFields": []string{"startdatetime", "timeZoneShortName", "epsestimate", "epsactual", "epssurprisepct"},
+	}
+	params := url.Values{}
+	params.Set("lang", "en-US")
+	params.Set("region", "US")
+
+	data, err := t.client.Post(ctx, t.client.endpoints.Calendar, params, body)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		Finance struct {
+			Result []struct {
+				Documents []struct {
+					Columns []struct {
+						ID string `json:"id"`
+					} `json:"columns"`
+					Rows [][]interface{} `json:"rows"`
+				} `json:"documents"`
+			} `json:"result"`
+			Error *struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"finance"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(t.client.endpoints.Calendar, params, data, "earnings dates", err))
+	}
+
+	if response.Finance.Error != nil {
+		return nil, NewSymbolError(t.Symbol, &APIError{
+			Code:        response.Finance.Error.Code,
+			Description: response.Finance.Error.Description,
+		})
+	}
+
+	if len(response.Finance.Result) == 0 || len(response.Finance.Result[0].Documents) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	doc := response.Finance.Result[0].Documents[0]
+	columns := make(map[string]int, len(doc.Columns))
+	for i, c := range doc.Columns {
+		columns[c.ID] = i
+	}
+	cell := func(row []interface{}, id string) interface{} {
+		if i, ok := columns[id]; ok && i < len(row) {
+			return row[i]
+		}
+		return nil
+	}
+	number := func(row []interface{}, id string) *float64 {
+		if v, ok := cell(row, id).(float64); ok {
+			return &v
+		}
+		return nil
+	}
+
+	dates := make([]EarningsDate, 0, len(doc.Rows))
+	for _, row := range doc.Rows {
+		start, _ := cell(row, "startdatetime").(string)
+		date, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			continue
+		}
+		tz, _ := cell(row, "timeZoneShortName").(string)
+		dates = append(dates, EarningsDate{
+			Date:            date,
+			Timezone:        tz,
+			EPSEstimate:     number(row, "epsestimate"),
+			ReportedEPS:     number(row, "epsactual"),
+			SurprisePercent: number(row, "epssurprisepct"),
+		})
+	}
+	return dates, nil
+}
+
 // GetIPOCalendar fetches upcoming IPO events
 func GetIPOCalendar(ctx context.Context, params CalendarParams) ([]IPOEvent, error) {
 	client, err := getDefaultClient()
@@ -68,7 +176,7 @@ func GetIPOCalendar(ctx context.Context, params CalendarParams) ([]IPOEvent, err
 // GetIPOCalendarWithClient fetches IPO calendar using a specific client
 func GetIPOCalendarWithClient(ctx context.Context, client *Client, params CalendarParams) ([]IPOEvent, error) {
 	queryParams := buildCalendarParams(params, "ipo")
-	data, err := client.Get(ctx, CalendarURL, queryParams)
+	data, err := client.Get(ctx, client.endpoints.Calendar, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +195,7 @@ func GetIPOCalendarWithClient(ctx context.Context, client *Client, params Calend
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, parseError(client.endpoints.Calendar, queryParams, data, "response", err)
 	}
 
 	var events []IPOEvent
@@ -110,7 +218,7 @@ func GetSplitsCalendar(ctx context.Context, params CalendarParams) ([]SplitEvent
 		return nil, err
 	}
 	queryParams := buildCalendarParams(params, "splits")
-	data, err := client.Get(ctx, CalendarURL, queryParams)
+	data, err := client.Get(ctx, client.endpoints.Calendar, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +236,7 @@ func GetSplitsCalendar(ctx context.Context, params CalendarParams) ([]SplitEvent
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, parseError(client.endpoints.Calendar, queryParams, data, "response", err)
 	}
 
 	var events []SplitEvent
@@ -144,6 +252,136 @@ func GetSplitsCalendar(ctx context.Context, params CalendarParams) ([]SplitEvent
 	return events, nil
 }
 
+// GetDividendsCalendar fetches ex-dividend and payment dates for the given
+// symbols, keeping events whose ex-dividend date falls within params.Start
+// and params.End. Yahoo has no market-wide dividend calendar, so symbols are
+// required and each one is looked up through the calendarEvents module.
+func GetDividendsCalendar(ctx context.Context, symbols []string, params CalendarParams) ([]DividendEvent, error) {
+	if len(symbols) == 0 {
+		return nil, ErrInvalidSymbol
+	}
+	if params.Start.IsZero() {
+		params.Start = time.Now()
+	}
+	if params.End.IsZero() {
+		params.End = params.Start.AddDate(0, 0, 7)
+	}
+
+	// Symbols without calendar data (funds, indices) fail individually;
+	// only give up when nothing could be fetched
+	infos, err := DownloadInfo(ctx, symbols, ModuleCalendarEvents)
+	if err != nil && len(infos) == 0 {
+		return nil, err
+	}
+
+	start := params.Start.Truncate(24 * time.Hour).Unix()
+	end := params.End.Truncate(24*time.Hour).AddDate(0, 0, 1).Unix()
+
+	var events []DividendEvent
+	for sym, info := range infos {
+		if info == nil || info.CalendarEvents == nil || info.CalendarEvents.Dividend == nil {
+			continue
+		}
+		div := info.CalendarEvents.Dividend
+		if div.ExDividendDate < start || div.ExDividendDate >= end {
+			continue
+		}
+		events = append(events, DividendEvent{
+			Symbol:         sym,
+			ExDividendDate: div.ExDividendDate,
+			DividendDate:   div.DividendDate,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].ExDividendDate != events[j].ExDividendDate {
+			return events[i].ExDividendDate < events[j].ExDividendDate
+		}
+		return events[i].Symbol < events[j].Symbol
+	})
+	return events, nil
+}
+
+// Calendar fetches the ticker's next earnings date and its estimates, and
+// its latest ex-dividend and payment dates. It returns ErrNoData for
+// securities without calendar events, such as indices and currencies.
+func (t *Ticker) Calendar(ctx context.Context) (*TickerCalendar, error) {
+	ctx, span := t.startSpan(ctx, "Calendar")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
+	params := buildModulesParams(ModuleCalendarEvents)
+
+	data, err := t.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		QuoteSummary struct {
+			Result []struct {
+				CalendarEvents *struct {
+					Earnings struct {
+						EarningsDate           []RawValue `json:"earningsDate"`
+						EarningsCallDate       []RawValue `json:"earningsCallDate"`
+						IsEarningsDateEstimate bool       `json:"isEarningsDateEstimate"`
+						EarningsAverage        RawValue   `json:"earningsAverage"`
+						EarningsLow            RawValue   `json:"earningsLow"`
+						EarningsHigh           RawValue   `json:"earningsHigh"`
+						RevenueAverage         RawValue   `json:"revenueAverage"`
+						RevenueLow             RawValue   `json:"revenueLow"`
+						RevenueHigh            RawValue   `json:"revenueHigh"`
+					} `json:"earnings"`
+					ExDividendDate RawValue `json:"exDividendDate"`
+					DividendDate   RawValue `json:"dividendDate"`
+				} `json:"calendarEvents"`
+			} `json:"result"`
+		} `json:"quoteSummary"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "calendar events", err))
+	}
+
+	if len(response.QuoteSummary.Result) == 0 || response.QuoteSummary.Result[0].CalendarEvents == nil {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	events := response.QuoteSummary.Result[0].CalendarEvents
+	earnings := events.Earnings
+	return &TickerCalendar{
+		EarningsDates:     rawDates(earnings.EarningsDate),
+		EarningsCallDates: rawDates(earnings.EarningsCallDate),
+		EarningsEstimated: earnings.IsEarningsDateEstimate,
+		EarningsAverage:   earnings.EarningsAverage.Raw,
+		EarningsLow:       earnings.EarningsLow.Raw,
+		EarningsHigh:      earnings.EarningsHigh.Raw,
+		RevenueAverage:    earnings.RevenueAverage.Raw,
+		RevenueLow:        earnings.RevenueLow.Raw,
+		RevenueHigh:       earnings.RevenueHigh.Raw,
+		ExDividendDate:    rawDate(events.ExDividendDate),
+		DividendDate:      rawDate(events.DividendDate),
+	}, nil
+}
+
+// rawDate converts a raw unix timestamp to UTC, leaving zero for none
+func rawDate(v RawValue) time.Time {
+	if v.Raw == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(v.Raw), 0).UTC()
+}
+
+// rawDates converts raw unix timestamps to UTC, skipping empty ones
+func rawDates(values []RawValue) []time.Time {
+	var dates []time.Time
+	for _, v := range values {
+		if v.Raw != 0 {
+			dates = append(dates, rawDate(v))
+		}
+	}
+	return dates
+}
+
 func buildCalendarParams(params CalendarParams, calendarType string) url.Values {
 	queryParams := url.Values{}
 	if params.Start.IsZero() {
@@ -0,0 +1,90 @@
+package yfinance
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// intradayLimits returns how long a range of an intraday interval Yahoo
+// serves per request and how far back it keeps bars, or zeros for daily
+// and longer intervals. Both are a day short of Yahoo's limits to allow
+// for clock skew.
+func intradayLimits(interval Interval) (perRequest, lookback time.Duration) {
+	const day = 24 * time.Hour
+	switch interval {
+	case Interval1m:
+		return 7 * day, 29 * day
+	case Interval2m, Interval5m, Interval15m, Interval30m, Interval90m:
+		return 59 * day, 59 * day
+	case Interval60m, Interval1h:
+		return 729 * day, 729 * day
+	}
+	return 0, 0
+}
+
+// historyChunk is the date range of one chart request
+type historyChunk struct {
+	start, end time.Time
+}
+
+// historyChunks splits an intraday date range into ranges Yahoo serves,
+// starting no earlier than it keeps bars. It returns nil when params need
+// no splitting.
+func historyChunks(params HistoryParams, now time.Time) []historyChunk {
+	perRequest, lookback := intradayLimits(params.Interval)
+	if perRequest == 0 || params.Start.IsZero() || params.End.IsZero() {
+		return nil
+	}
+	start, end := params.Start, params.End
+	if oldest := now.Add(-lookback); start.Before(oldest) {
+		start = oldest
+	}
+	if !end.After(start) || (start.Equal(params.Start) && end.Sub(start) <= perRequest) {
+		return nil
+	}
+
+	var chunks []historyChunk
+	for s := start; s.Before(end); s = s.Add(perRequest) {
+		chunks = append(chunks, historyChunk{start: s, end: minTime(s.Add(perRequest), end)})
+	}
+	return chunks
+}
+
+// chunkedHistory fetches each chunk in turn and stitches the bars together,
+// dropping duplicates where chunks meet
+func (t *Ticker) chunkedHistory(ctx context.Context, params HistoryParams, chunks []historyChunk) (*ChartData, error) {
+	var chart *ChartData
+	var bars []Bar
+	for _, c := range chunks {
+		chunk := params
+		chunk.Start, chunk.End = c.start, c.end
+		data, err := t.history(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		chart = data
+		bars = append(bars, data.Bars...)
+	}
+
+	// A later chunk's copy of a bar is the more complete one
+	sort.SliceStable(bars, func(i, j int) bool { return bars[i].Timestamp.Before(bars[j].Timestamp) })
+	stitched := bars[:0]
+	for _, b := range bars {
+		if n := len(stitched); n > 0 && stitched[n-1].Timestamp.Equal(b.Timestamp) {
+			stitched[n-1] = b
+			continue
+		}
+		stitched = append(stitched, b)
+	}
+	chart.Bars = stitched
+	return chart, nil
+}
+
+// minTime returns the earlier of a and b
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -3,14 +3,21 @@ package yfinance
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"maps"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 )
 
 // ClientOption is a function that configures Client options
@@ -19,22 +26,55 @@ type ClientOption func(*Client)
 // Client represents a Yahoo Finance API client with authentication
 type Client struct {
 	httpClient  *http.Client
+	sharedHTTP  bool
 	userAgent   string
+	endpoints   Endpoints
 	crumb       string
 	crumbMu     sync.RWMutex
+	quoteAuthAt time.Time // Quotes skip authentication until then
 	timeout     time.Duration
 	retryConfig *RetryConfig
 	proxyConfig *ProxyConfig
 	rateLimiter *RateLimiter
+	breaker     *circuitBreaker
+	middleware  []Middleware
+	observers   []Observer
+	logger      *slog.Logger
+
+	endpointLimiters map[string]*RateLimiter
+	transportConfig  *TransportConfig
+	tracerProvider   trace.TracerProvider
+	cache            CacheStore
+	cacheTTLs        map[DataType]time.Duration
+	quoteChunkSize   int
+	quoteConcurrency int
+
+	flight singleflight.Group
+
+	sessionStore   SessionStore
+	sessionOnce    sync.Once
+	sessionCreated time.Time
 }
 
 // WithHTTPClient sets a custom HTTP client
 func WithHTTPClient(client *http.Client) ClientOption {
 	return func(c *Client) {
 		c.httpClient = client
+		c.sharedHTTP = true
 	}
 }
 
+// setTransport replaces the HTTP client's transport. A client passed to
+// WithHTTPClient is copied first so the caller's is left unchanged.
+func (c *Client) setTransport(rt http.RoundTripper) {
+	if c.sharedHTTP {
+		hc := *c.httpClient
+		c.httpClient = &hc
+		c.sharedHTTP = false
+	}
+	c.httpClient.Transport = rt
+}
+
 // WithUserAgent sets a custom User-Agent header
 func WithUserAgent(ua string) ClientOption {
 	return func(c *Client) {
@@ -63,24 +103,42 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		},
 		userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
 		timeout:   30 * time.Second,
+		endpoints: DefaultEndpoints(),
 	}
 
 	for _, opt := range opts {
 		opt(client)
 	}
 
+	if err := client.configureTransport(); err != nil {
+		return nil, err
+	}
+	if err := client.configureProxy(); err != nil {
+		return nil, err
+	}
+	client.applyCompression()
+	client.applyMiddleware()
+	if client.logger == nil {
+		client.logger = discardLogger
+	}
+
 	return client, nil
 }
 
 // authenticate obtains cookies and crumb token for authenticated requests
 func (c *Client) authenticate(ctx context.Context) error {
+	c.logger.DebugContext(ctx, "yfinance: authenticating")
+
 	// First, get cookies from fc.yahoo.com
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, CookieURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoints.Cookie, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create cookie request: %w", err)
 	}
 	req.Header.Set("User-Agent", c.userAgent)
 
+	if err := c.wait(ctx, "cookie"); err != nil {
+		return err
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to get cookies: %w", err)
@@ -88,12 +146,15 @@ func (c *Client) authenticate(ctx context.Context) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	// Then, get the crumb
-	req, err = http.NewRequestWithContext(ctx, http.MethodGet, CrumbURL, nil)
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, c.endpoints.Crumb, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create crumb request: %w", err)
 	}
 	req.Header.Set("User-Agent", c.userAgent)
 
+	if err := c.wait(ctx, "crumb"); err != nil {
+		return err
+	}
 	resp, err = c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to get crumb: %w", err)
@@ -101,7 +162,7 @@ func (c *Client) authenticate(ctx context.Context) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to get crumb: status %d", resp.StatusCode)
+		return fmt.Errorf("%w: crumb request returned status %d", ErrAuthentication, resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -116,13 +177,18 @@ func (c *Client) authenticate(ctx context.Context) error {
 
 	c.crumbMu.Lock()
 	c.crumb = crumb
+	c.sessionCreated = time.Now()
 	c.crumbMu.Unlock()
+	c.logger.DebugContext(ctx, "yfinance: authenticated")
 
+	c.saveSession()
 	return nil
 }
 
 // ensureAuthenticated ensures the client has valid authentication
 func (c *Client) ensureAuthenticated(ctx context.Context) error {
+	c.sessionOnce.Do(func() { c.restoreSession() })
+
 	c.crumbMu.RLock()
 	crumb := c.crumb
 	c.crumbMu.RUnlock()
@@ -140,19 +206,78 @@ func (c *Client) getCrumb() string {
 	return c.crumb
 }
 
-// Get performs a GET request to the specified URL
+// Get performs a GET request to the specified URL. With a cache configured,
+// fresh cached responses are returned without a request unless ctx comes from
+// BypassCache. Concurrent calls for the same URL and parameters share one
+// request and its response, which callers must not modify.
 func (c *Client) Get(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
-	if err := c.ensureAuthenticated(ctx); err != nil {
+	ctx, span := c.startRequestSpan(ctx, http.MethodGet, endpoint)
+	data, err := c.get(ctx, endpoint, params)
+	endSpan(span, err)
+	return data, err
+}
+
+// get implements Get within its span
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
+	var key string
+	var ttl time.Duration
+	if c.cache != nil {
+		dataType := c.cacheDataType(endpoint, params)
+		key, ttl = cacheKey(dataType, endpoint, params), c.cacheTTL(dataType)
+		if ttl >= 0 && !cacheBypassed(ctx) {
+			data, ok := c.cache.Get(key)
+			trace.SpanFromContext(ctx).SetAttributes(attrCacheHit.Bool(ok))
+			for _, o := range c.observers {
+				o.ObserveCache(dataType, ok)
+			}
+			if ok {
+				c.logger.DebugContext(ctx, "yfinance: cache hit", "endpoint", endpoint, "type", dataType)
+				return data, nil
+			}
+			c.logger.DebugContext(ctx, "yfinance: cache miss", "endpoint", endpoint, "type", dataType)
+		}
+	}
+
+	// Identical concurrent requests share one upstream call. If it fails
+	// because the caller that made it gave up, the others try on their own.
+	flightKey := endpoint + "?" + params.Encode()
+	if crumbless(ctx) {
+		flightKey = "crumbless:" + flightKey
+	}
+	v, err, shared := c.flight.Do(flightKey, func() (any, error) {
+		return c.fetch(ctx, endpoint, params, key, ttl)
+	})
+	if shared {
+		c.logger.DebugContext(ctx, "yfinance: coalesced request", "endpoint", endpoint)
+		if isContextError(err) && ctx.Err() == nil {
+			return c.fetch(ctx, endpoint, params, key, ttl)
+		}
+	}
+	if err != nil {
 		return nil, err
 	}
+	return v.([]byte), nil
+}
 
-	// Add crumb to params
+// isContextError reports whether err comes from a canceled or expired context
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// fetch sends a GET request and caches the response under key for ttl
+func (c *Client) fetch(ctx context.Context, endpoint string, params url.Values, key string, ttl time.Duration) ([]byte, error) {
+	// Add crumb to a copy of params, which may be shared
+	params = maps.Clone(params)
 	if params == nil {
 		params = url.Values{}
 	}
-	crumb := c.getCrumb()
-	if crumb != "" {
-		params.Set("crumb", crumb)
+	if !crumbless(ctx) {
+		if err := c.ensureAuthenticated(ctx); err != nil {
+			return nil, err
+		}
+		if crumb := c.getCrumb(); crumb != "" {
+			params.Set("crumb", crumb)
+		}
 	}
 
 	reqURL := endpoint
@@ -168,7 +293,7 @@ func (c *Client) Get(ctx context.Context, endpoint string, params url.Values) ([
 	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(ctx, req)
 	if err != nil {
 		return nil, &RequestError{Endpoint: endpoint, Method: "GET", Err: err}
 	}
@@ -179,37 +304,26 @@ func (c *Client) Get(ctx context.Context, endpoint string, params url.Values) ([
 		return nil, &RequestError{Endpoint: endpoint, Method: "GET", Err: err}
 	}
 
-	// Handle error responses
-	if resp.StatusCode == http.StatusUnauthorized {
-		// Try to re-authenticate
-		c.crumbMu.Lock()
-		c.crumb = ""
-		c.crumbMu.Unlock()
-		return nil, ErrAuthentication
-	}
-
-	if resp.StatusCode == http.StatusTooManyRequests {
-		return nil, ErrRateLimited
-	}
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, ErrNotFound
+	if err := c.checkResponse(ctx, endpoint, params, resp, body); err != nil {
+		return nil, err
 	}
 
-	if resp.StatusCode >= 400 {
-		var apiErr APIError
-		if json.Unmarshal(body, &apiErr) == nil && apiErr.Description != "" {
-			apiErr.StatusCode = resp.StatusCode
-			return nil, &apiErr
-		}
-		return nil, &APIError{StatusCode: resp.StatusCode, Description: string(body)}
+	if c.cache != nil && ttl >= 0 {
+		c.cache.Set(key, body, ttl)
 	}
-
 	return body, nil
 }
 
 // Post performs a POST request to the specified URL
 func (c *Client) Post(ctx context.Context, endpoint string, params url.Values, body interface{}) ([]byte, error) {
+	ctx, span := c.startRequestSpan(ctx, http.MethodPost, endpoint)
+	data, err := c.post(ctx, endpoint, params, body)
+	endSpan(span, err)
+	return data, err
+}
+
+// post implements Post within its span
+func (c *Client) post(ctx context.Context, endpoint string, params url.Values, body interface{}) ([]byte, error) {
 	if err := c.ensureAuthenticated(ctx); err != nil {
 		return nil, err
 	}
@@ -248,7 +362,7 @@ func (c *Client) Post(ctx context.Context, endpoint string, params url.Values, b
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(ctx, req)
 	if err != nil {
 		return nil, &RequestError{Endpoint: endpoint, Method: "POST", Err: err}
 	}
@@ -259,47 +373,94 @@ func (c *Client) Post(ctx context.Context, endpoint string, params url.Values, b
 		return nil, &RequestError{Endpoint: endpoint, Method: "POST", Err: err}
 	}
 
-	// Handle error responses
-	if resp.StatusCode == http.StatusUnauthorized {
+	if err := c.checkResponse(ctx, endpoint, params, resp, respBody); err != nil {
+		return nil, err
+	}
+	return respBody, nil
+}
+
+// checkResponse returns a QueryError for an error status or an HTML page
+// instead of JSON. A rejected crumb is dropped so the next request
+// authenticates again.
+func (c *Client) checkResponse(ctx context.Context, endpoint string, params url.Values, resp *http.Response, body []byte) error {
+	var err error
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		c.logger.DebugContext(ctx, "yfinance: crumb rejected, will re-authenticate", "endpoint", endpoint)
 		c.crumbMu.Lock()
 		c.crumb = ""
 		c.crumbMu.Unlock()
-		return nil, ErrAuthentication
-	}
-
-	if resp.StatusCode == http.StatusTooManyRequests {
-		return nil, ErrRateLimited
-	}
-
-	if resp.StatusCode >= 400 {
-		var apiErr APIError
-		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Description != "" {
-			apiErr.StatusCode = resp.StatusCode
-			return nil, &apiErr
+		err = ErrAuthentication
+	case resp.StatusCode == http.StatusTooManyRequests:
+		err = ErrRateLimited
+	case resp.StatusCode == http.StatusNotFound:
+		err = ErrNotFound
+	case resp.StatusCode >= 400:
+		apiErr := &APIError{}
+		if json.Unmarshal(body, apiErr) != nil || apiErr.Description == "" {
+			apiErr = &APIError{Description: truncateBody(body)}
 		}
-		return nil, &APIError{StatusCode: resp.StatusCode, Description: string(respBody)}
-	}
-
-	return respBody, nil
+		apiErr.StatusCode = resp.StatusCode
+		err = apiErr
+	case strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html"):
+		err = fmt.Errorf("%w: got an HTML page", ErrInvalidResponse)
+	default:
+		return nil
+	}
+	return newQueryError(endpoint, params, resp, body, err)
 }
 
-// defaultClient is a package-level default client
+// The package-level default client, used by package functions, tickers
+// created without WithClient, and streams. Readers load it without locking;
+// defaultMu serializes creating and replacing it.
 var (
-	defaultClient     *Client
-	defaultClientOnce sync.Once
-	errDefaultClient  error
+	defaultClient atomic.Pointer[Client]
+	defaultMu     sync.Mutex
 )
 
-// getDefaultClient returns the default client, creating it if necessary
+// getDefaultClient returns the default client, creating it if necessary. A
+// failure to create it is returned and tried again on the next call.
 func getDefaultClient() (*Client, error) {
-	defaultClientOnce.Do(func() {
-		defaultClient, errDefaultClient = NewClient()
-	})
-	return defaultClient, errDefaultClient
+	if client := defaultClient.Load(); client != nil {
+		return client, nil
+	}
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if client := defaultClient.Load(); client != nil {
+		return client, nil
+	}
+	client, err := NewClient()
+	if err != nil {
+		return nil, err
+	}
+	defaultClient.Store(client)
+	return client, nil
 }
 
-// SetDefaultClient sets the package-level default client
+// DefaultClient returns the package-level default client, creating one
+// with no options if none was configured
+func DefaultClient() (*Client, error) {
+	return getDefaultClient()
+}
+
+// SetDefaultClient sets the package-level default client. It is safe to call
+// while other goroutines make requests: requests already started finish on
+// the previous client. Passing nil makes the next use create a new client
+// with no options.
 func SetDefaultClient(client *Client) {
-	defaultClient = client
-	errDefaultClient = nil
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultClient.Store(client)
+}
+
+// Configure replaces the package-level default client with a new one built
+// from opts, so its cache, rate limiter, and other settings start fresh. On
+// error the current default client is kept.
+func Configure(opts ...ClientOption) error {
+	client, err := NewClient(opts...)
+	if err != nil {
+		return err
+	}
+	SetDefaultClient(client)
+	return nil
 }
@@ -0,0 +1,230 @@
+// Package columnar serializes historical bars to Apache Parquet files and
+// Arrow IPC streams, for loading into analytics tools such as DuckDB,
+// Polars, and Spark.
+//
+// Every format shares Schema, one row per bar with its symbol and interval,
+// so several symbols can go in one file:
+//
+//	res, _ := yfinance.Download(ctx, yfinance.DownloadParams{Symbols: symbols, Period: yfinance.Period5y})
+//	f, _ := os.Create("history.parquet")
+//	err := columnar.WriteParquet(f, columnar.Charts(res)...)
+//
+// It is a separate package so that importing yfinance does not pull in the
+// Arrow libraries.
+package columnar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// Schema is the layout of bars. Prices are null where Yahoo reported none,
+// and session is null for daily and longer bars.
+var Schema = arrow.NewSchema([]arrow.Field{
+	{Name: "symbol", Type: arrow.BinaryTypes.String},
+	{Name: "interval", Type: arrow.BinaryTypes.String},
+	{Name: "timestamp", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}},
+	{Name: "open", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "high", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "low", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "close", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "adj_close", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "volume", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "session", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+// Charts returns the charts of a Download result, by symbol
+func Charts(res *yfinance.DownloadResult) []*yfinance.ChartData {
+	symbols := make([]string, 0, len(res.Data))
+	for sym := range res.Data {
+		symbols = append(symbols, sym)
+	}
+	sort.Strings(symbols)
+
+	charts := make([]*yfinance.ChartData, 0, len(symbols))
+	for _, sym := range symbols {
+		if chart := res.Data[sym]; chart != nil {
+			if chart.Symbol == "" {
+				// Name the rows even if the chart was built by hand
+				named := *chart
+				named.Symbol = sym
+				chart = &named
+			}
+			charts = append(charts, chart)
+		}
+	}
+	return charts
+}
+
+// NewRecord builds one record batch of the charts' bars, in order. The
+// caller must Release it.
+func NewRecord(mem memory.Allocator, charts ...*yfinance.ChartData) arrow.Record {
+	b := array.NewRecordBuilder(mem, Schema)
+	defer b.Release()
+
+	symbol := b.Field(0).(*array.StringBuilder)
+	interval := b.Field(1).(*array.StringBuilder)
+	timestamp := b.Field(2).(*array.TimestampBuilder)
+	prices := []*array.Float64Builder{
+		b.Field(3).(*array.Float64Builder),
+		b.Field(4).(*array.Float64Builder),
+		b.Field(5).(*array.Float64Builder),
+		b.Field(6).(*array.Float64Builder),
+		b.Field(7).(*array.Float64Builder),
+	}
+	volume := b.Field(8).(*array.Int64Builder)
+	session := b.Field(9).(*array.StringBuilder)
+
+	for _, chart := range charts {
+		if chart == nil {
+			continue
+		}
+		granularity := string(chart.Interval)
+		if granularity == "" && chart.Meta != nil {
+			granularity = chart.Meta.DataGranularity
+		}
+		for _, bar := range chart.Bars {
+			symbol.Append(chart.Symbol)
+			interval.Append(granularity)
+			timestamp.Append(arrow.Timestamp(bar.Timestamp.UnixMilli()))
+			for i, p := range []float64{bar.Open, bar.High, bar.Low, bar.Close, bar.AdjClose} {
+				if p == 0 {
+					prices[i].AppendNull()
+				} else {
+					prices[i].Append(p)
+				}
+			}
+			volume.Append(bar.Volume)
+			if bar.Session == "" {
+				session.AppendNull()
+			} else {
+				session.Append(string(bar.Session))
+			}
+		}
+	}
+	return b.NewRecord()
+}
+
+// WriteArrow writes the charts' bars to w as an Arrow IPC stream of one
+// record batch
+func WriteArrow(w io.Writer, charts ...*yfinance.ChartData) error {
+	rec := NewRecord(memory.DefaultAllocator, charts...)
+	defer rec.Release()
+
+	iw := ipc.NewWriter(w, ipc.WithSchema(Schema))
+	if err := iw.Write(rec); err != nil {
+		_ = iw.Close()
+		return err
+	}
+	return iw.Close()
+}
+
+// WriteParquet writes the charts' bars to w as a Snappy-compressed Parquet
+// file of one row group. It does not close w.
+func WriteParquet(w io.Writer, charts ...*yfinance.ChartData) error {
+	rec := NewRecord(memory.DefaultAllocator, charts...)
+	defer rec.Release()
+
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	// Wrapped so closing the Parquet writer does not close w
+	fw, err := pqarrow.NewFileWriter(Schema, struct{ io.Writer }{w}, props, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+	if err != nil {
+		return err
+	}
+	if err := fw.Write(rec); err != nil {
+		_ = fw.Close()
+		return err
+	}
+	return fw.Close()
+}
+
+// ReadParquet reads the bars of a Parquet file with the columns of Schema,
+// in any order, into a chart per symbol and interval, in the order they
+// first appear. Null prices read as zero.
+func ReadParquet(ctx context.Context, r parquet.ReaderAtSeeker) ([]*yfinance.ChartData, error) {
+	mem := memory.DefaultAllocator
+	tbl, err := pqarrow.ReadTable(ctx, r, parquet.NewReaderProperties(mem), pqarrow.ArrowReadProperties{}, mem)
+	if err != nil {
+		return nil, err
+	}
+	defer tbl.Release()
+
+	columns := make([]int, Schema.NumFields())
+	for i, f := range Schema.Fields() {
+		indices := tbl.Schema().FieldIndices(f.Name)
+		if len(indices) == 0 {
+			return nil, fmt.Errorf("columnar: missing column %q", f.Name)
+		}
+		if !arrow.TypeEqual(tbl.Schema().Field(indices[0]).Type, f.Type) {
+			return nil, fmt.Errorf("columnar: column %q is %s, not %s", f.Name, tbl.Schema().Field(indices[0]).Type, f.Type)
+		}
+		columns[i] = indices[0]
+	}
+
+	var charts []*yfinance.ChartData
+	byKey := make(map[[2]string]*yfinance.ChartData)
+	tr := array.NewTableReader(tbl, 0)
+	defer tr.Release()
+	for tr.Next() {
+		rec := tr.Record()
+		col := func(i int) arrow.Array { return rec.Column(columns[i]) }
+		symbol := col(0).(*array.String)
+		interval := col(1).(*array.String)
+		timestamp := col(2).(*array.Timestamp)
+		prices := []*array.Float64{
+			col(3).(*array.Float64),
+			col(4).(*array.Float64),
+			col(5).(*array.Float64),
+			col(6).(*array.Float64),
+			col(7).(*array.Float64),
+		}
+		volume := col(8).(*array.Int64)
+		session := col(9).(*array.String)
+
+		for row := range int(rec.NumRows()) {
+			key := [2]string{symbol.Value(row), interval.Value(row)}
+			chart := byKey[key]
+			if chart == nil {
+				chart = &yfinance.ChartData{Symbol: key[0], Interval: yfinance.Interval(key[1])}
+				byKey[key] = chart
+				charts = append(charts, chart)
+			}
+			var p [5]float64
+			for i, arr := range prices {
+				if arr.IsValid(row) {
+					p[i] = arr.Value(row)
+				}
+			}
+			bar := yfinance.Bar{
+				Timestamp: time.UnixMilli(int64(timestamp.Value(row))),
+				Open:      p[0],
+				High:      p[1],
+				Low:       p[2],
+				Close:     p[3],
+				AdjClose:  p[4],
+				Volume:    volume.Value(row),
+			}
+			if session.IsValid(row) {
+				bar.Session = yfinance.MarketSession(session.Value(row))
+			}
+			chart.Bars = append(chart.Bars, bar)
+		}
+	}
+	if err := tr.Err(); err != nil {
+		return nil, err
+	}
+	return charts, nil
+}
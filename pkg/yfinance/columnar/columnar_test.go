@@ -0,0 +1,164 @@
+package columnar
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+var day = time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC)
+
+func testResult() *yfinance.DownloadResult {
+	return &yfinance.DownloadResult{Data: map[string]*yfinance.ChartData{
+		"MSFT": {
+			Interval: yfinance.Interval1d,
+			Bars:     []yfinance.Bar{{Timestamp: day, Open: 400, High: 410, Low: 395, Close: 405, AdjClose: 404, Volume: 1000}},
+		},
+		"AAPL": {
+			Symbol: "AAPL",
+			Meta:   &yfinance.ChartMeta{DataGranularity: "5m"},
+			Bars: []yfinance.Bar{
+				{Timestamp: day, Open: 180, High: 181, Low: 179, Close: 180.5, Volume: 500, Session: yfinance.RegularMarket},
+				{Timestamp: day.Add(5 * time.Minute), Volume: 0, Session: yfinance.RegularMarket},
+			},
+		},
+	}}
+}
+
+// checkTable tests the rows read back from either format
+func checkTable(t *testing.T, rec arrow.Record) {
+	t.Helper()
+	// Parquet adds field IDs to the metadata, so compare fields only
+	for i, want := range Schema.Fields() {
+		got := rec.Schema().Field(i)
+		if got.Name != want.Name || got.Nullable != want.Nullable || !arrow.TypeEqual(got.Type, want.Type) {
+			t.Fatalf("Expected field %d to be %v, got %v", i, want, got)
+		}
+	}
+	if rec.NumRows() != 3 {
+		t.Fatalf("Expected 3 rows, got %d", rec.NumRows())
+	}
+	symbols := rec.Column(0).(*array.String)
+	intervals := rec.Column(1).(*array.String)
+	if symbols.Value(0) != "AAPL" || symbols.Value(2) != "MSFT" || intervals.Value(0) != "5m" || intervals.Value(2) != "1d" {
+		t.Errorf("Unexpected symbols or intervals: %v %v", symbols, intervals)
+	}
+	if ts := rec.Column(2).(*array.Timestamp).Value(1); ts != arrow.Timestamp(day.Add(5*time.Minute).UnixMilli()) {
+		t.Errorf("Unexpected timestamp %v", ts)
+	}
+	closes := rec.Column(6).(*array.Float64)
+	if closes.Value(0) != 180.5 || !closes.IsNull(1) || closes.Value(2) != 405 {
+		t.Errorf("Unexpected closes: %v", closes)
+	}
+	if adj := rec.Column(7).(*array.Float64); !adj.IsNull(0) || adj.Value(2) != 404 {
+		t.Errorf("Unexpected adjusted closes: %v", adj)
+	}
+	if v := rec.Column(8).(*array.Int64); v.Value(0) != 500 || v.Value(2) != 1000 {
+		t.Errorf("Unexpected volumes: %v", v)
+	}
+	if s := rec.Column(9).(*array.String); s.Value(0) != string(yfinance.RegularMarket) || !s.IsNull(2) {
+		t.Errorf("Unexpected sessions: %v", s)
+	}
+}
+
+// TestWriteArrow tests an Arrow IPC round trip
+func TestWriteArrow(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteArrow(&buf, Charts(testResult())...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := ipc.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer r.Release()
+	if !r.Next() {
+		t.Fatalf("Expected a record batch: %v", r.Err())
+	}
+	checkTable(t, r.Record())
+}
+
+// TestWriteParquet tests a Parquet round trip
+func TestWriteParquet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, Charts(testResult())...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pf, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	table, err := fr.ReadTable(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer table.Release()
+
+	tr := array.NewTableReader(table, -1)
+	defer tr.Release()
+	if !tr.Next() {
+		t.Fatal("Expected a record batch")
+	}
+	checkTable(t, tr.Record())
+}
+
+// TestParquetStore tests that saved bars are merged by timestamp and read
+// back by range
+func TestParquetStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewParquetStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := store.LastBarTime(ctx, "AAPL", yfinance.Interval1d); ok || err != nil {
+		t.Fatalf("Expected an empty store, got %v, %v", ok, err)
+	}
+
+	at := func(d int) time.Time { return day.AddDate(0, 0, d) }
+	if err := store.SaveBars(ctx, "aapl", yfinance.Interval1d, []yfinance.Bar{
+		{Timestamp: at(1), Close: 2}, {Timestamp: at(0), Close: 1, Volume: 10},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveBars(ctx, "AAPL", yfinance.Interval1d, []yfinance.Bar{
+		{Timestamp: at(1), Close: 3}, {Timestamp: at(2), Close: 4, Session: yfinance.RegularMarket},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	last, ok, err := store.LastBarTime(ctx, "AAPL", yfinance.Interval1d)
+	if err != nil || !ok || !last.Equal(at(2)) {
+		t.Errorf("Expected last bar at %v, got %v, %v, %v", at(2), last, ok, err)
+	}
+	first, ok, err := store.FirstBarTime(ctx, "AAPL", yfinance.Interval1d)
+	if err != nil || !ok || !first.Equal(at(0)) {
+		t.Errorf("Expected first bar at %v, got %v, %v, %v", at(0), first, ok, err)
+	}
+	bars, err := store.Bars(ctx, "AAPL", yfinance.Interval1d, time.Time{}, time.Time{})
+	if err != nil || len(bars) != 3 {
+		t.Fatalf("Expected 3 bars, got %+v, %v", bars, err)
+	}
+	if bars[0].Volume != 10 || bars[1].Close != 3 || bars[2].Session != yfinance.RegularMarket {
+		t.Errorf("Unexpected merged bars: %+v", bars)
+	}
+	if bars, _ := store.Bars(ctx, "AAPL", yfinance.Interval1d, at(1), at(2)); len(bars) != 1 || bars[0].Close != 3 {
+		t.Errorf("Expected one bar in range, got %+v", bars)
+	}
+	if bars, _ := store.Bars(ctx, "AAPL", yfinance.Interval1h, time.Time{}, time.Time{}); len(bars) != 0 {
+		t.Errorf("Expected intervals stored apart, got %+v", bars)
+	}
+}
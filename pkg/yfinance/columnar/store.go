@@ -0,0 +1,156 @@
+package columnar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// ParquetStore is a yfinance.BarStore keeping a Parquet file per symbol and
+// interval in a directory, such as AAPL_1d.parquet, for
+// yfinance.DownloadIncremental. The files use Schema, so the directory can
+// be queried directly with tools like DuckDB's read_parquet('dir/*.parquet').
+// Saving rewrites the symbol's whole file, atomically.
+type ParquetStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+var _ yfinance.BarStore = (*ParquetStore)(nil)
+
+// NewParquetStore returns a store in dir, creating the directory if needed
+func NewParquetStore(dir string) (*ParquetStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("parquet store: %w", err)
+	}
+	return &ParquetStore{dir: dir}, nil
+}
+
+// Dir returns the store's directory
+func (s *ParquetStore) Dir() string {
+	return s.dir
+}
+
+// SaveBars stores bars of symbol at interval, replacing stored bars with
+// the same timestamps
+func (s *ParquetStore) SaveBars(ctx context.Context, symbol string, interval yfinance.Interval, bars []yfinance.Bar) error {
+	if len(bars) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.load(ctx, symbol, interval)
+	if err != nil {
+		return err
+	}
+	byTime := make(map[int64]yfinance.Bar, len(stored)+len(bars))
+	for _, b := range stored {
+		byTime[b.Timestamp.Unix()] = b
+	}
+	for _, b := range bars {
+		byTime[b.Timestamp.Unix()] = b
+	}
+	merged := make([]yfinance.Bar, 0, len(byTime))
+	for _, b := range byTime {
+		merged = append(merged, b)
+	}
+	slices.SortFunc(merged, func(a, b yfinance.Bar) int { return a.Timestamp.Compare(b.Timestamp) })
+
+	path := s.path(symbol, interval)
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("parquet store: %w", err)
+	}
+	chart := &yfinance.ChartData{Symbol: strings.ToUpper(symbol), Interval: interval, Bars: merged}
+	if err := WriteParquet(f, chart); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("parquet store: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("parquet store: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("parquet store: %w", err)
+	}
+	return nil
+}
+
+// Bars returns the stored bars of symbol at interval from start up to but
+// not including end, oldest first. A zero start or end leaves that side
+// unbounded.
+func (s *ParquetStore) Bars(ctx context.Context, symbol string, interval yfinance.Interval, start, end time.Time) ([]yfinance.Bar, error) {
+	s.mu.Lock()
+	bars, err := s.load(ctx, symbol, interval)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(bars, func(b yfinance.Bar) bool {
+		return (!start.IsZero() && b.Timestamp.Before(start)) || (!end.IsZero() && !b.Timestamp.Before(end))
+	}), nil
+}
+
+// FirstBarTime returns the timestamp of the oldest stored bar of symbol at
+// interval, or false if there is none
+func (s *ParquetStore) FirstBarTime(ctx context.Context, symbol string, interval yfinance.Interval) (time.Time, bool, error) {
+	s.mu.Lock()
+	bars, err := s.load(ctx, symbol, interval)
+	s.mu.Unlock()
+	if err != nil || len(bars) == 0 {
+		return time.Time{}, false, err
+	}
+	return bars[0].Timestamp, true, nil
+}
+
+// LastBarTime returns the timestamp of the newest stored bar of symbol at
+// interval, or false if there is none
+func (s *ParquetStore) LastBarTime(ctx context.Context, symbol string, interval yfinance.Interval) (time.Time, bool, error) {
+	s.mu.Lock()
+	bars, err := s.load(ctx, symbol, interval)
+	s.mu.Unlock()
+	if err != nil || len(bars) == 0 {
+		return time.Time{}, false, err
+	}
+	return bars[len(bars)-1].Timestamp, true, nil
+}
+
+// path returns the file of symbol at interval
+func (s *ParquetStore) path(symbol string, interval yfinance.Interval) string {
+	name := strings.ToUpper(symbol) + "_" + string(interval) + ".parquet"
+	return filepath.Join(s.dir, strings.ReplaceAll(name, string(filepath.Separator), "_"))
+}
+
+// load reads the stored bars of symbol at interval, oldest first, or none
+// if there is no file yet
+func (s *ParquetStore) load(ctx context.Context, symbol string, interval yfinance.Interval) ([]yfinance.Bar, error) {
+	f, err := os.Open(s.path(symbol, interval))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parquet store: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	charts, err := ReadParquet(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("parquet store: %w", err)
+	}
+	var bars []yfinance.Bar
+	for _, chart := range charts {
+		bars = append(bars, chart.Bars...)
+	}
+	return bars, nil
+}
@@ -0,0 +1,151 @@
+package yfinance
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ConstituentsAsOf is when the bundled index member lists were last
+// updated. Members that joined or left an index since then are missing or
+// still listed when Yahoo's list is unavailable.
+var ConstituentsAsOf = time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+//go:embed constituents/*.txt
+var constituentFiles embed.FS
+
+// indexFiles maps index symbols to their bundled member lists
+var indexFiles = map[string]string{
+	IndexSP500:     "constituents/sp500.txt",
+	IndexNasdaq100: "constituents/nasdaq100.txt",
+	IndexDowJones:  "constituents/dow30.txt",
+}
+
+// indexNames maps common index names to their symbols
+var indexNames = map[string]string{
+	"SP500":     "^GSPC",
+	"S&P500":    "^GSPC",
+	"SPX":       "^GSPC",
+	"GSPC":      "^GSPC",
+	"NASDAQ100": "^NDX",
+	"NDX":       "^NDX",
+	"DOW":       "^DJI",
+	"DOW30":     "^DJI",
+	"DJIA":      "^DJI",
+	"DJI":       "^DJI",
+}
+
+// ConstituentIndexes returns the symbols of the indexes
+// GetIndexConstituents knows, sorted
+func ConstituentIndexes() []string {
+	indexes := make([]string, 0, len(indexFiles))
+	for index := range indexFiles {
+		indexes = append(indexes, index)
+	}
+	slices.Sort(indexes)
+	return indexes
+}
+
+// GetIndexConstituents returns the member symbols of an index, sorted, for
+// feeding whole universes into Download or Screen. The index is given by
+// its symbol, such as IndexSP500, IndexNasdaq100, or IndexDowJones, or by
+// a name such as "sp500", "nasdaq100", or "dow". Members come from Yahoo's
+// index components when it lists them all, and otherwise from the lists
+// bundled with this package; see ConstituentsAsOf.
+func GetIndexConstituents(ctx context.Context, index string) ([]string, error) {
+	client, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return GetIndexConstituentsWithClient(ctx, client, index)
+}
+
+// GetIndexConstituentsWithClient returns the member symbols of an index
+// using a specific client; see GetIndexConstituents
+func GetIndexConstituentsWithClient(ctx context.Context, client *Client, index string) ([]string, error) {
+	bundled, err := BundledIndexConstituents(index)
+	if err != nil {
+		return nil, err
+	}
+	symbol := indexSymbol(index)
+
+	// Yahoo lists at most a page of components for large indexes, so a much
+	// shorter list than the bundled one is taken to be cut off
+	live, err := client.indexComponents(ctx, symbol)
+	if err != nil || len(live) < len(bundled)*9/10 {
+		client.logger.DebugContext(ctx, "yfinance: using bundled index constituents",
+			"index", symbol, "components", len(live), "error", err)
+		return bundled, nil
+	}
+	return live, nil
+}
+
+// BundledIndexConstituents returns the member symbols of an index, sorted,
+// from the lists bundled with this package, without a request. See
+// GetIndexConstituents for how the index is given.
+func BundledIndexConstituents(index string) ([]string, error) {
+	file, ok := indexFiles[indexSymbol(index)]
+	if !ok {
+		return nil, fmt.Errorf("yfinance: no constituents for index %q (known: %s)", index, strings.Join(ConstituentIndexes(), ", "))
+	}
+	data, err := constituentFiles.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []string
+	for line := range strings.Lines(string(data)) {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		symbols = append(symbols, strings.Fields(line)...)
+	}
+	slices.Sort(symbols)
+	return slices.Compact(symbols), nil
+}
+
+// indexSymbol returns the symbol of an index given by symbol or name
+func indexSymbol(index string) string {
+	upper := strings.ToUpper(strings.TrimSpace(index))
+	name := strings.NewReplacer("-", "", "_", "", " ", "").Replace(upper)
+	if symbol, ok := indexNames[name]; ok {
+		return symbol
+	}
+	return upper
+}
+
+// indexComponents fetches the member symbols of an index from Yahoo's
+// components module, sorted
+func (c *Client) indexComponents(ctx context.Context, symbol string) ([]string, error) {
+	endpoint := fmt.Sprintf("%s/%s", c.endpoints.QuoteSummary, symbol)
+	params := buildModulesParams(ModuleComponents)
+
+	data, err := c.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(symbol, err)
+	}
+
+	var response struct {
+		QuoteSummary struct {
+			Result []struct {
+				Components *struct {
+					Components []string `json:"components"`
+				} `json:"components"`
+			} `json:"result"`
+		} `json:"quoteSummary"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(symbol, parseError(endpoint, params, data, "components", err))
+	}
+	if len(response.QuoteSummary.Result) == 0 || response.QuoteSummary.Result[0].Components == nil {
+		return nil, NewSymbolError(symbol, ErrNoData)
+	}
+
+	symbols := slices.Clone(response.QuoteSummary.Result[0].Components.Components)
+	slices.Sort(symbols)
+	return slices.Compact(symbols), nil
+}
@@ -0,0 +1,131 @@
+package yfinance
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// DefaultBenchmark is the benchmark Betas uses when none is given
+const DefaultBenchmark = "^GSPC"
+
+// CorrelationMatrix holds the pairwise correlations of several symbols'
+// returns. Values[i][j] is the correlation of Symbols[i] with Symbols[j].
+type CorrelationMatrix struct {
+	Symbols      []string    `json:"symbols"`
+	Values       [][]float64 `json:"values"`
+	Observations int         `json:"observations"` // Returns each correlation is computed from
+}
+
+// Get returns the correlation of two symbols, or false if either is not in
+// the matrix
+func (m *CorrelationMatrix) Get(a, b string) (float64, bool) {
+	i, j := slices.Index(m.Symbols, a), slices.Index(m.Symbols, b)
+	if i < 0 || j < 0 {
+		return 0, false
+	}
+	return m.Values[i][j], true
+}
+
+// Correlation computes the correlation matrix of the returns of several
+// series, such as DownloadResult.Data, over the bars all of them share.
+// Daily and longer bars are matched by trading day, since exchanges stamp
+// them at different times of day, and intraday bars by timestamp.
+func Correlation(series map[string]*ChartData) (*CorrelationMatrix, error) {
+	symbols := make([]string, 0, len(series))
+	for symbol := range series {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	returns, err := alignedReturns(series, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &CorrelationMatrix{Symbols: symbols, Values: make([][]float64, len(symbols)), Observations: len(returns[0])}
+	for i := range symbols {
+		m.Values[i] = make([]float64, len(symbols))
+		for j := range symbols {
+			switch {
+			case i == j:
+				m.Values[i][j] = 1
+			case j < i:
+				m.Values[i][j] = m.Values[j][i]
+			default:
+				m.Values[i][j] = correlation(returns[i], returns[j])
+			}
+		}
+	}
+	return m, nil
+}
+
+// Betas computes each symbol's beta against the benchmark's returns, over
+// the bars each shares with the benchmark. benchmark defaults to
+// DefaultBenchmark and must be one of the series.
+func Betas(series map[string]*ChartData, benchmark string) (map[string]float64, error) {
+	if benchmark == "" {
+		benchmark = DefaultBenchmark
+	}
+	if series[benchmark] == nil {
+		return nil, fmt.Errorf("benchmark %s is not among the series", benchmark)
+	}
+
+	betas := make(map[string]float64, len(series)-1)
+	for symbol := range series {
+		if symbol == benchmark {
+			continue
+		}
+		returns, err := alignedReturns(series, []string{symbol, benchmark})
+		if err != nil {
+			return nil, err
+		}
+		if Variance(returns[1]) > 0 {
+			betas[symbol] = Beta(returns[0], returns[1])
+		}
+	}
+	return betas, nil
+}
+
+// alignedReturns returns the simple returns of each symbol's closes on the
+// bars every symbol has, in the order of symbols. Bars are aligned as by
+// NewPanel with JoinInner.
+func alignedReturns(series map[string]*ChartData, symbols []string) ([][]float64, error) {
+	if len(symbols) < 2 {
+		return nil, fmt.Errorf("need at least two series, got %d", len(symbols))
+	}
+
+	charts := make(map[string]*ChartData, len(symbols))
+	for _, symbol := range symbols {
+		if series[symbol] == nil {
+			return nil, fmt.Errorf("no data for %s", symbol)
+		}
+		charts[symbol] = series[symbol]
+	}
+	panel := NewPanel(charts, PanelOptions{Join: JoinInner})
+	if panel.Len() < 3 {
+		return nil, fmt.Errorf("%w: only %d bars shared by %v", ErrNoData, panel.Len(), symbols)
+	}
+
+	returns := make([][]float64, len(symbols))
+	for i, symbol := range symbols {
+		prices := panelCloses(panel, symbol)
+		returns[i] = make([]float64, len(prices)-1)
+		for k := 1; k < len(prices); k++ {
+			returns[i][k-1] = prices[k]/prices[k-1] - 1
+		}
+	}
+	return returns, nil
+}
+
+// panelCloses returns a symbol's closes in the panel, adjusted for
+// dividends and splits where Yahoo provides them
+func panelCloses(p *Panel, symbol string) []float64 {
+	prices := p.Column(symbol, FieldAdjClose)
+	for row, c := range p.Column(symbol, FieldClose) {
+		if !(prices[row] > 0) {
+			prices[row] = c
+		}
+	}
+	return prices
+}
@@ -2,8 +2,14 @@ package yfinance
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"slices"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // DownloadParams defines parameters for batch downloading historical data
@@ -15,14 +21,51 @@ type DownloadParams struct {
 	End      time.Time // End date
 	PrePost  bool      // Include pre/post market data
 	Actions  bool      // Include dividends and splits
-	Progress bool      // Show progress (not implemented in Go)
 	Threads  int       // Number of concurrent downloads
+
+	// MaxRetriesPerSymbol is how many more times a failed symbol is
+	// attempted, on top of the client's own request retries. Errors that
+	// a retry cannot fix, such as an unknown symbol, are not retried.
+	MaxRetriesPerSymbol int
+	// RetryBackoff is the wait before a symbol's first retry, doubling for
+	// each one after (default 1s, at most 30s)
+	RetryBackoff time.Duration
+	// FailFast stops the download at the first symbol that fails, after
+	// its retries, instead of continuing with the rest. Symbols not
+	// downloaded by then fail with ErrDownloadStopped, and Download
+	// returns the first failure along with the partial result.
+	FailFast bool
+
+	// Progress, if set, is called as each symbol completes, with the
+	// number done so far and the symbol's error, if any. Calls are
+	// serialized.
+	Progress ProgressFunc
+	// Results, if set, receives each symbol's result as it completes and is
+	// closed after the last one. Sends block, so it must be received from
+	// concurrently. It is neither used nor closed when the download fails
+	// to start.
+	Results chan<- SymbolResult
+}
+
+// ProgressFunc reports that symbol completed, as the done-th of total, and
+// failed with err if it is not nil
+type ProgressFunc func(symbol string, done, total int, err error)
+
+// SymbolResult is the outcome of downloading one symbol
+type SymbolResult struct {
+	Symbol string
+	Data   *ChartData // Nil if Err is set
+	Err    error
 }
 
 // DownloadResult contains downloaded data for multiple symbols
 type DownloadResult struct {
 	Data   map[string]*ChartData
 	Errors map[string]error
+
+	// params and download repeat the download for RetryFailed
+	params   DownloadParams
+	download func(context.Context, DownloadParams) (*DownloadResult, error)
 }
 
 // Download fetches historical data for multiple symbols concurrently
@@ -37,19 +80,85 @@ func Download(ctx context.Context, params DownloadParams) (*DownloadResult, erro
 	}
 
 	result := &DownloadResult{
-		Data:   make(map[string]*ChartData),
-		Errors: make(map[string]error),
+		Data:     make(map[string]*ChartData),
+		Errors:   make(map[string]error),
+		params:   params,
+		download: Download,
 	}
 
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-	sem := make(chan struct{}, params.Threads) // Semaphore for concurrency limit
-
 	client, err := getDefaultClient()
 	if err != nil {
 		return nil, err
 	}
 
+	ctx, span := client.startSpan(ctx, "yfinance.Download", trace.SpanKindInternal,
+		attribute.Int("yfinance.symbols", len(params.Symbols)),
+		attribute.String("yfinance.interval", string(params.Interval)),
+	)
+	defer span.End()
+
+	err = downloadEach(ctx, client, params, result, func(ctx context.Context, ticker *Ticker) (*ChartData, error) {
+		histParams := HistoryParams{
+			Period:   params.Period,
+			Interval: params.Interval,
+			Start:    params.Start,
+			End:      params.End,
+			PrePost:  params.PrePost,
+		}
+
+		if params.Actions {
+			histParams.Events = "div,split"
+		}
+
+		return ticker.History(ctx, histParams)
+	})
+	span.SetAttributes(attribute.Int("yfinance.failed", len(result.Errors)))
+	return result, err
+}
+
+// RetryFailed downloads the symbols in result.Errors again, with the
+// parameters of the Download or DownloadIncremental call that returned
+// result, and updates result in place: symbols that now succeed move from
+// Errors to Data. It returns the first failure when the original call was
+// FailFast.
+func RetryFailed(ctx context.Context, result *DownloadResult) error {
+	if result.download == nil {
+		return errors.New("yfinance: result was not returned by Download")
+	}
+	if len(result.Errors) == 0 {
+		return nil
+	}
+
+	params := result.params
+	params.Symbols = slices.Sorted(maps.Keys(result.Errors))
+	params.Results = nil // Closed by the first call
+	retried, err := result.download(ctx, params)
+	if retried == nil {
+		return err
+	}
+	for sym, data := range retried.Data {
+		result.Data[sym] = data
+		delete(result.Errors, sym)
+	}
+	maps.Copy(result.Errors, retried.Errors)
+	return err
+}
+
+// downloadEach runs fetch for a ticker of each of params.Symbols, at most
+// params.Threads at a time, and records the chart or error in result,
+// reporting each to params.Progress and params.Results. Failed symbols are
+// retried as params sets, and with params.FailFast the first failure
+// stops the download and is returned.
+func downloadEach(ctx context.Context, client *Client, params DownloadParams, result *DownloadResult, fetch func(context.Context, *Ticker) (*ChartData, error)) error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, params.Threads) // Semaphore for concurrency limit
+	done := 0
+
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	var stopErr error // First failure, with FailFast
+
 	for _, symbol := range params.Symbols {
 		wg.Add(1)
 		go func(sym string) {
@@ -57,42 +166,94 @@ func Download(ctx context.Context, params DownloadParams) (*DownloadResult, erro
 			sem <- struct{}{}        // Acquire
 			defer func() { <-sem }() // Release
 
-			ticker, err := NewTicker(sym, WithClient(client))
-			if err != nil {
-				mu.Lock()
-				result.Errors[sym] = err
-				mu.Unlock()
-				return
-			}
-
-			histParams := HistoryParams{
-				Period:   params.Period,
-				Interval: params.Interval,
-				Start:    params.Start,
-				End:      params.End,
-				PrePost:  params.PrePost,
+			var data *ChartData
+			var err error
+			mu.Lock()
+			stopped := stopErr != nil
+			mu.Unlock()
+			if !stopped {
+				data, err = fetchWithRetry(ctx, client, sym, params, fetch)
 			}
 
-			if params.Actions {
-				histParams.Events = "div,split"
+			mu.Lock()
+			switch {
+			case stopped, err != nil && stopErr != nil:
+				// Skipped or cancelled after another symbol failed
+				err = NewSymbolError(sym, ErrDownloadStopped)
+			case err != nil && params.FailFast:
+				stopErr = err
+				stop()
 			}
-
-			data, err := ticker.History(ctx, histParams)
 			if err != nil {
-				mu.Lock()
+				data = nil
 				result.Errors[sym] = err
-				mu.Unlock()
-				return
+			} else {
+				result.Data[sym] = data
+			}
+			done++
+			if params.Progress != nil {
+				params.Progress(sym, done, len(params.Symbols), err)
 			}
-
-			mu.Lock()
-			result.Data[sym] = data
 			mu.Unlock()
+
+			if params.Results != nil {
+				params.Results <- SymbolResult{Symbol: sym, Data: data, Err: err}
+			}
 		}(symbol)
 	}
 
 	wg.Wait()
-	return result, nil
+	if params.Results != nil {
+		close(params.Results)
+	}
+	return stopErr
+}
+
+// fetchWithRetry runs fetch for a ticker of sym, retrying failures up to
+// params.MaxRetriesPerSymbol times with backoff
+func fetchWithRetry(ctx context.Context, client *Client, sym string, params DownloadParams, fetch func(context.Context, *Ticker) (*ChartData, error)) (*ChartData, error) {
+	ticker, err := NewTicker(sym, WithClient(client))
+	if err != nil {
+		return nil, err
+	}
+
+	const maxBackoff = 30 * time.Second
+	backoff := min(params.RetryBackoff, maxBackoff)
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	for attempt := 0; ; attempt++ {
+		data, err := fetch(ctx, ticker)
+		if err == nil || attempt >= params.MaxRetriesPerSymbol || !retryableDownload(err) {
+			return data, err
+		}
+
+		// Doubling the capped wait rather than shifting by attempt keeps
+		// large retry counts from overflowing
+		timer := time.NewTimer(calculateBackoff(backoff, maxBackoff, 0.1))
+		backoff = min(2*backoff, maxBackoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryableDownload reports whether a symbol's download may succeed when
+// attempted again, which is not the case for unknown symbols, missing
+// data, invalid parameters, or cancellation
+func retryableDownload(err error) bool {
+	for _, permanent := range []error{
+		ErrInvalidSymbol, ErrNotFound, ErrNoData, ErrInvalidInterval, ErrInvalidPeriod,
+		context.Canceled, context.DeadlineExceeded,
+	} {
+		if errors.Is(err, permanent) {
+			return false
+		}
+	}
+	return true
 }
 
 // DownloadQuotes fetches quotes for multiple symbols
@@ -1,6 +1,11 @@
 // Package yfinance provides a Go client for Yahoo Finance APIs.
 package yfinance
 
+import (
+	"net/url"
+	"strings"
+)
+
 // Base URLs for Yahoo Finance API endpoints
 const (
 	// BaseURL is the primary Yahoo Finance API endpoint
@@ -39,10 +44,14 @@ const (
 	QuoteSummaryURL = BaseURL + "/v10/finance/quoteSummary"
 	// QuoteURL provides real-time quote data
 	QuoteURL = Query1URL + "/v7/finance/quote"
+	// QuoteFallbackURL provides quote data without a crumb, with fewer fields
+	QuoteFallbackURL = Query1URL + "/v6/finance/quote"
 	// OptionsURL provides options chain data
 	OptionsURL = BaseURL + "/v7/finance/options"
 	// FundamentalsURL provides fundamental financial timeseries data
 	FundamentalsURL = BaseURL + "/ws/fundamentals-timeseries/v1/finance/timeseries"
+	// RecommendationsURL provides the symbols people also watch
+	RecommendationsURL = BaseURL + "/v6/finance/recommendationsbysymbol"
 )
 
 // Search and Discovery endpoints
@@ -88,3 +97,138 @@ const (
 	// WebSocketURL provides real-time streaming quotes
 	WebSocketURL = "wss://streamer.finance.yahoo.com/?version=2"
 )
+
+// Endpoints are the URLs a Client sends requests to. Ticker methods and the
+// package-level functions resolve their URLs through the client, so these
+// can point at a caching proxy or a regional mirror.
+type Endpoints struct {
+	Cookie          string // Authentication cookies, see CookieURL
+	Crumb           string // Crumb token, see CrumbURL
+	Chart           string // See ChartURL
+	QuoteSummary    string // See QuoteSummaryURL
+	Quote           string // See QuoteURL
+	QuoteFallback   string // Used when no crumb can be obtained, see QuoteFallbackURL
+	Options         string // See OptionsURL
+	Fundamentals    string // See FundamentalsURL
+	Recommendations string // See RecommendationsURL
+	Search          string // See SearchURL
+	Lookup          string // See LookupURL
+	Screener        string // See ScreenerURL
+	MarketSummary   string // See MarketSummaryURL
+	MarketTime      string // See MarketTimeURL
+	Sector          string // See SectorURL
+	Industry        string // See IndustryURL
+	Calendar        string // See CalendarURL
+	News            string // See NewsURL
+	WebSocket       string // Used by streams created with NewStream, see WebSocketURL
+}
+
+// DefaultEndpoints returns the Yahoo Finance endpoints
+func DefaultEndpoints() Endpoints {
+	return Endpoints{
+		Cookie:          CookieURL,
+		Crumb:           CrumbURL,
+		Chart:           ChartURL,
+		QuoteSummary:    QuoteSummaryURL,
+		Quote:           QuoteURL,
+		QuoteFallback:   QuoteFallbackURL,
+		Options:         OptionsURL,
+		Fundamentals:    FundamentalsURL,
+		Recommendations: RecommendationsURL,
+		Search:          SearchURL,
+		Lookup:          LookupURL,
+		Screener:        ScreenerURL,
+		MarketSummary:   MarketSummaryURL,
+		MarketTime:      MarketTimeURL,
+		Sector:          SectorURL,
+		Industry:        IndustryURL,
+		Calendar:        CalendarURL,
+		News:            NewsURL,
+		WebSocket:       WebSocketURL,
+	}
+}
+
+// fields returns pointers to every endpoint, in declaration order
+func (e *Endpoints) fields() []*string {
+	return []*string{
+		&e.Cookie, &e.Crumb, &e.Chart, &e.QuoteSummary, &e.Quote, &e.QuoteFallback,
+		&e.Options, &e.Fundamentals, &e.Recommendations, &e.Search, &e.Lookup, &e.Screener,
+		&e.MarketSummary, &e.MarketTime, &e.Sector, &e.Industry, &e.Calendar, &e.News, &e.WebSocket,
+	}
+}
+
+// endpointFieldNames are the names Name gives each field of Endpoints, in
+// the order of fields
+var endpointFieldNames = []string{
+	"cookie", "crumb", "chart", "quoteSummary", "quote", "quote",
+	"options", "fundamentals", "recommendations", "search", "lookup", "screener",
+	"marketSummary", "marketTime", "sector", "industry", "calendar", "news", "stream",
+}
+
+// Name returns the name of the endpoint u is a request to, such as "chart",
+// matching it against these URLs so that names stay right when endpoints are
+// moved with WithBaseURL or WithEndpoints. The endpoint with the longest
+// matching path wins. URLs none of them match are named by EndpointName.
+func (e Endpoints) Name(u *url.URL) string {
+	name, longest := "", -1
+	for i, f := range e.fields() {
+		base, err := url.Parse(*f)
+		if err != nil || base.Host != u.Host {
+			continue
+		}
+		path := strings.TrimRight(base.Path, "/")
+		if !matchesPath(u.Path, path) || len(path) <= longest {
+			continue
+		}
+		name, longest = endpointFieldNames[i], len(path)
+	}
+	if name == "" {
+		return EndpointName(u)
+	}
+	return name
+}
+
+// matchesPath reports whether path is prefix or lies under it. An empty
+// prefix only matches the root.
+func matchesPath(path, prefix string) bool {
+	if prefix == "" {
+		return path == "" || path == "/"
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// WithEndpoints overrides the client's endpoints. Empty fields keep their
+// current value.
+func WithEndpoints(endpoints Endpoints) ClientOption {
+	return func(c *Client) {
+		dst := c.endpoints.fields()
+		for i, f := range endpoints.fields() {
+			if *f != "" {
+				*dst[i] = *f
+			}
+		}
+	}
+}
+
+// WithBaseURL sends every API request that would go to BaseURL or Query1URL
+// to base instead, keeping the path, e.g. "http://localhost:8080" for a
+// caching proxy. Cookies, news, and the websocket stream are not on those
+// hosts and keep their endpoints; override them with WithEndpoints.
+func WithBaseURL(base string) ClientOption {
+	base = strings.TrimRight(base, "/")
+	return func(c *Client) {
+		for _, f := range c.endpoints.fields() {
+			for _, prefix := range []string{BaseURL, Query1URL} {
+				if strings.HasPrefix(*f, prefix) {
+					*f = base + strings.TrimPrefix(*f, prefix)
+					break
+				}
+			}
+		}
+	}
+}
+
+// Endpoints returns the URLs the client sends requests to
+func (c *Client) Endpoints() Endpoints {
+	return c.endpoints
+}
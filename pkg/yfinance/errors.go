@@ -3,6 +3,10 @@ package yfinance
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
 )
 
 // Sentinel errors for common error conditions
@@ -36,6 +40,18 @@ var (
 
 	// ErrWebSocketClosed is returned when WebSocket connection is closed
 	ErrWebSocketClosed = errors.New("yfinance: websocket connection closed")
+
+	// ErrDownloadStopped is recorded for the symbols a FailFast download
+	// did not get to after another symbol failed
+	ErrDownloadStopped = errors.New("yfinance: download stopped")
+
+	// ErrInvalidScreenQuery is returned for screener criteria that do not
+	// match the field catalog, see ValidateScreenCriteria
+	ErrInvalidScreenQuery = errors.New("yfinance: invalid screener query")
+
+	// ErrCircuitOpen is returned while an endpoint's circuit breaker is open,
+	// see CircuitOpenError
+	ErrCircuitOpen = errors.New("yfinance: circuit open")
 )
 
 // APIError represents an error returned by the Yahoo Finance API
@@ -53,6 +69,87 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("yfinance: API error (status %d): %s", e.StatusCode, e.Description)
 }
 
+// maxErrorBody is how much of a response body a QueryError keeps
+const maxErrorBody = 512
+
+// QueryError describes a response that could not be used: an error status,
+// an HTML page instead of JSON (as Yahoo serves during maintenance), or a
+// body that failed to parse. It wraps the underlying error, so errors.Is
+// still matches ErrNotFound, ErrRateLimited, and the like.
+type QueryError struct {
+	Endpoint   string     // URL without the query
+	Params     url.Values // Query parameters, without the crumb
+	StatusCode int        // HTTP status, or 0 when unknown such as for cached responses
+	RequestID  string     // Yahoo's request ID, for reports to Yahoo
+	Body       string     // Start of the response body, or of the part that failed to parse
+	Err        error
+}
+
+// Error implements the error interface
+func (e *QueryError) Error() string {
+	var b strings.Builder
+	b.WriteString("yfinance: ")
+	b.WriteString(e.Endpoint)
+	if len(e.Params) > 0 {
+		b.WriteString("?" + e.Params.Encode())
+	}
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, " (status %d", e.StatusCode)
+		if e.RequestID != "" {
+			b.WriteString(", request " + e.RequestID)
+		}
+		b.WriteString(")")
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+	if e.Body != "" {
+		fmt.Fprintf(&b, "; body: %q", e.Body)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// newQueryError builds a QueryError for a request to endpoint. resp may be
+// nil when the body did not come straight from a response.
+func newQueryError(endpoint string, params url.Values, resp *http.Response, body []byte, err error) *QueryError {
+	qe := &QueryError{Endpoint: endpoint, Body: truncateBody(body), Err: err}
+	if len(params) > 0 {
+		qe.Params = maps.Clone(params)
+		qe.Params.Del("crumb")
+	}
+	if resp != nil {
+		qe.StatusCode = resp.StatusCode
+		qe.RequestID = requestID(resp.Header)
+	}
+	return qe
+}
+
+// parseError wraps a failure to parse what from a response of endpoint
+func parseError(endpoint string, params url.Values, body []byte, what string, err error) error {
+	return newQueryError(endpoint, params, nil, body, fmt.Errorf("failed to parse %s: %w", what, err))
+}
+
+// truncateBody returns the start of body as text
+func truncateBody(body []byte) string {
+	if len(body) <= maxErrorBody {
+		return string(body)
+	}
+	return strings.ToValidUTF8(string(body[:maxErrorBody]), "") + "..."
+}
+
+// requestID returns the request ID Yahoo sends in one of several headers
+func requestID(h http.Header) string {
+	for _, name := range []string{"X-Yahoo-Request-Id", "Y-Rid", "X-Request-Id"} {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
 // RequestError wraps an error with request context
 type RequestError struct {
 	Endpoint string
@@ -91,6 +188,23 @@ func NewSymbolError(symbol string, err error) *SymbolError {
 	return &SymbolError{Symbol: symbol, Err: err}
 }
 
+// InvalidSymbolError explains why a symbol was rejected. It matches
+// ErrInvalidSymbol with errors.Is.
+type InvalidSymbolError struct {
+	Symbol string
+	Reason string
+}
+
+// Error implements the error interface
+func (e *InvalidSymbolError) Error() string {
+	return fmt.Sprintf("yfinance: invalid symbol %q: %s", e.Symbol, e.Reason)
+}
+
+// Unwrap returns ErrInvalidSymbol
+func (e *InvalidSymbolError) Unwrap() error {
+	return ErrInvalidSymbol
+}
+
 // IsNotFound checks if the error is a not found error
 func IsNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound)
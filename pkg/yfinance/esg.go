@@ -0,0 +1,114 @@
+package yfinance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ESGScores are a company's Sustainalytics environmental, social, and
+// governance risk scores. Lower scores mean less unmanaged risk.
+type ESGScores struct {
+	TotalESG             float64           `json:"totalEsg"`
+	EnvironmentScore     float64           `json:"environmentScore"`
+	SocialScore          float64           `json:"socialScore"`
+	GovernanceScore      float64           `json:"governanceScore"`
+	Percentile           float64           `json:"percentile"`         // Percentile of TotalESG among all rated companies
+	Performance          string            `json:"esgPerformance"`     // Relative to peers, e.g. "OUT_PERF" or "UNDER_PERF"
+	HighestControversy   int               `json:"highestControversy"` // From 0 (none) to 5 (severe)
+	RelatedControversies []string          `json:"relatedControversy,omitempty"`
+	PeerGroup            string            `json:"peerGroup"`
+	PeerCount            int               `json:"peerCount"`
+	Peers                ESGPeerComparison `json:"peers"`
+	RatingYear           int               `json:"ratingYear"`
+	RatingMonth          int               `json:"ratingMonth"`
+}
+
+// ESGPeerComparison holds the range of each score across a company's peer
+// group
+type ESGPeerComparison struct {
+	TotalESG           ESGRange `json:"totalEsg"`
+	Environment        ESGRange `json:"environment"`
+	Social             ESGRange `json:"social"`
+	Governance         ESGRange `json:"governance"`
+	HighestControversy ESGRange `json:"highestControversy"`
+}
+
+// ESGRange is the spread of a score across a peer group
+type ESGRange struct {
+	Min float64 `json:"min"`
+	Avg float64 `json:"avg"`
+	Max float64 `json:"max"`
+}
+
+// Sustainability fetches the ticker's ESG risk scores. It returns ErrNoData
+// for securities Sustainalytics does not rate, such as funds and indices.
+func (t *Ticker) Sustainability(ctx context.Context) (*ESGScores, error) {
+	ctx, span := t.startSpan(ctx, "Sustainability")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
+	params := buildModulesParams(ModuleESGScores)
+
+	data, err := t.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		QuoteSummary struct {
+			Result []struct {
+				ESGScores *struct {
+					TotalESG                          RawValue `json:"totalEsg"`
+					EnvironmentScore                  RawValue `json:"environmentScore"`
+					SocialScore                       RawValue `json:"socialScore"`
+					GovernanceScore                   RawValue `json:"governanceScore"`
+					Percentile                        RawValue `json:"percentile"`
+					ESGPerformance                    string   `json:"esgPerformance"`
+					HighestControversy                float64  `json:"highestControversy"`
+					RelatedControversy                []string `json:"relatedControversy"`
+					PeerGroup                         string   `json:"peerGroup"`
+					PeerCount                         int      `json:"peerCount"`
+					RatingYear                        int      `json:"ratingYear"`
+					RatingMonth                       int      `json:"ratingMonth"`
+					PeerESGScorePerformance           ESGRange `json:"peerEsgScorePerformance"`
+					PeerEnvironmentPerformance        ESGRange `json:"peerEnvironmentPerformance"`
+					PeerSocialPerformance             ESGRange `json:"peerSocialPerformance"`
+					PeerGovernancePerformance         ESGRange `json:"peerGovernancePerformance"`
+					PeerHighestControversyPerformance ESGRange `json:"peerHighestControversyPerformance"`
+				} `json:"esgScores"`
+			} `json:"result"`
+		} `json:"quoteSummary"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "esg scores", err))
+	}
+
+	if len(response.QuoteSummary.Result) == 0 || response.QuoteSummary.Result[0].ESGScores == nil {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	esg := response.QuoteSummary.Result[0].ESGScores
+	return &ESGScores{
+		TotalESG:             esg.TotalESG.Raw,
+		EnvironmentScore:     esg.EnvironmentScore.Raw,
+		SocialScore:          esg.SocialScore.Raw,
+		GovernanceScore:      esg.GovernanceScore.Raw,
+		Percentile:           esg.Percentile.Raw,
+		Performance:          esg.ESGPerformance,
+		HighestControversy:   int(esg.HighestControversy),
+		RelatedControversies: esg.RelatedControversy,
+		PeerGroup:            esg.PeerGroup,
+		PeerCount:            esg.PeerCount,
+		Peers: ESGPeerComparison{
+			TotalESG:           esg.PeerESGScorePerformance,
+			Environment:        esg.PeerEnvironmentPerformance,
+			Social:             esg.PeerSocialPerformance,
+			Governance:         esg.PeerGovernancePerformance,
+			HighestControversy: esg.PeerHighestControversyPerformance,
+		},
+		RatingYear:  esg.RatingYear,
+		RatingMonth: esg.RatingMonth,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package yfinance
+
+import (
+	"context"
+	"time"
+)
+
+// FastInfo is lightweight metadata about a ticker, derived from its daily
+// chart rather than the much larger quoteSummary response
+type FastInfo struct {
+	Symbol               string  `json:"symbol"`
+	Currency             string  `json:"currency"`
+	QuoteType            string  `json:"quoteType"`
+	Exchange             string  `json:"exchange"`
+	Timezone             string  `json:"timezone"`
+	LastPrice            float64 `json:"lastPrice"`
+	PreviousClose        float64 `json:"previousClose"`
+	DayHigh              float64 `json:"dayHigh"`
+	DayLow               float64 `json:"dayLow"`
+	LastVolume           int64   `json:"lastVolume"`
+	YearHigh             float64 `json:"yearHigh"`
+	YearLow              float64 `json:"yearLow"`
+	YearChange           float64 `json:"yearChange"` // Fractional price change over the year
+	FiftyDayAverage      float64 `json:"fiftyDayAverage"`
+	TwoHundredDayAverage float64 `json:"twoHundredDayAverage"`
+	Shares               int64   `json:"shares,omitempty"`    // Zero when Yahoo reports none
+	MarketCap            float64 `json:"marketCap,omitempty"` // LastPrice times Shares
+}
+
+// FastInfo fetches lightweight metadata from one year of daily bars, plus
+// the latest share count for the market cap. Shares and MarketCap are left
+// zero if the share count is unavailable, as for indices and currencies.
+func (t *Ticker) FastInfo(ctx context.Context) (*FastInfo, error) {
+	ctx, span := t.startSpan(ctx, "FastInfo")
+	defer span.End()
+
+	chart, err := t.History(ctx, HistoryParams{Period: Period1y, Interval: Interval1d})
+	if err != nil {
+		return nil, err
+	}
+
+	meta := chart.Meta
+	info := &FastInfo{
+		Symbol:        t.Symbol,
+		Currency:      meta.Currency,
+		QuoteType:     meta.InstrumentType,
+		Exchange:      meta.ExchangeName,
+		Timezone:      meta.ExchangeTimezoneName,
+		LastPrice:     meta.RegularMarketPrice,
+		PreviousClose: meta.PreviousClose,
+		DayHigh:       meta.RegularMarketDayHigh,
+		DayLow:        meta.RegularMarketDayLow,
+		LastVolume:    meta.RegularMarketVolume,
+		YearHigh:      meta.FiftyTwoWeekHigh,
+		YearLow:       meta.FiftyTwoWeekLow,
+	}
+
+	var bars []Bar
+	for _, b := range chart.Bars {
+		if b.Close > 0 {
+			bars = append(bars, b)
+		}
+	}
+	if n := len(bars); n > 0 {
+		last := bars[n-1]
+		if info.LastPrice == 0 {
+			info.LastPrice = last.Close
+		}
+		if info.PreviousClose == 0 && n > 1 {
+			info.PreviousClose = bars[n-2].Close
+		}
+		if info.YearHigh == 0 || info.YearLow == 0 {
+			info.YearHigh, info.YearLow = last.High, last.Low
+			for _, b := range bars {
+				info.YearHigh, info.YearLow = max(info.YearHigh, b.High), min(info.YearLow, b.Low)
+			}
+		}
+		info.YearChange = info.LastPrice/bars[0].Close - 1
+		info.FiftyDayAverage = averageClose(bars, 50)
+		info.TwoHundredDayAverage = averageClose(bars, 200)
+	}
+
+	if history, err := t.SharesHistory(ctx, time.Time{}, time.Time{}); err == nil && len(history) > 0 {
+		info.Shares = history[len(history)-1].Shares
+		info.MarketCap = info.LastPrice * float64(info.Shares)
+	}
+	return info, nil
+}
+
+// averageClose returns the mean close of the last n bars, or of all bars
+// if there are fewer
+func averageClose(bars []Bar, n int) float64 {
+	if len(bars) > n {
+		bars = bars[len(bars)-n:]
+	}
+	var sum float64
+	for _, b := range bars {
+		sum += b.Close
+	}
+	return sum / float64(len(bars))
+}
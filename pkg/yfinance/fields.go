@@ -0,0 +1,242 @@
+package yfinance
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fieldSet records the JSON fields a decoded response carried, so a value
+// Yahoo left out can be told apart from a real zero
+type fieldSet map[string]bool
+
+// decodeFields decodes the JSON object data into v, unwrapping Yahoo's
+// {"raw": ..., "fmt": ...} values, within arrays too, and dropping empty
+// ones, and returns the fields that carried a value. v must not have an
+// UnmarshalJSON method of its own.
+func decodeFields(data []byte, v any) (fieldSet, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	numeric := numericFields(reflect.TypeOf(v).Elem())
+	fields := make(fieldSet, len(obj))
+	changed := false
+	for k, e := range obj {
+		raw, ok, unwrapped := unwrapRawValues(e)
+		if ok && numeric[k] && len(raw) > 0 && raw[0] == '"' {
+			// A number sent as a string, such as "1,234" or "N/A"
+			f, valid := rawNumber(raw)
+			raw, ok, unwrapped = strconv.AppendFloat(nil, f, 'f', -1, 64), valid, true
+		}
+		if unwrapped {
+			changed = true
+			obj[k] = raw
+		}
+		if !ok {
+			delete(obj, k)
+			continue
+		}
+		fields[k] = true
+	}
+
+	if changed {
+		var err error
+		if data, err = json.Marshal(obj); err != nil {
+			return nil, err
+		}
+	}
+	return fields, json.Unmarshal(data, v)
+}
+
+// numericFieldsCache maps struct types to their numeric JSON fields
+var numericFieldsCache sync.Map
+
+// numericFields returns the JSON names of the numeric fields of struct type t
+func numericFields(t reflect.Type) map[string]bool {
+	if cached, ok := numericFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+	names := make(map[string]bool)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		switch f.Type.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+			if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" {
+				names[name] = true
+			}
+		}
+	}
+	numericFieldsCache.Store(t, names)
+	return names
+}
+
+// unwrapRawValues returns the bare value of a raw-value object, or data with
+// the raw values among its elements unwrapped if it is an array. ok is false
+// for null and empty values, and unwrapped reports whether data changed.
+func unwrapRawValues(data json.RawMessage) (raw json.RawMessage, ok, unwrapped bool) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		return data, false, false
+	case data[0] == '{':
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return data, true, false
+		}
+		if len(obj) == 0 {
+			return data, false, false
+		}
+		if _, isRaw := obj["raw"]; !isRaw {
+			return data, true, false
+		}
+		var v RawValue
+		if err := json.Unmarshal(data, &v); err != nil || !v.Valid {
+			return data, false, true
+		}
+		if r := bytes.TrimSpace(obj["raw"]); len(r) > 0 && r[0] != '"' {
+			return r, true, true
+		}
+		return strconv.AppendFloat(nil, v.Raw, 'f', -1, 64), true, true
+	case data[0] == '[':
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return data, true, false
+		}
+		for i, e := range elems {
+			if r, ok, u := unwrapRawValues(e); u {
+				if !ok {
+					r = json.RawMessage("null")
+				}
+				elems[i], unwrapped = r, true
+			}
+		}
+		if !unwrapped {
+			return data, true, false
+		}
+		out, err := json.Marshal(elems)
+		if err != nil {
+			return data, true, false
+		}
+		return out, true, true
+	default:
+		return data, true, false
+	}
+}
+
+// has reports whether v, a struct, has the field with the given JSON name.
+// Values that were not decoded from JSON, such as those built by hand or
+// from chart data, have each non-zero field.
+func (fields fieldSet) has(v any, name string) bool {
+	if fields != nil {
+		return fields[name]
+	}
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		if tag, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ","); tag == name {
+			return !rv.Field(i).IsZero()
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON decodes a quote and records which fields Yahoo sent
+func (q *Quote) UnmarshalJSON(data []byte) error {
+	type plain Quote
+	fields, err := decodeFields(data, (*plain)(q))
+	if err != nil {
+		return err
+	}
+	q.fields = fields
+	return nil
+}
+
+// Has reports whether the quote has a value for the field with the given
+// JSON name, such as "trailingPE", as opposed to a zero for missing data
+func (q *Quote) Has(field string) bool {
+	return q.fields.has(q, field)
+}
+
+// UnmarshalJSON decodes summary detail and records which fields Yahoo sent
+func (s *SummaryDetail) UnmarshalJSON(data []byte) error {
+	type plain SummaryDetail
+	fields, err := decodeFields(data, (*plain)(s))
+	if err != nil {
+		return err
+	}
+	s.fields = fields
+	return nil
+}
+
+// Has reports whether the summary detail has a value for the field with
+// the given JSON name, such as "forwardPE"
+func (s *SummaryDetail) Has(field string) bool {
+	return s.fields.has(s, field)
+}
+
+// UnmarshalJSON decodes key statistics and records which fields Yahoo sent
+func (k *KeyStatistics) UnmarshalJSON(data []byte) error {
+	type plain KeyStatistics
+	fields, err := decodeFields(data, (*plain)(k))
+	if err != nil {
+		return err
+	}
+	k.fields = fields
+	return nil
+}
+
+// Has reports whether the key statistics have a value for the field with
+// the given JSON name, such as "pegRatio"
+func (k *KeyStatistics) Has(field string) bool {
+	return k.fields.has(k, field)
+}
+
+// UnmarshalJSON decodes financial data and records which fields Yahoo sent
+func (f *FinancialData) UnmarshalJSON(data []byte) error {
+	type plain FinancialData
+	fields, err := decodeFields(data, (*plain)(f))
+	if err != nil {
+		return err
+	}
+	f.fields = fields
+	return nil
+}
+
+// Has reports whether the financial data has a value for the field with
+// the given JSON name, such as "debtToEquity"
+func (f *FinancialData) Has(field string) bool {
+	return f.fields.has(f, field)
+}
+
+// UnmarshalJSON decodes price information, unwrapping Yahoo's raw values
+func (p *PriceInfo) UnmarshalJSON(data []byte) error {
+	type plain PriceInfo
+	_, err := decodeFields(data, (*plain)(p))
+	return err
+}
+
+// UnmarshalJSON decodes an officer, unwrapping Yahoo's raw values
+func (o *Officer) UnmarshalJSON(data []byte) error {
+	type plain Officer
+	_, err := decodeFields(data, (*plain)(o))
+	return err
+}
+
+// UnmarshalJSON decodes earnings information, unwrapping Yahoo's raw values
+func (e *EarningsInfo) UnmarshalJSON(data []byte) error {
+	type plain EarningsInfo
+	_, err := decodeFields(data, (*plain)(e))
+	return err
+}
+
+// UnmarshalJSON decodes dividend information, unwrapping Yahoo's raw values
+func (d *DividendInfo) UnmarshalJSON(data []byte) error {
+	type plain DividendInfo
+	_, err := decodeFields(data, (*plain)(d))
+	return err
+}
@@ -0,0 +1,85 @@
+package yfinance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SECFiling is a document filed with the SEC, such as a 10-K or 8-K
+type SECFiling struct {
+	Date     time.Time    `json:"date"`
+	Type     string       `json:"type"` // Form type, e.g. "10-K", "10-Q", or "8-K"
+	Title    string       `json:"title"`
+	EdgarURL string       `json:"edgarUrl"`
+	Exhibits []SECExhibit `json:"exhibits,omitempty"`
+}
+
+// SECExhibit is a document attached to a filing
+type SECExhibit struct {
+	Type        string `json:"type"` // Exhibit type, e.g. "EX-21.1"
+	URL         string `json:"url"`
+	DownloadURL string `json:"downloadUrl,omitempty"`
+}
+
+// SECFilings fetches the ticker's recent SEC filings, newest first. It
+// returns ErrNoData for securities without filings, such as foreign
+// listings and funds.
+func (t *Ticker) SECFilings(ctx context.Context) ([]SECFiling, error) {
+	ctx, span := t.startSpan(ctx, "SECFilings")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
+	params := buildModulesParams(ModuleSecFilings)
+
+	data, err := t.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		QuoteSummary struct {
+			Result []struct {
+				SecFilings struct {
+					Filings []struct {
+						Date      string `json:"date"`
+						EpochDate int64  `json:"epochDate"`
+						Type      string `json:"type"`
+						Title     string `json:"title"`
+						EdgarURL  string `json:"edgarUrl"`
+						Exhibits  []struct {
+							Type        string `json:"type"`
+							URL         string `json:"url"`
+							DownloadURL string `json:"downloadUrl"`
+						} `json:"exhibits"`
+					} `json:"filings"`
+				} `json:"secFilings"`
+			} `json:"result"`
+		} `json:"quoteSummary"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "sec filings", err))
+	}
+
+	if len(response.QuoteSummary.Result) == 0 || len(response.QuoteSummary.Result[0].SecFilings.Filings) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	raw := response.QuoteSummary.Result[0].SecFilings.Filings
+	filings := make([]SECFiling, len(raw))
+	for i, f := range raw {
+		filing := SECFiling{Type: f.Type, Title: f.Title, EdgarURL: f.EdgarURL}
+		if f.EpochDate > 0 {
+			filing.Date = time.Unix(f.EpochDate, 0).UTC()
+		} else if d, err := time.Parse(time.DateOnly, f.Date); err == nil {
+			filing.Date = d
+		}
+		for _, e := range f.Exhibits {
+			filing.Exhibits = append(filing.Exhibits, SECExhibit{Type: e.Type, URL: e.URL, DownloadURL: e.DownloadURL})
+		}
+		filings[i] = filing
+	}
+	return filings, nil
+}
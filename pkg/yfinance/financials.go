@@ -23,7 +23,13 @@ type FinancialStatementPeriod struct {
 }
 
 // IncomeStatement fetches income statement data
+//
+// Deprecated: Yahoo no longer fills the quoteSummary statement modules
+// beyond a few fields. Use Ticker.Financials.
 func (t *Ticker) IncomeStatement(ctx context.Context, quarterly bool) (*FinancialStatement, error) {
+	ctx, span := t.startSpan(ctx, "IncomeStatement")
+	defer span.End()
+
 	var module string
 	if quarterly {
 		module = ModuleIncomeStatementHistoryQuarterly
@@ -34,7 +40,13 @@ func (t *Ticker) IncomeStatement(ctx context.Context, quarterly bool) (*Financia
 }
 
 // BalanceSheet fetches balance sheet data
+//
+// Deprecated: Yahoo no longer fills the quoteSummary statement modules
+// beyond a few fields. Use Ticker.Financials.
 func (t *Ticker) BalanceSheet(ctx context.Context, quarterly bool) (*FinancialStatement, error) {
+	ctx, span := t.startSpan(ctx, "BalanceSheet")
+	defer span.End()
+
 	var module string
 	if quarterly {
 		module = ModuleBalanceSheetHistoryQuarterly
@@ -45,7 +57,13 @@ func (t *Ticker) BalanceSheet(ctx context.Context, quarterly bool) (*FinancialSt
 }
 
 // CashFlow fetches cash flow statement data
+//
+// Deprecated: Yahoo no longer fills the quoteSummary statement modules
+// beyond a few fields. Use Ticker.Financials.
 func (t *Ticker) CashFlow(ctx context.Context, quarterly bool) (*FinancialStatement, error) {
+	ctx, span := t.startSpan(ctx, "CashFlow")
+	defer span.End()
+
 	var module string
 	if quarterly {
 		module = ModuleCashFlowStatementHistoryQuarterly
@@ -56,10 +74,16 @@ func (t *Ticker) CashFlow(ctx context.Context, quarterly bool) (*FinancialStatem
 }
 
 // AllFinancialStatements fetches all financial statements at once
+//
+// Deprecated: Yahoo no longer fills the quoteSummary statement modules
+// beyond a few fields. Use Ticker.Financials.
 func (t *Ticker) AllFinancialStatements(ctx context.Context, quarterly bool) (*AllFinancials, error) {
+	ctx, span := t.startSpan(ctx, "AllFinancialStatements")
+	defer span.End()
+
 	modules := FinancialModules()
 
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(modules...)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -74,7 +98,7 @@ func (t *Ticker) AllFinancialStatements(ctx context.Context, quarterly bool) (*A
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse financials: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "financials", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -112,7 +136,7 @@ type AllFinancials struct {
 
 // fetchFinancialStatement is a helper to fetch and parse financial statements
 func (t *Ticker) fetchFinancialStatement(ctx context.Context, module, annualKey, quarterlyKey string, quarterly bool) (*FinancialStatement, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(module)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -127,7 +151,7 @@ func (t *Ticker) fetchFinancialStatement(ctx context.Context, module, annualKey,
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse financial statement: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "financial statement", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -150,7 +174,7 @@ func (t *Ticker) fetchFinancialStatement(ctx context.Context, module, annualKey,
 	// Try different key names based on statement type
 	var parsed map[string]json.RawMessage
 	if err := json.Unmarshal(rawData, &parsed); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse financial data: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, rawData, "financial data", err))
 	}
 
 	// Find the statements array (different key names for different statement types)
@@ -168,7 +192,7 @@ func (t *Ticker) fetchFinancialStatement(ctx context.Context, module, annualKey,
 
 	var statements []map[string]interface{}
 	if err := json.Unmarshal(statementsRaw, &statements); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse statements: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, statementsRaw, "statements", err))
 	}
 
 	fs := &FinancialStatement{Symbol: t.Symbol}
@@ -0,0 +1,126 @@
+package yfinance
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+// FundOverlapResult compares the holdings of two or more funds. Yahoo reports
+// only each fund's largest holdings, typically the top ten, so the overlap
+// is a lower bound on the funds' true overlap.
+type FundOverlapResult struct {
+	Funds         []string              `json:"funds"`
+	Common        []OverlapHolding      `json:"common"`        // Holdings every fund holds, largest overlap first
+	WeightOverlap float64               `json:"weightOverlap"` // Sum of the common holdings' overlaps, as a fraction
+	Sectors       []FundSectorWeighting `json:"sectors"`       // Sector exposure of an equal-weighted mix of the funds, largest first
+}
+
+// OverlapHolding is a holding shared by every compared fund
+type OverlapHolding struct {
+	Symbol  string             `json:"symbol"`
+	Name    string             `json:"name"`
+	Weights map[string]float64 `json:"weights"` // Weight in each fund, by fund symbol
+	Overlap float64            `json:"overlap"` // Smallest weight in any fund
+}
+
+// fundOverlapConcurrency bounds the funds FundOverlap fetches at once
+const fundOverlapConcurrency = 4
+
+// FundOverlap fetches the holdings of two or more funds in parallel
+// and reports the holdings they share, how much of their weight overlaps,
+// and their combined sector exposure
+func FundOverlap(ctx context.Context, symbols ...string) (*FundOverlapResult, error) {
+	client, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return FundOverlapWithClient(ctx, client, symbols...)
+}
+
+// FundOverlapWithClient compares fund holdings using a specific
+// client. It fails if any fund's holdings cannot be fetched.
+func FundOverlapWithClient(ctx context.Context, client *Client, symbols ...string) (*FundOverlapResult, error) {
+	if len(symbols) < 2 {
+		return nil, fmt.Errorf("fund overlap needs at least two funds, got %d", len(symbols))
+	}
+
+	ctx, span := client.startSpan(ctx, "yfinance.FundOverlap", trace.SpanKindInternal,
+		attribute.Int("yfinance.symbols", len(symbols)),
+	)
+	defer span.End()
+
+	funds := make([]*FundData, len(symbols))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(fundOverlapConcurrency)
+	for i, symbol := range symbols {
+		g.Go(func() error {
+			ticker, err := NewTicker(symbol, WithClient(client))
+			if err != nil {
+				return err
+			}
+			funds[i], err = ticker.FundData(ctx)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return fundOverlap(symbols, funds), nil
+}
+
+// fundOverlap compares the holdings of funds, which are in the order of
+// symbols. Holdings without a symbol are matched by name.
+func fundOverlap(symbols []string, funds []*FundData) *FundOverlapResult {
+	overlap := &FundOverlapResult{Funds: symbols}
+
+	held := make(map[string]*OverlapHolding)
+	var order []string
+	sectors := make(map[string]float64)
+	for i, fund := range funds {
+		for _, h := range fund.Holdings {
+			key := h.Symbol
+			if key == "" {
+				key = h.Name
+			}
+			holding, ok := held[key]
+			if !ok {
+				holding = &OverlapHolding{Symbol: h.Symbol, Name: h.Name, Weights: make(map[string]float64)}
+				held[key] = holding
+				order = append(order, key)
+			}
+			holding.Weights[symbols[i]] += h.Percent
+		}
+		for _, sw := range fund.SectorWeightings {
+			sectors[sw.Sector] += sw.Percent / float64(len(funds))
+		}
+	}
+
+	for _, key := range order {
+		holding := held[key]
+		if len(holding.Weights) < len(symbols) {
+			continue
+		}
+		holding.Overlap = holding.Weights[symbols[0]]
+		for _, w := range holding.Weights {
+			holding.Overlap = min(holding.Overlap, w)
+		}
+		overlap.Common = append(overlap.Common, *holding)
+		overlap.WeightOverlap += holding.Overlap
+	}
+	slices.SortStableFunc(overlap.Common, func(a, b OverlapHolding) int { return cmp.Compare(b.Overlap, a.Overlap) })
+
+	for sector, weight := range sectors {
+		overlap.Sectors = append(overlap.Sectors, FundSectorWeighting{Sector: sector, Percent: weight})
+	}
+	slices.SortFunc(overlap.Sectors, func(a, b FundSectorWeighting) int {
+		return cmp.Or(cmp.Compare(b.Percent, a.Percent), cmp.Compare(a.Sector, b.Sector))
+	})
+	return overlap
+}
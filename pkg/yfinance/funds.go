@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
 )
 
 // FundHolding represents a holding in an ETF or mutual fund
@@ -23,18 +25,71 @@ type FundSectorWeighting struct {
 
 // FundOverview represents fund overview data
 type FundOverview struct {
-	Category                  string  `json:"category"`
-	FundFamily                string  `json:"fundFamily"`
-	LegalType                 string  `json:"legalType"`
-	TotalAssets               int64   `json:"totalAssets"`
-	YTDReturn                 float64 `json:"ytdReturn"`
-	TrailingThreeMonthReturns float64 `json:"trailingThreeMonthReturns"`
-	TrailingThreeYearReturns  float64 `json:"trailingThreeYearReturns"`
-	TrailingFiveYearReturns   float64 `json:"trailingFiveYearReturns"`
-	ExpenseRatio              float64 `json:"annualReportExpenseRatio"`
-	Turnover                  float64 `json:"turnover"`
-	TotalHoldings             int     `json:"holdings"`
-	Top10HoldingsPercent      float64 `json:"top10HoldingsPercent"`
+	Category                  string               `json:"category"`
+	FundFamily                string               `json:"fundFamily"`
+	LegalType                 string               `json:"legalType"`
+	TotalAssets               int64                `json:"totalAssets"`
+	YTDReturn                 float64              `json:"ytdReturn"`
+	TrailingThreeMonthReturns float64              `json:"trailingThreeMonthReturns"`
+	TrailingThreeYearReturns  float64              `json:"trailingThreeYearReturns"`
+	TrailingFiveYearReturns   float64              `json:"trailingFiveYearReturns"`
+	ExpenseRatio              float64              `json:"annualReportExpenseRatio"`
+	Turnover                  float64              `json:"turnover"`
+	TotalHoldings             int                  `json:"holdings"`
+	Top10HoldingsPercent      float64              `json:"top10HoldingsPercent"`
+	AnnualReturns             []FundAnnualReturn   `json:"annualReturns,omitempty"` // Newest year first
+	Risk                      []FundRiskStatistics `json:"risk,omitempty"`
+	CategoryRisk              []FundRiskStatistics `json:"categoryRisk,omitempty"` // The same statistics for the fund's category
+}
+
+// FundAnnualReturn is a fund's total return over one calendar year, beside
+// the average return of its category
+type FundAnnualReturn struct {
+	Year           int     `json:"year"`
+	Return         float64 `json:"return"`
+	CategoryReturn float64 `json:"categoryReturn,omitempty"`
+}
+
+// FundRiskStatistics are a fund's risk measures over a trailing period
+// against its benchmark
+type FundRiskStatistics struct {
+	Period           string  `json:"period"` // e.g. "3y", "5y", or "10y"
+	Alpha            float64 `json:"alpha"`
+	Beta             float64 `json:"beta"`
+	MeanAnnualReturn float64 `json:"meanAnnualReturn"`
+	RSquared         float64 `json:"rSquared"`
+	StdDev           float64 `json:"stdDev"`
+	SharpeRatio      float64 `json:"sharpeRatio"`
+	TreynorRatio     float64 `json:"treynorRatio"`
+}
+
+// fundPerformanceModule is the fundPerformance module of quoteSummary
+type fundPerformanceModule struct {
+	TrailingReturns    json.RawMessage `json:"trailingReturns"`
+	AnnualTotalReturns struct {
+		Returns    []annualReturn `json:"returns"`
+		ReturnsCat []annualReturn `json:"returnsCat"`
+	} `json:"annualTotalReturns"`
+	RiskOverviewStatistics struct {
+		RiskStatistics    []riskStatistics `json:"riskStatistics"`
+		RiskStatisticsCat []riskStatistics `json:"riskStatisticsCat"`
+	} `json:"riskOverviewStatistics"`
+}
+
+type annualReturn struct {
+	Year        string   `json:"year"`
+	AnnualValue RawValue `json:"annualValue"`
+}
+
+type riskStatistics struct {
+	Year             string   `json:"year"`
+	Alpha            RawValue `json:"alpha"`
+	Beta             RawValue `json:"beta"`
+	MeanAnnualReturn RawValue `json:"meanAnnualReturn"`
+	RSquared         RawValue `json:"rSquared"`
+	StdDev           RawValue `json:"stdDev"`
+	SharpeRatio      RawValue `json:"sharpeRatio"`
+	TreynorRatio     RawValue `json:"treynorRatio"`
 }
 
 // FundData represents all fund-specific data
@@ -42,13 +97,169 @@ type FundData struct {
 	Overview         *FundOverview         `json:"overview"`
 	Holdings         []FundHolding         `json:"holdings"`
 	SectorWeightings []FundSectorWeighting `json:"sectorWeightings"`
-	BondHoldings     map[string]float64    `json:"bondHoldings,omitempty"`
-	EquityHoldings   map[string]float64    `json:"equityHoldings,omitempty"`
+	EquityHoldings   *FundEquityHoldings   `json:"equityHoldings,omitempty"`
+	BondHoldings     *FundBondHoldings     `json:"bondHoldings,omitempty"`
+	BondRatings      map[string]float64    `json:"bondRatings,omitempty"`  // Weight by credit rating, e.g. "aaa"
+	AssetClasses     map[string]float64    `json:"assetClasses,omitempty"` // Weight by asset class, e.g. "stock" or "cash"
+}
+
+// FundEquityHoldings are valuation statistics of a fund's stock holdings.
+// Category holds the averages of the fund's category, if Yahoo reports them.
+type FundEquityHoldings struct {
+	PriceToEarnings         float64             `json:"priceToEarnings"`
+	PriceToBook             float64             `json:"priceToBook"`
+	PriceToSales            float64             `json:"priceToSales"`
+	PriceToCashflow         float64             `json:"priceToCashflow"`
+	MedianMarketCap         float64             `json:"medianMarketCap"`
+	ThreeYearEarningsGrowth float64             `json:"threeYearEarningsGrowth"`
+	Category                *FundEquityHoldings `json:"category,omitempty"`
+}
+
+// FundBondHoldings are statistics of a fund's bond holdings. Duration and
+// Maturity are in years. Category holds the averages of the fund's
+// category, if Yahoo reports them.
+type FundBondHoldings struct {
+	Duration      float64           `json:"duration"`
+	Maturity      float64           `json:"maturity"`
+	CreditQuality float64           `json:"creditQuality"`
+	Category      *FundBondHoldings `json:"category,omitempty"`
+}
+
+// equityHoldings is the equityHoldings block of topHoldings
+type equityHoldings struct {
+	PriceToEarnings            RawValue `json:"priceToEarnings"`
+	PriceToBook                RawValue `json:"priceToBook"`
+	PriceToSales               RawValue `json:"priceToSales"`
+	PriceToCashflow            RawValue `json:"priceToCashflow"`
+	MedianMarketCap            RawValue `json:"medianMarketCap"`
+	ThreeYearEarningsGrowth    RawValue `json:"threeYearEarningsGrowth"`
+	PriceToEarningsCat         RawValue `json:"priceToEarningsCat"`
+	PriceToBookCat             RawValue `json:"priceToBookCat"`
+	PriceToSalesCat            RawValue `json:"priceToSalesCat"`
+	PriceToCashflowCat         RawValue `json:"priceToCashflowCat"`
+	MedianMarketCapCat         RawValue `json:"medianMarketCapCat"`
+	ThreeYearEarningsGrowthCat RawValue `json:"threeYearEarningsGrowthCat"`
+}
+
+// bondHoldings is the bondHoldings block of topHoldings
+type bondHoldings struct {
+	Duration         RawValue `json:"duration"`
+	Maturity         RawValue `json:"maturity"`
+	CreditQuality    RawValue `json:"creditQuality"`
+	DurationCat      RawValue `json:"durationCat"`
+	MaturityCat      RawValue `json:"maturityCat"`
+	CreditQualityCat RawValue `json:"creditQualityCat"`
+}
+
+// FundData fetches a fund's profile, performance, holdings, sector and
+// asset class weights, and equity and bond statistics in one request
+func (t *Ticker) FundData(ctx context.Context) (*FundData, error) {
+	ctx, span := t.startSpan(ctx, "FundData")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
+	params := buildModulesParams(ModuleTopHoldings, ModuleFundProfile, ModuleFundPerformance, ModuleDefaultKeyStatistics)
+
+	data, err := t.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		QuoteSummary struct {
+			Result []struct {
+				TopHoldings *struct {
+					CashPosition        RawValue `json:"cashPosition"`
+					StockPosition       RawValue `json:"stockPosition"`
+					BondPosition        RawValue `json:"bondPosition"`
+					PreferredPosition   RawValue `json:"preferredPosition"`
+					ConvertiblePosition RawValue `json:"convertiblePosition"`
+					OtherPosition       RawValue `json:"otherPosition"`
+					Holdings            []struct {
+						Symbol  string   `json:"symbol"`
+						Name    string   `json:"holdingName"`
+						Percent RawValue `json:"holdingPercent"`
+					} `json:"holdings"`
+					SectorWeightings []map[string]RawValue `json:"sectorWeightings"`
+					BondRatings      []map[string]RawValue `json:"bondRatings"`
+					EquityHoldings   equityHoldings        `json:"equityHoldings"`
+					BondHoldings     bondHoldings          `json:"bondHoldings"`
+				} `json:"topHoldings"`
+				FundProfile struct {
+					CategoryName           string `json:"categoryName"`
+					Family                 string `json:"family"`
+					LegalType              string `json:"legalType"`
+					FeesExpensesInvestment struct {
+						AnnualReportExpenseRatio RawValue `json:"annualReportExpenseRatio"`
+						AnnualHoldingsTurnover   RawValue `json:"annualHoldingsTurnover"`
+					} `json:"feesExpensesInvestment"`
+				} `json:"fundProfile"`
+				FundPerformance      fundPerformanceModule `json:"fundPerformance"`
+				DefaultKeyStatistics struct {
+					TotalAssets RawValue `json:"totalAssets"`
+				} `json:"defaultKeyStatistics"`
+			} `json:"result"`
+		} `json:"quoteSummary"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "fund data", err))
+	}
+
+	if len(response.QuoteSummary.Result) == 0 || response.QuoteSummary.Result[0].TopHoldings == nil {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	result := response.QuoteSummary.Result[0]
+	top, profile := result.TopHoldings, result.FundProfile
+	fund := &FundData{
+		Overview: &FundOverview{
+			Category:      profile.CategoryName,
+			FundFamily:    profile.Family,
+			LegalType:     profile.LegalType,
+			TotalAssets:   int64(result.DefaultKeyStatistics.TotalAssets.Raw),
+			ExpenseRatio:  profile.FeesExpensesInvestment.AnnualReportExpenseRatio.Raw,
+			Turnover:      profile.FeesExpensesInvestment.AnnualHoldingsTurnover.Raw,
+			TotalHoldings: len(top.Holdings),
+		},
+		EquityHoldings: top.EquityHoldings.typed(),
+		BondHoldings:   top.BondHoldings.typed(),
+		BondRatings:    make(map[string]float64),
+		AssetClasses:   make(map[string]float64),
+	}
+	result.FundPerformance.apply(fund.Overview)
+	for _, h := range top.Holdings {
+		fund.Holdings = append(fund.Holdings, FundHolding{Symbol: h.Symbol, Name: h.Name, Percent: h.Percent.Raw})
+		fund.Overview.Top10HoldingsPercent += h.Percent.Raw
+	}
+	for _, sw := range top.SectorWeightings {
+		for sector, weight := range sw {
+			fund.SectorWeightings = append(fund.SectorWeightings, FundSectorWeighting{Sector: sector, Percent: weight.Raw})
+		}
+	}
+	for name, weight := range map[string]RawValue{
+		"cash": top.CashPosition, "stock": top.StockPosition, "bond": top.BondPosition,
+		"preferred": top.PreferredPosition, "convertible": top.ConvertiblePosition, "other": top.OtherPosition,
+	} {
+		if weight.Valid {
+			fund.AssetClasses[name] = weight.Raw
+		}
+	}
+	for _, br := range top.BondRatings {
+		for rating, weight := range br {
+			fund.BondRatings[rating] = weight.Raw
+		}
+	}
+
+	return fund, nil
 }
 
 // FundHoldings fetches holdings for an ETF or mutual fund
 func (t *Ticker) FundHoldings(ctx context.Context) ([]FundHolding, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "FundHoldings")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleTopHoldings)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -71,7 +282,7 @@ func (t *Ticker) FundHoldings(ctx context.Context) ([]FundHolding, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse fund holdings: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "fund holdings", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -92,7 +303,10 @@ func (t *Ticker) FundHoldings(ctx context.Context) ([]FundHolding, error) {
 
 // FundSectorWeightings fetches sector weightings for an ETF or mutual fund
 func (t *Ticker) FundSectorWeightings(ctx context.Context) ([]FundSectorWeighting, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "FundSectorWeightings")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleTopHoldings)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -111,7 +325,7 @@ func (t *Ticker) FundSectorWeightings(ctx context.Context) ([]FundSectorWeightin
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse sector weightings: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "sector weightings", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -133,7 +347,10 @@ func (t *Ticker) FundSectorWeightings(ctx context.Context) ([]FundSectorWeightin
 
 // FundProfile fetches fund profile/overview data
 func (t *Ticker) FundProfile(ctx context.Context) (*FundOverview, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "FundProfile")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleFundProfile)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -154,7 +371,7 @@ func (t *Ticker) FundProfile(ctx context.Context) (*FundOverview, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse fund profile: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "fund profile", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -169,9 +386,14 @@ func (t *Ticker) FundProfile(ctx context.Context) (*FundOverview, error) {
 	}, nil
 }
 
-// FundPerformance fetches fund performance data
+// FundPerformance fetches a fund's trailing returns, its annual returns
+// beside its category's, and its risk statistics over 3, 5, and 10 years.
+// Only the performance fields of the overview are set.
 func (t *Ticker) FundPerformance(ctx context.Context) (*FundOverview, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "FundPerformance")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleFundPerformance)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -182,37 +404,145 @@ func (t *Ticker) FundPerformance(ctx context.Context) (*FundOverview, error) {
 	var response struct {
 		QuoteSummary struct {
 			Result []struct {
-				FundPerformance struct {
-					TrailingReturns []struct {
-						Period string   `json:"period"`
-						Return RawValue `json:"return"`
-					} `json:"trailingReturns"`
-				} `json:"fundPerformance"`
+				FundPerformance *fundPerformanceModule `json:"fundPerformance"`
 			} `json:"result"`
 		} `json:"quoteSummary"`
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse fund performance: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "fund performance", err))
 	}
 
-	if len(response.QuoteSummary.Result) == 0 {
+	if len(response.QuoteSummary.Result) == 0 || response.QuoteSummary.Result[0].FundPerformance == nil {
 		return nil, NewSymbolError(t.Symbol, ErrNoData)
 	}
 
 	overview := &FundOverview{}
-	for _, tr := range response.QuoteSummary.Result[0].FundPerformance.TrailingReturns {
-		switch tr.Period {
-		case "ytd":
-			overview.YTDReturn = tr.Return.Raw
-		case "3m":
-			overview.TrailingThreeMonthReturns = tr.Return.Raw
-		case "3y":
-			overview.TrailingThreeYearReturns = tr.Return.Raw
-		case "5y":
-			overview.TrailingFiveYearReturns = tr.Return.Raw
+	response.QuoteSummary.Result[0].FundPerformance.apply(overview)
+	return overview, nil
+}
+
+// apply fills the overview's trailing returns, annual returns, and risk
+// statistics
+func (m *fundPerformanceModule) apply(o *FundOverview) {
+	returns := trailingReturns(m.TrailingReturns)
+	o.YTDReturn = returns["ytd"]
+	o.TrailingThreeMonthReturns = returns["threeMonth"]
+	o.TrailingThreeYearReturns = returns["threeYear"]
+	o.TrailingFiveYearReturns = returns["fiveYear"]
+
+	category := make(map[string]float64)
+	for _, r := range m.AnnualTotalReturns.ReturnsCat {
+		if r.AnnualValue.Valid {
+			category[r.Year] = r.AnnualValue.Raw
+		}
+	}
+	for _, r := range m.AnnualTotalReturns.Returns {
+		year, err := strconv.Atoi(r.Year)
+		if err != nil || !r.AnnualValue.Valid {
+			continue
 		}
+		o.AnnualReturns = append(o.AnnualReturns, FundAnnualReturn{Year: year, Return: r.AnnualValue.Raw, CategoryReturn: category[r.Year]})
 	}
+	slices.SortFunc(o.AnnualReturns, func(a, b FundAnnualReturn) int { return b.Year - a.Year })
 
-	return overview, nil
+	o.Risk = riskOverview(m.RiskOverviewStatistics.RiskStatistics)
+	o.CategoryRisk = riskOverview(m.RiskOverviewStatistics.RiskStatisticsCat)
+}
+
+// riskOverview converts Yahoo's risk statistics, one per trailing period
+func riskOverview(stats []riskStatistics) []FundRiskStatistics {
+	var out []FundRiskStatistics
+	for _, r := range stats {
+		out = append(out, FundRiskStatistics{
+			Period:           r.Year,
+			Alpha:            r.Alpha.Raw,
+			Beta:             r.Beta.Raw,
+			MeanAnnualReturn: r.MeanAnnualReturn.Raw,
+			RSquared:         r.RSquared.Raw,
+			StdDev:           r.StdDev.Raw,
+			SharpeRatio:      r.SharpeRatio.Raw,
+			TreynorRatio:     r.TreynorRatio.Raw,
+		})
+	}
+	return out
+}
+
+// trailingReturns reads fundPerformance's trailing returns, keyed like
+// "ytd", "oneYear", or "threeYear". Yahoo sends an object of returns by
+// period, or in older responses a list of {period, return} pairs with
+// periods like "3y".
+func trailingReturns(raw json.RawMessage) map[string]float64 {
+	returns := make(map[string]float64)
+	var byPeriod map[string]RawValue
+	if err := json.Unmarshal(raw, &byPeriod); err == nil {
+		for period, v := range byPeriod {
+			if v.Valid && period != "asOfDate" {
+				returns[period] = v.Raw
+			}
+		}
+		return returns
+	}
+
+	var list []struct {
+		Period string   `json:"period"`
+		Return RawValue `json:"return"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return returns
+	}
+	names := map[string]string{"1m": "oneMonth", "3m": "threeMonth", "1y": "oneYear", "3y": "threeYear", "5y": "fiveYear", "10y": "tenYear"}
+	for _, r := range list {
+		period := r.Period
+		if name, ok := names[period]; ok {
+			period = name
+		}
+		returns[period] = r.Return.Raw
+	}
+	return returns
+}
+
+// typed returns the equity statistics, or nil if Yahoo sent none, as for
+// bond funds
+func (e equityHoldings) typed() *FundEquityHoldings {
+	if !anyValid(e.PriceToEarnings, e.PriceToBook, e.PriceToSales, e.PriceToCashflow, e.MedianMarketCap, e.ThreeYearEarningsGrowth) {
+		return nil
+	}
+	holdings := &FundEquityHoldings{
+		PriceToEarnings:         e.PriceToEarnings.Raw,
+		PriceToBook:             e.PriceToBook.Raw,
+		PriceToSales:            e.PriceToSales.Raw,
+		PriceToCashflow:         e.PriceToCashflow.Raw,
+		MedianMarketCap:         e.MedianMarketCap.Raw,
+		ThreeYearEarningsGrowth: e.ThreeYearEarningsGrowth.Raw,
+	}
+	if anyValid(e.PriceToEarningsCat, e.PriceToBookCat, e.PriceToSalesCat, e.PriceToCashflowCat, e.MedianMarketCapCat, e.ThreeYearEarningsGrowthCat) {
+		holdings.Category = &FundEquityHoldings{
+			PriceToEarnings:         e.PriceToEarningsCat.Raw,
+			PriceToBook:             e.PriceToBookCat.Raw,
+			PriceToSales:            e.PriceToSalesCat.Raw,
+			PriceToCashflow:         e.PriceToCashflowCat.Raw,
+			MedianMarketCap:         e.MedianMarketCapCat.Raw,
+			ThreeYearEarningsGrowth: e.ThreeYearEarningsGrowthCat.Raw,
+		}
+	}
+	return holdings
+}
+
+// typed returns the bond statistics, or nil if Yahoo sent none, as for
+// stock funds
+func (b bondHoldings) typed() *FundBondHoldings {
+	if !anyValid(b.Duration, b.Maturity, b.CreditQuality) {
+		return nil
+	}
+	holdings := &FundBondHoldings{Duration: b.Duration.Raw, Maturity: b.Maturity.Raw, CreditQuality: b.CreditQuality.Raw}
+	if anyValid(b.DurationCat, b.MaturityCat, b.CreditQualityCat) {
+		holdings.Category = &FundBondHoldings{Duration: b.DurationCat.Raw, Maturity: b.MaturityCat.Raw, CreditQuality: b.CreditQualityCat.Raw}
+	}
+	return holdings
+}
+
+// anyValid reports whether Yahoo sent any of the values
+func anyValid(values ...RawValue) bool {
+	return slices.ContainsFunc(values, func(v RawValue) bool { return v.Valid })
 }
@@ -0,0 +1,107 @@
+package yfinance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FinancialsOption configures Ticker.Financials
+type FinancialsOption func(*financialsConfig)
+
+type financialsConfig struct {
+	currency string
+}
+
+// WithCurrency converts statement values into currency, such as "USD", at
+// the closing exchange rate of each period's as-of date, or the last close
+// before it. ADRs often report statements in their home currency while
+// trading in dollars.
+func WithCurrency(currency string) FinancialsOption {
+	return func(c *financialsConfig) {
+		c.currency = strings.ToUpper(currency)
+	}
+}
+
+// fxLookback is how far before the earliest as-of date FX rates are
+// fetched, so periods ending on a weekend or holiday still find a close
+const fxLookback = 7 * 24 * time.Hour
+
+// convertFinancial converts the values not already in currency, fetching
+// the daily rates of each reported currency once
+func (t *Ticker) convertFinancial(ctx context.Context, f *Financial, currency string) error {
+	rates := make(map[string][]Bar)
+	for metric, values := range f.Data {
+		for i, v := range values {
+			if v.CurrencyCode == "" || v.CurrencyCode == currency {
+				continue
+			}
+			bars, ok := rates[v.CurrencyCode]
+			if !ok {
+				var err error
+				if bars, err = t.fxRates(ctx, v.CurrencyCode, currency, f.Timestamp); err != nil {
+					return err
+				}
+				rates[v.CurrencyCode] = bars
+			}
+			date, _ := time.Parse(time.DateOnly, v.AsOfDate)
+			bar, ok := closeAt(bars, date)
+			if !ok {
+				return NewSymbolError(t.Symbol, fmt.Errorf("%w: no %s%s rate on or before %s", ErrNoData, v.CurrencyCode, currency, v.AsOfDate))
+			}
+			values[i] = FinancialValue{
+				Raw:              v.ReportedValue * bar.Close,
+				ReportedValue:    v.ReportedValue,
+				AsOfDate:         v.AsOfDate,
+				PeriodType:       v.PeriodType,
+				CurrencyCode:     currency,
+				ReportedCurrency: v.CurrencyCode,
+				FXRate:           bar.Close,
+				FXDate:           bar.Timestamp.Format(time.DateOnly),
+			}
+		}
+		f.Data[metric] = values
+	}
+	return nil
+}
+
+// fxRates fetches the daily closes of the from/to pair, such as EURUSD=X,
+// covering the given as-of dates
+func (t *Ticker) fxRates(ctx context.Context, from, to string, dates []int64) ([]Bar, error) {
+	if len(dates) == 0 {
+		return nil, nil
+	}
+	pair, err := NewTicker(from+to+"=X", WithClient(t.client))
+	if err != nil {
+		return nil, err
+	}
+	chart, err := pair.History(ctx, HistoryParams{
+		Interval: Interval1d,
+		Start:    time.Unix(dates[0], 0).Add(-fxLookback),
+		End:      time.Unix(dates[len(dates)-1], 0).AddDate(0, 0, 1),
+		Timezone: "UTC",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var bars []Bar
+	for _, b := range chart.Bars {
+		if b.Close > 0 {
+			bars = append(bars, b)
+		}
+	}
+	return bars, nil
+}
+
+// closeAt returns the last bar on or before date's day. bars are oldest
+// first.
+func closeAt(bars []Bar, date time.Time) (Bar, bool) {
+	end := date.AddDate(0, 0, 1)
+	i := sort.Search(len(bars), func(i int) bool { return !bars[i].Timestamp.Before(end) })
+	if i == 0 {
+		return Bar{}, false
+	}
+	return bars[i-1], true
+}
@@ -2,6 +2,7 @@ package yfinance
 
 import (
 	"math"
+	"time"
 )
 
 // Greeks contains the calculated option Greeks
@@ -23,39 +24,43 @@ type OptionWithGreeks struct {
 // s = current stock price, k = strike, r = risk-free rate, t = time to expiry (years)
 // sigma = implied volatility, isCall = true for call, false for put
 func CalculateGreeks(s, k, r, t, sigma float64, isCall bool) *Greeks {
+	return CalculateGreeksWithYield(s, k, r, 0, t, sigma, isCall)
+}
+
+// CalculateGreeksWithYield calculates Black-Scholes-Merton Greeks for a
+// European option on an underlying paying a continuous dividend yield q,
+// such as 0.03 for 3%. The other parameters are those of CalculateGreeks.
+func CalculateGreeksWithYield(s, k, r, q, t, sigma float64, isCall bool) *Greeks {
 	if t <= 0 || sigma <= 0 {
 		return nil
 	}
 
 	sqrtT := math.Sqrt(t)
-	d1 := (math.Log(s/k) + (r+sigma*sigma/2)*t) / (sigma * sqrtT)
+	d1 := (math.Log(s/k) + (r-q+sigma*sigma/2)*t) / (sigma * sqrtT)
 	d2 := d1 - sigma*sqrtT
-
-	// Standard normal CDF
-	nd1Val := normalCDF(d1)
-	nd2Val := normalCDF(d2)
-	nd2Neg := normalCDF(-d2)
+	divDiscount, discount := math.Exp(-q*t), math.Exp(-r*t)
 
 	// Standard normal PDF
 	nd1PDF := normalPDF(d1)
 
 	g := &Greeks{}
 
+	decay := -(s * divDiscount * nd1PDF * sigma / (2 * sqrtT))
 	if isCall {
 		// Call option Greeks
-		g.Delta = nd1Val
-		g.Theta = -(s * nd1PDF * sigma / (2 * sqrtT)) - r*k*math.Exp(-r*t)*nd2Val
-		g.Rho = k * t * math.Exp(-r*t) * nd2Val / 100 // Per 1% change
+		g.Delta = divDiscount * normalCDF(d1)
+		g.Theta = decay - r*k*discount*normalCDF(d2) + q*s*divDiscount*normalCDF(d1)
+		g.Rho = k * t * discount * normalCDF(d2) / 100 // Per 1% change
 	} else {
 		// Put option Greeks
-		g.Delta = nd1Val - 1
-		g.Theta = -(s * nd1PDF * sigma / (2 * sqrtT)) + r*k*math.Exp(-r*t)*nd2Neg
-		g.Rho = -k * t * math.Exp(-r*t) * nd2Neg / 100 // Per 1% change
+		g.Delta = -divDiscount * normalCDF(-d1)
+		g.Theta = decay + r*k*discount*normalCDF(-d2) - q*s*divDiscount*normalCDF(-d1)
+		g.Rho = -k * t * discount * normalCDF(-d2) / 100 // Per 1% change
 	}
 
 	// Common Greeks
-	g.Gamma = nd1PDF / (s * sigma * sqrtT)
-	g.Vega = s * sqrtT * nd1PDF / 100 // Per 1% change in IV
+	g.Gamma = divDiscount * nd1PDF / (s * sigma * sqrtT)
+	g.Vega = s * divDiscount * sqrtT * nd1PDF / 100 // Per 1% change in IV
 
 	// Convert theta to daily
 	g.Theta /= 365
@@ -75,14 +80,7 @@ func normalPDF(x float64) float64 {
 
 // CalculateOptionGreeks adds Greeks to an option
 func CalculateOptionGreeks(opt *Option, underlyingPrice, riskFreeRate float64, isCall bool) *OptionWithGreeks {
-	// Calculate time to expiration in years
-	now := float64(unixNow())
-	expiry := float64(opt.Expiration)
-	T := (expiry - now) / (365.25 * 24 * 60 * 60)
-
-	if T <= 0 {
-		T = 0.0001 // Avoid division by zero
-	}
+	T := timeToExpiry(opt.Expiration, timeNow())
 
 	greeks := CalculateGreeks(
 		underlyingPrice,
@@ -133,9 +131,24 @@ type OptionChainWithGreeks struct {
 	Puts            []OptionWithGreeks `json:"puts"`
 }
 
-// Helper to get current unix timestamp
-func unixNow() int64 {
-	return int64(float64(1e9) * float64(1)) // Placeholder - will use time.Now().Unix()
+// timeNow is the clock time to expiry is measured from, replaced in tests
+var timeNow = time.Now
+
+// minTimeToExpiry floors the time to expiry, in years, so contracts on or
+// past their expiry still get finite Greeks
+const minTimeToExpiry = 0.0001
+
+// timeToExpiry returns the years from at until an option expires. Yahoo
+// dates expirations at midnight UTC of the expiry day, but US options stop
+// trading at the 4pm New York close, so an option expiring today still has
+// hours left.
+func timeToExpiry(expiration int64, at time.Time) float64 {
+	day := time.Unix(expiration, 0).UTC()
+	expiry := time.Date(day.Year(), day.Month(), day.Day(), 20, 0, 0, 0, time.UTC)
+	if loc, err := loadLocation("America/New_York"); err == nil {
+		expiry = time.Date(day.Year(), day.Month(), day.Day(), 16, 0, 0, 0, loc)
+	}
+	return max(expiry.Sub(at).Hours()/(365.25*24), minTimeToExpiry)
 }
 
 // ImpliedVolatility calculates implied volatility using Newton-Raphson method
@@ -172,21 +185,33 @@ func ImpliedVolatility(marketPrice, s, k, r, t float64, isCall bool) float64 {
 
 // blackScholesPrice calculates the Black-Scholes option price
 func blackScholesPrice(s, k, r, t, sigma float64, isCall bool) float64 {
+	return BlackScholesPrice(s, k, r, 0, t, sigma, isCall)
+}
+
+// BlackScholesPrice calculates the Black-Scholes-Merton price of a European
+// option on an underlying paying a continuous dividend yield q. At or past
+// expiry it is the intrinsic value.
+func BlackScholesPrice(s, k, r, q, t, sigma float64, isCall bool) float64 {
 	if t <= 0 {
-		if isCall {
-			return math.Max(s-k, 0)
-		}
-		return math.Max(k-s, 0)
+		return intrinsic(s, k, isCall)
 	}
 
 	sqrtT := math.Sqrt(t)
-	d1 := (math.Log(s/k) + (r+sigma*sigma/2)*t) / (sigma * sqrtT)
+	d1 := (math.Log(s/k) + (r-q+sigma*sigma/2)*t) / (sigma * sqrtT)
 	d2 := d1 - sigma*sqrtT
 
 	if isCall {
-		return s*normalCDF(d1) - k*math.Exp(-r*t)*normalCDF(d2)
+		return s*math.Exp(-q*t)*normalCDF(d1) - k*math.Exp(-r*t)*normalCDF(d2)
+	}
+	return k*math.Exp(-r*t)*normalCDF(-d2) - s*math.Exp(-q*t)*normalCDF(-d1)
+}
+
+// intrinsic returns the exercise value of an option
+func intrinsic(s, k float64, isCall bool) float64 {
+	if isCall {
+		return math.Max(s-k, 0)
 	}
-	return k*math.Exp(-r*t)*normalCDF(-d2) - s*normalCDF(-d1)
+	return math.Max(k-s, 0)
 }
 
 // blackScholesVega calculates vega for IV calculation
@@ -0,0 +1,139 @@
+package yfinance
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+)
+
+// GrowthMetrics are the growth rates of a company's revenue, earnings per
+// share, free cash flow, and dividends paid across its statement history
+type GrowthMetrics struct {
+	Revenue      GrowthSeries `json:"revenue"`
+	EPS          GrowthSeries `json:"eps"`
+	FreeCashFlow GrowthSeries `json:"freeCashFlow"`
+	Dividends    GrowthSeries `json:"dividends"`
+}
+
+// GrowthSeries is the growth of one metric. Rates are fractions, so 10%
+// growth is 0.1.
+type GrowthSeries struct {
+	// YoY is the growth over the same period a year earlier, for each
+	// period that has one, oldest first
+	YoY []DatedValue `json:"yoy"`
+	// CAGR is the compound annual growth rate from the first to the last
+	// reported value, over Years. It is zero if either value is not
+	// positive, since growth from or to a loss has no meaningful rate.
+	CAGR  float64 `json:"cagr"`
+	Years float64 `json:"years"`
+}
+
+// GetGrowthMetrics computes year-over-year growth and CAGR from the
+// statement's annual periods, or from its quarterly periods if it has no
+// annual ones. Periods may be in any order and may come from quoteSummary
+// statements or Financial.Periods. EPS falls back to net income over
+// diluted shares, and free cash flow to operating cash flow plus capital
+// expenditure, when Yahoo does not report them.
+func GetGrowthMetrics(fs *FinancialStatement) *GrowthMetrics {
+	periods, lag := fs.Annual, 1
+	if len(periods) == 0 {
+		periods, lag = fs.Quarterly, 4
+	}
+	periods = slices.Clone(periods)
+	slices.SortFunc(periods, func(a, b FinancialStatementPeriod) int { return a.Date.Compare(b.Date) })
+
+	return &GrowthMetrics{
+		Revenue: growthSeries(periods, lag, func(p FinancialStatementPeriod) (float64, bool) {
+			return statementValue(p, "TotalRevenue", "totalRevenue")
+		}),
+		EPS: growthSeries(periods, lag, func(p FinancialStatementPeriod) (float64, bool) {
+			if eps, ok := statementValue(p, "DilutedEPS", "BasicEPS"); ok {
+				return eps, true
+			}
+			income, ok := statementValue(p, "NetIncome", "netIncome")
+			shares, _ := statementValue(p, "DilutedAverageShares")
+			return ratio(income, shares), ok && shares != 0
+		}),
+		FreeCashFlow: growthSeries(periods, lag, func(p FinancialStatementPeriod) (float64, bool) {
+			if fcf, ok := statementValue(p, "FreeCashFlow"); ok {
+				return fcf, true
+			}
+			operating, ok := statementValue(p, "OperatingCashFlow", "totalCashFromOperatingActivities")
+			capex, _ := statementValue(p, "CapitalExpenditure", "capitalExpenditures")
+			return operating + capex, ok
+		}),
+		Dividends: growthSeries(periods, lag, func(p FinancialStatementPeriod) (float64, bool) {
+			paid, ok := statementValue(p, "CashDividendsPaid", "dividendsPaid")
+			return math.Abs(paid), ok
+		}),
+	}
+}
+
+// GrowthSummary fetches the ticker's annual statements and computes their
+// growth metrics
+func (t *Ticker) GrowthSummary(ctx context.Context) (*GrowthMetrics, error) {
+	ctx, span := t.startSpan(ctx, "GrowthSummary")
+	defer span.End()
+
+	keys := []string{
+		"TotalRevenue", "DilutedEPS", "BasicEPS", "NetIncome", "DilutedAverageShares",
+		"FreeCashFlow", "OperatingCashFlow", "CapitalExpenditure", "CashDividendsPaid",
+	}
+	financial, err := t.Financials(ctx, keys, "annual")
+	if err != nil {
+		return nil, err
+	}
+	return GetGrowthMetrics(&FinancialStatement{Symbol: t.Symbol, Annual: financial.Periods()}), nil
+}
+
+// growthSeries computes the growth of the metric value returns, comparing
+// each period with the one lag periods before it. periods are oldest first.
+func growthSeries(periods []FinancialStatementPeriod, lag int, value func(FinancialStatementPeriod) (float64, bool)) GrowthSeries {
+	var series GrowthSeries
+	type point struct {
+		date  time.Time
+		value float64
+		ok    bool
+	}
+	points := make([]point, len(periods))
+	for i, p := range periods {
+		v, ok := value(p)
+		points[i] = point{p.Date, v, ok}
+	}
+
+	for i := lag; i < len(points); i++ {
+		cur, prev := points[i], points[i-lag]
+		if cur.ok && prev.ok && prev.value != 0 {
+			series.YoY = append(series.YoY, DatedValue{Date: cur.date, Value: (cur.value - prev.value) / math.Abs(prev.value)})
+		}
+	}
+
+	first, last := -1, -1
+	for i, p := range points {
+		if p.ok {
+			last = i
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+	if first < 0 || last <= first {
+		return series
+	}
+	series.Years = points[last].date.Sub(points[first].date).Hours() / 24 / 365.25
+	if points[first].value > 0 && points[last].value > 0 && series.Years > 0 {
+		series.CAGR = math.Pow(points[last].value/points[first].value, 1/series.Years) - 1
+	}
+	return series
+}
+
+// statementValue returns the first of keys present in the period's data
+func statementValue(p FinancialStatementPeriod, keys ...string) (float64, bool) {
+	for _, key := range keys {
+		if v, ok := p.Data[key]; ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -63,7 +64,10 @@ type InsiderPurchases struct {
 
 // MajorHolders fetches major holders breakdown
 func (t *Ticker) MajorHolders(ctx context.Context) (*MajorHolders, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "MajorHolders")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleMajorHoldersBreakdown)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -85,7 +89,7 @@ func (t *Ticker) MajorHolders(ctx context.Context) (*MajorHolders, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse major holders: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "major holders", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -103,7 +107,10 @@ func (t *Ticker) MajorHolders(ctx context.Context) (*MajorHolders, error) {
 
 // InstitutionalHolders fetches institutional holders
 func (t *Ticker) InstitutionalHolders(ctx context.Context) ([]Holder, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "InstitutionalHolders")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleInstitutionOwnership)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -128,7 +135,7 @@ func (t *Ticker) InstitutionalHolders(ctx context.Context) ([]Holder, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse institutional holders: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "institutional holders", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -151,7 +158,10 @@ func (t *Ticker) InstitutionalHolders(ctx context.Context) ([]Holder, error) {
 
 // MutualFundHolders fetches mutual fund holders
 func (t *Ticker) MutualFundHolders(ctx context.Context) ([]Holder, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "MutualFundHolders")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleFundOwnership)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -176,7 +186,7 @@ func (t *Ticker) MutualFundHolders(ctx context.Context) ([]Holder, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse mutual fund holders: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "mutual fund holders", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -199,7 +209,10 @@ func (t *Ticker) MutualFundHolders(ctx context.Context) ([]Holder, error) {
 
 // InsiderTransactions fetches insider transactions
 func (t *Ticker) InsiderTransactions(ctx context.Context) ([]InsiderTransaction, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "InsiderTransactions")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleInsiderTransactions)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -227,7 +240,7 @@ func (t *Ticker) InsiderTransactions(ctx context.Context) ([]InsiderTransaction,
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse insider transactions: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "insider transactions", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -253,7 +266,10 @@ func (t *Ticker) InsiderTransactions(ctx context.Context) ([]InsiderTransaction,
 
 // InsiderRosterHolders fetches insider roster holders
 func (t *Ticker) InsiderRosterHolders(ctx context.Context) ([]InsiderHolder, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "InsiderRosterHolders")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleInsiderHolders)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -282,7 +298,7 @@ func (t *Ticker) InsiderRosterHolders(ctx context.Context) ([]InsiderHolder, err
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse insider roster holders: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "insider roster holders", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -309,7 +325,10 @@ func (t *Ticker) InsiderRosterHolders(ctx context.Context) ([]InsiderHolder, err
 
 // InsiderPurchasesData fetches insider purchase activity summary
 func (t *Ticker) InsiderPurchasesData(ctx context.Context) (*InsiderPurchases, error) {
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "InsiderPurchasesData")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := buildModulesParams(ModuleNetSharePurchaseActivity)
 
 	data, err := t.client.Get(ctx, endpoint, params)
@@ -334,7 +353,7 @@ func (t *Ticker) InsiderPurchasesData(ctx context.Context) (*InsiderPurchases, e
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse insider purchases: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "insider purchases", err))
 	}
 
 	if len(response.QuoteSummary.Result) == 0 {
@@ -352,3 +371,54 @@ func (t *Ticker) InsiderPurchasesData(ctx context.Context) (*InsiderPurchases, e
 		PercentSellShares:  ip.SellPercentInsiderShares.Raw,
 	}, nil
 }
+
+// HolderReport is the holdings reported on one date
+type HolderReport struct {
+	Date    time.Time `json:"date"` // Midnight UTC of the report date
+	Holders []Holder  `json:"holders"`
+	Shares  int64     `json:"shares"` // Total of the holders' shares
+	Value   int64     `json:"value"`  // Total of the holders' values
+}
+
+// InstitutionalHolderHistory fetches the institutional holders and groups
+// them by report date, oldest first, keeping reports between start and end
+// inclusive. A zero start or end leaves that side open. Yahoo lists each
+// holder's latest 13F filing only, so earlier positions of a holder that
+// has filed again are not included.
+func (t *Ticker) InstitutionalHolderHistory(ctx context.Context, start, end time.Time) ([]HolderReport, error) {
+	ctx, span := t.startSpan(ctx, "InstitutionalHolderHistory")
+	defer span.End()
+
+	holders, err := t.InstitutionalHolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return groupHolders(holders, start, end), nil
+}
+
+// groupHolders groups holders into reports by date between start and end
+func groupHolders(holders []Holder, start, end time.Time) []HolderReport {
+	byDate := make(map[time.Time]*HolderReport)
+	for _, h := range holders {
+		y, m, d := h.DateReported.UTC().Date()
+		date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		if !start.IsZero() && date.Before(start) || !end.IsZero() && date.After(end) {
+			continue
+		}
+		report, ok := byDate[date]
+		if !ok {
+			report = &HolderReport{Date: date}
+			byDate[date] = report
+		}
+		report.Holders = append(report.Holders, h)
+		report.Shares += h.Shares
+		report.Value += h.Value
+	}
+
+	reports := make([]HolderReport, 0, len(byDate))
+	for _, r := range byDate {
+		reports = append(reports, *r)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Date.Before(reports[j].Date) })
+	return reports
+}
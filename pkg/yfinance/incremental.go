@@ -0,0 +1,176 @@
+package yfinance
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// BarStore keeps historical bars by symbol and interval, so history can be
+// extended incrementally instead of refetched. SQLiteCache is a BarStore,
+// and the columnar package has one keeping a Parquet file per symbol.
+type BarStore interface {
+	// SaveBars stores bars of symbol at interval, replacing stored bars
+	// with the same timestamps
+	SaveBars(ctx context.Context, symbol string, interval Interval, bars []Bar) error
+	// Bars returns the stored bars of symbol at interval from start up to
+	// but not including end, oldest first. A zero start or end leaves that
+	// side unbounded.
+	Bars(ctx context.Context, symbol string, interval Interval, start, end time.Time) ([]Bar, error)
+	// FirstBarTime returns the timestamp of the oldest stored bar of symbol
+	// at interval, or false if there is none
+	FirstBarTime(ctx context.Context, symbol string, interval Interval) (time.Time, bool, error)
+	// LastBarTime returns the timestamp of the newest stored bar of symbol
+	// at interval, or false if there is none
+	LastBarTime(ctx context.Context, symbol string, interval Interval) (time.Time, bool, error)
+}
+
+// DownloadIncremental is Download backed by store. For each symbol only
+// the bars from the newest stored one onwards are fetched, the newest
+// being refetched since it may have been incomplete, and added to the
+// store, along with those from params.Start up to the oldest stored one.
+// Symbols with nothing stored are backfilled from params.Period, Start,
+// and End, or with the full history when none are set. Intraday fetches
+// are limited to the history Yahoo serves for the interval.
+//
+// Each chart in the result holds the stored bars from params.Start up to
+// params.End, all of them when unset, so a daily job refreshing a large
+// universe makes one small request per symbol. Actions and PrePost apply
+// to fetches only; stored bars carry no dividends or splits.
+func DownloadIncremental(ctx context.Context, store BarStore, params DownloadParams) (*DownloadResult, error) {
+	if len(params.Symbols) == 0 {
+		return nil, ErrInvalidSymbol
+	}
+	if params.Threads <= 0 {
+		params.Threads = 5
+	}
+	if params.Interval == "" {
+		params.Interval = Interval1d
+	}
+
+	client, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := client.startSpan(ctx, "yfinance.DownloadIncremental", trace.SpanKindInternal,
+		attribute.Int("yfinance.symbols", len(params.Symbols)),
+		attribute.String("yfinance.interval", string(params.Interval)),
+	)
+	defer span.End()
+
+	result := &DownloadResult{
+		Data:   make(map[string]*ChartData),
+		Errors: make(map[string]error),
+		params: params,
+		download: func(ctx context.Context, params DownloadParams) (*DownloadResult, error) {
+			return DownloadIncremental(ctx, store, params)
+		},
+	}
+	err = downloadEach(ctx, client, params, result, func(ctx context.Context, ticker *Ticker) (*ChartData, error) {
+		histParams := HistoryParams{
+			Period:   params.Period,
+			Interval: params.Interval,
+			Start:    params.Start,
+			End:      params.End,
+			PrePost:  params.PrePost,
+		}
+		if params.Actions {
+			histParams.Events = "div,split"
+		}
+
+		fetched, err := syncBars(ctx, store, ticker, histParams)
+		if err != nil {
+			return nil, err
+		}
+		bars, err := store.Bars(ctx, ticker.Symbol, params.Interval, params.Start, params.End)
+		if err != nil {
+			return nil, NewSymbolError(ticker.Symbol, err)
+		}
+
+		chart := &ChartData{Symbol: ticker.Symbol, Interval: params.Interval, Bars: bars}
+		if fetched != nil {
+			chart.Currency, chart.Meta = fetched.Currency, fetched.Meta
+		}
+		return chart, nil
+	})
+	span.SetAttributes(attribute.Int("yfinance.failed", len(result.Errors)))
+	return result, err
+}
+
+// syncBars fetches the bars of ticker missing from store and saves them.
+// With stored bars, those from params.Start up to the oldest stored one
+// and from the newest onwards, before params.End, are fetched; without,
+// params decides what is backfilled, the full history when it sets no
+// range. Intraday ranges are clamped to Yahoo's lookback for the interval.
+// It returns the last fetched chart, or nil if the store was already up to
+// date.
+func syncBars(ctx context.Context, store BarStore, ticker *Ticker, params HistoryParams) (*ChartData, error) {
+	first, ok, err := store.FirstBarTime(ctx, ticker.Symbol, params.Interval)
+	if err != nil {
+		return nil, NewSymbolError(ticker.Symbol, err)
+	}
+
+	now := time.Now()
+	_, lookback := intradayLimits(params.Interval)
+	clamp := func(start time.Time) time.Time {
+		if oldest := now.Add(-lookback); lookback > 0 && start.Before(oldest) {
+			return oldest
+		}
+		return start
+	}
+
+	if !ok {
+		switch {
+		case !params.Start.IsZero():
+			params.Start = clamp(params.Start)
+		case params.Period != "":
+		case lookback > 0:
+			params.Start, params.End = now.Add(-lookback), now
+		default:
+			params.Period = PeriodMax
+		}
+		return fetchBars(ctx, store, ticker, params)
+	}
+
+	last, _, err := store.LastBarTime(ctx, ticker.Symbol, params.Interval)
+	if err != nil {
+		return nil, NewSymbolError(ticker.Symbol, err)
+	}
+	end := params.End
+	if end.IsZero() {
+		end = now
+	}
+	params.Period = ""
+
+	var chart *ChartData
+	if start := clamp(params.Start); !params.Start.IsZero() && start.Before(first) {
+		backfill := params
+		backfill.Start, backfill.End = start, first
+		if end.Before(first) {
+			backfill.End = end
+		}
+		if chart, err = fetchBars(ctx, store, ticker, backfill); err != nil {
+			return nil, err
+		}
+	}
+	if end.After(last) {
+		params.Start, params.End = clamp(last), end
+		return fetchBars(ctx, store, ticker, params)
+	}
+	return chart, nil
+}
+
+// fetchBars fetches the history of ticker and saves its bars to store
+func fetchBars(ctx context.Context, store BarStore, ticker *Ticker, params HistoryParams) (*ChartData, error) {
+	chart, err := ticker.History(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.SaveBars(ctx, ticker.Symbol, params.Interval, chart.Bars); err != nil {
+		return nil, NewSymbolError(ticker.Symbol, err)
+	}
+	return chart, nil
+}
@@ -0,0 +1,148 @@
+package yfinance
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// JSONL record types, in each record's "type" field
+const (
+	RecordBar   = "bar"
+	RecordQuote = "quote"
+	RecordTick  = "tick"
+)
+
+// JSONLWriter streams bars, quotes, and stream messages to a writer as
+// JSON Lines, one object per line. Every record carries "type", "symbol",
+// and an RFC 3339 "time", so mixed output can be filtered with tools like
+// jq (select(.type == "tick")) or keyed by symbol in a log pipeline.
+// Records are written as they are given, without buffering, and the
+// writer is safe for concurrent use.
+type JSONLWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLWriter returns a writer of JSON Lines to w
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &JSONLWriter{enc: enc}
+}
+
+// barRecord is a bar with the chart it belongs to
+type barRecord struct {
+	Type     string        `json:"type"`
+	Symbol   string        `json:"symbol"`
+	Interval Interval      `json:"interval,omitempty"`
+	Time     time.Time     `json:"time"`
+	Open     float64       `json:"open"`
+	High     float64       `json:"high"`
+	Low      float64       `json:"low"`
+	Close    float64       `json:"close"`
+	AdjClose float64       `json:"adjClose"`
+	Volume   int64         `json:"volume"`
+	Session  MarketSession `json:"session,omitempty"`
+}
+
+// quoteRecord is a quote, timed by its regular market time
+type quoteRecord struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time,omitzero"`
+	*Quote
+}
+
+// tickRecord is a stream message, with its millisecond time replaced by
+// an RFC 3339 one
+type tickRecord struct {
+	Type   string    `json:"type"`
+	Symbol string    `json:"symbol"`
+	Time   time.Time `json:"time,omitzero"`
+	*StreamMessage
+}
+
+// WriteChart writes a line for each of the chart's bars, oldest first
+func (w *JSONLWriter) WriteChart(chart *ChartData) error {
+	interval := chart.Interval
+	if interval == "" && chart.Meta != nil {
+		interval = Interval(chart.Meta.DataGranularity)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, b := range chart.Bars {
+		err := w.enc.Encode(barRecord{
+			Type:     RecordBar,
+			Symbol:   chart.Symbol,
+			Interval: interval,
+			Time:     b.Timestamp.UTC(),
+			Open:     b.Open,
+			High:     b.High,
+			Low:      b.Low,
+			Close:    b.Close,
+			AdjClose: b.AdjClose,
+			Volume:   b.Volume,
+			Session:  b.Session,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteDownload writes the bars of every chart in a Download result, by
+// symbol
+func (w *JSONLWriter) WriteDownload(res *DownloadResult) error {
+	for _, sym := range slices.Sorted(maps.Keys(res.Data)) {
+		chart := res.Data[sym]
+		if chart == nil {
+			continue
+		}
+		if chart.Symbol == "" {
+			named := *chart
+			named.Symbol = sym
+			chart = &named
+		}
+		if err := w.WriteChart(chart); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteQuotes writes a line for each quote
+func (w *JSONLWriter) WriteQuotes(quotes ...Quote) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := range quotes {
+		rec := quoteRecord{Type: RecordQuote, Quote: &quotes[i]}
+		if t := quotes[i].RegularMarketTime; t > 0 {
+			rec.Time = time.Unix(t, 0).UTC()
+		}
+		if err := w.enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteStreamMessages writes a line for each stream message
+func (w *JSONLWriter) WriteStreamMessages(msgs ...StreamMessage) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := range msgs {
+		rec := tickRecord{Type: RecordTick, Symbol: msgs[i].ID, StreamMessage: &msgs[i]}
+		if t := msgs[i].Time; t > 0 {
+			rec.Time = time.UnixMilli(t).UTC()
+		}
+		if err := w.enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
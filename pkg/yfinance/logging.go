@@ -0,0 +1,43 @@
+package yfinance
+
+import (
+	"log/slog"
+	"net/url"
+)
+
+// discardLogger is used when no logger is configured
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// WithLogger sets the logger for debug logs of requests, retries, cache
+// hits and misses, and authentication
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithStreamLogger sets the logger for debug logs of connections,
+// subscriptions, and read errors
+func WithStreamLogger(logger *slog.Logger) StreamOption {
+	return func(s *Stream) {
+		s.logger = logger
+	}
+}
+
+// Logger returns the client's logger, which discards logs unless one was
+// set with WithLogger
+func (c *Client) Logger() *slog.Logger {
+	return c.logger
+}
+
+// redactURL returns u as a string with the crumb hidden
+func redactURL(u *url.URL) string {
+	if !u.Query().Has("crumb") {
+		return u.String()
+	}
+	redacted := *u
+	query := u.Query()
+	query.Set("crumb", "REDACTED")
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
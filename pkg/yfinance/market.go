@@ -3,7 +3,6 @@ package yfinance
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 )
 
 // GetMarketSummary fetches market summary data
@@ -18,7 +17,7 @@ func GetMarketSummary(ctx context.Context) (*MarketSummary, error) {
 
 // GetMarketSummaryWithClient fetches market summary using a specific client
 func GetMarketSummaryWithClient(ctx context.Context, client *Client) (*MarketSummary, error) {
-	data, err := client.Get(ctx, MarketSummaryURL, nil)
+	data, err := client.Get(ctx, client.endpoints.MarketSummary, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +33,7 @@ func GetMarketSummaryWithClient(ctx context.Context, client *Client) (*MarketSum
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse market summary response: %w", err)
+		return nil, parseError(client.endpoints.MarketSummary, nil, data, "market summary response", err)
 	}
 
 	if response.MarketSummaryResponse.Error != nil {
@@ -67,7 +66,7 @@ func GetMarketTime(ctx context.Context, exchange string) (*MarketTime, error) {
 
 // GetMarketTimeWithClient fetches market time using a specific client
 func GetMarketTimeWithClient(ctx context.Context, client *Client, exchange string) (*MarketTime, error) {
-	data, err := client.Get(ctx, MarketTimeURL, nil)
+	data, err := client.Get(ctx, client.endpoints.MarketTime, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +82,7 @@ func GetMarketTimeWithClient(ctx context.Context, client *Client, exchange strin
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse market time response: %w", err)
+		return nil, parseError(client.endpoints.MarketTime, nil, data, "market time response", err)
 	}
 
 	if response.Finance.Error != nil {
@@ -145,11 +144,12 @@ func GetTrendingWithClient(ctx context.Context, client *Client, region string, c
 // Major index symbols
 var (
 	// US Indices
-	IndexSP500    = "^GSPC"
-	IndexDowJones = "^DJI"
-	IndexNasdaq   = "^IXIC"
-	IndexRussell  = "^RUT"
-	IndexVIX      = "^VIX"
+	IndexSP500     = "^GSPC"
+	IndexDowJones  = "^DJI"
+	IndexNasdaq    = "^IXIC"
+	IndexNasdaq100 = "^NDX"
+	IndexRussell   = "^RUT"
+	IndexVIX       = "^VIX"
 
 	// International Indices
 	IndexFTSE100  = "^FTSE"
@@ -0,0 +1,122 @@
+// Package metrics exports Yahoo Finance client activity as Prometheus
+// metrics.
+//
+// A Collector is both a prometheus.Collector and a yfinance.Observer:
+//
+//	m := metrics.NewCollector("myapp")
+//	prometheus.MustRegister(m)
+//	client, _ := yfinance.NewClient(yfinance.WithObserver(m))
+//
+// Streams created with yfinance.NewStream pick up the default client's
+// observers; others can use yfinance.WithStreamObserver.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// Collector records client requests, cache lookups, rate limiter waits, and
+// stream messages. The cache hit ratio is
+// rate(cache_lookups_total{result="hit"}) over rate(cache_lookups_total),
+// and stream throughput is rate(stream_messages_total).
+type Collector struct {
+	requests       *prometheus.CounterVec
+	duration       *prometheus.HistogramVec
+	cacheLookups   *prometheus.CounterVec
+	rateLimitWait  prometheus.Histogram
+	streamMessages prometheus.Counter
+}
+
+var _ yfinance.Observer = (*Collector)(nil)
+
+// NewCollector creates a collector whose metrics are prefixed with
+// namespace, e.g. "myapp" gives myapp_yfinance_requests_total
+func NewCollector(namespace string) *Collector {
+	const subsystem = "yfinance"
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "Yahoo Finance HTTP requests by endpoint, method, and status (\"error\" when no response was received).",
+		}, []string{"endpoint", "method", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Yahoo Finance HTTP request latency by endpoint and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint", "method"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_lookups_total",
+			Help:      "Response cache lookups by data type and result (hit or miss).",
+		}, []string{"type", "result"}),
+		rateLimitWait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "rate_limit_wait_seconds",
+			Help:      "Time spent waiting for the client-side rate limiter.",
+			Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		}),
+		streamMessages: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "stream_messages_total",
+			Help:      "Messages received on the live quote stream.",
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.cacheLookups.Describe(ch)
+	c.rateLimitWait.Describe(ch)
+	c.streamMessages.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.cacheLookups.Collect(ch)
+	c.rateLimitWait.Collect(ch)
+	c.streamMessages.Collect(ch)
+}
+
+// ObserveRequest implements yfinance.Observer
+func (c *Collector) ObserveRequest(endpoint, method string, status int, duration time.Duration, err error) {
+	code := "error"
+	if err == nil && status > 0 {
+		code = strconv.Itoa(status)
+	}
+	c.requests.WithLabelValues(endpoint, method, code).Inc()
+	c.duration.WithLabelValues(endpoint, method).Observe(duration.Seconds())
+}
+
+// ObserveCache implements yfinance.Observer
+func (c *Collector) ObserveCache(dataType yfinance.DataType, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	c.cacheLookups.WithLabelValues(string(dataType), result).Inc()
+}
+
+// ObserveRateLimitWait implements yfinance.Observer
+func (c *Collector) ObserveRateLimitWait(wait time.Duration) {
+	c.rateLimitWait.Observe(wait.Seconds())
+}
+
+// ObserveStreamMessage implements yfinance.Observer
+func (c *Collector) ObserveStreamMessage() {
+	c.streamMessages.Inc()
+}
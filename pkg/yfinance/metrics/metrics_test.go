@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// session supplies a crumb so requests skip authentication
+type session struct{}
+
+func (session) LoadSession() (*yfinance.Session, error) {
+	return &yfinance.Session{Crumb: "test", Created: time.Now()}, nil
+}
+
+func (session) SaveSession(*yfinance.Session) error { return nil }
+
+// TestCollector tests that client activity is recorded
+func TestCollector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	m := NewCollector("test")
+	client, err := yfinance.NewClient(
+		yfinance.WithObserver(m),
+		yfinance.WithSessionStore(session{}),
+		yfinance.WithRateLimiter(100, 10),
+		yfinance.WithCache(yfinance.NewCache(yfinance.CacheConfig{Type: yfinance.CacheTypeMemory, DefaultTTL: time.Minute, MaxSize: 10})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), srv.URL+"/v8/finance/chart/AAPL", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m.ObserveStreamMessage()
+
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("chart", "GET", "200")); got != 1 {
+		t.Errorf("Expected 1 chart request, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.cacheLookups.WithLabelValues("other", "hit")); got != 1 {
+		t.Errorf("Expected 1 cache hit, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.cacheLookups.WithLabelValues("other", "miss")); got != 1 {
+		t.Errorf("Expected 1 cache miss, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.streamMessages); got != 1 {
+		t.Errorf("Expected 1 stream message, got %v", got)
+	}
+	if n := testutil.CollectAndCount(m, "test_yfinance_rate_limit_wait_seconds"); n != 1 {
+		t.Errorf("Expected rate limiter waits, got %d series", n)
+	}
+}
@@ -0,0 +1,109 @@
+package yfinance
+
+import (
+	"net/http"
+)
+
+// Middleware wraps the transport that sends requests, to observe or change
+// them: logging, metrics, extra headers, request signing, and so on. It sees
+// every HTTP request a Client makes, including authentication and each
+// retry, and the websocket handshake of a Stream.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// WithMiddleware adds middleware to the client. The first middleware given
+// is the outermost, so it sees requests first and responses last.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithRequestHook calls hook with each request before it is sent. A hook
+// error fails the request without sending it.
+func WithRequestHook(hook func(req *http.Request) error) ClientOption {
+	return WithMiddleware(RequestHook(hook))
+}
+
+// WithResponseHook calls hook with each response before the client reads
+// it. A hook error fails the request.
+func WithResponseHook(hook func(resp *http.Response) error) ClientOption {
+	return WithMiddleware(ResponseHook(hook))
+}
+
+// RequestHook returns middleware that calls hook before each request
+func RequestHook(hook func(req *http.Request) error) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// RoundTrippers must not modify the caller's request
+			req = req.Clone(req.Context())
+			if err := hook(req); err != nil {
+				return nil, &hookError{err}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// ResponseHook returns middleware that calls hook after each response
+func ResponseHook(hook func(resp *http.Response) error) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			if err := hook(resp); err != nil {
+				if resp.Body != nil {
+					_ = resp.Body.Close()
+				}
+				return nil, &hookError{err}
+			}
+			return resp, nil
+		})
+	}
+}
+
+// hookError is an error returned by a hook. Requests failed by hooks are not
+// retried.
+type hookError struct {
+	err error
+}
+
+func (e *hookError) Error() string { return e.err.Error() }
+
+func (e *hookError) Unwrap() error { return e.err }
+
+// Middleware returns the client's middleware, outermost first
+func (c *Client) Middleware() []Middleware {
+	return append([]Middleware(nil), c.middleware...)
+}
+
+// chain wraps base in mw, with mw[0] outermost
+func chain(base http.RoundTripper, mw []Middleware) http.RoundTripper {
+	for i := len(mw) - 1; i >= 0; i-- {
+		base = mw[i](base)
+	}
+	return base
+}
+
+// applyMiddleware wraps the HTTP client's transport in the middleware, with
+// observers innermost so they time only the request itself
+func (c *Client) applyMiddleware() {
+	if len(c.middleware) == 0 && len(c.observers) == 0 {
+		return
+	}
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if len(c.observers) > 0 {
+		base = observingTransport(base, c.observers, c.endpoints.Name)
+	}
+	c.setTransport(chain(base, c.middleware))
+}
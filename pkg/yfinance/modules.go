@@ -53,6 +53,7 @@ var QuoteSummaryModules = []string{
 
 	// Other
 	"futuresChain",
+	"components",
 }
 
 // Module category constants for easier grouping
@@ -111,6 +112,7 @@ const (
 
 	// Other
 	ModuleFuturesChain = "futuresChain"
+	ModuleComponents   = "components" // Members of an index
 )
 
 // DefaultModules returns a commonly used set of modules for basic info.
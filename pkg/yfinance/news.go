@@ -3,7 +3,6 @@ package yfinance
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/url"
 	"strconv"
 )
@@ -30,7 +29,7 @@ func GetNewsWithClient(ctx context.Context, client *Client, symbols []string, co
 	params.Set("newsCount", strconv.Itoa(count))
 	params.Set("quotesCount", "0")
 
-	data, err := client.Get(ctx, SearchURL, params)
+	data, err := client.Get(ctx, client.endpoints.Search, params)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +39,7 @@ func GetNewsWithClient(ctx context.Context, client *Client, symbols []string, co
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse news response: %w", err)
+		return nil, parseError(client.endpoints.Search, params, data, "news response", err)
 	}
 
 	return response.News, nil
@@ -0,0 +1,106 @@
+package yfinance
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Observer is notified of client and stream activity, for metrics.
+// Implementations must be safe for concurrent use and return quickly.
+type Observer interface {
+	// ObserveRequest is called after each HTTP request, including
+	// authentication and retries. endpoint comes from Endpoints.Name; status is
+	// 0 when the request failed without a response.
+	ObserveRequest(endpoint, method string, status int, duration time.Duration, err error)
+	// ObserveCache is called for each cache lookup
+	ObserveCache(dataType DataType, hit bool)
+	// ObserveRateLimitWait is called with the time spent waiting for the
+	// rate limiter before each request or stream message
+	ObserveRateLimitWait(wait time.Duration)
+	// ObserveStreamMessage is called for each message received on a stream
+	ObserveStreamMessage()
+}
+
+// WithObserver adds an observer of the client's activity
+func WithObserver(o Observer) ClientOption {
+	return func(c *Client) {
+		c.observers = append(c.observers, o)
+	}
+}
+
+// WithStreamObserver adds an observer of the stream's activity
+func WithStreamObserver(o Observer) StreamOption {
+	return func(s *Stream) {
+		s.observers = append(s.observers, o)
+	}
+}
+
+// Observers returns the client's observers
+func (c *Client) Observers() []Observer {
+	return append([]Observer(nil), c.observers...)
+}
+
+// endpointNames maps API paths to low-cardinality names. More specific
+// prefixes come first.
+var endpointNames = []struct {
+	prefix string
+	name   string
+}{
+	{"/v8/finance/chart", "chart"},
+	{"/v10/finance/quoteSummary", "quoteSummary"},
+	{"/v6/finance/quote/marketSummary", "marketSummary"},
+	{"/v6/finance/quote", "quote"},
+	{"/v7/finance/quote", "quote"},
+	{"/v7/finance/options", "options"},
+	{"/ws/fundamentals-timeseries", "fundamentals"},
+	{"/v6/finance/recommendationsbysymbol", "recommendations"},
+	{"/v1/finance/search", "search"},
+	{"/v1/finance/lookup", "lookup"},
+	{"/v1/finance/screener", "screener"},
+	{"/v6/finance/markettime", "marketTime"},
+	{"/v1/finance/sectors", "sector"},
+	{"/v1/finance/industries", "industry"},
+	{"/v1/finance/visualization", "calendar"},
+	{"/xhr/ncp", "news"},
+	{"/v1/test/getcrumb", "crumb"},
+}
+
+// EndpointName returns a low-cardinality name for a request URL, such as
+// "chart" or "quoteSummary", leaving out symbols and parameters so it can be
+// used as a metric label. It knows only Yahoo's own hosts and paths; a
+// client names its requests with Endpoints.Name, which also follows
+// endpoints it was configured with. Unknown URLs are "other".
+func EndpointName(u *url.URL) string {
+	switch {
+	case u.Host == "fc.yahoo.com":
+		return "cookie"
+	case strings.HasPrefix(u.Host, "streamer."):
+		return "stream"
+	}
+	for _, e := range endpointNames {
+		if strings.HasPrefix(u.Path, e.prefix) {
+			return e.name
+		}
+	}
+	return "other"
+}
+
+// observingTransport reports each request to observers, naming its endpoint
+// with name
+func observingTransport(next http.RoundTripper, observers []Observer, name func(*url.URL) string) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		var status int
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		endpoint, duration := name(req.URL), time.Since(start)
+		for _, o := range observers {
+			o.ObserveRequest(endpoint, req.Method, status, duration, err)
+		}
+		return resp, err
+	})
+}
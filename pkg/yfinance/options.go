@@ -0,0 +1,177 @@
+package yfinance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ExpiryChain holds the calls and puts of one expiration
+type ExpiryChain struct {
+	Expiration time.Time `json:"expiration"`
+	Calls      []Option  `json:"calls"`
+	Puts       []Option  `json:"puts"`
+}
+
+// AllOptionChains holds the option chains of every expiration of a
+// ticker, nearest first
+type AllOptionChains struct {
+	Symbol          string        `json:"symbol"`
+	UnderlyingPrice float64       `json:"underlyingPrice"`
+	Expirations     []ExpiryChain `json:"expirations"`
+}
+
+// OptionsParams narrows an option chain to the contracts of interest.
+// Yahoo always sends the full chain of an expiration, so the filters run
+// after the fetch, but callers get back only what they asked for. Zero
+// fields do not filter.
+type OptionsParams struct {
+	Expiration time.Time // Expiry day; zero means the nearest expiration
+	MinStrike  float64
+	MaxStrike  float64
+	// Moneyness keeps strikes within this fraction of the underlying
+	// price, so 0.1 keeps strikes from 10% below to 10% above it
+	Moneyness       float64
+	CallsOnly       bool
+	PutsOnly        bool
+	MinOpenInterest int64
+	MinVolume       int64
+}
+
+// Validate reports whether the parameters are consistent
+func (p OptionsParams) Validate() error {
+	if p.CallsOnly && p.PutsOnly {
+		return errors.New("options: CallsOnly and PutsOnly are exclusive")
+	}
+	if p.MinStrike < 0 || p.MaxStrike < 0 || p.Moneyness < 0 || p.MinOpenInterest < 0 || p.MinVolume < 0 {
+		return errors.New("options: filters must not be negative")
+	}
+	if p.MaxStrike > 0 && p.MinStrike > p.MaxStrike {
+		return fmt.Errorf("options: min strike %g is above max strike %g", p.MinStrike, p.MaxStrike)
+	}
+	return nil
+}
+
+// Filter returns a copy of the chain with only the contracts and strikes
+// matching the parameters. Expiration is not applied, since a chain holds
+// one expiration.
+func (p OptionsParams) Filter(chain *OptionChain) *OptionChain {
+	filtered := &OptionChain{
+		Symbol:          chain.Symbol,
+		UnderlyingPrice: chain.UnderlyingPrice,
+		ExpirationDates: chain.ExpirationDates,
+	}
+	for _, strike := range chain.Strikes {
+		if p.keepStrike(strike, chain.UnderlyingPrice) {
+			filtered.Strikes = append(filtered.Strikes, strike)
+		}
+	}
+	if !p.PutsOnly {
+		filtered.Calls = p.filterContracts(chain.Calls, chain.UnderlyingPrice)
+	}
+	if !p.CallsOnly {
+		filtered.Puts = p.filterContracts(chain.Puts, chain.UnderlyingPrice)
+	}
+	return filtered
+}
+
+// filterContracts keeps the contracts matching the parameters
+func (p OptionsParams) filterContracts(contracts []Option, spot float64) []Option {
+	var kept []Option
+	for _, o := range contracts {
+		if p.keepStrike(o.Strike, spot) && o.OpenInterest >= p.MinOpenInterest && o.Volume >= p.MinVolume {
+			kept = append(kept, o)
+		}
+	}
+	return kept
+}
+
+// keepStrike reports whether the strike is within the strike range and
+// the moneyness window around spot
+func (p OptionsParams) keepStrike(strike, spot float64) bool {
+	if strike < p.MinStrike || (p.MaxStrike > 0 && strike > p.MaxStrike) {
+		return false
+	}
+	return p.Moneyness == 0 || spot <= 0 || math.Abs(strike-spot)/spot <= p.Moneyness
+}
+
+// OptionsWithParams fetches the option chain of params.Expiration and
+// filters it with params
+func (t *Ticker) OptionsWithParams(ctx context.Context, params OptionsParams) (*OptionChain, error) {
+	if err := params.Validate(); err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+	var expiration string
+	if !params.Expiration.IsZero() {
+		day := params.Expiration
+		expiration = strconv.FormatInt(time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC).Unix(), 10)
+	}
+	chain, err := t.Options(ctx, expiration)
+	if err != nil {
+		return nil, err
+	}
+	return params.Filter(chain), nil
+}
+
+// optionsConcurrency bounds the expirations OptionsAll fetches at once
+const optionsConcurrency = 4
+
+// OptionExpirations fetches the ticker's option expiration dates, nearest
+// first. Expirations are midnight UTC of the expiry day, as Yahoo reports
+// them.
+func (t *Ticker) OptionExpirations(ctx context.Context) ([]time.Time, error) {
+	chain, err := t.Options(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	expirations := make([]time.Time, len(chain.ExpirationDates))
+	for i, ts := range chain.ExpirationDates {
+		expirations[i] = time.Unix(ts, 0).UTC()
+	}
+	return expirations, nil
+}
+
+// OptionsAll fetches the option chains of every expiration, a few at a
+// time. It fails if any expiration cannot be fetched.
+func (t *Ticker) OptionsAll(ctx context.Context) (*AllOptionChains, error) {
+	ctx, span := t.startSpan(ctx, "OptionsAll")
+	defer span.End()
+
+	// The default chain lists the expirations and is the nearest one
+	nearest, err := t.Options(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	all := &AllOptionChains{
+		Symbol:          t.Symbol,
+		UnderlyingPrice: nearest.UnderlyingPrice,
+		Expirations:     make([]ExpiryChain, len(nearest.ExpirationDates)),
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(optionsConcurrency)
+	for i, ts := range nearest.ExpirationDates {
+		all.Expirations[i].Expiration = time.Unix(ts, 0).UTC()
+		if i == 0 {
+			all.Expirations[i].Calls, all.Expirations[i].Puts = nearest.Calls, nearest.Puts
+			continue
+		}
+		g.Go(func() error {
+			chain, err := t.Options(ctx, strconv.FormatInt(ts, 10))
+			if err != nil {
+				return err
+			}
+			all.Expirations[i].Calls, all.Expirations[i].Puts = chain.Calls, chain.Puts
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
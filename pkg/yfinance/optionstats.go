@@ -0,0 +1,124 @@
+package yfinance
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+// ChainAnalytics are the positioning statistics of one expiration's option
+// chain
+type ChainAnalytics struct {
+	Expiration       time.Time `json:"expiration,omitzero"`
+	MaxPain          float64   `json:"maxPain"` // Strike at which open contracts pay holders least at expiry
+	CallVolume       int64     `json:"callVolume"`
+	PutVolume        int64     `json:"putVolume"`
+	CallOpenInterest int64     `json:"callOpenInterest"`
+	PutOpenInterest  int64     `json:"putOpenInterest"`
+	// Put/call ratios are zero when there are no calls
+	PutCallVolumeRatio       float64          `json:"putCallVolumeRatio"`
+	PutCallOpenInterestRatio float64          `json:"putCallOpenInterestRatio"`
+	CallWalls                []StrikeInterest `json:"callWalls"` // Strikes with the most call open interest, largest first
+	PutWalls                 []StrikeInterest `json:"putWalls"`  // Strikes with the most put open interest, largest first
+}
+
+// StrikeInterest is the open interest at one strike
+type StrikeInterest struct {
+	Strike       float64 `json:"strike"`
+	OpenInterest int64   `json:"openInterest"`
+}
+
+// openInterestWalls is how many strikes CallWalls and PutWalls list
+const openInterestWalls = 3
+
+// Analytics computes max pain, put/call ratios, and open interest walls of
+// the chain
+func (o *OptionChain) Analytics() *ChainAnalytics {
+	a := chainAnalytics(o.Calls, o.Puts)
+	if len(o.Calls) > 0 {
+		a.Expiration = time.Unix(o.Calls[0].Expiration, 0).UTC()
+	} else if len(o.Puts) > 0 {
+		a.Expiration = time.Unix(o.Puts[0].Expiration, 0).UTC()
+	}
+	return a
+}
+
+// Analytics computes the analytics of each expiration, nearest first
+func (a *AllOptionChains) Analytics() []ChainAnalytics {
+	analytics := make([]ChainAnalytics, len(a.Expirations))
+	for i, e := range a.Expirations {
+		analytics[i] = *chainAnalytics(e.Calls, e.Puts)
+		analytics[i].Expiration = e.Expiration
+	}
+	return analytics
+}
+
+// chainAnalytics computes the analytics of one expiration's contracts
+func chainAnalytics(calls, puts []Option) *ChainAnalytics {
+	a := &ChainAnalytics{}
+	for _, c := range calls {
+		a.CallVolume += c.Volume
+		a.CallOpenInterest += c.OpenInterest
+	}
+	for _, p := range puts {
+		a.PutVolume += p.Volume
+		a.PutOpenInterest += p.OpenInterest
+	}
+	if a.CallVolume > 0 {
+		a.PutCallVolumeRatio = float64(a.PutVolume) / float64(a.CallVolume)
+	}
+	if a.CallOpenInterest > 0 {
+		a.PutCallOpenInterestRatio = float64(a.PutOpenInterest) / float64(a.CallOpenInterest)
+	}
+	a.MaxPain = maxPain(calls, puts)
+	a.CallWalls = walls(calls)
+	a.PutWalls = walls(puts)
+	return a
+}
+
+// maxPain returns the strike minimizing the total value of open contracts
+// at expiry, or zero if there is no open interest
+func maxPain(calls, puts []Option) float64 {
+	var strikes []float64
+	for _, o := range slices.Concat(calls, puts) {
+		if o.OpenInterest > 0 {
+			strikes = append(strikes, o.Strike)
+		}
+	}
+	slices.Sort(strikes)
+	strikes = slices.Compact(strikes)
+
+	best, lowest := 0.0, math.Inf(1)
+	for _, x := range strikes {
+		var payout float64
+		for _, c := range calls {
+			payout += float64(c.OpenInterest) * math.Max(x-c.Strike, 0)
+		}
+		for _, p := range puts {
+			payout += float64(p.OpenInterest) * math.Max(p.Strike-x, 0)
+		}
+		if payout < lowest {
+			best, lowest = x, payout
+		}
+	}
+	return best
+}
+
+// walls returns the strikes with the most open interest, largest first
+func walls(contracts []Option) []StrikeInterest {
+	byStrike := make(map[float64]int64)
+	for _, o := range contracts {
+		if o.OpenInterest > 0 {
+			byStrike[o.Strike] += o.OpenInterest
+		}
+	}
+	strikes := make([]StrikeInterest, 0, len(byStrike))
+	for strike, oi := range byStrike {
+		strikes = append(strikes, StrikeInterest{Strike: strike, OpenInterest: oi})
+	}
+	slices.SortFunc(strikes, func(a, b StrikeInterest) int {
+		return cmp.Or(cmp.Compare(b.OpenInterest, a.OpenInterest), cmp.Compare(a.Strike, b.Strike))
+	})
+	return strikes[:min(len(strikes), openInterestWalls)]
+}
@@ -0,0 +1,243 @@
+package yfinance
+
+import (
+	"encoding/csv"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// BarField names a column of bar data
+type BarField string
+
+const (
+	FieldOpen     BarField = "open"
+	FieldHigh     BarField = "high"
+	FieldLow      BarField = "low"
+	FieldClose    BarField = "close"
+	FieldAdjClose BarField = "adjClose"
+	FieldVolume   BarField = "volume"
+)
+
+// panelFields are the fields a Panel holds, in bar order
+var panelFields = []BarField{FieldOpen, FieldHigh, FieldLow, FieldClose, FieldAdjClose, FieldVolume}
+
+// FillMethod controls how a Panel fills times a symbol has no bar for
+type FillMethod int
+
+const (
+	// FillNone leaves gaps as NaN
+	FillNone FillMethod = iota
+	// FillForward carries the last prices forward with zero volume. Times
+	// before a symbol's first bar stay NaN.
+	FillForward
+)
+
+// Join controls which times a Panel's index includes
+type Join int
+
+const (
+	// JoinOuter indexes every time any symbol has a bar
+	JoinOuter Join = iota
+	// JoinInner indexes only the times every symbol has a bar
+	JoinInner
+)
+
+// PanelOptions configures how NewPanel aligns charts
+type PanelOptions struct {
+	Fill FillMethod
+	Join Join
+}
+
+// Panel holds several symbols' bars aligned on one time index, like a
+// data frame with a column per symbol and field. Daily and longer bars are
+// indexed by their trading date in the bar's own time zone, which is the
+// exchange's for fetched charts, at midnight UTC, so exchanges that open
+// at different times line up; intraday bars by their UTC time. Correlation,
+// Betas, and the portfolio package align series the same way.
+type Panel struct {
+	Symbols []string    // Sorted
+	Index   []time.Time // Oldest first
+
+	values map[BarField][][]float64 // By field, then symbol, then index row
+}
+
+// Panel aligns the result's charts with NewPanel
+func (r *DownloadResult) Panel(opts PanelOptions) *Panel {
+	return NewPanel(r.Data, opts)
+}
+
+// NewPanel aligns charts, keyed by symbol, on a common time index. Bars
+// without a close count as missing, and rows with no bar for a symbol hold
+// NaN unless opts.Fill fills them.
+func NewPanel(charts map[string]*ChartData, opts PanelOptions) *Panel {
+	p := &Panel{values: make(map[BarField][][]float64, len(panelFields))}
+	for _, sym := range slices.Sorted(maps.Keys(charts)) {
+		if charts[sym] != nil {
+			p.Symbols = append(p.Symbols, sym)
+		}
+	}
+
+	byTime := make([]map[time.Time]Bar, len(p.Symbols))
+	counts := make(map[time.Time]int)
+	for i, sym := range p.Symbols {
+		chart := charts[sym]
+		interval := chart.Interval
+		if interval == "" && chart.Meta != nil {
+			interval = Interval(chart.Meta.DataGranularity)
+		}
+		daily := intervalDuration(interval) == 0
+		byTime[i] = make(map[time.Time]Bar, len(chart.Bars))
+		for _, b := range chart.Bars {
+			if b.Close <= 0 {
+				continue
+			}
+			t := b.Timestamp.UTC()
+			if daily {
+				t = time.Date(b.Timestamp.Year(), b.Timestamp.Month(), b.Timestamp.Day(), 0, 0, 0, 0, time.UTC)
+			}
+			if _, dup := byTime[i][t]; !dup {
+				counts[t]++
+			}
+			byTime[i][t] = b
+		}
+	}
+	for t, n := range counts {
+		if opts.Join == JoinOuter || n == len(p.Symbols) {
+			p.Index = append(p.Index, t)
+		}
+	}
+	slices.SortFunc(p.Index, time.Time.Compare)
+
+	for _, f := range panelFields {
+		p.values[f] = make([][]float64, len(p.Symbols))
+	}
+	for i := range p.Symbols {
+		for _, f := range panelFields {
+			p.values[f][i] = make([]float64, len(p.Index))
+		}
+		var last Bar
+		var seen bool
+		for row, t := range p.Index {
+			b, ok := byTime[i][t]
+			switch {
+			case ok:
+				last, seen = b, true
+			case opts.Fill == FillForward && seen:
+				b = Bar{Open: last.Close, High: last.Close, Low: last.Close, Close: last.Close, AdjClose: last.AdjClose}
+			default:
+				for _, f := range panelFields {
+					p.values[f][i][row] = math.NaN()
+				}
+				continue
+			}
+			for k, v := range []float64{b.Open, b.High, b.Low, b.Close, b.AdjClose, float64(b.Volume)} {
+				p.values[panelFields[k]][i][row] = v
+			}
+		}
+	}
+	return p
+}
+
+// Len returns the number of rows in the index
+func (p *Panel) Len() int {
+	return len(p.Index)
+}
+
+// Slice returns the rows of the panel from start through end. A zero start
+// or end leaves that side unbounded.
+func (p *Panel) Slice(start, end time.Time) *Panel {
+	lo := 0
+	for lo < len(p.Index) && !start.IsZero() && p.Index[lo].Before(start) {
+		lo++
+	}
+	hi := len(p.Index)
+	for hi > lo && !end.IsZero() && p.Index[hi-1].After(end) {
+		hi--
+	}
+
+	sliced := &Panel{
+		Symbols: p.Symbols,
+		Index:   p.Index[lo:hi:hi],
+		values:  make(map[BarField][][]float64, len(p.values)),
+	}
+	for f, columns := range p.values {
+		sliced.values[f] = make([][]float64, len(columns))
+		for i, c := range columns {
+			sliced.values[f][i] = c[lo:hi:hi]
+		}
+	}
+	return sliced
+}
+
+// Column returns a copy of one symbol's field, a value per index row, or
+// nil if the symbol or field is unknown
+func (p *Panel) Column(symbol string, field BarField) []float64 {
+	i := slices.Index(p.Symbols, symbol)
+	if i < 0 || p.values[field] == nil {
+		return nil
+	}
+	return slices.Clone(p.values[field][i])
+}
+
+// Values returns a field as rows of the index by columns of Symbols, such
+// as the close of every symbol at each time
+func (p *Panel) Values(field BarField) [][]float64 {
+	columns := p.values[field]
+	if columns == nil {
+		return nil
+	}
+	rows := make([][]float64, len(p.Index))
+	for row := range rows {
+		rows[row] = make([]float64, len(p.Symbols))
+		for col := range p.Symbols {
+			rows[row][col] = columns[col][row]
+		}
+	}
+	return rows
+}
+
+// Matrix returns a field as a row-major matrix of the index by Symbols, in
+// the layout gonum's mat.NewDense(rows, cols, data) takes
+func (p *Panel) Matrix(field BarField) (rows, cols int, data []float64) {
+	columns := p.values[field]
+	if columns == nil {
+		return 0, 0, nil
+	}
+	rows, cols = len(p.Index), len(p.Symbols)
+	data = make([]float64, 0, rows*cols)
+	for row := range rows {
+		for col := range cols {
+			data = append(data, columns[col][row])
+		}
+	}
+	return rows, cols, data
+}
+
+// WriteCSV writes a field as CSV with a time column and a column per
+// symbol. Times are RFC 3339, and NaN values are left empty.
+func (p *Panel) WriteCSV(w io.Writer, field BarField) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{"time"}, p.Symbols...)); err != nil {
+		return err
+	}
+	for row, values := range p.Values(field) {
+		record := make([]string, 0, len(values)+1)
+		record = append(record, p.Index[row].Format(time.RFC3339))
+		for _, v := range values {
+			if math.IsNaN(v) {
+				record = append(record, "")
+			} else {
+				record = append(record, strconv.FormatFloat(v, 'f', -1, 64))
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
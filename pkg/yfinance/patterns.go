@@ -0,0 +1,135 @@
+package yfinance
+
+import (
+	"math"
+	"time"
+)
+
+// CandlePattern names a candlestick pattern
+type CandlePattern string
+
+const (
+	Doji               CandlePattern = "doji"
+	Hammer             CandlePattern = "hammer"
+	BullishEngulfing   CandlePattern = "bullish_engulfing"
+	BearishEngulfing   CandlePattern = "bearish_engulfing"
+	MorningStar        CandlePattern = "morning_star"
+	EveningStar        CandlePattern = "evening_star"
+	ThreeWhiteSoldiers CandlePattern = "three_white_soldiers"
+)
+
+// PatternDirection is the price move a pattern suggests
+type PatternDirection string
+
+const (
+	Bullish PatternDirection = "bullish"
+	Bearish PatternDirection = "bearish"
+	Neutral PatternDirection = "neutral" // Indecision, as for a doji
+)
+
+// PatternEvent is a pattern completed by the bar at Index
+type PatternEvent struct {
+	Pattern   CandlePattern    `json:"pattern"`
+	Direction PatternDirection `json:"direction"`
+	Date      time.Time        `json:"date"`
+	Index     int              `json:"index"` // Index of the pattern's last bar
+}
+
+// Patterns detects candlestick patterns in the chart's bars
+func (c *ChartData) Patterns() []PatternEvent {
+	return DetectPatterns(c.Bars)
+}
+
+// DetectPatterns finds doji, hammer, engulfing, morning and evening star,
+// and three white soldiers patterns in bars, oldest first, in the order
+// they complete. Patterns read only shape, except that a hammer must
+// follow a decline; bars without prices never match.
+func DetectPatterns(bars []Bar) []PatternEvent {
+	var events []PatternEvent
+	add := func(i int, pattern CandlePattern, direction PatternDirection) {
+		events = append(events, PatternEvent{Pattern: pattern, Direction: direction, Date: bars[i].Timestamp, Index: i})
+	}
+
+	for i, b := range bars {
+		c := newCandle(b)
+		if !c.valid {
+			continue
+		}
+		if c.body <= 0.1*c.size {
+			add(i, Doji, Neutral)
+		}
+		// A long lower shadow after a decline: sellers were pushed back
+		if i >= 3 && c.body > 0 && c.lower >= 2*c.body && c.upper <= 0.1*c.size &&
+			bars[i-1].Close < bars[i-3].Close {
+			add(i, Hammer, Bullish)
+		}
+		if i < 1 {
+			continue
+		}
+
+		prev := newCandle(bars[i-1])
+		if prev.valid && c.body > prev.body {
+			switch {
+			case prev.bearish() && c.bullish() && b.Open <= bars[i-1].Close && b.Close >= bars[i-1].Open:
+				add(i, BullishEngulfing, Bullish)
+			case prev.bullish() && c.bearish() && b.Open >= bars[i-1].Close && b.Close <= bars[i-1].Open:
+				add(i, BearishEngulfing, Bearish)
+			}
+		}
+		if i < 2 {
+			continue
+		}
+
+		first := newCandle(bars[i-2])
+		if first.valid && prev.valid && first.body >= 0.5*first.size && prev.body <= 0.3*first.body {
+			midpoint := (bars[i-2].Open + bars[i-2].Close) / 2
+			switch {
+			case first.bearish() && c.bullish() && prev.top() < bars[i-2].Close && b.Close > midpoint:
+				add(i, MorningStar, Bullish)
+			case first.bullish() && c.bearish() && prev.bottom() > bars[i-2].Close && b.Close < midpoint:
+				add(i, EveningStar, Bearish)
+			}
+		}
+		if first.valid && prev.valid && soldier(bars[i-2], bars[i-1]) && soldier(bars[i-1], b) && first.bullish() {
+			add(i, ThreeWhiteSoldiers, Bullish)
+		}
+	}
+	return events
+}
+
+// soldier reports whether b is a strong bullish bar continuing prev's
+// advance: opening within prev's body, closing higher, near its high
+func soldier(prev, b Bar) bool {
+	c := newCandle(b)
+	return c.valid && c.bullish() && b.Close > prev.Close &&
+		b.Open >= math.Min(prev.Open, prev.Close) && b.Open <= math.Max(prev.Open, prev.Close) &&
+		c.upper <= 0.3*c.body
+}
+
+// candle is the shape of a bar
+type candle struct {
+	open, close  float64
+	body, size   float64
+	upper, lower float64 // Shadows above and below the body
+	valid        bool
+}
+
+func newCandle(b Bar) candle {
+	if b.Open <= 0 || b.High <= 0 || b.Low <= 0 || b.Close <= 0 || b.High < b.Low {
+		return candle{}
+	}
+	return candle{
+		open:  b.Open,
+		close: b.Close,
+		body:  math.Abs(b.Close - b.Open),
+		size:  b.High - b.Low,
+		upper: b.High - math.Max(b.Open, b.Close),
+		lower: math.Min(b.Open, b.Close) - b.Low,
+		valid: b.High > b.Low,
+	}
+}
+
+func (c candle) bullish() bool   { return c.close > c.open }
+func (c candle) bearish() bool   { return c.close < c.open }
+func (c candle) top() float64    { return math.Max(c.open, c.close) }
+func (c candle) bottom() float64 { return math.Min(c.open, c.close) }
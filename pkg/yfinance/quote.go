@@ -0,0 +1,245 @@
+package yfinance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// crumblessKey marks contexts whose requests are sent without a crumb
+type crumblessKey struct{}
+
+// withoutCrumb returns a context whose requests skip authentication
+func withoutCrumb(ctx context.Context) context.Context {
+	return context.WithValue(ctx, crumblessKey{}, true)
+}
+
+// crumbless reports whether ctx comes from withoutCrumb
+func crumbless(ctx context.Context) bool {
+	v, _ := ctx.Value(crumblessKey{}).(bool)
+	return v
+}
+
+// quoteAuthRetryDelay is how long quotes are fetched without a crumb after
+// authentication failed, before it is tried again
+const quoteAuthRetryDelay = 5 * time.Minute
+
+// quoteAuthDegraded reports whether quotes should skip authentication
+// because it failed recently
+func (c *Client) quoteAuthDegraded() bool {
+	c.crumbMu.RLock()
+	defer c.crumbMu.RUnlock()
+	return time.Now().Before(c.quoteAuthAt)
+}
+
+// degradeQuoteAuth makes quotes skip authentication for quoteAuthRetryDelay
+func (c *Client) degradeQuoteAuth() {
+	c.crumbMu.Lock()
+	c.quoteAuthAt = time.Now().Add(quoteAuthRetryDelay)
+	c.crumbMu.Unlock()
+}
+
+// DefaultQuoteChunkSize is how many symbols a quote request carries at most
+// unless WithQuoteChunkSize says otherwise
+const DefaultQuoteChunkSize = 200
+
+// WithQuoteChunkSize sets how many symbols a quote request carries at most.
+// Longer lists, which would overrun URL length and Yahoo's limits, are
+// split into requests of this size sent concurrently.
+func WithQuoteChunkSize(size int) ClientOption {
+	return func(c *Client) {
+		c.quoteChunkSize = size
+	}
+}
+
+// DefaultQuoteConcurrency is how many quote requests of one call are in
+// flight at once unless WithQuoteConcurrency says otherwise
+const DefaultQuoteConcurrency = 4
+
+// WithQuoteConcurrency sets how many of the requests a long symbol list is
+// split into are sent at once
+func WithQuoteConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.quoteConcurrency = n
+	}
+}
+
+// quotes fetches quotes for symbols, in chunks of the client's quote chunk
+// size fetched concurrently under its rate limiter. The quotes of each
+// chunk follow those of the chunk before. When chunks fail, the quotes of
+// the others are returned along with the chunks' errors joined.
+func (c *Client) quotes(ctx context.Context, symbols []string) ([]Quote, error) {
+	size := c.quoteChunkSize
+	if size <= 0 {
+		size = DefaultQuoteChunkSize
+	}
+	if len(symbols) <= size {
+		return c.quoteChunk(ctx, symbols)
+	}
+	limit := c.quoteConcurrency
+	if limit <= 0 {
+		limit = DefaultQuoteConcurrency
+	}
+
+	chunks := slices.Collect(slices.Chunk(symbols, size))
+	results := make([][]Quote, len(chunks))
+	errs := make([]error, len(chunks))
+	var g errgroup.Group
+	g.SetLimit(limit)
+	for i, chunk := range chunks {
+		g.Go(func() error {
+			results[i], errs[i] = c.quoteChunk(ctx, chunk)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return slices.Concat(results...), errors.Join(errs...)
+}
+
+// quoteChunk fetches quotes for symbols in one request. When no crumb can be
+// obtained or it is rejected, the quotes come from the v6 quote endpoint,
+// which needs none, or failing that from chart metadata, so quotes keep
+// working with fewer fields instead of failing with ErrAuthentication.
+// After such a failure, quotes go straight to the fallbacks for
+// quoteAuthRetryDelay rather than authenticating again on every call.
+func (c *Client) quoteChunk(ctx context.Context, symbols []string) ([]Quote, error) {
+	err := ErrAuthentication
+	if !c.quoteAuthDegraded() {
+		var quotes []Quote
+		quotes, err = c.quoteResponse(ctx, c.endpoints.Quote, symbols)
+		if !errors.Is(err, ErrAuthentication) {
+			return quotes, err
+		}
+		c.logger.DebugContext(ctx, "yfinance: quote authentication failed, using crumbless fallback", "error", err)
+		c.degradeQuoteAuth()
+	}
+
+	ctx = withoutCrumb(ctx)
+	if fallback, ferr := c.quoteResponse(ctx, c.endpoints.QuoteFallback, symbols); ferr == nil && len(fallback) > 0 {
+		return fallback, nil
+	}
+	if fallback, ferr := c.chartQuotes(ctx, symbols); ferr == nil {
+		return fallback, nil
+	}
+	return nil, err
+}
+
+// quoteResponse fetches quotes for symbols from a quote endpoint
+func (c *Client) quoteResponse(ctx context.Context, endpoint string, symbols []string) ([]Quote, error) {
+	params := url.Values{}
+	params.Set("symbols", joinSymbols(symbols))
+
+	data, err := c.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		QuoteResponse struct {
+			Result []Quote `json:"result"`
+			Error  *struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"quoteResponse"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, parseError(endpoint, params, data, "quote response", err)
+	}
+
+	if response.QuoteResponse.Error != nil {
+		return nil, &APIError{
+			Code:        response.QuoteResponse.Error.Code,
+			Description: response.QuoteResponse.Error.Description,
+		}
+	}
+
+	return response.QuoteResponse.Result, nil
+}
+
+// chartQuotes builds quotes for symbols from the metadata of their daily
+// charts. Like the quote endpoint, it leaves out unknown symbols.
+func (c *Client) chartQuotes(ctx context.Context, symbols []string) ([]Quote, error) {
+	params := url.Values{}
+	params.Set("range", string(Period1d))
+	params.Set("interval", string(Interval1d))
+
+	quotes := make([]*Quote, len(symbols))
+	var mu sync.Mutex
+	var errs []error
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(4)
+	for i, symbol := range symbols {
+		g.Go(func() error {
+			endpoint := fmt.Sprintf("%s/%s", c.endpoints.Chart, symbol)
+			data, err := c.Get(ctx, endpoint, params)
+			if err == nil {
+				var response chartResponse
+				if err = json.Unmarshal(data, &response); err != nil {
+					err = parseError(endpoint, params, data, "chart response", err)
+				} else if len(response.Chart.Result) > 0 {
+					quotes[i] = quoteFromChart(&response.Chart.Result[0].Meta)
+					if quotes[i].Symbol == "" {
+						quotes[i].Symbol = symbol
+					}
+				}
+			}
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, NewSymbolError(symbol, err))
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var result []Quote
+	for _, q := range quotes {
+		if q != nil {
+			result = append(result, *q)
+		}
+	}
+	if len(result) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+// quoteFromChart builds a quote from chart metadata
+func quoteFromChart(meta *ChartMeta) *Quote {
+	previous := meta.PreviousClose
+	if previous == 0 {
+		previous = meta.ChartPreviousClose
+	}
+	q := &Quote{
+		Symbol:                     meta.Symbol,
+		ShortName:                  meta.ShortName,
+		LongName:                   meta.LongName,
+		Exchange:                   meta.ExchangeName,
+		FullExchangeName:           meta.FullExchangeName,
+		QuoteType:                  meta.InstrumentType,
+		Currency:                   meta.Currency,
+		RegularMarketPrice:         meta.RegularMarketPrice,
+		RegularMarketDayHigh:       meta.RegularMarketDayHigh,
+		RegularMarketDayLow:        meta.RegularMarketDayLow,
+		RegularMarketVolume:        meta.RegularMarketVolume,
+		RegularMarketPreviousClose: previous,
+		RegularMarketTime:          meta.RegularMarketTime,
+		FiftyTwoWeekHigh:           meta.FiftyTwoWeekHigh,
+		FiftyTwoWeekLow:            meta.FiftyTwoWeekLow,
+	}
+	if previous != 0 {
+		q.RegularMarketChange = q.RegularMarketPrice - previous
+		q.RegularMarketChangePercent = q.RegularMarketChange / previous * 100
+	}
+	return q
+}
@@ -0,0 +1,84 @@
+package yfinance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Quote types Yahoo reports in QuoteTypeInfo.QuoteType and Quote.QuoteType
+const (
+	QuoteTypeEquity     = "EQUITY"
+	QuoteTypeETF        = "ETF"
+	QuoteTypeMutualFund = "MUTUALFUND"
+	QuoteTypeIndex      = "INDEX"
+	QuoteTypeCrypto     = "CRYPTOCURRENCY"
+	QuoteTypeCurrency   = "CURRENCY"
+	QuoteTypeFuture     = "FUTURE"
+	QuoteTypeOption     = "OPTION"
+)
+
+// QuoteType fetches what kind of instrument the ticker is, where it trades,
+// and when it first traded
+func (t *Ticker) QuoteType(ctx context.Context) (*QuoteTypeInfo, error) {
+	ctx, span := t.startSpan(ctx, "QuoteType")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
+	params := buildModulesParams(ModuleQuoteType)
+
+	data, err := t.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		QuoteSummary struct {
+			Result []struct {
+				QuoteType *QuoteTypeInfo `json:"quoteType"`
+			} `json:"result"`
+		} `json:"quoteSummary"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "quote type", err))
+	}
+
+	if len(response.QuoteSummary.Result) == 0 || response.QuoteSummary.Result[0].QuoteType == nil {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+	return response.QuoteSummary.Result[0].QuoteType, nil
+}
+
+// IsEquity reports whether the instrument is a stock
+func (q *QuoteTypeInfo) IsEquity() bool { return q.QuoteType == QuoteTypeEquity }
+
+// IsETF reports whether the instrument is an exchange-traded fund
+func (q *QuoteTypeInfo) IsETF() bool { return q.QuoteType == QuoteTypeETF }
+
+// IsFund reports whether the instrument is an ETF or mutual fund
+func (q *QuoteTypeInfo) IsFund() bool {
+	return q.QuoteType == QuoteTypeETF || q.QuoteType == QuoteTypeMutualFund
+}
+
+// IsIndex reports whether the instrument is an index
+func (q *QuoteTypeInfo) IsIndex() bool { return q.QuoteType == QuoteTypeIndex }
+
+// IsCrypto reports whether the instrument is a cryptocurrency
+func (q *QuoteTypeInfo) IsCrypto() bool { return q.QuoteType == QuoteTypeCrypto }
+
+// IsCurrency reports whether the instrument is an FX rate
+func (q *QuoteTypeInfo) IsCurrency() bool { return q.QuoteType == QuoteTypeCurrency }
+
+// IsFuture reports whether the instrument is a futures contract
+func (q *QuoteTypeInfo) IsFuture() bool { return q.QuoteType == QuoteTypeFuture }
+
+// FirstTraded returns when the instrument first traded, or the zero time if
+// Yahoo does not know
+func (q *QuoteTypeInfo) FirstTraded() time.Time {
+	if q.FirstTradeDate == 0 {
+		return time.Time{}
+	}
+	return time.Unix(q.FirstTradeDate, 0).UTC()
+}
@@ -0,0 +1,125 @@
+package yfinance
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// FinancialRatios are ratios derived from one fiscal year's statements.
+// Ratios are fractions, so a 25% margin is 0.25, and are zero when Yahoo
+// does not report their inputs or the denominator is zero.
+type FinancialRatios struct {
+	Date            time.Time `json:"date"` // Fiscal year end
+	GrossMargin     float64   `json:"grossMargin"`
+	OperatingMargin float64   `json:"operatingMargin"`
+	NetMargin       float64   `json:"netMargin"`
+	ROIC            float64   `json:"roic"` // After-tax operating income over invested capital
+	ROE             float64   `json:"roe"`
+	CurrentRatio    float64   `json:"currentRatio"`
+	DebtToEBITDA    float64   `json:"debtToEbitda"`
+	FCFYield        float64   `json:"fcfYield"`    // Free cash flow over the market cap at year end
+	PayoutRatio     float64   `json:"payoutRatio"` // Dividends paid over net income
+}
+
+// ratioKeys are the statement metrics Ratios needs
+var ratioKeys = []string{
+	"TotalRevenue", "GrossProfit", "OperatingIncome", "NetIncome", "PretaxIncome", "TaxProvision", "EBITDA",
+	"InvestedCapital", "StockholdersEquity", "CurrentAssets", "CurrentLiabilities", "TotalDebt",
+	"FreeCashFlow", "CashDividendsPaid",
+}
+
+// Ratios fetches the ticker's annual statements and computes margins,
+// returns on capital, liquidity, leverage, free cash flow yield, and payout
+// ratio for each fiscal year, oldest first
+func (t *Ticker) Ratios(ctx context.Context) ([]FinancialRatios, error) {
+	ctx, span := t.startSpan(ctx, "Ratios")
+	defer span.End()
+
+	types := make([]string, 0, len(ratioKeys)+1)
+	for _, key := range ratioKeys {
+		types = append(types, "annual"+key)
+	}
+	types = append(types, "trailingMarketCap")
+
+	end := time.Now()
+	series, err := t.timeseries(ctx, types, end.AddDate(-10, 0, 0), end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Statement values by fiscal year end, then metric
+	years := make(map[time.Time]map[string]float64)
+	for _, key := range ratioKeys {
+		for _, p := range series["annual"+key] {
+			date, err := time.Parse(time.DateOnly, p.AsOfDate)
+			if err != nil || !p.ReportedValue.Valid {
+				continue
+			}
+			if years[date] == nil {
+				years[date] = make(map[string]float64)
+			}
+			years[date][key] = p.ReportedValue.Raw
+		}
+	}
+	if len(years) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	var caps []DatedValue
+	for _, p := range series["trailingMarketCap"] {
+		if date, err := time.Parse(time.DateOnly, p.AsOfDate); err == nil && p.ReportedValue.Valid {
+			caps = append(caps, DatedValue{Date: date, Value: p.ReportedValue.Raw})
+		}
+	}
+	sort.Slice(caps, func(i, j int) bool { return caps[i].Date.Before(caps[j].Date) })
+
+	ratios := make([]FinancialRatios, 0, len(years))
+	for date, values := range years {
+		ratios = append(ratios, financialRatios(date, values, marketCapAt(caps, date)))
+	}
+	sort.Slice(ratios, func(i, j int) bool { return ratios[i].Date.Before(ratios[j].Date) })
+	return ratios, nil
+}
+
+// financialRatios computes one year's ratios from its statement values and
+// its market cap
+func financialRatios(date time.Time, v map[string]float64, marketCap float64) FinancialRatios {
+	// Effective tax rate, for operating income after tax
+	taxRate := 0.0
+	if v["PretaxIncome"] > 0 {
+		taxRate = min(max(v["TaxProvision"]/v["PretaxIncome"], 0), 1)
+	}
+	return FinancialRatios{
+		Date:            date,
+		GrossMargin:     ratio(v["GrossProfit"], v["TotalRevenue"]),
+		OperatingMargin: ratio(v["OperatingIncome"], v["TotalRevenue"]),
+		NetMargin:       ratio(v["NetIncome"], v["TotalRevenue"]),
+		ROIC:            ratio(v["OperatingIncome"]*(1-taxRate), v["InvestedCapital"]),
+		ROE:             ratio(v["NetIncome"], v["StockholdersEquity"]),
+		CurrentRatio:    ratio(v["CurrentAssets"], v["CurrentLiabilities"]),
+		DebtToEBITDA:    ratio(v["TotalDebt"], v["EBITDA"]),
+		FCFYield:        ratio(v["FreeCashFlow"], marketCap),
+		// Dividends paid are reported as a cash outflow
+		PayoutRatio: ratio(math.Abs(v["CashDividendsPaid"]), v["NetIncome"]),
+	}
+}
+
+// marketCapAt returns the latest market cap on or before date, or zero if
+// there is none. caps are oldest first.
+func marketCapAt(caps []DatedValue, date time.Time) float64 {
+	i := sort.Search(len(caps), func(i int) bool { return caps[i].Date.After(date) })
+	if i == 0 {
+		return 0
+	}
+	return caps[i-1].Value
+}
+
+// ratio returns a/b, or zero if b is zero
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
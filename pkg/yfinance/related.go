@@ -0,0 +1,179 @@
+package yfinance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// RelatedTicker is a symbol Yahoo recommends alongside another
+type RelatedTicker struct {
+	Symbol string  `json:"symbol"`
+	Score  float64 `json:"score"` // Higher is more related
+}
+
+// PeerStats are the key statistics of one company in a Comparison. Values
+// Yahoo does not report are zero.
+type PeerStats struct {
+	Symbol          string  `json:"symbol"`
+	Name            string  `json:"name"`
+	Currency        string  `json:"currency"`
+	Price           float64 `json:"price"`
+	MarketCap       float64 `json:"marketCap"`
+	EnterpriseValue float64 `json:"enterpriseValue"`
+	TrailingPE      float64 `json:"trailingPE"`
+	ForwardPE       float64 `json:"forwardPE"`
+	PEGRatio        float64 `json:"pegRatio"`
+	PriceToBook     float64 `json:"priceToBook"`
+	EVToEBITDA      float64 `json:"evToEbitda"`
+	EVToRevenue     float64 `json:"evToRevenue"`
+	ProfitMargin    float64 `json:"profitMargin"`
+	OperatingMargin float64 `json:"operatingMargin"`
+	ReturnOnEquity  float64 `json:"returnOnEquity"`
+	RevenueGrowth   float64 `json:"revenueGrowth"`
+	EarningsGrowth  float64 `json:"earningsGrowth"`
+	DebtToEquity    float64 `json:"debtToEquity"`
+	DividendYield   float64 `json:"dividendYield"`
+	Beta            float64 `json:"beta"`
+}
+
+// Comparison holds the key statistics of a ticker and its peers, the
+// ticker first and the peers in the order given
+type Comparison struct {
+	Peers   []PeerStats `json:"peers"`
+	Missing []string    `json:"missing,omitempty"` // Peers whose statistics could not be fetched
+}
+
+// compareConcurrency bounds the peers Compare fetches at once
+const compareConcurrency = 4
+
+// compareModules are the quoteSummary modules PeerStats are built from
+var compareModules = []string{ModulePrice, ModuleSummaryDetail, ModuleDefaultKeyStatistics, ModuleFinancialData}
+
+// RelatedTickers fetches the symbols Yahoo shows as "people also watch"
+// for the ticker, most related first
+func (t *Ticker) RelatedTickers(ctx context.Context) ([]RelatedTicker, error) {
+	ctx, span := t.startSpan(ctx, "RelatedTickers")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.Recommendations, t.Symbol)
+
+	data, err := t.client.Get(ctx, endpoint, nil)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		Finance struct {
+			Result []struct {
+				RecommendedSymbols []RelatedTicker `json:"recommendedSymbols"`
+			} `json:"result"`
+			Error *struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"finance"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, nil, data, "recommended symbols", err))
+	}
+
+	if response.Finance.Error != nil {
+		return nil, NewSymbolError(t.Symbol, &APIError{
+			Code:        response.Finance.Error.Code,
+			Description: response.Finance.Error.Description,
+		})
+	}
+
+	if len(response.Finance.Result) == 0 || len(response.Finance.Result[0].RecommendedSymbols) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+	return response.Finance.Result[0].RecommendedSymbols, nil
+}
+
+// Compare fetches the key statistics of the ticker and peers side by side.
+// With no peers, the ticker's related tickers are used. Peers that fail
+// are listed in Comparison.Missing; only a failure for the ticker itself
+// is returned as an error.
+func (t *Ticker) Compare(ctx context.Context, peers ...string) (*Comparison, error) {
+	ctx, span := t.startSpan(ctx, "Compare")
+	defer span.End()
+
+	if len(peers) == 0 {
+		related, err := t.RelatedTickers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range related {
+			peers = append(peers, r.Symbol)
+		}
+	}
+
+	own, err := t.peerStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A failed peer is only missing from the comparison, so none cancels
+	// the others
+	stats := make([]*PeerStats, len(peers))
+	var g errgroup.Group
+	g.SetLimit(compareConcurrency)
+	for i, symbol := range peers {
+		g.Go(func() error {
+			if peer, err := NewTicker(symbol, WithClient(t.client)); err == nil {
+				stats[i], _ = peer.peerStats(ctx)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	comparison := &Comparison{Peers: []PeerStats{*own}}
+	for i, s := range stats {
+		if s != nil {
+			comparison.Peers = append(comparison.Peers, *s)
+		} else {
+			comparison.Missing = append(comparison.Missing, peers[i])
+		}
+	}
+	return comparison, nil
+}
+
+// peerStats fetches the ticker's key statistics for a Comparison
+func (t *Ticker) peerStats(ctx context.Context) (*PeerStats, error) {
+	info, err := t.Info(ctx, compareModules...)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &PeerStats{Symbol: t.Symbol}
+	if p := info.Price; p != nil {
+		stats.Name, stats.Currency, stats.Price = p.ShortName, p.Currency, p.RegularMarketPrice
+		stats.MarketCap = float64(p.MarketCap)
+	}
+	if d := info.SummaryDetail; d != nil {
+		stats.TrailingPE, stats.ForwardPE = d.TrailingPE, d.ForwardPE
+		stats.DividendYield, stats.Beta = d.DividendYield, d.Beta
+		if stats.MarketCap == 0 {
+			stats.MarketCap = float64(d.MarketCap)
+		}
+	}
+	if k := info.KeyStatistics; k != nil {
+		stats.EnterpriseValue = float64(k.EnterpriseValue)
+		stats.PEGRatio, stats.PriceToBook = k.PegRatio, k.PriceToBook
+		stats.EVToEBITDA, stats.EVToRevenue = k.EnterpriseToEbitda, k.EnterpriseToRevenue
+	}
+	if f := info.FinancialData; f != nil {
+		stats.ProfitMargin, stats.OperatingMargin = f.ProfitMargins, f.OperatingMargins
+		stats.ReturnOnEquity, stats.DebtToEquity = f.ReturnOnEquity, f.DebtToEquity
+		stats.RevenueGrowth, stats.EarningsGrowth = f.RevenueGrowth, f.EarningsGrowth
+	}
+	return stats, nil
+}
@@ -0,0 +1,132 @@
+package yfinance
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// RepairKind names a kind of fix the Repair option makes
+type RepairKind string
+
+// Kinds of repairs
+const (
+	RepairDroppedNull      RepairKind = "dropped-null"      // A bar without prices was removed
+	RepairDroppedDuplicate RepairKind = "dropped-duplicate" // A repeated bar was removed in favor of its twin
+	RepairUnitMixup        RepairKind = "unit-mixup"        // Prices 100x off, e.g. pence as pounds, were rescaled
+	RepairFilledAdjClose   RepairKind = "filled-adjclose"   // A missing AdjClose was derived from a later bar
+)
+
+// Repair records one fix made to a chart's bars
+type Repair struct {
+	Timestamp time.Time  `json:"timestamp"`
+	Kind      RepairKind `json:"kind"`
+	Factor    float64    `json:"factor,omitempty"` // Scale applied by a unit-mixup repair
+}
+
+// repairWindow is how many bars on each side a bar's prices are compared
+// with to spot unit mixups
+const repairWindow = 5
+
+// repairBars fixes the defects Yahoo intermittently returns and reports
+// each fix: bars without prices, repeated bars, prices 100x too large or
+// small, and missing adjusted closes. Daily and longer bars are repeated
+// when they share a date.
+func repairBars(bars []Bar, interval Interval) ([]Bar, []Repair) {
+	var repairs []Repair
+
+	kept := bars[:0]
+	for _, b := range bars {
+		if b.Open == 0 && b.High == 0 && b.Low == 0 && b.Close == 0 {
+			repairs = append(repairs, Repair{Timestamp: b.Timestamp, Kind: RepairDroppedNull})
+			continue
+		}
+		if n := len(kept); n > 0 && sameBar(kept[n-1], b, interval) {
+			repairs = append(repairs, Repair{Timestamp: b.Timestamp, Kind: RepairDroppedDuplicate})
+			if b.Volume > kept[n-1].Volume {
+				kept[n-1] = b
+			}
+			continue
+		}
+		kept = append(kept, b)
+	}
+	bars = kept
+
+	closes := make([]float64, len(bars))
+	for i, b := range bars {
+		closes[i] = b.Close
+	}
+	for i := range bars {
+		neighbors := make([]float64, 0, 2*repairWindow)
+		for j := max(0, i-repairWindow); j < min(len(bars), i+repairWindow+1); j++ {
+			if j != i && closes[j] > 0 {
+				neighbors = append(neighbors, closes[j])
+			}
+		}
+		if len(neighbors) < 2 {
+			continue
+		}
+		if factor := unitFactor(closes[i], median(neighbors)); factor != 1 {
+			b := &bars[i]
+			b.Open, b.High, b.Low, b.Close = b.Open*factor, b.High*factor, b.Low*factor, b.Close*factor
+			b.AdjClose *= factor
+			repairs = append(repairs, Repair{Timestamp: b.Timestamp, Kind: RepairUnitMixup, Factor: factor})
+		}
+	}
+
+	// Adjustments apply backwards in time, so a bar shares the adjustment
+	// ratio of the next bar that has one
+	ratio := 1.0
+	for i := len(bars) - 1; i >= 0; i-- {
+		b := &bars[i]
+		switch {
+		case b.AdjClose > 0 && b.Close > 0:
+			ratio = b.AdjClose / b.Close
+		case b.AdjClose == 0 && b.Close > 0:
+			b.AdjClose = b.Close * ratio
+			repairs = append(repairs, Repair{Timestamp: b.Timestamp, Kind: RepairFilledAdjClose})
+		}
+	}
+
+	sort.SliceStable(repairs, func(i, j int) bool { return repairs[i].Timestamp.Before(repairs[j].Timestamp) })
+	return bars, repairs
+}
+
+// sameBar reports whether b repeats prev: the same timestamp, or for daily
+// and longer intervals the same date
+func sameBar(prev, b Bar, interval Interval) bool {
+	if prev.Timestamp.Equal(b.Timestamp) {
+		return true
+	}
+	if perRequest, _ := intradayLimits(interval); perRequest > 0 {
+		return false
+	}
+	y1, m1, d1 := prev.Timestamp.Date()
+	y2, m2, d2 := b.Timestamp.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// unitFactor returns the factor that brings price in line with reference
+// when it is about 100 times too large or small, or 1
+func unitFactor(price, reference float64) float64 {
+	if price <= 0 || reference <= 0 {
+		return 1
+	}
+	switch r := math.Log10(price / reference); {
+	case r > 1.7 && r < 2.3:
+		return 0.01
+	case r < -1.7 && r > -2.3:
+		return 100
+	}
+	return 1
+}
+
+// median returns the median of values, reordering them
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
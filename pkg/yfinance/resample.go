@@ -0,0 +1,106 @@
+package yfinance
+
+import (
+	"fmt"
+	"time"
+)
+
+// intervalDuration returns the length of an intraday interval, or zero for
+// daily and longer intervals
+func intervalDuration(interval Interval) time.Duration {
+	switch interval {
+	case Interval1m:
+		return time.Minute
+	case Interval2m:
+		return 2 * time.Minute
+	case Interval5m:
+		return 5 * time.Minute
+	case Interval15m:
+		return 15 * time.Minute
+	case Interval30m:
+		return 30 * time.Minute
+	case Interval60m, Interval1h:
+		return time.Hour
+	case Interval90m:
+		return 90 * time.Minute
+	}
+	return 0
+}
+
+// Resample aggregates bars, oldest first, into bars of a longer interval:
+// the first open, highest high, lowest low, last close and adjusted
+// close, and summed volume. Calendar boundaries are taken in each bar's
+// own time zone, which History sets to the exchange's, so daily bars
+// follow the exchange's trading day.
+//
+// Intraday buckets start at the first bar of each session and never span
+// two sessions or days, so hourly bars line up with a 9:30 open as
+// Yahoo's do. Weekly bars start on Monday and monthly and quarterly bars
+// on the first of the month. Each bar is stamped with its bucket's start.
+// Bars without a close are skipped. The 5d interval is not supported.
+func Resample(bars []Bar, interval Interval) ([]Bar, error) {
+	step := intervalDuration(interval)
+	switch {
+	case step > 0, interval == Interval1d, interval == Interval1wk, interval == Interval1mo, interval == Interval3mo:
+	default:
+		return nil, fmt.Errorf("%w: cannot resample to %q", ErrInvalidInterval, interval)
+	}
+
+	var out []Bar
+	var key, sessionStart time.Time
+	var session MarketSession
+	for _, b := range bars {
+		if b.Close <= 0 {
+			continue
+		}
+
+		start := bucketStart(b.Timestamp, interval)
+		if step > 0 {
+			if len(out) == 0 || b.Session != session || !sameDay(b.Timestamp, sessionStart) {
+				session, sessionStart = b.Session, b.Timestamp
+			}
+			start = sessionStart.Add(b.Timestamp.Sub(sessionStart) / step * step)
+		}
+
+		if len(out) > 0 && start.Equal(key) {
+			last := &out[len(out)-1]
+			last.High = max(last.High, b.High)
+			last.Low = min(last.Low, b.Low)
+			last.Close, last.AdjClose = b.Close, b.AdjClose
+			last.Volume += b.Volume
+			continue
+		}
+		key = start
+		bar := b
+		bar.Timestamp = start
+		if step == 0 {
+			bar.Session = ""
+		}
+		out = append(out, bar)
+	}
+	return out, nil
+}
+
+// bucketStart returns the start of the daily or longer bucket holding t,
+// in t's time zone
+func bucketStart(t time.Time, interval Interval) time.Time {
+	y, m, d := t.Date()
+	switch interval {
+	case Interval1wk:
+		// Weekday counts from Sunday; weeks start on Monday
+		d -= (int(t.Weekday()) + 6) % 7
+	case Interval1mo:
+		d = 1
+	case Interval3mo:
+		m, d = m-(m-1)%3, 1
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// sameDay reports whether a and b fall on the same calendar day in a's
+// time zone
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.In(a.Location()).Date()
+	return ay == by && am == bm && ad == bd
+}
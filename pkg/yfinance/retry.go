@@ -2,12 +2,18 @@ package yfinance
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RetryConfig configures retry behavior
@@ -60,7 +66,13 @@ func WithProxyURL(proxyURL string) ClientOption {
 	}
 }
 
-// doWithRetry executes a request with retry logic
+// doWithRetry executes a request with retry logic. Network errors and
+// responses with a status in RetryOnStatus are retried with exponential
+// backoff, waiting for the server's Retry-After instead when it is given and
+// no longer than MaxBackoff. When Retry-After asks for a longer wait, the
+// response is returned as is. Waiting stops as soon as ctx is done. With a
+// circuit breaker, each attempt counts towards the endpoint's circuit, and
+// an open circuit ends the loop with a CircuitOpenError.
 func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
 	config := c.retryConfig
 	if config == nil {
@@ -71,20 +83,48 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 
 	var lastErr error
 	backoff := config.InitialBackoff
+	endpoint := c.endpoints.Name(req.URL)
 
 	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
-		// Clone request for retry
+		// Clone request for retry, rewinding the body consumed by the
+		// previous attempt
 		reqClone := req.Clone(ctx)
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			reqClone.Body = body
+		}
 
+		if err := c.breaker.allow(endpoint, time.Now()); err != nil {
+			c.logger.DebugContext(ctx, "yfinance: circuit open", "endpoint", endpoint)
+			return nil, err
+		}
+		if err := c.wait(ctx, endpoint); err != nil {
+			c.breaker.release(endpoint)
+			return nil, err
+		}
+		start := time.Now()
+		c.logger.DebugContext(ctx, "yfinance: request", "method", req.Method, "url", redactURL(req.URL), "attempt", attempt+1)
 		resp, err := c.httpClient.Do(reqClone)
 		if err != nil {
+			if ctx.Err() != nil {
+				c.breaker.release(endpoint)
+				return nil, ctx.Err()
+			}
+			var hookErr *hookError
+			if errors.As(err, &hookErr) {
+				c.breaker.release(endpoint)
+				return nil, err
+			}
+			c.breaker.record(endpoint, nil, err, time.Now())
 			lastErr = err
 			if attempt < config.MaxRetries {
 				waitTime := calculateBackoff(backoff, config.MaxBackoff, config.Jitter)
-				select {
-				case <-ctx.Done():
-					return nil, ctx.Err()
-				case <-time.After(waitTime):
+				c.logger.DebugContext(ctx, "yfinance: request failed, retrying", "url", redactURL(req.URL), "error", err, "wait", waitTime)
+				if err := sleepContext(ctx, waitTime); err != nil {
+					return nil, err
 				}
 				backoff = time.Duration(float64(backoff) * config.BackoffFactor)
 				continue
@@ -92,22 +132,27 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 			return nil, fmt.Errorf("request failed after %d retries: %w", config.MaxRetries, lastErr)
 		}
 
+		c.logger.DebugContext(ctx, "yfinance: response", "url", redactURL(req.URL), "status", resp.StatusCode, "duration", time.Since(start).Round(time.Millisecond))
+		c.breaker.record(endpoint, resp, nil, time.Now())
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.Int("http.response.status_code", resp.StatusCode),
+			attribute.Int("http.request.resend_count", attempt),
+		)
+
 		// Check if we should retry based on status code
 		if shouldRetry(resp.StatusCode, config.RetryOnStatus) && attempt < config.MaxRetries {
-			_ = resp.Body.Close()
 			waitTime := calculateBackoff(backoff, config.MaxBackoff, config.Jitter)
-
-			// Check for Retry-After header
-			if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-				if duration, err := time.ParseDuration(retryAfter + "s"); err == nil {
-					waitTime = duration
+			if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if wait > config.MaxBackoff {
+					return resp, nil
 				}
+				waitTime = wait
 			}
+			_ = resp.Body.Close()
 
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(waitTime):
+			c.logger.DebugContext(ctx, "yfinance: retrying", "url", redactURL(req.URL), "status", resp.StatusCode, "wait", waitTime)
+			if err := sleepContext(ctx, waitTime); err != nil {
+				return nil, err
 			}
 			backoff = time.Duration(float64(backoff) * config.BackoffFactor)
 			continue
@@ -119,6 +164,39 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 	return nil, fmt.Errorf("request failed after %d retries: %w", config.MaxRetries, lastErr)
 }
 
+// parseRetryAfter parses a Retry-After header given as seconds or as an
+// HTTP date relative to now
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if wait := t.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // calculateBackoff calculates the backoff duration with jitter
 func calculateBackoff(base, max time.Duration, jitter float64) time.Duration {
 	backoff := base
@@ -145,15 +223,16 @@ func shouldRetry(statusCode int, retryOnStatus []int) bool {
 	return false
 }
 
-// configureProxy applies proxy configuration to the HTTP client
-func (c *Client) configureProxy() {
+// configureProxy applies proxy configuration to the HTTP client, keeping any
+// other settings of an existing transport
+func (c *Client) configureProxy() error {
 	if c.proxyConfig == nil || c.proxyConfig.URL == "" {
-		return
+		return nil
 	}
 
 	proxyURL, err := url.Parse(c.proxyConfig.URL)
 	if err != nil {
-		return
+		return fmt.Errorf("invalid proxy URL %q: %w", c.proxyConfig.URL, err)
 	}
 
 	// Add authentication if provided
@@ -161,15 +240,20 @@ func (c *Client) configureProxy() {
 		proxyURL.User = url.UserPassword(c.proxyConfig.Username, c.proxyConfig.Password)
 	}
 
-	transport := &http.Transport{
-		Proxy: http.ProxyURL(proxyURL),
+	transport, err := c.httpTransport()
+	if err != nil {
+		return fmt.Errorf("cannot apply proxy: %w", err)
 	}
+	transport.Proxy = http.ProxyURL(proxyURL)
 
-	c.httpClient.Transport = transport
+	c.setTransport(transport)
+	return nil
 }
 
-// RateLimiter implements a simple token bucket rate limiter
+// RateLimiter implements a simple token bucket rate limiter.
+// It is safe for concurrent use.
 type RateLimiter struct {
+	mu             sync.Mutex
 	tokens         float64
 	maxTokens      float64
 	refillRate     float64 // tokens per second
@@ -189,13 +273,16 @@ func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
 // Wait blocks until a token is available
 func (rl *RateLimiter) Wait(ctx context.Context) error {
 	for {
+		rl.mu.Lock()
 		rl.refill()
 		if rl.tokens >= 1 {
 			rl.tokens--
+			rl.mu.Unlock()
 			return nil
 		}
-
 		waitTime := time.Duration((1 - rl.tokens) / rl.refillRate * float64(time.Second))
+		rl.mu.Unlock()
+
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -204,7 +291,7 @@ func (rl *RateLimiter) Wait(ctx context.Context) error {
 	}
 }
 
-// refill adds tokens based on elapsed time
+// refill adds tokens based on elapsed time; callers must hold rl.mu
 func (rl *RateLimiter) refill() {
 	now := time.Now()
 	elapsed := now.Sub(rl.lastRefillTime).Seconds()
@@ -212,9 +299,76 @@ func (rl *RateLimiter) refill() {
 	rl.lastRefillTime = now
 }
 
-// WithRateLimiter configures rate limiting for the client
+// WithRateLimiter configures rate limiting for the client. Every request
+// the client sends, including authentication and each retry, waits for a
+// token.
 func WithRateLimiter(requestsPerSecond float64, burst int) ClientOption {
 	return func(c *Client) {
 		c.rateLimiter = NewRateLimiter(requestsPerSecond, burst)
 	}
 }
+
+// Rate is a token bucket rate: PerSecond requests per second on average,
+// with bursts of up to Burst requests
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// WithEndpointRateLimits gives endpoints their own rate limits, keyed by
+// endpoint name as given by Endpoints.Name, e.g.
+//
+//	WithEndpointRateLimits(map[string]Rate{
+//		"chart":    {PerSecond: 2, Burst: 5},
+//		"screener": {PerSecond: 0.5, Burst: 1},
+//	})
+//
+// Requests to those endpoints wait for their own limiter and then for the
+// global one from WithRateLimiter, if any. A Burst below 1 is treated as 1,
+// and a non-positive PerSecond removes the endpoint's limit.
+func WithEndpointRateLimits(limits map[string]Rate) ClientOption {
+	return func(c *Client) {
+		if c.endpointLimiters == nil {
+			c.endpointLimiters = make(map[string]*RateLimiter)
+		}
+		for endpoint, rate := range limits {
+			if rate.PerSecond <= 0 {
+				delete(c.endpointLimiters, endpoint)
+				continue
+			}
+			c.endpointLimiters[endpoint] = NewRateLimiter(rate.PerSecond, max(rate.Burst, 1))
+		}
+	}
+}
+
+// RateLimiter returns the client's rate limiter, or nil if it has none
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.rateLimiter
+}
+
+// EndpointRateLimiter returns the rate limiter of an endpoint, by name as
+// given by Endpoints.Name, or nil if it has none of its own
+func (c *Client) EndpointRateLimiter(endpoint string) *RateLimiter {
+	return c.endpointLimiters[endpoint]
+}
+
+// wait blocks until the endpoint's rate limiter and the global one, if any,
+// allow another request
+func (c *Client) wait(ctx context.Context, endpoint string) error {
+	limiter := c.endpointLimiters[endpoint]
+	if c.rateLimiter == nil && limiter == nil {
+		return nil
+	}
+	start := time.Now()
+	var err error
+	if limiter != nil {
+		err = limiter.Wait(ctx)
+	}
+	if err == nil && c.rateLimiter != nil {
+		err = c.rateLimiter.Wait(ctx)
+	}
+	for _, o := range c.observers {
+		o.ObserveRateLimitWait(time.Since(start))
+	}
+	return err
+}
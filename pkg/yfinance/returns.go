@@ -0,0 +1,172 @@
+package yfinance
+
+import (
+	"math"
+	"time"
+)
+
+// StatsParams configures ChartData.Stats. Zero fields take their
+// defaults.
+type StatsParams struct {
+	RiskFreeRate float64 // Annual rate, such as 0.04, subtracted for Sharpe and Sortino
+	// PeriodsPerYear annualizes returns and volatility and defaults to 252
+	// for daily bars; use 52 for weekly bars or 12 for monthly ones
+	PeriodsPerYear float64
+}
+
+// PerformanceStats summarize the returns of a run of bars. Returns are
+// fractions, so 0.1 is 10%.
+type PerformanceStats struct {
+	Start            time.Time `json:"start"`
+	End              time.Time `json:"end"`
+	TotalReturn      float64   `json:"totalReturn"`
+	AnnualReturn     float64   `json:"annualReturn"`
+	AnnualVolatility float64   `json:"annualVolatility"`
+	Sharpe           float64   `json:"sharpe"`
+	Sortino          float64   `json:"sortino"` // Like Sharpe, but penalizing only returns below the risk-free rate
+	MaxDrawdown      Drawdown  `json:"maxDrawdown"`
+}
+
+// Drawdown is a fall from a peak close
+type Drawdown struct {
+	Depth    float64   `json:"depth"` // Fall from the peak as a positive fraction
+	Peak     time.Time `json:"peak,omitzero"`
+	Trough   time.Time `json:"trough,omitzero"`
+	Recovery time.Time `json:"recovery,omitzero"` // When the peak was regained; zero if it has not been
+}
+
+// Returns returns the simple return of each bar over the one before it
+func (c *ChartData) Returns() []DatedValue {
+	return barReturns(c.Bars, func(prev, cur float64) float64 { return cur/prev - 1 })
+}
+
+// LogReturns returns the log return of each bar over the one before it
+func (c *ChartData) LogReturns() []DatedValue {
+	return barReturns(c.Bars, func(prev, cur float64) float64 { return math.Log(cur / prev) })
+}
+
+// CumulativeReturn returns the return from the first bar to the last
+func (c *ChartData) CumulativeReturn() float64 {
+	prices := closes(c.Bars)
+	if len(prices) < 2 {
+		return 0
+	}
+	return prices[len(prices)-1].Value/prices[0].Value - 1
+}
+
+// MaxDrawdown returns the largest fall from a peak close to a later close
+func (c *ChartData) MaxDrawdown() Drawdown {
+	return maxDrawdown(closes(c.Bars))
+}
+
+// Stats computes return, volatility, risk-adjusted return, and drawdown
+// statistics of the chart's bars
+func (c *ChartData) Stats(params StatsParams) PerformanceStats {
+	return performanceStats(closes(c.Bars), params)
+}
+
+// RollingStats computes Stats over each run of window consecutive bars,
+// one per bar from the window's end onward
+func (c *ChartData) RollingStats(window int, params StatsParams) []PerformanceStats {
+	prices := closes(c.Bars)
+	if window < 2 {
+		return nil
+	}
+	var stats []PerformanceStats
+	for end := window; end <= len(prices); end++ {
+		stats = append(stats, performanceStats(prices[end-window:end], params))
+	}
+	return stats
+}
+
+// closes returns the bars' closes adjusted for dividends and splits where
+// Yahoo provides them, skipping bars without a close
+func closes(bars []Bar) []DatedValue {
+	prices := make([]DatedValue, 0, len(bars))
+	for _, b := range bars {
+		price := b.AdjClose
+		if price <= 0 {
+			price = b.Close
+		}
+		if price > 0 {
+			prices = append(prices, DatedValue{Date: b.Timestamp, Value: price})
+		}
+	}
+	return prices
+}
+
+// barReturns applies ret to each pair of consecutive closes
+func barReturns(bars []Bar, ret func(prev, cur float64) float64) []DatedValue {
+	prices := closes(bars)
+	if len(prices) < 2 {
+		return nil
+	}
+	returns := make([]DatedValue, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		returns[i-1] = DatedValue{Date: prices[i].Date, Value: ret(prices[i-1].Value, prices[i].Value)}
+	}
+	return returns
+}
+
+// performanceStats computes the statistics of a run of closes
+func performanceStats(prices []DatedValue, params StatsParams) PerformanceStats {
+	if params.PeriodsPerYear <= 0 {
+		params.PeriodsPerYear = tradingDaysPerYear
+	}
+	var stats PerformanceStats
+	if len(prices) < 2 {
+		return stats
+	}
+	stats.Start, stats.End = prices[0].Date, prices[len(prices)-1].Date
+	stats.TotalReturn = prices[len(prices)-1].Value/prices[0].Value - 1
+	stats.MaxDrawdown = maxDrawdown(prices)
+
+	returns := make([]float64, len(prices)-1)
+	for i := range returns {
+		returns[i] = prices[i+1].Value/prices[i].Value - 1
+	}
+	n := float64(len(returns))
+	stats.AnnualReturn = math.Pow(1+stats.TotalReturn, params.PeriodsPerYear/n) - 1
+
+	riskFree := params.RiskFreeRate / params.PeriodsPerYear
+	var mean, downside float64
+	for _, r := range returns {
+		mean += r
+		if excess := r - riskFree; excess < 0 {
+			downside += excess * excess
+		}
+	}
+	mean /= n
+	std := math.Sqrt(Variance(returns))
+	downside = math.Sqrt(downside / n)
+
+	stats.AnnualVolatility = std * math.Sqrt(params.PeriodsPerYear)
+	if std > 0 {
+		stats.Sharpe = (mean - riskFree) / std * math.Sqrt(params.PeriodsPerYear)
+	}
+	if downside > 0 {
+		stats.Sortino = (mean - riskFree) / downside * math.Sqrt(params.PeriodsPerYear)
+	}
+	return stats
+}
+
+// maxDrawdown returns the largest fall from a peak to a later close, with
+// when the peak was regained
+func maxDrawdown(prices []DatedValue) Drawdown {
+	var worst Drawdown
+	var peak DatedValue
+	worstPeak := -1.0
+	for _, p := range prices {
+		if p.Value > peak.Value {
+			peak = p
+		}
+		if worstPeak > 0 && worst.Recovery.IsZero() && p.Value >= worstPeak && p.Date.After(worst.Trough) {
+			worst.Recovery = p.Date
+		}
+		if depth := 1 - p.Value/peak.Value; depth > worst.Depth {
+			worst = Drawdown{Depth: depth, Peak: peak.Date, Trough: p.Date}
+			worstPeak = peak.Value
+		}
+	}
+	return worst
+}
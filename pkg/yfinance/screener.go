@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // Screen performs stock screening based on criteria
@@ -25,8 +28,13 @@ func ScreenWithClient(ctx context.Context, client *Client, criteria ScreenCriter
 	if criteria.Region == "" {
 		criteria.Region = "us"
 	}
+	if !criteria.Unchecked {
+		if err := ValidateScreenCriteria(criteria); err != nil {
+			return nil, err
+		}
+	}
 
-	data, err := client.Post(ctx, ScreenerURL, nil, criteria)
+	data, err := client.Post(ctx, client.endpoints.Screener, nil, criteria)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +54,121 @@ func ScreenWithClient(ctx context.Context, client *Client, criteria ScreenCriter
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse screener response: %w", err)
+		return nil, parseError(client.endpoints.Screener, nil, data, "screener response", err)
+	}
+
+	if response.Finance.Error != nil {
+		return nil, &APIError{
+			Code:        response.Finance.Error.Code,
+			Description: response.Finance.Error.Description,
+		}
+	}
+
+	result := &ScreenResult{}
+	if len(response.Finance.Result) > 0 {
+		r := response.Finance.Result[0]
+		result.Count = r.Count
+		result.Total = r.Total
+		result.Quotes = r.Quotes
+	}
+
+	return result, nil
+}
+
+// PredefinedScreener is the ID of one of Yahoo's saved screeners, run
+// with ScreenPredefined
+type PredefinedScreener string
+
+// Yahoo's saved screeners, as in Python yfinance's
+// PREDEFINED_SCREENER_QUERIES
+const (
+	ScreenerAggressiveSmallCaps      PredefinedScreener = "aggressive_small_caps"
+	ScreenerDayGainers               PredefinedScreener = "day_gainers"
+	ScreenerDayLosers                PredefinedScreener = "day_losers"
+	ScreenerGrowthTechnologyStocks   PredefinedScreener = "growth_technology_stocks"
+	ScreenerMostActives              PredefinedScreener = "most_actives"
+	ScreenerMostShortedStocks        PredefinedScreener = "most_shorted_stocks"
+	ScreenerSmallCapGainers          PredefinedScreener = "small_cap_gainers"
+	ScreenerUndervaluedGrowthStocks  PredefinedScreener = "undervalued_growth_stocks"
+	ScreenerUndervaluedLargeCaps     PredefinedScreener = "undervalued_large_caps"
+	ScreenerConservativeForeignFunds PredefinedScreener = "conservative_foreign_funds"
+	ScreenerHighYieldBond            PredefinedScreener = "high_yield_bond"
+	ScreenerPortfolioAnchors         PredefinedScreener = "portfolio_anchors"
+	ScreenerSolidLargeGrowthFunds    PredefinedScreener = "solid_large_growth_funds"
+	ScreenerSolidMidcapGrowthFunds   PredefinedScreener = "solid_midcap_growth_funds"
+	ScreenerTopMutualFunds           PredefinedScreener = "top_mutual_funds"
+)
+
+// PredefinedScreeners returns the IDs of Yahoo's known saved screeners,
+// stock screeners first
+func PredefinedScreeners() []PredefinedScreener {
+	return []PredefinedScreener{
+		ScreenerAggressiveSmallCaps, ScreenerDayGainers, ScreenerDayLosers,
+		ScreenerGrowthTechnologyStocks, ScreenerMostActives, ScreenerMostShortedStocks,
+		ScreenerSmallCapGainers, ScreenerUndervaluedGrowthStocks, ScreenerUndervaluedLargeCaps,
+		ScreenerConservativeForeignFunds, ScreenerHighYieldBond, ScreenerPortfolioAnchors,
+		ScreenerSolidLargeGrowthFunds, ScreenerSolidMidcapGrowthFunds, ScreenerTopMutualFunds,
+	}
+}
+
+// maxScreenSize is the most results Yahoo returns from a screener at once
+const maxScreenSize = 250
+
+// ScreenPredefined runs one of Yahoo's saved screeners and returns size
+// results after the first offset, at most 250. Yahoo keeps the query and
+// sort order of saved screeners; the result carries its title and
+// description. IDs other than the known constants are passed through.
+func ScreenPredefined(ctx context.Context, id PredefinedScreener, offset, size int) (*ScreenResult, error) {
+	client, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return ScreenPredefinedWithClient(ctx, client, id, offset, size)
+}
+
+// ScreenPredefinedWithClient runs a saved screener using a specific client
+func ScreenPredefinedWithClient(ctx context.Context, client *Client, id PredefinedScreener, offset, size int) (*ScreenResult, error) {
+	if id == "" {
+		return nil, fmt.Errorf("yfinance: screener ID cannot be empty")
+	}
+	if size <= 0 {
+		size = 25
+	}
+	if size > maxScreenSize {
+		return nil, fmt.Errorf("yfinance: saved screeners return at most %d results, got size %d", maxScreenSize, size)
+	}
+
+	endpoint := client.endpoints.Screener + "/predefined/saved"
+	params := url.Values{}
+	params.Set("scrIds", string(id))
+	params.Set("count", strconv.Itoa(size))
+	params.Set("start", strconv.Itoa(offset))
+	params.Set("formatted", "false")
+
+	data, err := client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Finance struct {
+			Result []struct {
+				Title       string  `json:"title"`
+				Description string  `json:"description"`
+				Count       int     `json:"count"`
+				Total       int     `json:"total"`
+				Quotes      []Quote `json:"quotes"`
+			} `json:"result"`
+			Error *struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"finance"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, parseError(endpoint, params, data, "screener response", err)
 	}
 
 	if response.Finance.Error != nil {
@@ -59,6 +181,8 @@ func ScreenWithClient(ctx context.Context, client *Client, criteria ScreenCriter
 	result := &ScreenResult{}
 	if len(response.Finance.Result) > 0 {
 		r := response.Finance.Result[0]
+		result.Title = r.Title
+		result.Description = r.Description
 		result.Count = r.Count
 		result.Total = r.Total
 		result.Quotes = r.Quotes
@@ -69,9 +193,9 @@ func ScreenWithClient(ctx context.Context, client *Client, criteria ScreenCriter
 
 // Predefined screener queries
 
-// ScreenMostActive returns the most actively traded stocks
-func ScreenMostActive(ctx context.Context, size int) (*ScreenResult, error) {
-	criteria := ScreenCriteria{
+// MostActiveCriteria returns criteria for the most actively traded stocks
+func MostActiveCriteria(size int) ScreenCriteria {
+	return ScreenCriteria{
 		Size:      size,
 		SortField: "dayvolume",
 		SortType:  "DESC",
@@ -85,12 +209,11 @@ func ScreenMostActive(ctx context.Context, size int) (*ScreenResult, error) {
 			},
 		},
 	}
-	return Screen(ctx, criteria)
 }
 
-// ScreenGainers returns top gaining stocks
-func ScreenGainers(ctx context.Context, size int) (*ScreenResult, error) {
-	criteria := ScreenCriteria{
+// GainersCriteria returns criteria for top gaining stocks
+func GainersCriteria(size int) ScreenCriteria {
+	return ScreenCriteria{
 		Size:      size,
 		SortField: "percentchange",
 		SortType:  "DESC",
@@ -108,12 +231,11 @@ func ScreenGainers(ctx context.Context, size int) (*ScreenResult, error) {
 			},
 		},
 	}
-	return Screen(ctx, criteria)
 }
 
-// ScreenLosers returns top losing stocks
-func ScreenLosers(ctx context.Context, size int) (*ScreenResult, error) {
-	criteria := ScreenCriteria{
+// LosersCriteria returns criteria for top losing stocks
+func LosersCriteria(size int) ScreenCriteria {
+	return ScreenCriteria{
 		Size:      size,
 		SortField: "percentchange",
 		SortType:  "ASC",
@@ -131,7 +253,43 @@ func ScreenLosers(ctx context.Context, size int) (*ScreenResult, error) {
 			},
 		},
 	}
-	return Screen(ctx, criteria)
+}
+
+// HighDividendCriteria returns criteria for stocks yielding at least minYield percent
+func HighDividendCriteria(minYield float64, size int) ScreenCriteria {
+	return ScreenCriteria{
+		Size:      size,
+		SortField: "dividendyield",
+		SortType:  "DESC",
+		Query: map[string]interface{}{
+			"operator": "and",
+			"operands": []map[string]interface{}{
+				{
+					"operator": "eq",
+					"operands": []interface{}{"region", "us"},
+				},
+				{
+					"operator": "gte",
+					"operands": []interface{}{"dividendyield", minYield},
+				},
+			},
+		},
+	}
+}
+
+// ScreenMostActive returns the most actively traded stocks
+func ScreenMostActive(ctx context.Context, size int) (*ScreenResult, error) {
+	return Screen(ctx, MostActiveCriteria(size))
+}
+
+// ScreenGainers returns top gaining stocks
+func ScreenGainers(ctx context.Context, size int) (*ScreenResult, error) {
+	return Screen(ctx, GainersCriteria(size))
+}
+
+// ScreenLosers returns top losing stocks
+func ScreenLosers(ctx context.Context, size int) (*ScreenResult, error) {
+	return Screen(ctx, LosersCriteria(size))
 }
 
 // ScreenByMarketCap screens stocks by market cap range
@@ -194,23 +352,91 @@ func ScreenBySector(ctx context.Context, sector string, size int) (*ScreenResult
 
 // ScreenHighDividend screens for high dividend yield stocks
 func ScreenHighDividend(ctx context.Context, minYield float64, size int) (*ScreenResult, error) {
-	criteria := ScreenCriteria{
-		Size:      size,
-		SortField: "dividendyield",
-		SortType:  "DESC",
-		Query: map[string]interface{}{
-			"operator": "and",
-			"operands": []map[string]interface{}{
-				{
-					"operator": "eq",
-					"operands": []interface{}{"region", "us"},
-				},
-				{
-					"operator": "gte",
-					"operands": []interface{}{"dividendyield", minYield},
-				},
-			},
-		},
+	return Screen(ctx, HighDividendCriteria(minYield, size))
+}
+
+// screenOperators maps DSL comparison tokens to screener operators, longest first
+var screenOperators = []struct {
+	token    string
+	operator string
+}{
+	{">=", "gte"},
+	{"<=", "lte"},
+	{"=", "eq"},
+	{">", "gt"},
+	{"<", "lt"},
+}
+
+// ParseScreenQuery builds a screener query from a compact expression such as
+// "region=us and percentchange>5 and intradaymarketcap>=1e9". Terms are
+// joined with "and" or commas; a range can be written as "field=low..high".
+// Numeric values are sent as numbers, everything else as strings.
+func ParseScreenQuery(expr string) (map[string]interface{}, error) {
+	var terms []string
+	for _, part := range strings.Split(expr, ",") {
+		fields := strings.Fields(part)
+		start := 0
+		for i, f := range fields {
+			if strings.EqualFold(f, "and") {
+				terms = append(terms, strings.Join(fields[start:i], ""))
+				start = i + 1
+			}
+		}
+		terms = append(terms, strings.Join(fields[start:], ""))
 	}
-	return Screen(ctx, criteria)
+
+	operands := make([]map[string]interface{}, 0, len(terms))
+	for _, term := range terms {
+		if term == "" {
+			return nil, fmt.Errorf("empty term in screener query %q", expr)
+		}
+		operand, err := parseScreenTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+
+	return map[string]interface{}{
+		"operator": "and",
+		"operands": operands,
+	}, nil
+}
+
+// parseScreenTerm converts a single "field<op>value" term into a query operand
+func parseScreenTerm(term string) (map[string]interface{}, error) {
+	for _, op := range screenOperators {
+		i := strings.Index(term, op.token)
+		if i <= 0 {
+			continue
+		}
+		field := strings.ToLower(term[:i])
+		value := term[i+len(op.token):]
+		if value == "" {
+			return nil, fmt.Errorf("missing value in screener term %q", term)
+		}
+
+		if op.operator == "eq" {
+			if low, high, ok := strings.Cut(value, ".."); ok {
+				return map[string]interface{}{
+					"operator": "btwn",
+					"operands": []interface{}{field, screenValue(low), screenValue(high)},
+				}, nil
+			}
+		}
+
+		return map[string]interface{}{
+			"operator": op.operator,
+			"operands": []interface{}{field, screenValue(value)},
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid screener term %q (want field<op>value)", term)
+}
+
+// screenValue returns v as a float64 when it is numeric, otherwise as a string
+func screenValue(v string) interface{} {
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return f
+	}
+	return v
 }
@@ -0,0 +1,357 @@
+package yfinance
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ScreenFieldCategory groups screenable fields
+type ScreenFieldCategory string
+
+// Screenable field categories. Price and trading fields cover the
+// technical side, ESG the Sustainalytics risk scores, and the rest
+// fundamentals.
+const (
+	ScreenCategoryProfile         ScreenFieldCategory = "profile"
+	ScreenCategoryPrice           ScreenFieldCategory = "price"
+	ScreenCategoryTrading         ScreenFieldCategory = "trading"
+	ScreenCategoryShortInterest   ScreenFieldCategory = "short_interest"
+	ScreenCategoryValuation       ScreenFieldCategory = "valuation"
+	ScreenCategoryProfitability   ScreenFieldCategory = "profitability"
+	ScreenCategoryLeverage        ScreenFieldCategory = "leverage"
+	ScreenCategoryLiquidity       ScreenFieldCategory = "liquidity"
+	ScreenCategoryIncomeStatement ScreenFieldCategory = "income_statement"
+	ScreenCategoryBalanceSheet    ScreenFieldCategory = "balance_sheet"
+	ScreenCategoryCashFlow        ScreenFieldCategory = "cash_flow"
+	ScreenCategoryESG             ScreenFieldCategory = "esg"
+)
+
+// Screener query operators
+const (
+	ScreenAnd     = "and"
+	ScreenOr      = "or"
+	ScreenEq      = "eq"
+	ScreenGt      = "gt"
+	ScreenGte     = "gte"
+	ScreenLt      = "lt"
+	ScreenLte     = "lte"
+	ScreenBetween = "btwn"  // Operands: field, low, high
+	ScreenIsIn    = "is-in" // Operands: field, then one or more values
+)
+
+var (
+	numberOperators = []string{ScreenEq, ScreenGt, ScreenGte, ScreenLt, ScreenLte, ScreenBetween}
+	textOperators   = []string{ScreenEq, ScreenIsIn}
+)
+
+// ScreenField describes a field screener queries can filter and sort on
+type ScreenField struct {
+	Name        string              `json:"name"`
+	Category    ScreenFieldCategory `json:"category"`
+	Description string              `json:"description"`
+	Numeric     bool                `json:"numeric"`          // Compared as a number rather than text
+	Operators   []string            `json:"operators"`        // Operators the field can be used with
+	Values      []string            `json:"values,omitempty"` // Allowed values of text fields, any when empty
+}
+
+// numberField returns a numeric field
+func numberField(category ScreenFieldCategory, name, description string) ScreenField {
+	return ScreenField{Name: name, Category: category, Description: description, Numeric: true, Operators: numberOperators}
+}
+
+// textField returns a text field, limited to values when any are given
+func textField(category ScreenFieldCategory, name, description string, values ...string) ScreenField {
+	return ScreenField{Name: name, Category: category, Description: description, Operators: textOperators, Values: values}
+}
+
+// screenRegions are the region codes Yahoo's equity screener accepts
+var screenRegions = []string{
+	"ae", "ar", "at", "au", "be", "br", "ca", "ch", "cl", "cn", "co", "cz", "de", "dk", "ee", "eg", "es",
+	"fi", "fr", "gb", "gr", "hk", "hu", "id", "ie", "il", "in", "is", "it", "jp", "kr", "kw", "lk", "lt",
+	"lv", "mx", "my", "nl", "no", "nz", "pe", "ph", "pk", "pl", "pt", "qa", "ro", "ru", "sa", "se", "sg",
+	"sr", "th", "tr", "tw", "us", "ve", "vn", "za",
+}
+
+// screenSectors are the sectors Yahoo's equity screener accepts
+var screenSectors = []string{
+	"Basic Materials", "Communication Services", "Consumer Cyclical", "Consumer Defensive", "Energy",
+	"Financial Services", "Healthcare", "Industrials", "Real Estate", "Technology", "Utilities",
+}
+
+// screenFields is the catalog, after Python yfinance's EQUITY_SCREENER_FIELDS
+var screenFields = []ScreenField{
+	textField(ScreenCategoryProfile, "region", "Region code, e.g. us", screenRegions...),
+	textField(ScreenCategoryProfile, "sector", "Sector, e.g. Technology", screenSectors...),
+	textField(ScreenCategoryProfile, "industry", "Industry, e.g. Semiconductors"),
+	textField(ScreenCategoryProfile, "exchange", "Exchange code, e.g. NMS or NYQ"),
+	textField(ScreenCategoryProfile, "peer_group", "Sustainalytics peer group, e.g. Banks"),
+
+	numberField(ScreenCategoryPrice, "intradayprice", "Current price"),
+	numberField(ScreenCategoryPrice, "percentchange", "Change from the previous close, in percent"),
+	numberField(ScreenCategoryPrice, "intradaymarketcap", "Current market capitalization"),
+	numberField(ScreenCategoryPrice, "lastclosemarketcap.lasttwelvemonths", "Market capitalization at the last close"),
+	numberField(ScreenCategoryPrice, "fiftytwowkpercentchange", "Change over 52 weeks, in percent"),
+	numberField(ScreenCategoryPrice, "lastclose52weekhigh.lasttwelvemonths", "52-week high"),
+	numberField(ScreenCategoryPrice, "lastclose52weeklow.lasttwelvemonths", "52-week low"),
+
+	numberField(ScreenCategoryTrading, "dayvolume", "Volume traded today"),
+	numberField(ScreenCategoryTrading, "eodvolume", "Volume traded on the last full day"),
+	numberField(ScreenCategoryTrading, "avgdailyvol3m", "Average daily volume over 3 months"),
+	numberField(ScreenCategoryTrading, "beta", "Beta against the market"),
+	numberField(ScreenCategoryTrading, "pctheldinsider", "Shares held by insiders, in percent"),
+	numberField(ScreenCategoryTrading, "pctheldinst", "Shares held by institutions, in percent"),
+
+	numberField(ScreenCategoryShortInterest, "short_interest.value", "Shares sold short"),
+	numberField(ScreenCategoryShortInterest, "short_percentage_of_float.value", "Short interest as a percent of float"),
+	numberField(ScreenCategoryShortInterest, "short_percentage_of_shares_outstanding.value", "Short interest as a percent of shares outstanding"),
+	numberField(ScreenCategoryShortInterest, "days_to_cover_short.value", "Days of average volume to cover short interest"),
+	numberField(ScreenCategoryShortInterest, "short_interest_percentage_change.value", "Change in short interest, in percent"),
+
+	numberField(ScreenCategoryValuation, "peratio.lasttwelvemonths", "Price to trailing earnings"),
+	numberField(ScreenCategoryValuation, "pegratio_5y", "Price/earnings to 5-year growth"),
+	numberField(ScreenCategoryValuation, "pricebookratio.quarterly", "Price to book value"),
+	numberField(ScreenCategoryValuation, "bookvalueshare.lasttwelvemonths", "Book value per share"),
+	numberField(ScreenCategoryValuation, "lastclosepriceearnings.lasttwelvemonths", "Price to earnings at the last close"),
+	numberField(ScreenCategoryValuation, "lastclosepricetangiblebookvalue.lasttwelvemonths", "Price to tangible book value"),
+	numberField(ScreenCategoryValuation, "lastclosemarketcaptotalrevenue.lasttwelvemonths", "Market capitalization to revenue"),
+	numberField(ScreenCategoryValuation, "lastclosetevtotalrevenue.lasttwelvemonths", "Enterprise value to revenue"),
+
+	numberField(ScreenCategoryProfitability, "returnonassets.lasttwelvemonths", "Return on assets, in percent"),
+	numberField(ScreenCategoryProfitability, "returnonequity.lasttwelvemonths", "Return on equity, in percent"),
+	numberField(ScreenCategoryProfitability, "returnontotalcapital.lasttwelvemonths", "Return on total capital, in percent"),
+	numberField(ScreenCategoryProfitability, "dividendyield", "Trailing dividend yield, in percent"),
+	numberField(ScreenCategoryProfitability, "forward_dividend_yield", "Forward dividend yield, in percent"),
+	numberField(ScreenCategoryProfitability, "forward_dividend_per_share", "Forward dividend per share"),
+	numberField(ScreenCategoryProfitability, "consecutive_years_of_dividend_growth_count", "Consecutive years of dividend growth"),
+
+	numberField(ScreenCategoryLeverage, "totaldebtequity.lasttwelvemonths", "Total debt to equity, in percent"),
+	numberField(ScreenCategoryLeverage, "ltdebtequity.lasttwelvemonths", "Long-term debt to equity, in percent"),
+	numberField(ScreenCategoryLeverage, "totaldebtebitda.lasttwelvemonths", "Total debt to EBITDA"),
+	numberField(ScreenCategoryLeverage, "netdebtebitda.lasttwelvemonths", "Net debt to EBITDA"),
+	numberField(ScreenCategoryLeverage, "ebitinterestexpense.lasttwelvemonths", "EBIT to interest expense"),
+	numberField(ScreenCategoryLeverage, "ebitdainterestexpense.lasttwelvemonths", "EBITDA to interest expense"),
+	numberField(ScreenCategoryLeverage, "lastclosetevebit.lasttwelvemonths", "Enterprise value to EBIT"),
+	numberField(ScreenCategoryLeverage, "lastclosetevebitda.lasttwelvemonths", "Enterprise value to EBITDA"),
+
+	numberField(ScreenCategoryLiquidity, "currentratio.lasttwelvemonths", "Current assets to current liabilities"),
+	numberField(ScreenCategoryLiquidity, "quickratio.lasttwelvemonths", "Liquid assets to current liabilities"),
+	numberField(ScreenCategoryLiquidity, "operatingcashflowtocurrentliabilities.lasttwelvemonths", "Operating cash flow to current liabilities"),
+	numberField(ScreenCategoryLiquidity, "altmanzscoreusingtheaveragestockinformationforaperiod.lasttwelvemonths", "Altman Z-score"),
+
+	numberField(ScreenCategoryIncomeStatement, "totalrevenues.lasttwelvemonths", "Revenue"),
+	numberField(ScreenCategoryIncomeStatement, "totalrevenues1yrgrowth.lasttwelvemonths", "Revenue growth over a year, in percent"),
+	numberField(ScreenCategoryIncomeStatement, "quarterlyrevenuegrowth.quarterly", "Quarterly revenue growth, in percent"),
+	numberField(ScreenCategoryIncomeStatement, "grossprofit.lasttwelvemonths", "Gross profit"),
+	numberField(ScreenCategoryIncomeStatement, "grossprofitmargin.lasttwelvemonths", "Gross margin, in percent"),
+	numberField(ScreenCategoryIncomeStatement, "operatingincome.lasttwelvemonths", "Operating income"),
+	numberField(ScreenCategoryIncomeStatement, "ebit.lasttwelvemonths", "EBIT"),
+	numberField(ScreenCategoryIncomeStatement, "ebitda.lasttwelvemonths", "EBITDA"),
+	numberField(ScreenCategoryIncomeStatement, "ebitdamargin.lasttwelvemonths", "EBITDA margin, in percent"),
+	numberField(ScreenCategoryIncomeStatement, "ebitda1yrgrowth.lasttwelvemonths", "EBITDA growth over a year, in percent"),
+	numberField(ScreenCategoryIncomeStatement, "netincomeis.lasttwelvemonths", "Net income"),
+	numberField(ScreenCategoryIncomeStatement, "netincomemargin.lasttwelvemonths", "Net margin, in percent"),
+	numberField(ScreenCategoryIncomeStatement, "netincome1yrgrowth.lasttwelvemonths", "Net income growth over a year, in percent"),
+	numberField(ScreenCategoryIncomeStatement, "netepsbasic.lasttwelvemonths", "Basic EPS"),
+	numberField(ScreenCategoryIncomeStatement, "netepsdiluted.lasttwelvemonths", "Diluted EPS"),
+	numberField(ScreenCategoryIncomeStatement, "basicepscontinuingoperations.lasttwelvemonths", "Basic EPS from continuing operations"),
+	numberField(ScreenCategoryIncomeStatement, "dilutedepscontinuingoperations.lasttwelvemonths", "Diluted EPS from continuing operations"),
+	numberField(ScreenCategoryIncomeStatement, "dilutedeps1yrgrowth.lasttwelvemonths", "Diluted EPS growth over a year, in percent"),
+	numberField(ScreenCategoryIncomeStatement, "epsgrowth.lasttwelvemonths", "EPS growth, in percent"),
+
+	numberField(ScreenCategoryBalanceSheet, "totalassets.lasttwelvemonths", "Total assets"),
+	numberField(ScreenCategoryBalanceSheet, "totalcurrentassets.lasttwelvemonths", "Current assets"),
+	numberField(ScreenCategoryBalanceSheet, "totalcashandshortterminvestments.lasttwelvemonths", "Cash and short-term investments"),
+	numberField(ScreenCategoryBalanceSheet, "totalcurrentliabilities.lasttwelvemonths", "Current liabilities"),
+	numberField(ScreenCategoryBalanceSheet, "totaldebt.lasttwelvemonths", "Total debt"),
+	numberField(ScreenCategoryBalanceSheet, "totalequity.lasttwelvemonths", "Total equity"),
+	numberField(ScreenCategoryBalanceSheet, "totalcommonequity.lasttwelvemonths", "Common equity"),
+	numberField(ScreenCategoryBalanceSheet, "totalcommonsharesoutstanding.lasttwelvemonths", "Common shares outstanding"),
+	numberField(ScreenCategoryBalanceSheet, "totalsharesoutstanding", "Shares outstanding"),
+
+	numberField(ScreenCategoryCashFlow, "cashfromoperations.lasttwelvemonths", "Operating cash flow"),
+	numberField(ScreenCategoryCashFlow, "cashfromoperations1yrgrowth.lasttwelvemonths", "Operating cash flow growth over a year, in percent"),
+	numberField(ScreenCategoryCashFlow, "capitalexpenditure.lasttwelvemonths", "Capital expenditure"),
+	numberField(ScreenCategoryCashFlow, "leveredfreecashflow.lasttwelvemonths", "Levered free cash flow"),
+	numberField(ScreenCategoryCashFlow, "leveredfreecashflow1yrgrowth.lasttwelvemonths", "Levered free cash flow growth over a year, in percent"),
+	numberField(ScreenCategoryCashFlow, "unleveredfreecashflow.lasttwelvemonths", "Unlevered free cash flow"),
+
+	numberField(ScreenCategoryESG, "esg_score", "Total ESG risk score, lower is better"),
+	numberField(ScreenCategoryESG, "environmental_score", "Environmental risk score"),
+	numberField(ScreenCategoryESG, "social_score", "Social risk score"),
+	numberField(ScreenCategoryESG, "governance_score", "Governance risk score"),
+	numberField(ScreenCategoryESG, "highest_controversy", "Highest controversy level, from 0 (none) to 5 (severe)"),
+}
+
+// ScreenFields returns the catalog of fields screener queries can use, by
+// category
+func ScreenFields() []ScreenField {
+	fields := make([]ScreenField, len(screenFields))
+	for i, f := range screenFields {
+		f.Operators = slices.Clone(f.Operators)
+		f.Values = slices.Clone(f.Values)
+		fields[i] = f
+	}
+	return fields
+}
+
+// LookupScreenField returns the catalog entry of a field
+func LookupScreenField(name string) (ScreenField, bool) {
+	for _, f := range ScreenFields() {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return ScreenField{}, false
+}
+
+// ValidateScreenCriteria checks criteria's query and sort field against
+// the field catalog, so mistakes are reported with what was expected
+// instead of as an opaque Yahoo 400. Errors wrap ErrInvalidScreenQuery.
+// ScreenWithClient calls it unless criteria.Unchecked is set.
+func ValidateScreenCriteria(criteria ScreenCriteria) error {
+	if criteria.Size < 0 || criteria.Size > maxScreenSize {
+		return fmt.Errorf("%w: size %d is not between 1 and %d", ErrInvalidScreenQuery, criteria.Size, maxScreenSize)
+	}
+	if criteria.Offset < 0 {
+		return fmt.Errorf("%w: negative offset %d", ErrInvalidScreenQuery, criteria.Offset)
+	}
+	if t := strings.ToUpper(criteria.SortType); t != "" && t != "ASC" && t != "DESC" {
+		return fmt.Errorf("%w: sort type %q is not ASC or DESC", ErrInvalidScreenQuery, criteria.SortType)
+	}
+	if criteria.SortField != "" {
+		if _, err := screenField(criteria.SortField); err != nil {
+			return fmt.Errorf("%w: sort %v", ErrInvalidScreenQuery, err)
+		}
+	}
+	if criteria.Query == nil {
+		return nil
+	}
+	if err := validateScreenQuery(criteria.Query, "query"); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidScreenQuery, err)
+	}
+	return nil
+}
+
+// validateScreenQuery checks one node of a query tree, at path
+func validateScreenQuery(query map[string]interface{}, path string) error {
+	operator, _ := query["operator"].(string)
+	operator = strings.ToLower(operator)
+	operands, ok := screenOperands(query["operands"])
+	if !ok {
+		return fmt.Errorf("%s: operands must be a list", path)
+	}
+	path = fmt.Sprintf("%s (%s)", path, operator)
+
+	switch operator {
+	case ScreenAnd, ScreenOr:
+		if len(operands) == 0 {
+			return fmt.Errorf("%s: no operands", path)
+		}
+		for i, operand := range operands {
+			sub, ok := operand.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: operand %d is not a query", path, i+1)
+			}
+			if err := validateScreenQuery(sub, fmt.Sprintf("%s operand %d", path, i+1)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ScreenEq, ScreenGt, ScreenGte, ScreenLt, ScreenLte, ScreenBetween, ScreenIsIn:
+	case "":
+		return fmt.Errorf("%s: missing operator", path)
+	default:
+		return fmt.Errorf("%s: unknown operator (want and, or, %s)", path, strings.Join(numberOperators, ", ")+", "+ScreenIsIn)
+	}
+
+	if len(operands) == 0 {
+		return fmt.Errorf("%s: missing field", path)
+	}
+	name, ok := operands[0].(string)
+	if !ok {
+		return fmt.Errorf("%s: first operand must be a field name, got %v", path, operands[0])
+	}
+	field, err := screenField(name)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	if !slices.Contains(field.Operators, operator) {
+		return fmt.Errorf("%s: %s does not support %s (want %s)", path, name, operator, strings.Join(field.Operators, ", "))
+	}
+
+	values := operands[1:]
+	switch want := map[string]int{ScreenBetween: 2, ScreenIsIn: -1}[operator]; {
+	case want == -1 && len(values) == 0:
+		return fmt.Errorf("%s: %s needs at least one value", path, name)
+	case want == 0 && len(values) != 1, want > 0 && len(values) != want:
+		return fmt.Errorf("%s: %s needs %d value(s), got %d", path, name, max(want, 1), len(values))
+	}
+	for _, v := range values {
+		if err := checkScreenValue(field, v); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// screenOperands returns the operands of a query node, which may be built
+// by hand as either []interface{} or []map[string]interface{}
+func screenOperands(v interface{}) ([]interface{}, bool) {
+	switch operands := v.(type) {
+	case []interface{}:
+		return operands, true
+	case []map[string]interface{}:
+		list := make([]interface{}, len(operands))
+		for i, o := range operands {
+			list[i] = o
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// screenField returns the catalog entry of name, or an error suggesting
+// similar fields
+func screenField(name string) (ScreenField, error) {
+	if f, ok := LookupScreenField(name); ok {
+		return f, nil
+	}
+	lower := strings.ToLower(name)
+	var similar []string
+	for _, f := range screenFields {
+		base, _, _ := strings.Cut(f.Name, ".")
+		if lower != "" && (strings.Contains(f.Name, lower) || strings.Contains(lower, base)) {
+			similar = append(similar, f.Name)
+		}
+	}
+	if len(similar) > 0 {
+		return ScreenField{}, fmt.Errorf("unknown field %q (did you mean %s?)", name, strings.Join(similar[:min(len(similar), 3)], " or "))
+	}
+	return ScreenField{}, fmt.Errorf("unknown field %q (see ScreenFields)", name)
+}
+
+// checkScreenValue checks that v suits field
+func checkScreenValue(field ScreenField, v interface{}) error {
+	if field.Numeric {
+		switch v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+			return nil
+		}
+		return fmt.Errorf("%s needs a number, got %v", field.Name, v)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("%s needs text, got %v", field.Name, v)
+	}
+	if len(field.Values) > 0 && !slices.Contains(field.Values, s) {
+		for _, allowed := range field.Values {
+			if strings.EqualFold(allowed, s) {
+				return fmt.Errorf("%s value %q is written %q", field.Name, s, allowed)
+			}
+		}
+		return fmt.Errorf("%s value %q is not one of %s", field.Name, s, strings.Join(field.Values, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package yfinance
+
+import (
+	"context"
+)
+
+// defaultScreenPageSize is the page size of a ScreenPager whose criteria
+// set none
+const defaultScreenPageSize = 100
+
+// ScreenPager walks the pages of a screener, offset by offset, until its
+// total is exhausted:
+//
+//	pager := yfinance.NewScreenPager(nil, yfinance.GainersCriteria(100))
+//	for pager.Next(ctx) {
+//		for _, q := range pager.Page().Quotes { ... }
+//	}
+//	if err := pager.Err(); err != nil { ... }
+//
+// Each page is one request through the client, so its rate limiter and
+// retries pace long walks. A pager is not safe for concurrent use.
+type ScreenPager struct {
+	client   *Client
+	fetch    func(ctx context.Context, client *Client, offset, size int) (*ScreenResult, error)
+	pageSize int
+	offset   int
+	total    int // -1 until the first page
+	page     *ScreenResult
+	err      error
+}
+
+// NewScreenPager returns a pager over the results of criteria, in pages of
+// criteria.Size (default 100, at most 250) starting at criteria.Offset. A
+// nil client uses the default one.
+func NewScreenPager(client *Client, criteria ScreenCriteria) *ScreenPager {
+	return newScreenPager(client, criteria.Offset, criteria.Size, func(ctx context.Context, client *Client, offset, size int) (*ScreenResult, error) {
+		criteria.Offset, criteria.Size = offset, size
+		return ScreenWithClient(ctx, client, criteria)
+	})
+}
+
+// NewPredefinedScreenPager returns a pager over the results of a saved
+// screener, in pages of pageSize (default 100, at most 250). A nil client
+// uses the default one.
+func NewPredefinedScreenPager(client *Client, id PredefinedScreener, pageSize int) *ScreenPager {
+	return newScreenPager(client, 0, pageSize, func(ctx context.Context, client *Client, offset, size int) (*ScreenResult, error) {
+		return ScreenPredefinedWithClient(ctx, client, id, offset, size)
+	})
+}
+
+func newScreenPager(client *Client, offset, pageSize int, fetch func(context.Context, *Client, int, int) (*ScreenResult, error)) *ScreenPager {
+	if pageSize <= 0 {
+		pageSize = defaultScreenPageSize
+	}
+	pageSize = min(pageSize, maxScreenSize)
+	return &ScreenPager{client: client, fetch: fetch, pageSize: pageSize, offset: offset, total: -1}
+}
+
+// Next fetches the next page and reports whether there was one. It returns
+// false once the total is reached, a page comes back empty, or a request
+// fails, which Err then returns.
+func (p *ScreenPager) Next(ctx context.Context) bool {
+	if p.err != nil || (p.total >= 0 && p.offset >= p.total) {
+		return false
+	}
+	if p.client == nil {
+		p.client, p.err = getDefaultClient()
+		if p.err != nil {
+			return false
+		}
+	}
+
+	page, err := p.fetch(ctx, p.client, p.offset, p.pageSize)
+	if err != nil {
+		p.err, p.page = err, nil
+		return false
+	}
+	p.total = page.Total
+	if len(page.Quotes) == 0 {
+		// Yahoo's total can run past the results it will serve
+		p.total, p.page = p.offset, nil
+		return false
+	}
+	p.offset += len(page.Quotes)
+	p.page = page
+	return true
+}
+
+// Page returns the page fetched by the last successful Next
+func (p *ScreenPager) Page() *ScreenResult {
+	return p.page
+}
+
+// Err returns the error that stopped the pager, if any
+func (p *ScreenPager) Err() error {
+	return p.err
+}
+
+// Total returns the number of matches the screener reported, or -1 before
+// the first page
+func (p *ScreenPager) Total() int {
+	return p.total
+}
+
+// ScreenAll returns every result of criteria, walking its pages with a
+// ScreenPager. On failure it returns the results fetched so far with the
+// error.
+func ScreenAll(ctx context.Context, criteria ScreenCriteria) ([]Quote, error) {
+	return collectScreenPages(ctx, NewScreenPager(nil, criteria))
+}
+
+// ScreenAllWithClient returns every result of criteria using a specific
+// client; see ScreenAll
+func ScreenAllWithClient(ctx context.Context, client *Client, criteria ScreenCriteria) ([]Quote, error) {
+	return collectScreenPages(ctx, NewScreenPager(client, criteria))
+}
+
+// ScreenPredefinedAll returns every result of a saved screener, walking
+// its pages with a ScreenPager
+func ScreenPredefinedAll(ctx context.Context, id PredefinedScreener) ([]Quote, error) {
+	return collectScreenPages(ctx, NewPredefinedScreenPager(nil, id, maxScreenSize))
+}
+
+// collectScreenPages gathers the quotes of every page of pager
+func collectScreenPages(ctx context.Context, pager *ScreenPager) ([]Quote, error) {
+	var quotes []Quote
+	for pager.Next(ctx) {
+		quotes = append(quotes, pager.Page().Quotes...)
+	}
+	return quotes, pager.Err()
+}
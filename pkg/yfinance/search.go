@@ -80,7 +80,7 @@ func SearchWithClient(ctx context.Context, client *Client, query string, opts ..
 	params.Set("region", config.Region)
 	params.Set("lang", config.Lang)
 
-	data, err := client.Get(ctx, SearchURL, params)
+	data, err := client.Get(ctx, client.endpoints.Search, params)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +92,7 @@ func SearchWithClient(ctx context.Context, client *Client, query string, opts ..
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse search response: %w", err)
+		return nil, parseError(client.endpoints.Search, params, data, "search response", err)
 	}
 
 	return &SearchResult{
@@ -125,7 +125,7 @@ func LookupWithClient(ctx context.Context, client *Client, query, lookupType str
 		params.Set("type", lookupType)
 	}
 
-	data, err := client.Get(ctx, LookupURL, params)
+	data, err := client.Get(ctx, client.endpoints.Lookup, params)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +139,7 @@ func LookupWithClient(ctx context.Context, client *Client, query, lookupType str
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse lookup response: %w", err)
+		return nil, parseError(client.endpoints.Lookup, params, data, "lookup response", err)
 	}
 
 	result := &LookupResult{
@@ -169,38 +169,13 @@ func QuoteMultiple(ctx context.Context, symbols []string) ([]Quote, error) {
 	return QuoteMultipleWithClient(ctx, client, symbols)
 }
 
-// QuoteMultipleWithClient fetches multiple quotes using a specific client
+// QuoteMultipleWithClient fetches multiple quotes using a specific client.
+// Without a crumb it falls back to quotes with fewer fields; see
+// Ticker.Quote. Long symbol lists are split into several requests; see
+// WithQuoteChunkSize. If some of them fail, the quotes of the others are
+// returned with a non-nil error joining the failures.
 func QuoteMultipleWithClient(ctx context.Context, client *Client, symbols []string) ([]Quote, error) {
-	params := url.Values{}
-	params.Set("symbols", joinSymbols(symbols))
-
-	data, err := client.Get(ctx, QuoteURL, params)
-	if err != nil {
-		return nil, err
-	}
-
-	var response struct {
-		QuoteResponse struct {
-			Result []Quote `json:"result"`
-			Error  *struct {
-				Code        string `json:"code"`
-				Description string `json:"description"`
-			} `json:"error"`
-		} `json:"quoteResponse"`
-	}
-
-	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse quote response: %w", err)
-	}
-
-	if response.QuoteResponse.Error != nil {
-		return nil, &APIError{
-			Code:        response.QuoteResponse.Error.Code,
-			Description: response.QuoteResponse.Error.Description,
-		}
-	}
-
-	return response.QuoteResponse.Result, nil
+	return client.quotes(ctx, symbols)
 }
 
 func joinSymbols(symbols []string) string {
@@ -3,7 +3,6 @@ package yfinance
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 )
 
 // GetSectors fetches available sectors
@@ -18,7 +17,7 @@ func GetSectors(ctx context.Context) ([]Sector, error) {
 
 // GetSectorsWithClient fetches sectors using a specific client
 func GetSectorsWithClient(ctx context.Context, client *Client) ([]Sector, error) {
-	data, err := client.Get(ctx, SectorURL, nil)
+	data, err := client.Get(ctx, client.endpoints.Sector, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +35,7 @@ func GetSectorsWithClient(ctx context.Context, client *Client) ([]Sector, error)
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse sectors response: %w", err)
+		return nil, parseError(client.endpoints.Sector, nil, data, "sectors response", err)
 	}
 
 	if response.Finance.Error != nil {
@@ -65,7 +64,7 @@ func GetIndustries(ctx context.Context) ([]Industry, error) {
 
 // GetIndustriesWithClient fetches industries using a specific client
 func GetIndustriesWithClient(ctx context.Context, client *Client) ([]Industry, error) {
-	data, err := client.Get(ctx, IndustryURL, nil)
+	data, err := client.Get(ctx, client.endpoints.Industry, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +82,7 @@ func GetIndustriesWithClient(ctx context.Context, client *Client) ([]Industry, e
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse industries response: %w", err)
+		return nil, parseError(client.endpoints.Industry, nil, data, "industries response", err)
 	}
 
 	if response.Finance.Error != nil {
@@ -0,0 +1,69 @@
+package yfinance
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// RevenueSegments is one fiscal year's revenue split by business segment
+// and by geography. Either map may be empty.
+type RevenueSegments struct {
+	Date       time.Time          `json:"date"` // Fiscal year end
+	Currency   string             `json:"currency,omitempty"`
+	Business   map[string]float64 `json:"business,omitempty"`   // Revenue by business segment, e.g. "iPhone"
+	Geographic map[string]float64 `json:"geographic,omitempty"` // Revenue by region, e.g. "Americas"
+}
+
+// Timeseries types of the segment breakdowns
+const (
+	segmentRevenueType   = "annualRevenueBySegment"
+	geographyRevenueType = "annualRevenueByGeography"
+)
+
+// RevenueSegments fetches the ticker's annual revenue by business segment
+// and by geography, oldest first. This is best effort: Yahoo reports
+// segments for few companies, in an undocumented shape, and the method
+// returns ErrNoData when there are none.
+func (t *Ticker) RevenueSegments(ctx context.Context) ([]RevenueSegments, error) {
+	ctx, span := t.startSpan(ctx, "RevenueSegments")
+	defer span.End()
+
+	end := time.Now()
+	series, err := t.timeseries(ctx, []string{segmentRevenueType, geographyRevenueType}, end.AddDate(-10, 0, 0), end)
+	if err != nil {
+		return nil, err
+	}
+
+	years := make(map[time.Time]*RevenueSegments)
+	add := func(points []timeseriesPoint, split func(*RevenueSegments) map[string]float64) {
+		for _, p := range points {
+			name := p.Segment
+			if name == "" {
+				name = p.SegmentName
+			}
+			date, err := time.Parse(time.DateOnly, p.AsOfDate)
+			if err != nil || name == "" || !p.ReportedValue.Valid {
+				continue
+			}
+			year, ok := years[date]
+			if !ok {
+				year = &RevenueSegments{Date: date, Currency: p.CurrencyCode, Business: map[string]float64{}, Geographic: map[string]float64{}}
+				years[date] = year
+			}
+			split(year)[name] = p.ReportedValue.Raw
+		}
+	}
+	add(series[segmentRevenueType], func(r *RevenueSegments) map[string]float64 { return r.Business })
+	add(series[geographyRevenueType], func(r *RevenueSegments) map[string]float64 { return r.Geographic })
+	if len(years) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	segments := make([]RevenueSegments, 0, len(years))
+	for _, year := range years {
+		segments = append(segments, *year)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].Date.Before(segments[j].Date) })
+	return segments, nil
+}
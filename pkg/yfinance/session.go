@@ -0,0 +1,193 @@
+package yfinance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultSessionMaxAge is how long a saved session is reused before the
+// client authenticates again
+const DefaultSessionMaxAge = 24 * time.Hour
+
+// Session is an authenticated Yahoo session: the cookies set by
+// fc.yahoo.com and the crumb issued for them
+type Session struct {
+	Crumb   string         `json:"crumb"`
+	Cookies []*http.Cookie `json:"cookies"`
+	Created time.Time      `json:"created"`
+}
+
+// SessionStore persists sessions between processes
+type SessionStore interface {
+	// LoadSession returns the saved session, or nil if there is none
+	LoadSession() (*Session, error)
+	// SaveSession replaces the saved session
+	SaveSession(s *Session) error
+}
+
+// FileSessionStore keeps the session in a JSON file readable only by the
+// current user
+type FileSessionStore struct {
+	Path string
+}
+
+// LoadSession implements SessionStore
+func (f *FileSessionStore) LoadSession() (*Session, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session %s: %w", f.Path, err)
+	}
+	return &s, nil
+}
+
+// SaveSession implements SessionStore. The file is replaced atomically so
+// concurrent processes never read a partial session.
+func (f *FileSessionStore) SaveSession(s *Session) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), ".session-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// WithSessionStore loads the authenticated session from store instead of
+// authenticating, and saves each new session to it. Saved sessions older than
+// DefaultSessionMaxAge are ignored.
+func WithSessionStore(store SessionStore) ClientOption {
+	return func(c *Client) {
+		c.sessionStore = store
+	}
+}
+
+// WithSessionFile persists the authenticated session in the file at path
+func WithSessionFile(path string) ClientOption {
+	return WithSessionStore(&FileSessionStore{Path: path})
+}
+
+// Session returns the client's current session, or nil before it has
+// authenticated
+func (c *Client) Session() *Session {
+	c.crumbMu.RLock()
+	defer c.crumbMu.RUnlock()
+	if c.crumb == "" {
+		return nil
+	}
+	return &Session{Crumb: c.crumb, Cookies: c.sessionCookies(), Created: c.sessionCreated}
+}
+
+// restoreSession installs a saved session if it is still fresh. It reports
+// whether a session was restored.
+func (c *Client) restoreSession() bool {
+	if c.sessionStore == nil || c.httpClient.Jar == nil {
+		return false
+	}
+	s, err := c.sessionStore.LoadSession()
+	if err != nil || s == nil || s.Crumb == "" || time.Since(s.Created) > DefaultSessionMaxAge {
+		return false
+	}
+
+	for _, u := range c.sessionURLs() {
+		c.httpClient.Jar.SetCookies(u, s.Cookies)
+	}
+	c.crumbMu.Lock()
+	c.crumb = s.Crumb
+	c.sessionCreated = s.Created
+	c.crumbMu.Unlock()
+	c.logger.Debug("yfinance: restored saved session", "age", time.Since(s.Created).Round(time.Second))
+	return true
+}
+
+// saveSession saves the current session, ignoring store errors since the
+// session can always be obtained again
+func (c *Client) saveSession() {
+	if c.sessionStore == nil {
+		return
+	}
+	if s := c.Session(); s != nil {
+		if err := c.sessionStore.SaveSession(s); err != nil {
+			c.logger.Debug("yfinance: failed to save session", "error", err)
+		}
+	}
+}
+
+// sessionCookies returns the cookies sent to Yahoo's API hosts. Cookies of
+// yahoo.com hosts are scoped to the whole domain so they apply to every host
+// when restored; others, such as those of a server set with WithBaseURL,
+// keep the host they were sent to. Callers must hold crumbMu.
+func (c *Client) sessionCookies() []*http.Cookie {
+	if c.httpClient.Jar == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var cookies []*http.Cookie
+	for _, u := range c.sessionURLs() {
+		domain := cookieDomain(u)
+		for _, ck := range c.httpClient.Jar.Cookies(u) {
+			key := domain + ";" + ck.Name
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			cookies = append(cookies, &http.Cookie{
+				Name:    ck.Name,
+				Value:   ck.Value,
+				Domain:  domain,
+				Path:    "/",
+				Expires: c.sessionCreated.Add(DefaultSessionMaxAge),
+				Secure:  u.Scheme == "https",
+			})
+		}
+	}
+	return cookies
+}
+
+// cookieDomain returns the domain a session cookie read from u is saved for
+func cookieDomain(u *url.URL) string {
+	host := u.Hostname()
+	if host == "yahoo.com" || strings.HasSuffix(host, ".yahoo.com") {
+		return ".yahoo.com"
+	}
+	return host
+}
+
+// sessionURLs are the hosts a session's cookies are read from and restored to
+func (c *Client) sessionURLs() []*url.URL {
+	var urls []*url.URL
+	for _, raw := range []string{c.endpoints.Crumb, c.endpoints.Quote, c.endpoints.News} {
+		if u, err := url.Parse(raw); err == nil {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,90 @@
+package yfinance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SharesCount is the number of shares outstanding on a date
+type SharesCount struct {
+	Date   time.Time `json:"date"`
+	Shares int64     `json:"shares"`
+}
+
+// SharesHistory fetches the shares outstanding reported between start and
+// end, oldest first, to follow buybacks and dilution. A zero end means now
+// and a zero start eighteen months before end. Yahoo reports a count
+// whenever it changes, so dates are irregular.
+func (t *Ticker) SharesHistory(ctx context.Context, start, end time.Time) ([]SharesCount, error) {
+	ctx, span := t.startSpan(ctx, "SharesHistory")
+	defer span.End()
+
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		start = end.AddDate(0, -18, 0)
+	}
+	if !end.After(start) {
+		return nil, NewSymbolError(t.Symbol, fmt.Errorf("%w: end %s is not after start %s", ErrInvalidPeriod,
+			end.Format(time.DateOnly), start.Format(time.DateOnly)))
+	}
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.Fundamentals, t.Symbol)
+	params := url.Values{}
+	params.Set("symbol", t.Symbol)
+	params.Set("period1", strconv.FormatInt(start.Unix(), 10))
+	params.Set("period2", strconv.FormatInt(end.Unix(), 10))
+
+	data, err := t.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		Timeseries struct {
+			Result []struct {
+				Timestamp []int64 `json:"timestamp"`
+				SharesOut []int64 `json:"shares_out"`
+			} `json:"result"`
+			Error *struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"timeseries"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "shares response", err))
+	}
+
+	if response.Timeseries.Error != nil {
+		return nil, NewSymbolError(t.Symbol, &APIError{
+			Code:        response.Timeseries.Error.Code,
+			Description: response.Timeseries.Error.Description,
+		})
+	}
+
+	if len(response.Timeseries.Result) == 0 || len(response.Timeseries.Result[0].SharesOut) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+
+	// Counts are paired with dates by position, which is only sound when
+	// there is one of each
+	result := response.Timeseries.Result[0]
+	if len(result.Timestamp) != len(result.SharesOut) {
+		return nil, NewSymbolError(t.Symbol, newQueryError(endpoint, params, nil, data,
+			fmt.Errorf("%w: %d dates for %d share counts", ErrInvalidResponse, len(result.Timestamp), len(result.SharesOut))))
+	}
+	history := make([]SharesCount, len(result.SharesOut))
+	for i := range history {
+		history[i] = SharesCount{Date: time.Unix(result.Timestamp[i], 0), Shares: result.SharesOut[i]}
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].Date.Before(history[j].Date) })
+	return history, nil
+}
@@ -0,0 +1,136 @@
+package yfinance
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IncomeStatementRow is one period of an income statement with stable field
+// names. Fields Yahoo does not report for the period are zero; every value
+// remains in FinancialStatementPeriod.Data under Yahoo's own key.
+type IncomeStatementRow struct {
+	Date                            time.Time `json:"date"`
+	TotalRevenue                    float64   `json:"totalRevenue" statement:"TotalRevenue,totalRevenue"`
+	CostOfRevenue                   float64   `json:"costOfRevenue" statement:"CostOfRevenue,costOfRevenue"`
+	GrossProfit                     float64   `json:"grossProfit" statement:"GrossProfit,grossProfit"`
+	ResearchAndDevelopment          float64   `json:"researchAndDevelopment" statement:"ResearchAndDevelopment,researchDevelopment"`
+	SellingGeneralAndAdministration float64   `json:"sellingGeneralAndAdministration" statement:"SellingGeneralAndAdministration,sellingGeneralAdministrative"`
+	OperatingExpense                float64   `json:"operatingExpense" statement:"OperatingExpense,totalOperatingExpenses"`
+	OperatingIncome                 float64   `json:"operatingIncome" statement:"OperatingIncome,operatingIncome"`
+	InterestExpense                 float64   `json:"interestExpense" statement:"InterestExpenseNonOperating,InterestExpense,interestExpense"`
+	PretaxIncome                    float64   `json:"pretaxIncome" statement:"PretaxIncome,incomeBeforeTax"`
+	TaxProvision                    float64   `json:"taxProvision" statement:"TaxProvision,incomeTaxExpense"`
+	NetIncome                       float64   `json:"netIncome" statement:"NetIncome,netIncome"`
+	EBIT                            float64   `json:"ebit" statement:"EBIT,ebit"`
+	EBITDA                          float64   `json:"ebitda" statement:"EBITDA"`
+	BasicEPS                        float64   `json:"basicEps" statement:"BasicEPS"`
+	DilutedEPS                      float64   `json:"dilutedEps" statement:"DilutedEPS"`
+	DilutedAverageShares            float64   `json:"dilutedAverageShares" statement:"DilutedAverageShares"`
+}
+
+// BalanceSheetRow is one period of a balance sheet with stable field names
+type BalanceSheetRow struct {
+	Date                   time.Time `json:"date"`
+	TotalAssets            float64   `json:"totalAssets" statement:"TotalAssets,totalAssets"`
+	CurrentAssets          float64   `json:"currentAssets" statement:"CurrentAssets,totalCurrentAssets"`
+	CashAndCashEquivalents float64   `json:"cashAndCashEquivalents" statement:"CashAndCashEquivalents,cash"`
+	Receivables            float64   `json:"receivables" statement:"Receivables,netReceivables"`
+	Inventory              float64   `json:"inventory" statement:"Inventory,inventory"`
+	Goodwill               float64   `json:"goodwill" statement:"Goodwill,goodWill"`
+	TotalLiabilities       float64   `json:"totalLiabilities" statement:"TotalLiabilitiesNetMinorityInterest,totalLiab"`
+	CurrentLiabilities     float64   `json:"currentLiabilities" statement:"CurrentLiabilities,totalCurrentLiabilities"`
+	AccountsPayable        float64   `json:"accountsPayable" statement:"AccountsPayable,accountsPayable"`
+	LongTermDebt           float64   `json:"longTermDebt" statement:"LongTermDebt,longTermDebt"`
+	TotalDebt              float64   `json:"totalDebt" statement:"TotalDebt"`
+	NetDebt                float64   `json:"netDebt" statement:"NetDebt"`
+	StockholdersEquity     float64   `json:"stockholdersEquity" statement:"StockholdersEquity,totalStockholderEquity"`
+	RetainedEarnings       float64   `json:"retainedEarnings" statement:"RetainedEarnings,retainedEarnings"`
+	TreasuryStock          float64   `json:"treasuryStock" statement:"TreasuryStock,treasuryStock"`
+	WorkingCapital         float64   `json:"workingCapital" statement:"WorkingCapital"`
+	InvestedCapital        float64   `json:"investedCapital" statement:"InvestedCapital"`
+	TangibleBookValue      float64   `json:"tangibleBookValue" statement:"TangibleBookValue"`
+}
+
+// CashFlowRow is one period of a cash flow statement with stable field
+// names. Outflows, such as capital expenditure, are negative.
+type CashFlowRow struct {
+	Date                     time.Time `json:"date"`
+	OperatingCashFlow        float64   `json:"operatingCashFlow" statement:"OperatingCashFlow,totalCashFromOperatingActivities"`
+	InvestingCashFlow        float64   `json:"investingCashFlow" statement:"InvestingCashFlow,totalCashflowsFromInvestingActivities"`
+	FinancingCashFlow        float64   `json:"financingCashFlow" statement:"FinancingCashFlow,totalCashFromFinancingActivities"`
+	CapitalExpenditure       float64   `json:"capitalExpenditure" statement:"CapitalExpenditure,capitalExpenditures"`
+	FreeCashFlow             float64   `json:"freeCashFlow" statement:"FreeCashFlow"`
+	Depreciation             float64   `json:"depreciation" statement:"DepreciationAmortizationDepletion,depreciation"`
+	StockBasedCompensation   float64   `json:"stockBasedCompensation" statement:"StockBasedCompensation"`
+	ChangeInWorkingCapital   float64   `json:"changeInWorkingCapital" statement:"ChangeInWorkingCapital"`
+	DividendsPaid            float64   `json:"dividendsPaid" statement:"CashDividendsPaid,dividendsPaid"`
+	RepurchaseOfCapitalStock float64   `json:"repurchaseOfCapitalStock" statement:"RepurchaseOfCapitalStock,repurchaseOfStock"`
+	ChangesInCash            float64   `json:"changesInCash" statement:"ChangesInCash,changeInCash"`
+}
+
+// IncomeStatement maps the period's values to an income statement row
+func (p FinancialStatementPeriod) IncomeStatement() IncomeStatementRow {
+	row := IncomeStatementRow{Date: p.Date}
+	fillStatementRow(&row, p.Data)
+	return row
+}
+
+// BalanceSheet maps the period's values to a balance sheet row
+func (p FinancialStatementPeriod) BalanceSheet() BalanceSheetRow {
+	row := BalanceSheetRow{Date: p.Date}
+	fillStatementRow(&row, p.Data)
+	return row
+}
+
+// CashFlow maps the period's values to a cash flow row
+func (p FinancialStatementPeriod) CashFlow() CashFlowRow {
+	row := CashFlowRow{Date: p.Date}
+	fillStatementRow(&row, p.Data)
+	return row
+}
+
+// Periods groups the timeseries values by as-of date into statement
+// periods, oldest first, so they map to typed rows like quoteSummary
+// statements do
+func (f *Financial) Periods() []FinancialStatementPeriod {
+	byDate := make(map[string]*FinancialStatementPeriod)
+	for metric, values := range f.Data {
+		for _, v := range values {
+			period, ok := byDate[v.AsOfDate]
+			if !ok {
+				date, _ := time.Parse(time.DateOnly, v.AsOfDate)
+				period = &FinancialStatementPeriod{Date: date, EndDate: v.AsOfDate, Currency: v.CurrencyCode, Data: make(map[string]float64)}
+				byDate[v.AsOfDate] = period
+			}
+			period.Data[metric] = v.Raw
+		}
+	}
+
+	periods := make([]FinancialStatementPeriod, 0, len(byDate))
+	for _, p := range byDate {
+		periods = append(periods, *p)
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].EndDate < periods[j].EndDate })
+	return periods
+}
+
+// fillStatementRow sets each float field of the row pointed to from the
+// first of its statement tag's keys present in data. Timeseries keys are
+// listed first and quoteSummary keys after.
+func fillStatementRow(row any, data map[string]float64) {
+	v := reflect.ValueOf(row).Elem()
+	for i := range v.NumField() {
+		tag := v.Type().Field(i).Tag.Get("statement")
+		if tag == "" {
+			continue
+		}
+		for key := range strings.SplitSeq(tag, ",") {
+			if value, ok := data[key]; ok {
+				v.Field(i).SetFloat(value)
+				break
+			}
+		}
+	}
+}
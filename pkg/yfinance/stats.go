@@ -0,0 +1,64 @@
+package yfinance
+
+import "math"
+
+// Mean returns the average of xs, or zero if it is empty
+func Mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// Variance returns the sample variance of xs, with Bessel's correction
+func Variance(xs []float64) float64 {
+	return Covariance(xs, xs)
+}
+
+// Covariance returns the sample covariance of equal-length xs and ys
+func Covariance(xs, ys []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	mx, my := Mean(xs), Mean(ys)
+	var sum float64
+	for i := range xs {
+		sum += (xs[i] - mx) * (ys[i] - my)
+	}
+	return sum / float64(len(xs)-1)
+}
+
+// Beta returns the sensitivity of returns to benchmark returns over the
+// same periods, or zero if the benchmark is constant
+func Beta(returns, benchmark []float64) float64 {
+	v := Variance(benchmark)
+	if v == 0 {
+		return 0
+	}
+	return Covariance(returns, benchmark) / v
+}
+
+// TrackingError returns the volatility of returns in excess of benchmark
+// returns over the same periods, annualized with periodsPerYear, such as
+// 252 for daily returns
+func TrackingError(returns, benchmark []float64, periodsPerYear float64) float64 {
+	excess := make([]float64, len(returns))
+	for i := range excess {
+		excess[i] = returns[i] - benchmark[i]
+	}
+	return math.Sqrt(Variance(excess) * periodsPerYear)
+}
+
+// correlation returns the Pearson correlation of xs and ys, or zero if
+// either is constant
+func correlation(xs, ys []float64) float64 {
+	vx, vy := Variance(xs), Variance(ys)
+	if vx == 0 || vy == 0 {
+		return 0
+	}
+	return Covariance(xs, ys) / math.Sqrt(vx*vy)
+}
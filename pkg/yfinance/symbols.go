@@ -0,0 +1,137 @@
+package yfinance
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// maxSymbolLength bounds symbols; Yahoo's longest, option contracts, are
+// about 21 characters
+const maxSymbolLength = 32
+
+// exchangeSuffixes are the one-letter Yahoo exchange suffixes, which
+// NormalizeSymbol must not mistake for share classes
+var exchangeSuffixes = map[string]bool{
+	"L": true, // London
+	"T": true, // Tokyo
+	"F": true, // Frankfurt
+	"V": true, // TSX Venture
+}
+
+// fiatCurrencies are the currencies NormalizeSymbol treats a pair of as FX
+// rather than crypto
+var fiatCurrencies = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "JPY": true, "CHF": true, "CAD": true,
+	"AUD": true, "NZD": true, "CNY": true, "HKD": true, "SGD": true, "SEK": true,
+	"NOK": true, "DKK": true, "INR": true, "KRW": true, "MXN": true, "BRL": true,
+	"ZAR": true, "TRY": true, "PLN": true, "ILS": true, "TWD": true, "THB": true,
+}
+
+// NormalizeSymbol rewrites common spellings of a symbol into Yahoo's form:
+//
+//   - case and surrounding spaces: " aapl " -> "AAPL"
+//   - share classes: "BRK.B" or "BRK/B" -> "BRK-B"
+//   - FX pairs: "EUR/USD" -> "EURUSD=X"
+//   - crypto pairs: "BTC/USD" or "BTC_USD" -> "BTC-USD"
+//
+// Exchange suffixes ("VOD.L", "SHOP.TO", "RELIANCE.NS"), futures ("ES=F"),
+// and indices ("^GSPC") are kept as they are. The result is not validated.
+func NormalizeSymbol(symbol string) string {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if strings.ContainsAny(symbol, "=^") {
+		return symbol
+	}
+
+	for _, sep := range []string{"/", "_"} {
+		base, quote, ok := strings.Cut(symbol, sep)
+		if !ok || base == "" || quote == "" {
+			continue
+		}
+		if fiatCurrencies[base] && fiatCurrencies[quote] {
+			return base + quote + "=X"
+		}
+		return base + "-" + quote // A crypto pair, or a share class written "BRK/B"
+	}
+
+	if i := strings.LastIndexByte(symbol, '.'); i > 0 {
+		if class := symbol[i+1:]; len(class) == 1 && !exchangeSuffixes[class] {
+			return symbol[:i] + "-" + class
+		}
+	}
+	return symbol
+}
+
+// ValidateSymbol checks that symbol is well-formed, without looking it up.
+// It returns an *InvalidSymbolError describing the first problem found.
+func ValidateSymbol(symbol string) error {
+	invalid := func(reason string) error {
+		return &InvalidSymbolError{Symbol: symbol, Reason: reason}
+	}
+	switch {
+	case symbol == "":
+		return invalid("empty")
+	case len(symbol) > maxSymbolLength:
+		return invalid("too long")
+	case strings.TrimSpace(symbol) != symbol:
+		return invalid("surrounding spaces")
+	}
+
+	for i, r := range symbol {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '^' && i == 0:
+		case r == '.' || r == '-' || r == '=' || r == '&':
+		default:
+			return invalid("unexpected character " + string(r))
+		}
+	}
+
+	body := strings.TrimPrefix(symbol, "^")
+	if body == "" {
+		return invalid("no symbol after ^")
+	}
+	if strings.ContainsAny(body[:1], ".-=&") || strings.ContainsAny(body[len(body)-1:], ".-=&") {
+		return invalid("starts or ends with a separator")
+	}
+	if root, kind, ok := strings.Cut(body, "="); ok {
+		switch strings.ToUpper(kind) {
+		case "F", "X":
+		default:
+			return invalid("unknown =" + kind + " suffix; expected =F or =X")
+		}
+		if strings.Contains(root, "=") || root == "" {
+			return invalid("misplaced =")
+		}
+	}
+	return nil
+}
+
+// WithVerify makes NewTicker check that the symbol exists with a quote
+// lookup, failing with an *InvalidSymbolError if it does not. The lookup
+// cannot be cancelled; call Ticker.Verify to use a context.
+func WithVerify() TickerOption {
+	return func(t *Ticker) {
+		t.verify = true
+	}
+}
+
+// Verify checks that the ticker's symbol is well-formed and that Yahoo
+// knows it, with a single quote lookup. Unknown symbols fail with an
+// *InvalidSymbolError; network and other failures are returned as they are.
+func (t *Ticker) Verify(ctx context.Context) error {
+	ctx, span := t.startSpan(ctx, "Verify")
+	defer span.End()
+
+	if err := ValidateSymbol(t.Symbol); err != nil {
+		return err
+	}
+	quotes, err := t.client.quotes(ctx, []string{t.Symbol})
+	switch {
+	case errors.Is(err, ErrNotFound) || err == nil && len(quotes) == 0:
+		return &InvalidSymbolError{Symbol: t.Symbol, Reason: "not found"}
+	case err != nil:
+		return NewSymbolError(t.Symbol, err)
+	}
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +16,7 @@ import (
 type Ticker struct {
 	Symbol string
 	client *Client
+	verify bool // Set by WithVerify
 }
 
 // TickerOption is a function that configures Ticker options
@@ -49,50 +52,85 @@ func NewTicker(symbol string, opts ...TickerOption) (*Ticker, error) {
 		ticker.client = client
 	}
 
+	if ticker.verify {
+		if err := ticker.Verify(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+
 	return ticker, nil
 }
 
-// Quote fetches real-time quote data for the ticker
+// Quote fetches real-time quote data for the ticker. If no crumb can be
+// obtained, the quote comes from endpoints that need none and has fewer
+// fields filled in.
 func (t *Ticker) Quote(ctx context.Context) (*Quote, error) {
-	params := url.Values{}
-	params.Set("symbols", t.Symbol)
+	ctx, span := t.startSpan(ctx, "Quote")
+	defer span.End()
 
-	data, err := t.client.Get(ctx, QuoteURL, params)
+	quotes, err := t.client.quotes(ctx, []string{t.Symbol})
 	if err != nil {
 		return nil, NewSymbolError(t.Symbol, err)
 	}
-
-	var response struct {
-		QuoteResponse struct {
-			Result []Quote `json:"result"`
-			Error  *struct {
-				Code        string `json:"code"`
-				Description string `json:"description"`
-			} `json:"error"`
-		} `json:"quoteResponse"`
+	if len(quotes) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNotFound)
 	}
+	return &quotes[0], nil
+}
 
-	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse quote response: %w", err))
-	}
+// History fetches historical OHLCV data for the ticker. Intraday date
+// ranges longer than Yahoo serves in one request are fetched in chunks and
+// stitched together; bars older than Yahoo keeps for the interval are left
+// out.
+func (t *Ticker) History(ctx context.Context, params HistoryParams) (*ChartData, error) {
+	ctx, span := t.startSpan(ctx, "History")
+	defer span.End()
 
-	if response.QuoteResponse.Error != nil {
-		return nil, NewSymbolError(t.Symbol, &APIError{
-			Code:        response.QuoteResponse.Error.Code,
-			Description: response.QuoteResponse.Error.Description,
-		})
+	if err := params.Validate(); err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
 	}
-
-	if len(response.QuoteResponse.Result) == 0 {
-		return nil, NewSymbolError(t.Symbol, ErrNotFound)
+	var chart *ChartData
+	var err error
+	if chunks := historyChunks(params, time.Now()); chunks != nil {
+		chart, err = t.chunkedHistory(ctx, params, chunks)
+	} else {
+		chart, err = t.history(ctx, params)
 	}
+	if err != nil {
+		return nil, err
+	}
+	chart.Bars = filterSessions(chart.Bars, params.Sessions)
+	if params.Repair {
+		chart.Bars, chart.Repairs = repairBars(chart.Bars, params.Interval)
+	}
+	if params.AutoAdjust || params.BackAdjust {
+		adjustBars(chart.Bars, params.AutoAdjust)
+	}
+	return chart, nil
+}
 
-	return &response.QuoteResponse.Result[0], nil
+// adjustBars scales each bar's Open, High, and Low by AdjClose/Close, like
+// Python yfinance's back_adjust, and with adjustClose also sets Close to
+// AdjClose, like its auto_adjust
+func adjustBars(bars []Bar, adjustClose bool) {
+	for i := range bars {
+		b := &bars[i]
+		if b.Close == 0 {
+			continue
+		}
+		ratio := b.AdjClose / b.Close
+		b.Open *= ratio
+		b.High *= ratio
+		b.Low *= ratio
+		if adjustClose {
+			b.Close = b.AdjClose
+		}
+	}
 }
 
-// History fetches historical OHLCV data for the ticker
-func (t *Ticker) History(ctx context.Context, params HistoryParams) (*ChartData, error) {
-	endpoint := fmt.Sprintf("%s/%s", ChartURL, t.Symbol)
+// history fetches one chart request's worth of history
+func (t *Ticker) history(ctx context.Context, params HistoryParams) (*ChartData, error) {
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.Chart, t.Symbol)
 
 	queryParams := url.Values{}
 
@@ -129,33 +167,9 @@ func (t *Ticker) History(ctx context.Context, params HistoryParams) (*ChartData,
 		return nil, NewSymbolError(t.Symbol, err)
 	}
 
-	var response struct {
-		Chart struct {
-			Result []struct {
-				Meta       ChartMeta `json:"meta"`
-				Timestamp  []int64   `json:"timestamp"`
-				Indicators struct {
-					Quote []struct {
-						Open   []float64 `json:"open"`
-						High   []float64 `json:"high"`
-						Low    []float64 `json:"low"`
-						Close  []float64 `json:"close"`
-						Volume []int64   `json:"volume"`
-					} `json:"quote"`
-					AdjClose []struct {
-						AdjClose []float64 `json:"adjclose"`
-					} `json:"adjclose"`
-				} `json:"indicators"`
-			} `json:"result"`
-			Error *struct {
-				Code        string `json:"code"`
-				Description string `json:"description"`
-			} `json:"error"`
-		} `json:"chart"`
-	}
-
+	var response chartResponse
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse chart response: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, queryParams, data, "chart response", err))
 	}
 
 	if response.Chart.Error != nil {
@@ -170,59 +184,117 @@ func (t *Ticker) History(ctx context.Context, params HistoryParams) (*ChartData,
 	}
 
 	result := response.Chart.Result[0]
-	chartData := &ChartData{
+	return &ChartData{
 		Symbol:   t.Symbol,
 		Currency: result.Meta.Currency,
 		Interval: params.Interval,
 		Meta:     &result.Meta,
-		Bars:     make([]Bar, len(result.Timestamp)),
+		Bars:     result.bars(result.location(params.Timezone)),
+	}, nil
+}
+
+// chartResponse is the JSON shape of a chart response
+type chartResponse struct {
+	Chart struct {
+		Result []chartResult `json:"result"`
+		Error  *struct {
+			Code        string `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	} `json:"chart"`
+}
+
+// chartResult is the chart of one symbol
+type chartResult struct {
+	Meta       ChartMeta `json:"meta"`
+	Timestamp  []int64   `json:"timestamp"`
+	Indicators struct {
+		Quote []struct {
+			Open   []float64 `json:"open"`
+			High   []float64 `json:"high"`
+			Low    []float64 `json:"low"`
+			Close  []float64 `json:"close"`
+			Volume []int64   `json:"volume"`
+		} `json:"quote"`
+		AdjClose []struct {
+			AdjClose []float64 `json:"adjclose"`
+		} `json:"adjclose"`
+	} `json:"indicators"`
+}
+
+// location returns the zone to give bar timestamps: the named one, else
+// the exchange's, else a fixed zone at the exchange's current offset when
+// the zone database is unavailable
+func (result *chartResult) location(name string) *time.Location {
+	if name == "" {
+		name = result.Meta.ExchangeTimezoneName
+	}
+	if loc, err := loadLocation(name); err == nil && name != "" {
+		return loc
 	}
+	if result.Meta.Timezone != "" {
+		return time.FixedZone(result.Meta.Timezone, result.Meta.GMTOffset)
+	}
+	return time.UTC
+}
 
-	if len(result.Indicators.Quote) > 0 {
-		quote := result.Indicators.Quote[0]
-		var adjCloses []float64
-		if len(result.Indicators.AdjClose) > 0 {
-			adjCloses = result.Indicators.AdjClose[0].AdjClose
-		}
+// bars returns the OHLCV bars of a chart result with timestamps in loc
+func (result *chartResult) bars(loc *time.Location) []Bar {
+	bars := make([]Bar, len(result.Timestamp))
+	if len(result.Indicators.Quote) == 0 {
+		return bars
+	}
 
-		for i, ts := range result.Timestamp {
-			bar := Bar{
-				Timestamp: time.Unix(ts, 0),
-			}
-			if i < len(quote.Open) {
-				bar.Open = quote.Open[i]
-			}
-			if i < len(quote.High) {
-				bar.High = quote.High[i]
-			}
-			if i < len(quote.Low) {
-				bar.Low = quote.Low[i]
-			}
-			if i < len(quote.Close) {
-				bar.Close = quote.Close[i]
-			}
-			if i < len(quote.Volume) {
-				bar.Volume = quote.Volume[i]
-			}
-			if adjCloses != nil && i < len(adjCloses) {
-				bar.AdjClose = adjCloses[i]
-			} else {
-				bar.AdjClose = bar.Close
-			}
-			chartData.Bars[i] = bar
-		}
+	quote := result.Indicators.Quote[0]
+	var adjCloses []float64
+	if len(result.Indicators.AdjClose) > 0 {
+		adjCloses = result.Indicators.AdjClose[0].AdjClose
 	}
 
-	return chartData, nil
+	for i, ts := range result.Timestamp {
+		bar := Bar{
+			Timestamp: time.Unix(ts, 0).In(loc),
+		}
+		if result.Meta.TradingPeriods != nil {
+			bar.Session = result.Meta.TradingPeriods.session(ts)
+		}
+		if i < len(quote.Open) {
+			bar.Open = quote.Open[i]
+		}
+		if i < len(quote.High) {
+			bar.High = quote.High[i]
+		}
+		if i < len(quote.Low) {
+			bar.Low = quote.Low[i]
+		}
+		if i < len(quote.Close) {
+			bar.Close = quote.Close[i]
+		}
+		if i < len(quote.Volume) {
+			bar.Volume = quote.Volume[i]
+		}
+		if adjCloses != nil && i < len(adjCloses) {
+			bar.AdjClose = adjCloses[i]
+		} else {
+			bar.AdjClose = bar.Close
+		}
+		bars[i] = bar
+	}
+	return bars
 }
 
-// Info fetches comprehensive information about the ticker using quoteSummary
+// Info fetches comprehensive information about the ticker using quoteSummary.
+// Common modules are parsed into typed fields; every returned module is also
+// kept in QuoteSummary.Raw and can be decoded with QuoteSummary.Module.
 func (t *Ticker) Info(ctx context.Context, modules ...string) (*QuoteSummary, error) {
+	ctx, span := t.startSpan(ctx, "Info")
+	defer span.End()
+
 	if len(modules) == 0 {
 		modules = DefaultModules()
 	}
 
-	endpoint := fmt.Sprintf("%s/%s", QuoteSummaryURL, t.Symbol)
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.QuoteSummary, t.Symbol)
 	params := url.Values{}
 	params.Set("modules", strings.Join(modules, ","))
 
@@ -242,7 +314,7 @@ func (t *Ticker) Info(ctx context.Context, modules ...string) (*QuoteSummary, er
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse quote summary response: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "quote summary response", err))
 	}
 
 	if response.QuoteSummary.Error != nil {
@@ -257,36 +329,30 @@ func (t *Ticker) Info(ctx context.Context, modules ...string) (*QuoteSummary, er
 	}
 
 	result := response.QuoteSummary.Result[0]
-	summary := &QuoteSummary{Symbol: t.Symbol}
-
-	// Parse each module
-	if raw, ok := result["assetProfile"]; ok {
-		summary.AssetProfile = &AssetProfile{}
-		_ = json.Unmarshal(raw, summary.AssetProfile)
-	}
-	if raw, ok := result["summaryProfile"]; ok {
-		summary.SummaryProfile = &SummaryProfile{}
-		_ = json.Unmarshal(raw, summary.SummaryProfile)
-	}
-	if raw, ok := result["summaryDetail"]; ok {
-		summary.SummaryDetail = &SummaryDetail{}
-		_ = json.Unmarshal(raw, summary.SummaryDetail)
-	}
-	if raw, ok := result["price"]; ok {
-		summary.Price = &PriceInfo{}
-		_ = json.Unmarshal(raw, summary.Price)
-	}
-	if raw, ok := result["defaultKeyStatistics"]; ok {
-		summary.KeyStatistics = &KeyStatistics{}
-		_ = json.Unmarshal(raw, summary.KeyStatistics)
-	}
-	if raw, ok := result["financialData"]; ok {
-		summary.FinancialData = &FinancialData{}
-		_ = json.Unmarshal(raw, summary.FinancialData)
-	}
-	if raw, ok := result["calendarEvents"]; ok {
-		summary.CalendarEvents = &CalendarEvents{}
-		_ = json.Unmarshal(raw, summary.CalendarEvents)
+	summary := &QuoteSummary{Symbol: t.Symbol, Raw: result}
+
+	// Parse each module into its typed field
+	modulesInto := []struct {
+		name string
+		v    any
+	}{
+		{"assetProfile", &summary.AssetProfile},
+		{"summaryProfile", &summary.SummaryProfile},
+		{"summaryDetail", &summary.SummaryDetail},
+		{"price", &summary.Price},
+		{"defaultKeyStatistics", &summary.KeyStatistics},
+		{"financialData", &summary.FinancialData},
+		{"calendarEvents", &summary.CalendarEvents},
+		{"quoteType", &summary.QuoteType},
+	}
+	for _, m := range modulesInto {
+		raw, ok := result[m.name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, m.v); err != nil {
+			return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, raw, m.name+" module", err))
+		}
 	}
 
 	return summary, nil
@@ -294,7 +360,10 @@ func (t *Ticker) Info(ctx context.Context, modules ...string) (*QuoteSummary, er
 
 // Options fetches options chain data for the ticker
 func (t *Ticker) Options(ctx context.Context, expiration string) (*OptionChain, error) {
-	endpoint := fmt.Sprintf("%s/%s", OptionsURL, t.Symbol)
+	ctx, span := t.startSpan(ctx, "Options")
+	defer span.End()
+
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.Options, t.Symbol)
 	params := url.Values{}
 	if expiration != "" {
 		params.Set("date", expiration)
@@ -326,7 +395,7 @@ func (t *Ticker) Options(ctx context.Context, expiration string) (*OptionChain,
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse options response: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "options response", err))
 	}
 
 	if response.OptionChain.Error != nil {
@@ -356,66 +425,72 @@ func (t *Ticker) Options(ctx context.Context, expiration string) (*OptionChain,
 	return chain, nil
 }
 
-// Financials fetches financial statement data for the ticker
-func (t *Ticker) Financials(ctx context.Context, keys []string, period string) (*Financial, error) {
+// Financials fetches financial statement metrics from the fundamentals
+// timeseries for the last ten years. keys are metric names such as
+// "TotalRevenue", all of AllFinancialKeys if empty. period is "annual",
+// "quarterly", or "trailing" for trailing twelve months; anything else means
+// annual. Data is keyed by metric, each oldest period first, and Timestamp
+// lists the as-of dates of every period. WithCurrency converts the values
+// into another currency.
+func (t *Ticker) Financials(ctx context.Context, keys []string, period string, opts ...FinancialsOption) (*Financial, error) {
+	ctx, span := t.startSpan(ctx, "Financials")
+	defer span.End()
+
 	if len(keys) == 0 {
 		keys = AllFinancialKeys()
 	}
+	if period != "quarterly" && period != "trailing" {
+		period = "annual"
+	}
 
 	// Build type parameter
 	types := make([]string, len(keys))
 	for i, key := range keys {
-		if period == "quarterly" {
-			types[i] = "quarterly" + key
-		} else {
-			types[i] = "annual" + key
-		}
+		types[i] = period + key
 	}
 
-	endpoint := fmt.Sprintf("%s/%s", FundamentalsURL, t.Symbol)
-	params := url.Values{}
-	params.Set("type", strings.Join(types, ","))
-	params.Set("merge", "false")
-	params.Set("padTimeSeries", "true")
-
-	data, err := t.client.Get(ctx, endpoint, params)
+	end := time.Now()
+	series, err := t.timeseries(ctx, types, end.AddDate(-10, 0, 0), end)
 	if err != nil {
-		return nil, NewSymbolError(t.Symbol, err)
-	}
-
-	var response struct {
-		Timeseries struct {
-			Result []struct {
-				Meta      map[string]interface{}   `json:"meta"`
-				Timestamp []int64                  `json:"timestamp"`
-				Data      map[string][]interface{} `json:"-"`
-			} `json:"result"`
-			Error *struct {
-				Code        string `json:"code"`
-				Description string `json:"description"`
-			} `json:"error"`
-		} `json:"timeseries"`
-	}
-
-	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse financials response: %w", err))
-	}
-
-	if response.Timeseries.Error != nil {
-		return nil, NewSymbolError(t.Symbol, &APIError{
-			Code:        response.Timeseries.Error.Code,
-			Description: response.Timeseries.Error.Description,
-		})
+		return nil, err
 	}
 
 	financial := &Financial{
 		Symbol: t.Symbol,
 		Data:   make(map[string][]FinancialValue),
 	}
+	dates := make(map[int64]bool)
+	for _, key := range keys {
+		for _, p := range series[period+key] {
+			date, err := time.Parse(time.DateOnly, p.AsOfDate)
+			if err != nil || !p.ReportedValue.Valid {
+				continue
+			}
+			dates[date.Unix()] = true
+			financial.Data[key] = append(financial.Data[key], FinancialValue{
+				Raw:           p.ReportedValue.Raw,
+				Fmt:           p.ReportedValue.Fmt,
+				ReportedValue: p.ReportedValue.Raw,
+				AsOfDate:      p.AsOfDate,
+				PeriodType:    p.PeriodType,
+				CurrencyCode:  p.CurrencyCode,
+			})
+		}
+		slices.SortStableFunc(financial.Data[key], func(a, b FinancialValue) int { return strings.Compare(a.AsOfDate, b.AsOfDate) })
+	}
+	if len(financial.Data) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+	financial.Timestamp = slices.Sorted(maps.Keys(dates))
 
-	if len(response.Timeseries.Result) > 0 {
-		result := response.Timeseries.Result[0]
-		financial.Timestamp = result.Timestamp
+	var config financialsConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.currency != "" {
+		if err := t.convertFinancial(ctx, financial, config.currency); err != nil {
+			return nil, err
+		}
 	}
 
 	return financial, nil
@@ -423,6 +498,9 @@ func (t *Ticker) Financials(ctx context.Context, keys []string, period string) (
 
 // News fetches news articles related to the ticker
 func (t *Ticker) News(ctx context.Context, count int) ([]NewsItem, error) {
+	ctx, span := t.startSpan(ctx, "News")
+	defer span.End()
+
 	if count <= 0 {
 		count = 10
 	}
@@ -433,7 +511,7 @@ func (t *Ticker) News(ctx context.Context, count int) ([]NewsItem, error) {
 	params.Set("newsCount", strconv.Itoa(count))
 	params.Set("quotesCount", "0")
 
-	data, err := t.client.Get(ctx, SearchURL, params)
+	data, err := t.client.Get(ctx, t.client.endpoints.Search, params)
 	if err != nil {
 		return nil, NewSymbolError(t.Symbol, err)
 	}
@@ -443,7 +521,7 @@ func (t *Ticker) News(ctx context.Context, count int) ([]NewsItem, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse news response: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(t.client.endpoints.Search, params, data, "news response", err))
 	}
 
 	return response.News, nil
@@ -451,12 +529,15 @@ func (t *Ticker) News(ctx context.Context, count int) ([]NewsItem, error) {
 
 // Dividends fetches historical dividend data
 func (t *Ticker) Dividends(ctx context.Context, params HistoryParams) ([]Dividend, error) {
+	ctx, span := t.startSpan(ctx, "Dividends")
+	defer span.End()
+
 	if params.Period == "" {
 		params.Period = PeriodMax
 	}
 	params.Events = "div"
 
-	endpoint := fmt.Sprintf("%s/%s", ChartURL, t.Symbol)
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.Chart, t.Symbol)
 	queryParams := url.Values{}
 	queryParams.Set("range", string(params.Period))
 	queryParams.Set("interval", string(Interval1d))
@@ -481,7 +562,7 @@ func (t *Ticker) Dividends(ctx context.Context, params HistoryParams) ([]Dividen
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse dividends response: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, queryParams, data, "dividends response", err))
 	}
 
 	var dividends []Dividend
@@ -499,11 +580,14 @@ func (t *Ticker) Dividends(ctx context.Context, params HistoryParams) ([]Dividen
 
 // Splits fetches historical stock split data
 func (t *Ticker) Splits(ctx context.Context, params HistoryParams) ([]Split, error) {
+	ctx, span := t.startSpan(ctx, "Splits")
+	defer span.End()
+
 	if params.Period == "" {
 		params.Period = PeriodMax
 	}
 
-	endpoint := fmt.Sprintf("%s/%s", ChartURL, t.Symbol)
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.Chart, t.Symbol)
 	queryParams := url.Values{}
 	queryParams.Set("range", string(params.Period))
 	queryParams.Set("interval", string(Interval1d))
@@ -530,7 +614,7 @@ func (t *Ticker) Splits(ctx context.Context, params HistoryParams) ([]Split, err
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, NewSymbolError(t.Symbol, fmt.Errorf("failed to parse splits response: %w", err))
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, queryParams, data, "splits response", err))
 	}
 
 	var splits []Split
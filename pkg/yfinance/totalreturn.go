@@ -0,0 +1,68 @@
+package yfinance
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// TotalReturnPoint is one bar of a total-return series
+type TotalReturnPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Close     float64   `json:"close"`              // Split-adjusted close
+	Dividend  float64   `json:"dividend,omitempty"` // Paid per share with its ex-date in this bar
+	Index     float64   `json:"index"`              // Value of 100 invested at the first close
+}
+
+// totalReturnBase is the index value of the first point
+const totalReturnBase = 100
+
+// TotalReturnHistory fetches the price history with params and reinvests
+// each dividend at the close of the bar holding its ex-date, giving the
+// value over time of 100 invested at the first close. AutoAdjust and
+// BackAdjust are ignored, as the closes must not already include the
+// dividends.
+func (t *Ticker) TotalReturnHistory(ctx context.Context, params HistoryParams) ([]TotalReturnPoint, error) {
+	ctx, span := t.startSpan(ctx, "TotalReturnHistory")
+	defer span.End()
+
+	params.AutoAdjust, params.BackAdjust = false, false
+	chart, err := t.History(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	dividends, err := t.Dividends(ctx, HistoryParams{Period: PeriodMax})
+	if err != nil {
+		return nil, err
+	}
+	return totalReturn(chart.Bars, dividends), nil
+}
+
+// totalReturn builds the total-return series of bars, skipping bars
+// without a close. Dividends before the first bar are dropped.
+func totalReturn(bars []Bar, dividends []Dividend) []TotalReturnPoint {
+	points := make([]TotalReturnPoint, 0, len(bars))
+	for _, b := range bars {
+		if b.Close > 0 {
+			points = append(points, TotalReturnPoint{Timestamp: b.Timestamp, Close: b.Close})
+		}
+	}
+	if len(points) == 0 {
+		return points
+	}
+
+	for _, d := range dividends {
+		// The last bar starting at or before the ex-date holds it
+		i := sort.Search(len(points), func(i int) bool { return points[i].Timestamp.After(d.Date) }) - 1
+		if i >= 0 {
+			points[i].Dividend += d.Amount
+		}
+	}
+
+	points[0].Index = totalReturnBase
+	for i := 1; i < len(points); i++ {
+		prev, cur := points[i-1], points[i]
+		points[i].Index = prev.Index * (cur.Close + cur.Dividend) / prev.Close
+	}
+	return points
+}
@@ -0,0 +1,79 @@
+package yfinance
+
+import (
+	"context"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies this package's spans
+const tracerName = "github.com/amjadjibon/gotick/pkg/yfinance"
+
+// Span attributes
+const (
+	attrSymbol   = attribute.Key("yfinance.symbol")
+	attrEndpoint = attribute.Key("yfinance.endpoint")
+	attrCacheHit = attribute.Key("yfinance.cache_hit")
+)
+
+// WithTracerProvider sets the OpenTelemetry tracer provider for spans
+// around requests, Ticker methods, and Download. By default the global
+// provider is used, which records nothing unless the application installs
+// one.
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(c *Client) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithStreamTracerProvider sets the tracer provider for spans around
+// connecting and handling each stream message
+func WithStreamTracerProvider(tp trace.TracerProvider) StreamOption {
+	return func(s *Stream) {
+		s.tracerProvider = tp
+	}
+}
+
+// TracerProvider returns the client's tracer provider
+func (c *Client) TracerProvider() trace.TracerProvider {
+	if c.tracerProvider != nil {
+		return c.tracerProvider
+	}
+	return otel.GetTracerProvider()
+}
+
+// startSpan starts a span as a child of any span in ctx
+func (c *Client) startSpan(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return c.TracerProvider().Tracer(tracerName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// startRequestSpan starts a client span for a request to endpoint
+func (c *Client) startRequestSpan(ctx context.Context, method, endpoint string) (context.Context, trace.Span) {
+	name, full := "other", endpoint
+	if u, err := url.Parse(endpoint); err == nil {
+		name, full = c.endpoints.Name(u), redactURL(u)
+	}
+	return c.startSpan(ctx, "yfinance "+method+" "+name, trace.SpanKindClient,
+		attribute.String("http.request.method", method),
+		attribute.String("url.full", full),
+		attrEndpoint.String(name),
+	)
+}
+
+// startSpan starts a span for a Ticker method
+func (t *Ticker) startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return t.client.startSpan(ctx, "yfinance.Ticker."+method, trace.SpanKindInternal, attrSymbol.String(t.Symbol))
+}
+
+// endSpan records err on span, if any, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,93 @@
+package yfinance
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// MarketSession is the trading session a bar falls in
+type MarketSession string
+
+// Trading sessions of intraday bars
+const (
+	PreMarket     MarketSession = "pre"
+	RegularMarket MarketSession = "regular"
+	PostMarket    MarketSession = "post"
+)
+
+// TradingPeriod is the span of one session, in unix seconds
+type TradingPeriod struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// TradingPeriods are the sessions of each day of an intraday chart. Pre
+// and Post are empty unless extended hours were requested.
+type TradingPeriods struct {
+	Pre     []TradingPeriod `json:"pre,omitempty"`
+	Regular []TradingPeriod `json:"regular,omitempty"`
+	Post    []TradingPeriod `json:"post,omitempty"`
+}
+
+// UnmarshalJSON accepts Yahoo's shapes: a list of days of regular periods,
+// or an object of such lists per session when extended hours are included
+func (p *TradingPeriods) UnmarshalJSON(data []byte) error {
+	*p = TradingPeriods{}
+	if len(data) > 0 && data[0] == '[' {
+		var err error
+		p.Regular, err = flattenPeriods(data)
+		return err
+	}
+
+	var sessions map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return err
+	}
+	for name, into := range map[string]*[]TradingPeriod{"pre": &p.Pre, "regular": &p.Regular, "post": &p.Post} {
+		if raw, ok := sessions[name]; ok {
+			periods, err := flattenPeriods(raw)
+			if err != nil {
+				return err
+			}
+			*into = periods
+		}
+	}
+	return nil
+}
+
+// flattenPeriods decodes periods listed per day, or already flat
+func flattenPeriods(data []byte) ([]TradingPeriod, error) {
+	var days [][]TradingPeriod
+	if err := json.Unmarshal(data, &days); err == nil {
+		return slices.Concat(days...), nil
+	}
+	var periods []TradingPeriod
+	err := json.Unmarshal(data, &periods)
+	return periods, err
+}
+
+// session returns the session holding the unix time ts, or "" if none does
+func (p *TradingPeriods) session(ts int64) MarketSession {
+	for _, s := range []struct {
+		name    MarketSession
+		periods []TradingPeriod
+	}{{RegularMarket, p.Regular}, {PreMarket, p.Pre}, {PostMarket, p.Post}} {
+		for _, period := range s.periods {
+			if ts >= period.Start && ts < period.End {
+				return s.name
+			}
+		}
+	}
+	return ""
+}
+
+// filterSessions keeps the bars in one of sessions, and bars without a
+// session such as daily ones. No sessions keeps every bar.
+func filterSessions(bars []Bar, sessions []MarketSession) []Bar {
+	if len(sessions) == 0 {
+		return bars
+	}
+	return slices.DeleteFunc(bars, func(b Bar) bool {
+		return b.Session != "" && !slices.Contains(sessions, b.Session)
+	})
+}
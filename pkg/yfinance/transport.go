@@ -0,0 +1,172 @@
+package yfinance
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TransportConfig tunes the HTTP transport. Zero fields keep the defaults of
+// http.DefaultTransport.
+type TransportConfig struct {
+	MaxIdleConns        int           // Idle connections kept across all hosts
+	MaxIdleConnsPerHost int           // Idle connections kept per host
+	MaxConnsPerHost     int           // Connections per host, including active ones
+	IdleConnTimeout     time.Duration // How long an idle connection is kept
+	TLSHandshakeTimeout time.Duration // Timeout for TLS handshakes
+	KeepAlive           time.Duration // TCP keep-alive probe interval
+	DisableKeepAlives   bool          // Use each connection for a single request
+	DisableHTTP2        bool          // Only speak HTTP/1.1
+	DisableCompression  bool          // Do not request gzip or deflate responses
+}
+
+// DefaultTransportConfig returns transport settings suited to many requests
+// to the few Yahoo hosts
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		KeepAlive:           30 * time.Second,
+	}
+}
+
+// WithTransport tunes the client's HTTP transport. It applies to the
+// client's own transport or one set with WithHTTPClient, which must then be
+// an *http.Transport.
+func WithTransport(config TransportConfig) ClientOption {
+	return func(c *Client) {
+		c.transportConfig = &config
+	}
+}
+
+// httpTransport returns a copy of the HTTP client's transport to modify
+func (c *Client) httpTransport() (*http.Transport, error) {
+	switch t := c.httpClient.Transport.(type) {
+	case nil:
+		return http.DefaultTransport.(*http.Transport).Clone(), nil
+	case *http.Transport:
+		return t.Clone(), nil
+	default:
+		return nil, fmt.Errorf("cannot configure custom transport %T", t)
+	}
+}
+
+// configureTransport applies the transport configuration to the HTTP client.
+// Without one, a transport the client creates itself gets
+// DefaultTransportConfig.
+func (c *Client) configureTransport() error {
+	config := c.transportConfig
+	if config == nil {
+		if c.httpClient.Transport != nil {
+			return nil
+		}
+		defaults := DefaultTransportConfig()
+		config = &defaults
+	}
+	transport, err := c.httpTransport()
+	if err != nil {
+		return err
+	}
+
+	if config.MaxIdleConns > 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = config.MaxConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+	if config.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = config.TLSHandshakeTimeout
+	}
+	if config.KeepAlive != 0 {
+		transport.DialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: config.KeepAlive}).DialContext
+	}
+	transport.DisableKeepAlives = config.DisableKeepAlives
+	if config.DisableHTTP2 {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	c.setTransport(transport)
+	return nil
+}
+
+// applyCompression wraps the HTTP client's transport to request compressed
+// responses and decompress them, unless disabled
+func (c *Client) applyCompression() {
+	if c.transportConfig != nil && c.transportConfig.DisableCompression {
+		return
+	}
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.setTransport(&compressionTransport{next: base})
+}
+
+// compressionTransport asks for gzip or deflate responses and decodes them.
+// http.Transport only does this for gzip, and not at all once a caller sets
+// Accept-Encoding, so custom transports and middleware get it here too.
+type compressionTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *compressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") != "" {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoder io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		decoder, err = gzip.NewReader(resp.Body)
+	case "deflate":
+		decoder, err = zlib.NewReader(resp.Body)
+	default:
+		return resp, nil
+	}
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+
+	resp.Body = &decompressedBody{ReadCloser: decoder, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// decompressedBody reads through a decoder and closes the underlying body
+type decompressedBody struct {
+	io.ReadCloser
+	body io.ReadCloser
+}
+
+// Close closes the decoder and the body
+func (b *decompressedBody) Close() error {
+	_ = b.ReadCloser.Close()
+	return b.body.Close()
+}
@@ -1,6 +1,9 @@
 package yfinance
 
 import (
+	"encoding/json"
+	"fmt"
+	"sync"
 	"time"
 )
 
@@ -42,7 +45,8 @@ const (
 	PeriodMax Period = "max"
 )
 
-// Quote represents real-time quote data for a security
+// Quote represents real-time quote data for a security. Missing values are
+// zero; use Has to tell them apart from real zeros.
 type Quote struct {
 	Symbol                     string  `json:"symbol"`
 	ShortName                  string  `json:"shortName"`
@@ -89,6 +93,8 @@ type Quote struct {
 	SharesOutstanding          int64   `json:"sharesOutstanding"`
 	AverageDailyVolume3Month   int64   `json:"averageDailyVolume3Month"`
 	AverageDailyVolume10Day    int64   `json:"averageDailyVolume10Day"`
+
+	fields fieldSet // Fields Yahoo sent, for Has
 }
 
 // Bar represents a single OHLCV bar
@@ -100,6 +106,9 @@ type Bar struct {
 	Close     float64   `json:"close"`
 	AdjClose  float64   `json:"adjClose"`
 	Volume    int64     `json:"volume"`
+	// Session is the trading session of an intraday bar, and empty for
+	// daily and longer bars
+	Session MarketSession `json:"session,omitempty"`
 }
 
 // ChartData represents historical chart data
@@ -109,12 +118,17 @@ type ChartData struct {
 	Interval Interval   `json:"interval"`
 	Bars     []Bar      `json:"bars"`
 	Meta     *ChartMeta `json:"meta,omitempty"`
+	Repairs  []Repair   `json:"repairs,omitempty"` // Fixes made with HistoryParams.Repair
 }
 
 // ChartMeta contains metadata about chart data
 type ChartMeta struct {
+	Symbol               string  `json:"symbol"`
+	ShortName            string  `json:"shortName"`
+	LongName             string  `json:"longName"`
 	Currency             string  `json:"currency"`
 	ExchangeName         string  `json:"exchangeName"`
+	FullExchangeName     string  `json:"fullExchangeName"`
 	InstrumentType       string  `json:"instrumentType"`
 	FirstTradeDate       int64   `json:"firstTradeDate"`
 	RegularMarketTime    int64   `json:"regularMarketTime"`
@@ -122,10 +136,18 @@ type ChartMeta struct {
 	Timezone             string  `json:"timezone"`
 	ExchangeTimezoneName string  `json:"exchangeTimezoneName"`
 	RegularMarketPrice   float64 `json:"regularMarketPrice"`
+	RegularMarketDayHigh float64 `json:"regularMarketDayHigh"`
+	RegularMarketDayLow  float64 `json:"regularMarketDayLow"`
+	RegularMarketVolume  int64   `json:"regularMarketVolume"`
+	FiftyTwoWeekHigh     float64 `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow      float64 `json:"fiftyTwoWeekLow"`
+	PreviousClose        float64 `json:"previousClose"`
 	ChartPreviousClose   float64 `json:"chartPreviousClose"`
 	PriceHint            int     `json:"priceHint"`
 	DataGranularity      string  `json:"dataGranularity"`
 	Range                string  `json:"range"`
+
+	TradingPeriods *TradingPeriods `json:"tradingPeriods,omitempty"` // Intraday charts only
 }
 
 // HistoryParams defines parameters for fetching historical data
@@ -136,6 +158,67 @@ type HistoryParams struct {
 	End      time.Time `json:"end,omitempty"`
 	PrePost  bool      `json:"prepost,omitempty"`
 	Events   string    `json:"events,omitempty"` // "div", "split", "div,split"
+	// Timezone is the IANA name of the zone bar timestamps are in, such as
+	// "UTC" or "Europe/London". Empty means the exchange's own zone.
+	Timezone string `json:"timezone,omitempty"`
+	// AutoAdjust scales Open, High, and Low by AdjClose/Close and sets
+	// Close to AdjClose, so every price is adjusted for splits and
+	// dividends.
+	AutoAdjust bool `json:"autoAdjust,omitempty"`
+	// BackAdjust scales Open, High, and Low the same way but keeps the
+	// traded Close. AutoAdjust takes precedence.
+	BackAdjust bool `json:"backAdjust,omitempty"`
+	// Repair fixes bad bars before any adjustment and lists the fixes in
+	// ChartData.Repairs
+	Repair bool `json:"repair,omitempty"`
+	// Sessions keeps only intraday bars of these sessions; empty keeps
+	// all. Pre- and post-market bars also need PrePost.
+	Sessions []MarketSession `json:"sessions,omitempty"`
+}
+
+// IsValid reports whether the interval is one supported by Yahoo Finance
+func (i Interval) IsValid() bool {
+	switch i {
+	case Interval1m, Interval2m, Interval5m, Interval15m, Interval30m, Interval60m, Interval90m,
+		Interval1h, Interval1d, Interval5d, Interval1wk, Interval1mo, Interval3mo:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the period is one supported by Yahoo Finance
+func (p Period) IsValid() bool {
+	switch p {
+	case Period1d, Period5d, Period1mo, Period3mo, Period6mo, Period1y,
+		Period2y, Period5y, Period10y, PeriodYTD, PeriodMax:
+		return true
+	}
+	return false
+}
+
+// Validate checks that the period, interval, and date range are usable
+func (p HistoryParams) Validate() error {
+	if p.Interval != "" && !p.Interval.IsValid() {
+		return fmt.Errorf("%w: %q", ErrInvalidInterval, p.Interval)
+	}
+	if p.Period != "" && !p.Period.IsValid() {
+		return fmt.Errorf("%w: %q", ErrInvalidPeriod, p.Period)
+	}
+	if p.Timezone != "" {
+		if _, err := loadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", p.Timezone, err)
+		}
+	}
+	for _, s := range p.Sessions {
+		if s != PreMarket && s != RegularMarket && s != PostMarket {
+			return fmt.Errorf("invalid session %q: want pre, regular, or post", s)
+		}
+	}
+	if !p.Start.IsZero() && !p.End.IsZero() && !p.End.After(p.Start) {
+		return fmt.Errorf("%w: end %s is not after start %s", ErrInvalidPeriod,
+			p.End.Format(time.DateOnly), p.Start.Format(time.DateOnly))
+	}
+	return nil
 }
 
 // QuoteSummary represents comprehensive quote information
@@ -148,6 +231,34 @@ type QuoteSummary struct {
 	KeyStatistics  *KeyStatistics  `json:"defaultKeyStatistics,omitempty"`
 	FinancialData  *FinancialData  `json:"financialData,omitempty"`
 	CalendarEvents *CalendarEvents `json:"calendarEvents,omitempty"`
+	QuoteType      *QuoteTypeInfo  `json:"quoteType,omitempty"`
+
+	// Raw holds every returned module as Yahoo sent it, keyed by module
+	// name, including those without a typed field above
+	Raw map[string]json.RawMessage `json:"raw,omitempty"`
+}
+
+// Module decodes the named module into v, which should match the module's
+// JSON shape. It returns ErrNoData if the module was not returned.
+func (s *QuoteSummary) Module(name string, v any) error {
+	raw, ok := s.Raw[name]
+	if !ok {
+		return fmt.Errorf("%w: module %s", ErrNoData, name)
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// QuoteTypeInfo identifies a security and where it trades
+type QuoteTypeInfo struct {
+	Symbol            string `json:"symbol"`
+	UnderlyingSymbol  string `json:"underlyingSymbol,omitempty"`
+	ShortName         string `json:"shortName"`
+	LongName          string `json:"longName"`
+	QuoteType         string `json:"quoteType"` // e.g. "EQUITY", "ETF", or "CRYPTOCURRENCY"
+	Exchange          string `json:"exchange"`
+	FirstTradeDate    int64  `json:"firstTradeDateEpochUtc,omitempty"`
+	TimeZoneFullName  string `json:"timeZoneFullName"`
+	TimeZoneShortName string `json:"timeZoneShortName"`
 }
 
 // AssetProfile contains company profile information
@@ -227,6 +338,8 @@ type SummaryDetail struct {
 	TrailingAnnualDividendRate  float64 `json:"trailingAnnualDividendRate"`
 	TrailingAnnualDividendYield float64 `json:"trailingAnnualDividendYield"`
 	Currency                    string  `json:"currency"`
+
+	fields fieldSet // Fields Yahoo sent, for Has
 }
 
 // PriceInfo contains price information
@@ -285,6 +398,8 @@ type KeyStatistics struct {
 	EnterpriseToEbitda      float64 `json:"enterpriseToEbitda"`
 	FiftyTwoWeekChange      float64 `json:"52WeekChange"`
 	SandP52WeekChange       float64 `json:"SandP52WeekChange"`
+
+	fields fieldSet // Fields Yahoo sent, for Has
 }
 
 // FinancialData contains financial data
@@ -318,6 +433,8 @@ type FinancialData struct {
 	OperatingMargins        float64 `json:"operatingMargins"`
 	ProfitMargins           float64 `json:"profitMargins"`
 	FinancialCurrency       string  `json:"financialCurrency"`
+
+	fields fieldSet // Fields Yahoo sent, for Has
 }
 
 // CalendarEvents contains calendar events
@@ -375,8 +492,8 @@ type Option struct {
 // Financial represents financial statement data
 type Financial struct {
 	Symbol    string                      `json:"symbol"`
-	Timestamp []int64                     `json:"timestamp"`
-	Data      map[string][]FinancialValue `json:"data"`
+	Timestamp []int64                     `json:"timestamp"` // As-of dates of the periods, oldest first
+	Data      map[string][]FinancialValue `json:"data"`      // Values by metric, e.g. "TotalRevenue", oldest first
 }
 
 // FinancialValue represents a single financial value
@@ -385,7 +502,13 @@ type FinancialValue struct {
 	Fmt           string  `json:"fmt"`
 	ReportedValue float64 `json:"reportedValue,omitempty"`
 	AsOfDate      string  `json:"asOfDate,omitempty"`
-	PeriodType    string  `json:"periodType,omitempty"`
+	PeriodType    string  `json:"periodType,omitempty"`   // e.g. "12M" or "3M"
+	CurrencyCode  string  `json:"currencyCode,omitempty"` // Currency of Raw
+	// Set when WithCurrency converted the value: Raw is ReportedValue, in
+	// ReportedCurrency, times FXRate, the closing rate on FXDate
+	ReportedCurrency string  `json:"reportedCurrency,omitempty"`
+	FXRate           float64 `json:"fxRate,omitempty"`
+	FXDate           string  `json:"fxDate,omitempty"`
 }
 
 // SearchResult represents search results
@@ -491,13 +614,19 @@ type ScreenCriteria struct {
 	SortField string                 `json:"sortField,omitempty"`
 	SortType  string                 `json:"sortType,omitempty"`
 	Query     map[string]interface{} `json:"query,omitempty"`
+
+	// Unchecked skips validation against the field catalog, for fields
+	// Yahoo accepts that it does not list
+	Unchecked bool `json:"-"`
 }
 
 // ScreenResult represents screener results
 type ScreenResult struct {
-	Count  int     `json:"count"`
-	Total  int     `json:"total"`
-	Quotes []Quote `json:"quotes"`
+	Title       string  `json:"title,omitempty"`       // Of a saved screener
+	Description string  `json:"description,omitempty"` // Of a saved screener
+	Count       int     `json:"count"`
+	Total       int     `json:"total"`
+	Quotes      []Quote `json:"quotes"`
 }
 
 // EarningsEvent represents an earnings calendar event
@@ -511,6 +640,17 @@ type EarningsEvent struct {
 	StartDateTime    int64   `json:"startDateTime,omitempty"`
 }
 
+// EarningsDate is a past or upcoming earnings announcement of one company.
+// Figures Yahoo does not have, such as the reported EPS of an upcoming
+// announcement, are nil.
+type EarningsDate struct {
+	Date            time.Time `json:"date"`
+	Timezone        string    `json:"timezone,omitempty"` // Short name, e.g. "EDT"
+	EPSEstimate     *float64  `json:"epsEstimate,omitempty"`
+	ReportedEPS     *float64  `json:"reportedEps,omitempty"`
+	SurprisePercent *float64  `json:"surprisePercent,omitempty"`
+}
+
 // IPOEvent represents an IPO calendar event
 type IPOEvent struct {
 	Symbol      string  `json:"symbol"`
@@ -542,6 +682,29 @@ type SplitEvent struct {
 	SplitRatio       string `json:"splitRatio"`
 }
 
+// DividendEvent represents an upcoming dividend for a symbol
+type DividendEvent struct {
+	Symbol         string `json:"symbol"`
+	ExDividendDate int64  `json:"exDividendDate"`
+	DividendDate   int64  `json:"dividendDate,omitempty"`
+}
+
+// TickerCalendar holds a ticker's upcoming earnings and dividend events.
+// Dates are zero when Yahoo has none scheduled.
+type TickerCalendar struct {
+	EarningsDates     []time.Time `json:"earningsDates,omitempty"` // One date, or the window's bounds when unconfirmed
+	EarningsCallDates []time.Time `json:"earningsCallDates,omitempty"`
+	EarningsEstimated bool        `json:"earningsEstimated"` // Whether the earnings date is Yahoo's estimate
+	EarningsAverage   float64     `json:"earningsAverage,omitempty"`
+	EarningsLow       float64     `json:"earningsLow,omitempty"`
+	EarningsHigh      float64     `json:"earningsHigh,omitempty"`
+	RevenueAverage    float64     `json:"revenueAverage,omitempty"`
+	RevenueLow        float64     `json:"revenueLow,omitempty"`
+	RevenueHigh       float64     `json:"revenueHigh,omitempty"`
+	ExDividendDate    time.Time   `json:"exDividendDate"`
+	DividendDate      time.Time   `json:"dividendDate"` // Payment date
+}
+
 // CalendarParams defines parameters for calendar queries
 type CalendarParams struct {
 	Start  time.Time `json:"start,omitempty"`
@@ -572,3 +735,20 @@ type StreamMessage struct {
 	OpenPrice     float64 `json:"openPrice"`
 	ShortName     string  `json:"shortName"`
 }
+
+// locations caches loaded time zones by name
+var locations sync.Map
+
+// loadLocation is time.LoadLocation with caching, since each load reads
+// the zone database
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
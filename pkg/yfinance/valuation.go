@@ -0,0 +1,141 @@
+package yfinance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatedValue is a value as of a date
+type DatedValue struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// timeseriesPoint is one value of a fundamentals timeseries
+type timeseriesPoint struct {
+	AsOfDate      string   `json:"asOfDate"`
+	PeriodType    string   `json:"periodType"`
+	CurrencyCode  string   `json:"currencyCode"`
+	ReportedValue RawValue `json:"reportedValue"`
+	// Segment breakdowns name the segment of each value, under either key
+	Segment     string `json:"segment,omitempty"`
+	SegmentName string `json:"segmentName,omitempty"`
+}
+
+// MarketCapHistory fetches the ticker's market cap at each quarter end
+// between start and end, oldest first. A zero end means now and a zero
+// start five years before end.
+func (t *Ticker) MarketCapHistory(ctx context.Context, start, end time.Time) ([]DatedValue, error) {
+	ctx, span := t.startSpan(ctx, "MarketCapHistory")
+	defer span.End()
+	return t.valuationHistory(ctx, "trailingMarketCap", start, end)
+}
+
+// EnterpriseValueHistory fetches the ticker's enterprise value at each
+// quarter end between start and end, oldest first. A zero end means now and
+// a zero start five years before end.
+func (t *Ticker) EnterpriseValueHistory(ctx context.Context, start, end time.Time) ([]DatedValue, error) {
+	ctx, span := t.startSpan(ctx, "EnterpriseValueHistory")
+	defer span.End()
+	return t.valuationHistory(ctx, "trailingEnterpriseValue", start, end)
+}
+
+// valuationHistory fetches one valuation measure as dated values
+func (t *Ticker) valuationHistory(ctx context.Context, measure string, start, end time.Time) ([]DatedValue, error) {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		start = end.AddDate(-5, 0, 0)
+	}
+	if !end.After(start) {
+		return nil, NewSymbolError(t.Symbol, fmt.Errorf("%w: end %s is not after start %s", ErrInvalidPeriod,
+			end.Format(time.DateOnly), start.Format(time.DateOnly)))
+	}
+
+	series, err := t.timeseries(ctx, []string{measure}, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []DatedValue
+	for _, p := range series[measure] {
+		date, err := time.Parse(time.DateOnly, p.AsOfDate)
+		if err != nil || !p.ReportedValue.Valid {
+			continue
+		}
+		values = append(values, DatedValue{Date: date, Value: p.ReportedValue.Raw})
+	}
+	if len(values) == 0 {
+		return nil, NewSymbolError(t.Symbol, ErrNoData)
+	}
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Date.Before(values[j].Date) })
+	return values, nil
+}
+
+// timeseries fetches fundamentals timeseries of the given types between
+// start and end, keyed by type. Padding entries Yahoo sends as null are
+// dropped.
+func (t *Ticker) timeseries(ctx context.Context, types []string, start, end time.Time) (map[string][]timeseriesPoint, error) {
+	endpoint := fmt.Sprintf("%s/%s", t.client.endpoints.Fundamentals, t.Symbol)
+	params := url.Values{}
+	params.Set("symbol", t.Symbol)
+	params.Set("type", strings.Join(types, ","))
+	params.Set("merge", "false")
+	params.Set("period1", strconv.FormatInt(start.Unix(), 10))
+	params.Set("period2", strconv.FormatInt(end.Unix(), 10))
+
+	data, err := t.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, NewSymbolError(t.Symbol, err)
+	}
+
+	var response struct {
+		Timeseries struct {
+			Result []map[string]json.RawMessage `json:"result"`
+			Error  *struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"timeseries"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, "timeseries response", err))
+	}
+
+	if response.Timeseries.Error != nil {
+		return nil, NewSymbolError(t.Symbol, &APIError{
+			Code:        response.Timeseries.Error.Code,
+			Description: response.Timeseries.Error.Description,
+		})
+	}
+
+	// Each result holds one type, under a key named after it
+	series := make(map[string][]timeseriesPoint, len(types))
+	for _, result := range response.Timeseries.Result {
+		var meta struct {
+			Type []string `json:"type"`
+		}
+		if err := json.Unmarshal(result["meta"], &meta); err != nil || len(meta.Type) == 0 {
+			continue
+		}
+		name := meta.Type[0]
+		var points []*timeseriesPoint
+		if err := json.Unmarshal(result[name], &points); err != nil {
+			return nil, NewSymbolError(t.Symbol, parseError(endpoint, params, data, name+" timeseries", err))
+		}
+		for _, p := range points {
+			if p != nil {
+				series[name] = append(series[name], *p)
+			}
+		}
+	}
+	return series, nil
+}
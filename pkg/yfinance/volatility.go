@@ -0,0 +1,108 @@
+package yfinance
+
+import (
+	"fmt"
+	"math"
+)
+
+// VolatilityMethod is an estimator of realized volatility
+type VolatilityMethod string
+
+const (
+	// CloseToClose is the standard deviation of log returns between closes
+	CloseToClose VolatilityMethod = "close"
+	// Parkinson estimates from each bar's high-low range, which captures
+	// intraday moves closes miss
+	Parkinson VolatilityMethod = "parkinson"
+	// GarmanKlass adds the open-to-close move to the Parkinson range
+	GarmanKlass VolatilityMethod = "garman-klass"
+)
+
+// Defaults of VolatilityParams
+const (
+	defaultVolatilityWindow = 20
+	tradingDaysPerYear      = 252
+)
+
+// VolatilityParams configures HistoricalVolatility. Zero fields take
+// their defaults.
+type VolatilityParams struct {
+	Method VolatilityMethod // Defaults to CloseToClose
+	Window int              // Bars per estimate; defaults to 20
+	// PeriodsPerYear annualizes the estimate and defaults to 252 for daily
+	// bars; use 52 for weekly bars or 12 for monthly ones
+	PeriodsPerYear float64
+}
+
+// HistoricalVolatility computes annualized realized volatility over a
+// rolling window of the chart's bars; see the package-level function
+func (c *ChartData) HistoricalVolatility(params VolatilityParams) ([]DatedValue, error) {
+	return HistoricalVolatility(c.Bars, params)
+}
+
+// HistoricalVolatility computes annualized realized volatility over a
+// rolling window of bars, dated by each window's last bar, so 0.25 means
+// 25% a year and compares directly with implied volatility. Bars without
+// prices are skipped. It returns no values if there are too few bars for
+// one window.
+func HistoricalVolatility(bars []Bar, params VolatilityParams) ([]DatedValue, error) {
+	if params.Method == "" {
+		params.Method = CloseToClose
+	}
+	if params.Window <= 0 {
+		params.Window = defaultVolatilityWindow
+	}
+	if params.PeriodsPerYear <= 0 {
+		params.PeriodsPerYear = tradingDaysPerYear
+	}
+
+	var priced []Bar
+	for _, b := range bars {
+		if b.Open > 0 && b.High > 0 && b.Low > 0 && b.Close > 0 {
+			priced = append(priced, b)
+		}
+	}
+
+	// Each bar's contribution to the variance estimate
+	var terms []float64
+	var first int
+	switch params.Method {
+	case CloseToClose:
+		// Returns need the previous close, so the first bar only anchors
+		first = 1
+		for i := 1; i < len(priced); i++ {
+			terms = append(terms, math.Log(priced[i].Close/priced[i-1].Close))
+		}
+	case Parkinson:
+		for _, b := range priced {
+			hl := math.Log(b.High / b.Low)
+			terms = append(terms, hl*hl/(4*math.Ln2))
+		}
+	case GarmanKlass:
+		for _, b := range priced {
+			hl, co := math.Log(b.High/b.Low), math.Log(b.Close/b.Open)
+			terms = append(terms, hl*hl/2-(2*math.Ln2-1)*co*co)
+		}
+	default:
+		return nil, fmt.Errorf("unknown volatility method %q: want %s, %s, or %s", params.Method, CloseToClose, Parkinson, GarmanKlass)
+	}
+
+	var values []DatedValue
+	for end := params.Window; end <= len(terms); end++ {
+		window := terms[end-params.Window : end]
+		var variance float64
+		if params.Method == CloseToClose {
+			variance = Variance(window)
+		} else {
+			for _, term := range window {
+				variance += term
+			}
+			variance /= float64(len(window))
+		}
+		values = append(values, DatedValue{
+			Date:  priced[first+end-1].Timestamp,
+			Value: math.Sqrt(max(variance, 0) * params.PeriodsPerYear),
+		})
+	}
+	return values, nil
+}
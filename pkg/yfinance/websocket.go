@@ -5,31 +5,115 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 )
 
 // Stream represents a real-time WebSocket connection for streaming quotes
 type Stream struct {
-	symbols  []string
-	conn     *websocket.Conn
-	messages chan StreamMessage
-	errors   chan error
-	done     chan struct{}
-	mu       sync.Mutex
-	running  bool
-}
-
-// NewStream creates a new WebSocket stream for the given symbols
-func NewStream(symbols []string) *Stream {
-	return &Stream{
+	symbols   []string
+	url       string
+	conn      *websocket.Conn
+	messages  chan StreamMessage
+	errors    chan error
+	done      chan struct{}
+	mu        sync.Mutex
+	running   bool
+	limiter   *RateLimiter
+	mw        []Middleware
+	logger    *slog.Logger
+	observers []Observer
+	connects  int
+
+	tracerProvider trace.TracerProvider
+	traceCtx       context.Context // Span context of the Connect call, parent of message spans
+}
+
+// StreamOption is a function that configures Stream options
+type StreamOption func(*Stream)
+
+// WithStreamRateLimiter sets the rate limiter that connecting and each
+// subscribe or unsubscribe message wait on. Pass nil to disable limiting.
+func WithStreamRateLimiter(rl *RateLimiter) StreamOption {
+	return func(s *Stream) {
+		s.limiter = rl
+	}
+}
+
+// WithStreamURL sets the websocket URL to connect to, instead of the default
+// client's Endpoints().WebSocket
+func WithStreamURL(u string) StreamOption {
+	return func(s *Stream) {
+		s.url = u
+	}
+}
+
+// WithStreamMiddleware sets the middleware the websocket handshake request
+// passes through, outermost first
+func WithStreamMiddleware(mw ...Middleware) StreamOption {
+	return func(s *Stream) {
+		s.mw = mw
+	}
+}
+
+// NewStream creates a new WebSocket stream for the given symbols. By default
+// the stream shares the default client's rate limiter, middleware, and
+// websocket endpoint.
+func NewStream(symbols []string, opts ...StreamOption) *Stream {
+	s := &Stream{
 		symbols:  symbols,
 		messages: make(chan StreamMessage, 100),
 		errors:   make(chan error, 10),
 		done:     make(chan struct{}),
 	}
+	if client, err := getDefaultClient(); err == nil {
+		s.url = client.Endpoints().WebSocket
+		s.limiter = client.RateLimiter()
+		s.mw = client.Middleware()
+		s.logger = client.Logger()
+		s.observers = client.Observers()
+		s.tracerProvider = client.TracerProvider()
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.url == "" {
+		s.url = WebSocketURL
+	}
+	if s.logger == nil {
+		s.logger = discardLogger
+	}
+	if s.tracerProvider == nil {
+		s.tracerProvider = otel.GetTracerProvider()
+	}
+	return s
+}
+
+// tracer returns the stream's tracer
+func (s *Stream) tracer() trace.Tracer {
+	return s.tracerProvider.Tracer(tracerName)
+}
+
+// throttle waits for the rate limiter, if any
+func (s *Stream) throttle(ctx context.Context) error {
+	if s.limiter == nil {
+		return nil
+	}
+	start := time.Now()
+	err := s.limiter.Wait(ctx)
+	for _, o := range s.observers {
+		o.ObserveRateLimitWait(time.Since(start))
+	}
+	return err
 }
 
 // Connect establishes a WebSocket connection
@@ -41,9 +125,23 @@ func (s *Stream) Connect(ctx context.Context) error {
 		return nil
 	}
 
-	dialer := websocket.DefaultDialer
-	conn, _, err := dialer.DialContext(ctx, WebSocketURL, nil)
+	if err := s.throttle(ctx); err != nil {
+		return err
+	}
+	if s.connects > 0 {
+		s.logger.DebugContext(ctx, "yfinance: websocket reconnecting", "attempt", s.connects)
+	} else {
+		s.logger.DebugContext(ctx, "yfinance: websocket connecting", "symbols", len(s.symbols))
+	}
+	s.connects++
+	// Message spans join the caller's trace but outlive its context
+	s.traceCtx = trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+	spanCtx, span := s.tracer().Start(ctx, "yfinance.Stream.connect", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.Int("yfinance.symbols", len(s.symbols))))
+	conn, err := s.dial(spanCtx)
+	endSpan(span, err)
 	if err != nil {
+		s.logger.DebugContext(ctx, "yfinance: websocket connect failed", "error", err)
 		return fmt.Errorf("failed to connect to websocket: %w", err)
 	}
 
@@ -52,6 +150,11 @@ func (s *Stream) Connect(ctx context.Context) error {
 
 	// Subscribe to symbols
 	if len(s.symbols) > 0 {
+		if err := s.throttle(ctx); err != nil {
+			_ = s.conn.Close()
+			s.running = false
+			return err
+		}
 		if err := s.subscribe(s.symbols); err != nil {
 			_ = s.conn.Close()
 			s.running = false
@@ -60,13 +163,52 @@ func (s *Stream) Connect(ctx context.Context) error {
 	}
 
 	// Start reading messages
+	s.logger.DebugContext(ctx, "yfinance: websocket connected")
 	go s.readLoop()
 
 	return nil
 }
 
+// dial opens the websocket, sending the handshake request through the
+// stream's middleware
+func (s *Stream) dial(ctx context.Context) (*websocket.Conn, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var conn *websocket.Conn
+	dial := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		c, resp, err := websocket.DefaultDialer.DialContext(req.Context(), req.URL.String(), req.Header)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Body == nil {
+			resp.Body = http.NoBody
+		}
+		conn = c
+		return resp, nil
+	})
+
+	var transport http.RoundTripper = dial
+	if len(s.observers) > 0 {
+		transport = observingTransport(transport, s.observers, func(*url.URL) string { return "stream" })
+	}
+	if _, err := chain(transport, s.mw).RoundTrip(req); err != nil {
+		if conn != nil {
+			_ = conn.Close()
+		}
+		return nil, err
+	}
+	if conn == nil {
+		return nil, fmt.Errorf("websocket handshake was not sent")
+	}
+	return conn, nil
+}
+
 // subscribe sends a subscription message
 func (s *Stream) subscribe(symbols []string) error {
+	s.logger.Debug("yfinance: websocket subscribe", "symbols", symbols)
 	msg := map[string]interface{}{
 		"subscribe": symbols,
 	}
@@ -75,6 +217,7 @@ func (s *Stream) subscribe(symbols []string) error {
 
 // unsubscribe sends an unsubscription message
 func (s *Stream) unsubscribe(symbols []string) error {
+	s.logger.Debug("yfinance: websocket unsubscribe", "symbols", symbols)
 	msg := map[string]interface{}{
 		"unsubscribe": symbols,
 	}
@@ -83,6 +226,12 @@ func (s *Stream) unsubscribe(symbols []string) error {
 
 // Subscribe adds symbols to the subscription
 func (s *Stream) Subscribe(symbols ...string) error {
+	if s.IsConnected() {
+		if err := s.throttle(context.Background()); err != nil {
+			return err
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -100,6 +249,12 @@ func (s *Stream) Subscribe(symbols ...string) error {
 
 // Unsubscribe removes symbols from the subscription
 func (s *Stream) Unsubscribe(symbols ...string) error {
+	if s.IsConnected() {
+		if err := s.throttle(context.Background()); err != nil {
+			return err
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -150,21 +305,32 @@ func (s *Stream) readLoop() {
 		default:
 			_, data, err := s.conn.ReadMessage()
 			if err != nil {
+				s.logger.Debug("yfinance: websocket read failed", "error", err)
 				s.errors <- err
 				return
 			}
 
+			_, span := s.tracer().Start(s.traceCtx, "yfinance.Stream.message", trace.WithSpanKind(trace.SpanKindConsumer))
 			msg, err := parseStreamMessage(data)
 			if err != nil {
+				s.logger.Debug("yfinance: invalid stream message", "error", err)
+				endSpan(span, err)
 				s.errors <- err
 				continue
 			}
+			span.SetAttributes(attrSymbol.String(msg.ID))
+
+			for _, o := range s.observers {
+				o.ObserveStreamMessage()
+			}
 
 			select {
 			case s.messages <- *msg:
 			default:
 				// Channel full, skip message
+				span.SetAttributes(attribute.Bool("yfinance.dropped", true))
 			}
+			span.End()
 		}
 	}
 }
@@ -223,6 +389,7 @@ func (s *Stream) Close() error {
 
 	close(s.done)
 	s.running = false
+	s.logger.Debug("yfinance: websocket closed")
 
 	if s.conn != nil {
 		return s.conn.Close()
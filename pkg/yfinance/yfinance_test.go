@@ -1,13 +1,49 @@
 package yfinance
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
+// newTestClient returns a client without retries that already holds a crumb
+// and sends its API requests to a test server running handler
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient(append([]ClientOption{WithRetry(RetryConfig{}), WithBaseURL(srv.URL)}, opts...)...)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+	return client
+}
+
 // TestGreeksCalculation tests Black-Scholes Greeks calculation
 func TestGreeksCalculation(t *testing.T) {
 	// Test case: AAPL call option
@@ -131,289 +167,3409 @@ func TestCacheExpiration(t *testing.T) {
 	}
 }
 
-// TestRetryBackoff tests backoff calculation
-func TestRetryBackoff(t *testing.T) {
-	backoff := calculateBackoff(1*time.Second, 30*time.Second, 0)
-	if backoff != 1*time.Second {
-		t.Errorf("Expected 1s backoff, got %v", backoff)
+// TestCacheLRU tests least recently used eviction by entries and bytes
+func TestCacheLRU(t *testing.T) {
+	cache := NewCache(CacheConfig{Type: CacheTypeMemory, DefaultTTL: time.Minute, MaxSize: 2})
+	cache.Set("a", []byte("1"), 0)
+	cache.Set("b", []byte("2"), 0)
+	cache.Get("a")
+	cache.Set("c", []byte("3"), 0)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected the least recently used entry to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("Expected the recently used entry to be kept")
 	}
 
-	// Test max limit
-	backoff = calculateBackoff(60*time.Second, 30*time.Second, 0)
-	if backoff != 30*time.Second {
-		t.Errorf("Expected 30s max backoff, got %v", backoff)
+	// Each entry counts its key and data: 2 + 8 bytes
+	cache = NewCache(CacheConfig{Type: CacheTypeMemory, DefaultTTL: time.Minute, MaxBytes: 25})
+	cache.Set("k1", []byte("12345678"), 0)
+	cache.Set("k2", []byte("12345678"), 0)
+	cache.Set("k3", []byte("12345678"), 0)
+	cache.Set("k4", []byte(strings.Repeat("x", 30)), 0)
+	cache.Get("k1")
+	cache.Get("k3")
+
+	stats := cache.Stats()
+	want := CacheStats{Hits: 1, Misses: 1, Evictions: 1, Entries: 2, Bytes: 20}
+	if stats != want {
+		t.Errorf("Expected %+v, got %+v", want, stats)
 	}
 }
 
-// TestRateLimiter tests rate limiter
-func TestRateLimiter(t *testing.T) {
-	rl := NewRateLimiter(10, 5) // 10 req/s, burst of 5
-
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancel()
-
-	// Should be able to make burst requests immediately
-	for i := 0; i < 5; i++ {
-		err := rl.Wait(ctx)
-		if err != nil {
-			t.Errorf("Expected no error on burst request %d, got %v", i, err)
-		}
+// TestCacheSweep tests that sweeps remove expired, corrupt, stale, and
+// excess disk files
+func TestCacheSweep(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(CacheConfig{Type: CacheTypeDisk, Directory: dir, DefaultTTL: time.Minute, MaxDiskBytes: 200})
+	defer func() { _ = cache.Close() }()
+
+	cache.Set("expired", []byte("x"), -time.Second)
+	cache.Set("old", []byte(strings.Repeat("o", 60)), 0)
+	cache.Set("new", []byte(strings.Repeat("n", 60)), 0)
+	if tmp, _ := filepath.Glob(filepath.Join(dir, "*"+tempSuffix)); len(tmp) != 0 {
+		t.Errorf("Expected no temporary files after writes, got %v", tmp)
+	}
+	past := time.Now().Add(-time.Hour)
+	_ = os.Chtimes(filepath.Join(dir, "old.json"), past, past)
+	_ = os.WriteFile(filepath.Join(dir, "corrupt.json"), []byte("{"), 0o600)
+	_ = os.WriteFile(filepath.Join(dir, "stale.json.123"+tempSuffix), []byte("{"), 0o600)
+	_ = os.Chtimes(filepath.Join(dir, "stale.json.123"+tempSuffix), past, past)
+
+	if err := cache.Sweep(); err != nil {
+		t.Fatal(err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(files) != 1 || filepath.Base(files[0]) != "new.json" {
+		t.Errorf("Expected only new.json to be left, got %v", files)
+	}
+	if swept := cache.Stats().Swept; swept != 4 {
+		t.Errorf("Expected 4 swept files, got %d", swept)
 	}
-}
 
-// TestNewTicker tests ticker creation
-func TestNewTicker(t *testing.T) {
-	ticker, err := NewTicker("AAPL")
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	cache.Set("quote:abc/def", []byte("q"), 0)
+	if _, err := os.Stat(filepath.Join(dir, "quote_abc_def.json")); err != nil {
+		t.Errorf("Expected a path-safe file name, got %v", err)
+	}
+	if data, ok := cache.Get("quote:abc/def"); !ok || string(data) != "q" {
+		t.Errorf("Expected entry to be read back, got %q", data)
+	}
+	cache.Delete("quote:abc/def")
+	if _, err := os.Stat(filepath.Join(dir, "quote_abc_def.json")); !os.IsNotExist(err) {
+		t.Error("Expected entry to be deleted")
 	}
 
-	if ticker.Symbol != "AAPL" {
-		t.Errorf("Expected symbol AAPL, got %s", ticker.Symbol)
+	_ = os.WriteFile(filepath.Join(dir, "new.json"), []byte("not json"), 0o600)
+	if _, ok := cache.Get("new"); ok {
+		t.Error("Expected corrupt entry to be missed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.json")); !os.IsNotExist(err) {
+		t.Error("Expected corrupt entry to be removed on read")
 	}
-}
 
-// TestNewTickerEmpty tests empty symbol
-func TestNewTickerEmpty(t *testing.T) {
-	_, err := NewTicker("")
-	if !errors.Is(err, ErrInvalidSymbol) {
-		t.Errorf("Expected ErrInvalidSymbol, got %v", err)
+	janitor := NewCache(CacheConfig{Type: CacheTypeDisk, Directory: dir, SweepInterval: 10 * time.Millisecond})
+	defer func() { _ = janitor.Close() }()
+	janitor.Set("short", []byte("x"), 20*time.Millisecond)
+	deadline := time.Now().Add(2 * time.Second)
+	for janitor.Stats().Swept == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if janitor.Stats().Swept != 1 {
+		t.Error("Expected the janitor to sweep the expired entry")
 	}
 }
 
-// TestNewTickers tests batch ticker creation
-func TestNewTickers(t *testing.T) {
-	tickers, err := NewTickers("AAPL", "GOOGL", "MSFT")
+// TestSQLiteCache tests that chart and quote responses are stored as rows
+// and history is synced from the last stored bar
+func TestSQLiteCache(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewSQLiteCache(SQLiteCacheConfig{Path: filepath.Join(t.TempDir(), "cache.db")})
 	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+		t.Fatal(err)
 	}
+	defer func() { _ = cache.Close() }()
 
-	if len(tickers.Symbols()) != 3 {
-		t.Errorf("Expected 3 symbols, got %d", len(tickers.Symbols()))
+	cache.Set("other:a", []byte("1"), 0)
+	if data, ok := cache.Get("other:a"); !ok || string(data) != "1" {
+		t.Errorf("Expected cached response, got %q", data)
 	}
-
-	_, ok := tickers.Ticker("AAPL")
-	if !ok {
-		t.Error("Expected to find AAPL ticker")
+	cache.Set("other:b", []byte("2"), -time.Second)
+	if _, ok := cache.Get("other:b"); ok {
+		t.Error("Expected expired response to be missed")
 	}
-}
 
-// TestPeriodConstants tests period constant values
-func TestPeriodConstants(t *testing.T) {
-	tests := []struct {
-		period Period
-		want   string
-	}{
-		{Period1d, "1d"},
-		{Period5d, "5d"},
-		{Period1mo, "1mo"},
-		{Period1y, "1y"},
-		{PeriodMax, "max"},
-	}
+	chart := `{"chart":{"result":[{"meta":{"symbol":"AAPL","dataGranularity":"1d"},"timestamp":[%d,%d],
+		"indicators":{"quote":[{"open":[1,2],"high":[1,2],"low":[1,2],"close":[1,%d],"volume":[10,20]}]}}]}}`
+	cache.Set("history:a", []byte(fmt.Sprintf(chart, 86400, 2*86400, 2)), 0)
+	cache.Set("quote:a", []byte(`{"quoteResponse":{"result":[{"symbol":"AAPL","regularMarketPrice":3,"regularMarketTime":100}]}}`), 0)
+	cache.Clear()
 
-	for _, tt := range tests {
-		if string(tt.period) != tt.want {
-			t.Errorf("Expected %s, got %s", tt.want, string(tt.period))
-		}
+	bars, err := cache.Bars(ctx, "aapl", Interval1d, time.Time{}, time.Time{})
+	if err != nil || len(bars) != 2 || bars[1].Close != 2 || bars[1].AdjClose != 2 {
+		t.Fatalf("Expected 2 stored bars after Clear, got %+v, %v", bars, err)
 	}
-}
-
-// TestIntervalConstants tests interval constant values
-func TestIntervalConstants(t *testing.T) {
-	tests := []struct {
-		interval Interval
-		want     string
-	}{
-		{Interval1m, "1m"},
-		{Interval5m, "5m"},
-		{Interval1h, "1h"},
-		{Interval1d, "1d"},
-		{Interval1wk, "1wk"},
+	quotes, err := cache.Quotes(ctx, "AAPL", time.Unix(100, 0), time.Time{})
+	if err != nil || len(quotes) != 1 || quotes[0].RegularMarketPrice != 3 {
+		t.Errorf("Expected stored quote, got %+v, %v", quotes, err)
 	}
 
-	for _, tt := range tests {
-		if string(tt.interval) != tt.want {
-			t.Errorf("Expected %s, got %s", tt.want, string(tt.interval))
-		}
-	}
-}
+	var period1 string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		period1 = r.URL.Query().Get("period1")
+		_, _ = fmt.Fprintf(w, chart, 2*86400, 3*86400, 5)
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
 
-// TestDefaultModules tests default modules helper
-func TestDefaultModules(t *testing.T) {
-	modules := DefaultModules()
-	if len(modules) == 0 {
-		t.Error("Expected non-empty default modules")
+	bars, err = cache.SyncHistory(ctx, ticker, Interval1d)
+	if err != nil || len(bars) != 3 || bars[1].Close != 1 || bars[2].Close != 5 {
+		t.Fatalf("Expected 3 synced bars, got %+v, %v", bars, err)
+	}
+	if period1 != "172800" {
+		t.Errorf("Expected sync from the last stored bar, got period1=%s", period1)
 	}
 }
 
-// TestFinancialModules tests financial modules helper
-func TestFinancialModules(t *testing.T) {
-	modules := FinancialModules()
-	if len(modules) != 6 {
-		t.Errorf("Expected 6 financial modules, got %d", len(modules))
+// TestDownloadIncremental tests that only bars missing from the store are
+// fetched and the stored range is returned
+func TestDownloadIncremental(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewSQLiteCache(SQLiteCacheConfig{Path: filepath.Join(t.TempDir(), "bars.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+	if err := store.SaveBars(ctx, "AAPL", Interval1d, []Bar{{Timestamp: time.Unix(86400, 0), Close: 1}, {Timestamp: time.Unix(2*86400, 0), Close: 2}}); err != nil {
+		t.Fatal(err)
 	}
-}
 
-// TestErrorTypes tests custom error types
-func TestErrorTypes(t *testing.T) {
-	apiErr := &APIError{
-		Code:        "test",
-		Description: "test error",
-		StatusCode:  400,
+	var mu sync.Mutex
+	requests := make(map[string]url.Values)
+	var queries []url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sym := path.Base(r.URL.Path)
+		mu.Lock()
+		requests[sym] = r.URL.Query()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		_, _ = fmt.Fprintf(w, `{"chart":{"result":[{"meta":{"symbol":%q,"currency":"USD","dataGranularity":"1d"},"timestamp":[%d,%d],
+			"indicators":{"quote":[{"open":[1,1],"high":[1,1],"low":[1,1],"close":[3,4],"volume":[10,20]}]}}]}}`, sym, 2*86400, 3*86400)
+	})
+	prev, _ := DefaultClient()
+	SetDefaultClient(client)
+	t.Cleanup(func() { SetDefaultClient(prev) })
+
+	res, err := DownloadIncremental(ctx, store, DownloadParams{Symbols: []string{"AAPL", "MSFT"}, Period: Period1y})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", res.Errors)
+	}
+	if q := requests["AAPL"]; q.Get("period1") != "172800" || q.Get("range") != "" {
+		t.Errorf("Expected AAPL fetched from its last stored bar, got %v", q)
+	}
+	if q := requests["MSFT"]; q.Get("range") != "1y" {
+		t.Errorf("Expected MSFT backfilled over the period, got %v", q)
+	}
+	aapl := res.Data["AAPL"]
+	if len(aapl.Bars) != 3 || aapl.Bars[0].Close != 1 || aapl.Bars[1].Close != 3 || aapl.Bars[2].Close != 4 || aapl.Currency != "USD" {
+		t.Errorf("Expected stored and new AAPL bars, got %+v", aapl)
+	}
+	if bars, _ := store.Bars(ctx, "MSFT", Interval1d, time.Time{}, time.Time{}); len(bars) != 2 {
+		t.Errorf("Expected MSFT bars stored, got %+v", bars)
 	}
 
-	errStr := apiErr.Error()
-	if errStr == "" {
-		t.Error("Expected non-empty error string")
+	clear(requests)
+	res, err = DownloadIncremental(ctx, store, DownloadParams{Symbols: []string{"AAPL"}, End: time.Unix(3*86400, 0)})
+	if err != nil || len(requests) != 0 {
+		t.Fatalf("Expected no fetch when the store covers End, got %v, %v", requests, err)
+	}
+	if bars := res.Data["AAPL"].Bars; len(bars) != 2 {
+		t.Errorf("Expected stored bars before End, got %+v", bars)
 	}
 
-	symbolErr := NewSymbolError("AAPL", ErrNotFound)
-	if symbolErr.Error() == "" {
-		t.Error("Expected non-empty symbol error string")
+	queries = nil
+	if _, err := DownloadIncremental(ctx, store, DownloadParams{Symbols: []string{"AAPL"}, Start: time.Unix(3600, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 2 || queries[0].Get("period1") != "3600" || queries[0].Get("period2") != "86400" || queries[1].Get("period1") != "259200" {
+		t.Errorf("Expected a backfill before the oldest stored bar and a sync after the newest, got %v", queries)
 	}
 }
 
-// TestTickerSymbolNormalization tests that ticker symbol is uppercased
-func TestTickerSymbolNormalization(t *testing.T) {
-	ticker, err := NewTicker("aapl")
+// TestDownloadProgress tests that each symbol is reported to Progress and
+// Results as it completes
+func TestDownloadProgress(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if path.Base(r.URL.Path) == "BAD" {
+			http.Error(w, `{"chart":{"error":{"code":"Not Found","description":"No data found"}}}`, http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"chart":{"result":[{"meta":{"dataGranularity":"1d"},"timestamp":[86400],
+			"indicators":{"quote":[{"open":[1],"high":[1],"low":[1],"close":[1],"volume":[1]}]}}]}}`)
+	})
+	prev, _ := DefaultClient()
+	SetDefaultClient(client)
+	t.Cleanup(func() { SetDefaultClient(prev) })
+
+	var dones []int
+	failed := 0
+	collected := make(chan map[string]SymbolResult)
+	ch := make(chan SymbolResult)
+	go func() {
+		got := make(map[string]SymbolResult)
+		for res := range ch {
+			got[res.Symbol] = res
+		}
+		collected <- got
+	}()
+
+	res, err := Download(context.Background(), DownloadParams{
+		Symbols: []string{"AAPL", "MSFT", "BAD"},
+		Period:  Period5d,
+		Threads: 2,
+		Progress: func(symbol string, done, total int, err error) {
+			if total != 3 {
+				t.Errorf("Expected total 3, got %d", total)
+			}
+			if err != nil {
+				failed++
+			}
+			dones = append(dones, done)
+		},
+		Results: ch,
+	})
 	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+		t.Fatal(err)
 	}
-
-	if ticker.Symbol != "AAPL" {
-		t.Errorf("Expected symbol AAPL, got %s", ticker.Symbol)
+	if !slices.Equal(dones, []int{1, 2, 3}) || failed != 1 {
+		t.Errorf("Expected 3 progress calls with 1 failure, got %v, %d", dones, failed)
+	}
+	got := <-collected
+	if len(got) != 3 || got["BAD"].Err == nil || got["BAD"].Data != nil || got["AAPL"].Data != res.Data["AAPL"] {
+		t.Errorf("Unexpected results: %+v", got)
 	}
 }
 
-// TestTickerWithCustomClient tests ticker with custom client option
-func TestTickerWithCustomClient(t *testing.T) {
-	client, err := NewClient()
+// TestDownloadRetry tests per-symbol retries, fail-fast, and RetryFailed
+func TestDownloadRetry(t *testing.T) {
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	fixed := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sym := path.Base(r.URL.Path)
+		mu.Lock()
+		attempts[sym]++
+		n, ok := attempts[sym], fixed
+		mu.Unlock()
+		switch {
+		case sym == "FLAKY" && n == 1:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case sym == "BAD" && !ok:
+			http.Error(w, `{"chart":{"error":{"code":"Not Found","description":"No data found"}}}`, http.StatusNotFound)
+		default:
+			_, _ = fmt.Fprint(w, `{"chart":{"result":[{"meta":{"dataGranularity":"1d"},"timestamp":[86400],
+				"indicators":{"quote":[{"open":[1],"high":[1],"low":[1],"close":[1],"volume":[1]}]}}]}}`)
+		}
+	})
+	prev, _ := DefaultClient()
+	SetDefaultClient(client)
+	t.Cleanup(func() { SetDefaultClient(prev) })
+
+	ctx := context.Background()
+	res, err := Download(ctx, DownloadParams{
+		Symbols:             []string{"FLAKY", "BAD"},
+		Period:              Period5d,
+		MaxRetriesPerSymbol: 2,
+		RetryBackoff:        time.Millisecond,
+	})
 	if err != nil {
-		t.Fatalf("Expected no error creating client, got %v", err)
+		t.Fatal(err)
 	}
-
-	ticker, err := NewTicker("MSFT", WithClient(client))
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	if res.Data["FLAKY"] == nil || attempts["FLAKY"] != 2 {
+		t.Errorf("Expected FLAKY to succeed on its retry, got %d attempts, %v", attempts["FLAKY"], res.Errors["FLAKY"])
 	}
-
-	if ticker.Symbol != "MSFT" {
-		t.Errorf("Expected symbol MSFT, got %s", ticker.Symbol)
+	if !IsNotFound(res.Errors["BAD"]) || attempts["BAD"] != 1 {
+		t.Errorf("Expected BAD to fail without retries, got %d attempts, %v", attempts["BAD"], res.Errors["BAD"])
 	}
-}
-
-// TestStreamCreation tests WebSocket stream creation
-func TestStreamCreation(t *testing.T) {
-	stream := NewStream([]string{"AAPL", "GOOGL"})
 
-	symbols := stream.Symbols()
-	if len(symbols) != 2 {
-		t.Errorf("Expected 2 symbols, got %d", len(symbols))
+	res, err = Download(ctx, DownloadParams{Symbols: []string{"BAD", "AAPL", "MSFT"}, Period: Period5d, Threads: 1, FailFast: true})
+	if !IsNotFound(err) {
+		t.Errorf("Expected the first failure, got %v", err)
+	}
+	// Symbols that ran before BAD may have succeeded; the rest are stopped
+	if len(res.Data)+len(res.Errors) != 3 {
+		t.Errorf("Expected every symbol accounted for, got %v, %v", res.Data, res.Errors)
+	}
+	for sym, err := range res.Errors {
+		if sym != "BAD" && !errors.Is(err, ErrDownloadStopped) {
+			t.Errorf("Expected %s stopped, got %v", sym, err)
+		}
 	}
 
-	// Should not be connected yet since Connect() wasn't called
-	if stream.IsConnected() {
-		t.Error("Expected stream to not be connected before Connect()")
+	mu.Lock()
+	fixed = true
+	mu.Unlock()
+	if err := RetryFailed(ctx, res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Data) != 3 || len(res.Errors) != 0 {
+		t.Errorf("Expected every symbol after RetryFailed, got %v, %v", res.Data, res.Errors)
+	}
+	if err := RetryFailed(ctx, &DownloadResult{Errors: map[string]error{"AAPL": ErrNoData}}); err == nil {
+		t.Error("Expected an error for a result not from Download")
 	}
 }
 
-// TestStreamSymbolManagement tests stream subscribe/unsubscribe before connection
-func TestStreamSymbolManagement(t *testing.T) {
-	stream := NewStream([]string{})
+// TestQuoteChunks tests that long symbol lists are split into requests and
+// the quotes merged in order
+func TestQuoteChunks(t *testing.T) {
+	var requests, inFlight atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if inFlight.Add(1) > 1 {
+			t.Error("Expected one request at a time")
+		}
+		defer inFlight.Add(-1)
+		time.Sleep(5 * time.Millisecond)
+		symbols := strings.Split(r.URL.Query().Get("symbols"), ",")
+		if len(symbols) > 3 {
+			t.Errorf("Expected at most 3 symbols per request, got %v", symbols)
+		}
+		if slices.Contains(symbols, "FAIL") {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		quotes := make([]string, len(symbols))
+		for i, sym := range symbols {
+			quotes[i] = fmt.Sprintf(`{"symbol":%q}`, sym)
+		}
+		_, _ = fmt.Fprintf(w, `{"quoteResponse":{"result":[%s]}}`, strings.Join(quotes, ","))
+	}, WithQuoteChunkSize(3), WithQuoteConcurrency(1))
 
-	err := stream.Subscribe("AAPL", "GOOGL")
+	symbols := []string{"A", "B", "C", "D", "E", "F", "G"}
+	quotes, err := QuoteMultipleWithClient(context.Background(), client, symbols)
 	if err != nil {
-		t.Errorf("Expected no error on subscribe before connect, got %v", err)
+		t.Fatal(err)
+	}
+	got := make([]string, len(quotes))
+	for i, q := range quotes {
+		got[i] = q.Symbol
+	}
+	if !slices.Equal(got, symbols) || requests.Load() != 3 {
+		t.Errorf("Expected quotes in order from 3 requests, got %v from %d", got, requests.Load())
 	}
 
-	if len(stream.Symbols()) != 2 {
-		t.Errorf("Expected 2 symbols, got %d", len(stream.Symbols()))
+	quotes, err = QuoteMultipleWithClient(context.Background(), client, append(symbols, "FAIL"))
+	if err == nil || len(quotes) != 6 || quotes[5].Symbol != "F" {
+		t.Errorf("Expected the other chunks' quotes with the failed chunk's error, got %d quotes, %v", len(quotes), err)
 	}
 }
 
-// TestOptionChainWithGreeks tests option chain Greeks calculation
-func TestOptionChainWithGreeks(t *testing.T) {
-	chain := &OptionChain{
-		Symbol:          "AAPL",
-		UnderlyingPrice: 150.0,
-		Calls: []Option{
-			{Strike: 150, ImpliedVolatility: 0.25, Expiration: time.Now().Add(30 * 24 * time.Hour).Unix()},
-		},
-		Puts: []Option{
-			{Strike: 150, ImpliedVolatility: 0.25, Expiration: time.Now().Add(30 * 24 * time.Hour).Unix()},
-		},
+// TestIndexConstituents tests the bundled member lists and that Yahoo's
+// components are used only when complete
+func TestIndexConstituents(t *testing.T) {
+	for index, want := range map[string]int{"sp500": 500, "^NDX": 101, "Dow 30": 30} {
+		symbols, err := BundledIndexConstituents(index)
+		if err != nil || len(symbols) != want || !slices.IsSorted(symbols) {
+			t.Errorf("Expected %d sorted members of %s, got %d, %v", want, index, len(symbols), err)
+		}
+	}
+	if _, err := BundledIndexConstituents("^FTSE"); err == nil {
+		t.Error("Expected an error for an unknown index")
 	}
 
-	withGreeks := chain.WithGreeks(0.05)
+	components := []string{"MSFT", "AAPL"}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("modules") != ModuleComponents || path.Base(r.URL.Path) != "^DJI" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		data, _ := json.Marshal(components)
+		_, _ = fmt.Fprintf(w, `{"quoteSummary":{"result":[{"components":{"components":%s}}]}}`, data)
+	})
 
-	if len(withGreeks.Calls) != 1 {
-		t.Errorf("Expected 1 call, got %d", len(withGreeks.Calls))
+	ctx := context.Background()
+	symbols, err := GetIndexConstituentsWithClient(ctx, client, "dow")
+	if err != nil || len(symbols) != 30 {
+		t.Errorf("Expected the bundled list for a cut-off one, got %v, %v", symbols, err)
 	}
 
-	if len(withGreeks.Puts) != 1 {
-		t.Errorf("Expected 1 put, got %d", len(withGreeks.Puts))
+	components = []string{"NEW"}
+	for i := range 29 {
+		components = append(components, fmt.Sprintf("S%02d", i))
+	}
+	symbols, err = GetIndexConstituentsWithClient(ctx, client, IndexDowJones)
+	if err != nil || len(symbols) != 30 || !slices.Contains(symbols, "NEW") {
+		t.Errorf("Expected Yahoo's complete list, got %v, %v", symbols, err)
 	}
 }
 
-// TestCacheKeyGeneration tests cache key generation
-func TestCacheKeyGeneration(t *testing.T) {
-	params := map[string]string{"symbol": "AAPL", "modules": "price"}
-	key := CacheKey("quote", params)
+// TestScreenPredefined tests running a saved screener
+func TestScreenPredefined(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if !strings.HasSuffix(r.URL.Path, "/v1/finance/screener/predefined/saved") || q.Get("scrIds") != "day_gainers" ||
+			q.Get("count") != "2" || q.Get("start") != "10" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"finance":{"result":[{"id":"day_gainers","title":"Day Gainers","description":"Stocks ordered by change",
+			"count":2,"total":80,"quotes":[{"symbol":"AAA","regularMarketPrice":5},{"symbol":"BBB","regularMarketPrice":{"raw":7,"fmt":"7.00"}}]}],"error":null}}`))
+	})
 
-	if key == "" {
-		t.Error("Expected non-empty cache key")
+	res, err := ScreenPredefinedWithClient(context.Background(), client, ScreenerDayGainers, 10, 2)
+	if err != nil {
+		t.Fatal(err)
 	}
-
-	// Same inputs should produce same key
-	key2 := CacheKey("quote", params)
-	if key != key2 {
-		t.Errorf("Expected same cache key, got %s vs %s", key, key2)
+	if res.Title != "Day Gainers" || res.Total != 80 || len(res.Quotes) != 2 || res.Quotes[1].RegularMarketPrice != 7 {
+		t.Errorf("Unexpected result %+v", res)
 	}
-
-	// Different inputs should produce different keys
-	params2 := map[string]string{"symbol": "GOOGL", "modules": "price"}
-	key3 := CacheKey("quote", params2)
-	if key == key3 {
-		t.Error("Expected different cache keys for different symbols")
+	if _, err := ScreenPredefinedWithClient(context.Background(), client, ScreenerDayGainers, 0, 500); err == nil {
+		t.Error("Expected an error for more than 250 results")
 	}
 }
 
-// TestCacheClear tests cache clear operation
-func TestCacheClear(t *testing.T) {
-	cache := NewCache(CacheConfig{
-		Type:       CacheTypeMemory,
-		DefaultTTL: 1 * time.Minute,
-		MaxSize:    100,
+// TestScreenPager tests walking screener pages until the total is reached
+func TestScreenPager(t *testing.T) {
+	var offsets []int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var criteria ScreenCriteria
+		if err := json.NewDecoder(r.Body).Decode(&criteria); err != nil {
+			t.Errorf("Failed to decode criteria: %v", err)
+		}
+		offsets = append(offsets, criteria.Offset)
+		var quotes []string
+		for i := criteria.Offset; i < min(criteria.Offset+criteria.Size, 7); i++ {
+			quotes = append(quotes, fmt.Sprintf(`{"symbol":"S%d"}`, i))
+		}
+		_, _ = fmt.Fprintf(w, `{"finance":{"result":[{"count":%d,"total":7,"quotes":[%s]}]}}`, len(quotes), strings.Join(quotes, ","))
 	})
 
-	cache.Set("key1", []byte("data1"), 0)
-	cache.Set("key2", []byte("data2"), 0)
-
-	cache.Clear()
+	quotes, err := ScreenAllWithClient(context.Background(), client, ScreenCriteria{Size: 3, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 6 || quotes[0].Symbol != "S1" || quotes[5].Symbol != "S6" {
+		t.Errorf("Expected results 1 to 6, got %+v", quotes)
+	}
+	if !slices.Equal(offsets, []int{1, 4}) {
+		t.Errorf("Expected pages at offsets 1 and 4, got %v", offsets)
+	}
 
-	_, ok := cache.Get("key1")
-	if ok {
-		t.Error("Expected cache miss after clear")
+	pager := NewScreenPager(client, ScreenCriteria{Size: 5})
+	if pager.Total() != -1 || !pager.Next(context.Background()) || pager.Total() != 7 || len(pager.Page().Quotes) != 5 {
+		t.Errorf("Unexpected first page %+v of %d", pager.Page(), pager.Total())
+	}
+	if !pager.Next(context.Background()) || pager.Next(context.Background()) || pager.Err() != nil {
+		t.Errorf("Expected two pages, got error %v", pager.Err())
 	}
 }
 
-// TestRequestError tests request error type
-func TestRequestError(t *testing.T) {
-	reqErr := &RequestError{
-		Endpoint: "/test",
-		Method:   "GET",
+// TestValidateScreenCriteria tests checking screener criteria against the
+// field catalog
+func TestValidateScreenCriteria(t *testing.T) {
+	for _, criteria := range []ScreenCriteria{
+		MostActiveCriteria(25), GainersCriteria(25), LosersCriteria(25), HighDividendCriteria(4, 25),
+	} {
+		if err := ValidateScreenCriteria(criteria); err != nil {
+			t.Errorf("Expected preset %s to be valid, got %v", criteria.SortField, err)
+		}
+	}
+
+	parsed, err := ParseScreenQuery("region=us and percentchange>5 and intradaymarketcap=1e9..1e11")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	_ = json.Unmarshal([]byte(`{"operator":"or","operands":[{"operator":"is-in","operands":["sector","Energy","Utilities"]},
+		{"operator":"lt","operands":["esg_score",20]}]}`), &decoded)
+	for _, query := range []map[string]interface{}{parsed, decoded} {
+		if err := ValidateScreenCriteria(ScreenCriteria{Size: 25, SortField: "dayvolume", Query: query}); err != nil {
+			t.Errorf("Expected query to be valid, got %v", err)
+		}
+	}
+
+	tests := []struct {
+		expr, want string
+	}{
+		{"marketcap>1e9", "did you mean intradaymarketcap"},
+		{"sector=technology", `is written "Technology"`},
+		{"region=zz", "is not one of"},
+		{"beta>high", "needs a number"},
+		{"nosuchfield=1", "unknown field"},
+	}
+	for _, tt := range tests {
+		query, err := ParseScreenQuery(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ValidateScreenCriteria(ScreenCriteria{Query: query})
+		if !errors.Is(err, ErrInvalidScreenQuery) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.expr, tt.want, err)
+		}
+	}
+
+	bad := []ScreenCriteria{
+		{SortField: "volume"},
+		{SortType: "UP"},
+		{Size: 1000},
+		{Query: map[string]interface{}{"operator": "gt", "operands": []interface{}{"sector", "Energy"}}},
+		{Query: map[string]interface{}{"operator": "btwn", "operands": []interface{}{"beta", 1}}},
+		{Query: map[string]interface{}{"operator": "and", "operands": []interface{}{}}},
+		{Query: map[string]interface{}{"operator": "near", "operands": []interface{}{"beta", 1}}},
+	}
+	for _, criteria := range bad {
+		if err := ValidateScreenCriteria(criteria); !errors.Is(err, ErrInvalidScreenQuery) {
+			t.Errorf("Expected %+v to be invalid, got %v", criteria, err)
+		}
+	}
+
+	if f, ok := LookupScreenField("esg_score"); !ok || f.Category != ScreenCategoryESG || !f.Numeric {
+		t.Errorf("Unexpected esg_score entry %+v", f)
+	}
+	if _, err := ScreenWithClient(context.Background(), &Client{}, ScreenCriteria{SortField: "volume"}); !errors.Is(err, ErrInvalidScreenQuery) {
+		t.Errorf("Expected Screen to validate before posting, got %v", err)
+	}
+}
+
+// secFilingsFixture is a quoteSummary secFilings response with a filing
+// dated by epoch and one by date only
+const secFilingsFixture = `{"quoteSummary":{"result":[{"secFilings":{"filings":[
+	{"date":"2024-11-01","epochDate":1730419200,"type":"10-K","title":"Annual Report",
+		"edgarUrl":"https://yahoo.brand.edgar-online.com/1","exhibits":[
+		{"type":"EX-21.1","url":"https://example.com/ex21","downloadUrl":"https://example.com/ex21.pdf"},
+		{"type":"EX-23.1","url":"https://example.com/ex23"}]},
+	{"date":"2024-08-02","type":"10-Q","title":"Quarterly Report","edgarUrl":"https://yahoo.brand.edgar-online.com/2"}
+]}}],"error":null}}`
+
+// TestSECFilings tests parsing SEC filings and the error for securities
+// without any
+func TestSECFilings(t *testing.T) {
+	var modules string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch path.Base(r.URL.Path) {
+		case "AAPL":
+			modules = r.URL.Query().Get("modules")
+			_, _ = w.Write([]byte(secFilingsFixture))
+		case "SPY":
+			_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{"secFilings":{"filings":[]}}],"error":null}}`))
+		default:
+			_, _ = w.Write([]byte(`{"quoteSummary":{"result":[],"error":null}}`))
+		}
+	})
+
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+	filings, err := ticker.SECFilings(context.Background())
+	if err != nil {
+		t.Fatalf("SECFilings failed: %v", err)
+	}
+	if modules != ModuleSecFilings {
+		t.Errorf("Expected the secFilings module to be requested, got %q", modules)
+	}
+	if len(filings) != 2 {
+		t.Fatalf("Expected 2 filings, got %+v", filings)
+	}
+	annual := filings[0]
+	if !annual.Date.Equal(time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)) || annual.Type != "10-K" || annual.Title != "Annual Report" || annual.EdgarURL != "https://yahoo.brand.edgar-online.com/1" {
+		t.Errorf("Unexpected annual filing %+v", annual)
+	}
+	if len(annual.Exhibits) != 2 || annual.Exhibits[0].Type != "EX-21.1" || annual.Exhibits[0].DownloadURL != "https://example.com/ex21.pdf" || annual.Exhibits[1].DownloadURL != "" {
+		t.Errorf("Unexpected exhibits %+v", annual.Exhibits)
+	}
+	if quarterly := filings[1]; !quarterly.Date.Equal(time.Date(2024, 8, 2, 0, 0, 0, 0, time.UTC)) || quarterly.Type != "10-Q" || quarterly.Exhibits != nil {
+		t.Errorf("Expected the quarterly filing dated from its date, got %+v", quarterly)
+	}
+
+	for _, sym := range []string{"SPY", "VOD.L"} {
+		ticker, _ := NewTicker(sym, WithClient(client))
+		if _, err := ticker.SECFilings(context.Background()); !errors.Is(err, ErrNoData) {
+			t.Errorf("%s: expected ErrNoData, got %v", sym, err)
+		}
+	}
+}
+
+// TestSharesHistory tests parsing the shares outstanding timeseries and the
+// requested range
+func TestSharesHistory(t *testing.T) {
+	var query url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		switch path.Base(r.URL.Path) {
+		case "SPY":
+			_, _ = w.Write([]byte(`{"timeseries":{"result":[{"timestamp":[],"shares_out":[]}],"error":null}}`))
+		case "BAD":
+			// A timestamp missing its count
+			_, _ = w.Write([]byte(`{"timeseries":{"result":[{
+				"timestamp":[1714521600,1706745600,1722470400],
+				"shares_out":[15334099968,15441900544]}],"error":null}}`))
+		default:
+			// Out of order
+			_, _ = w.Write([]byte(`{"timeseries":{"result":[{
+				"timestamp":[1714521600,1706745600],
+				"shares_out":[15334099968,15441900544]}],"error":null}}`))
+		}
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	history, err := ticker.SharesHistory(ctx, start, end)
+	if err != nil {
+		t.Fatalf("SharesHistory failed: %v", err)
+	}
+	if query.Get("period1") != "1704067200" || query.Get("period2") != "1735603200" || query.Get("symbol") != "AAPL" {
+		t.Errorf("Expected the requested range, got %v", query)
+	}
+	if len(history) != 2 || history[0].Date.Unix() != 1706745600 || history[0].Shares != 15441900544 ||
+		history[1].Date.Unix() != 1714521600 || history[1].Shares != 15334099968 {
+		t.Errorf("Expected counts paired with their dates, oldest first, got %+v", history)
+	}
+
+	// Zero bounds default to the eighteen months up to now
+	if _, err := ticker.SharesHistory(ctx, time.Time{}, time.Time{}); err != nil {
+		t.Fatalf("SharesHistory failed: %v", err)
+	}
+	period1, _ := strconv.ParseInt(query.Get("period1"), 10, 64)
+	period2, _ := strconv.ParseInt(query.Get("period2"), 10, 64)
+	if days := (period2 - period1) / 86400; math.Abs(float64(time.Now().Unix()-period2)) > 60 || days < 540 || days > 553 {
+		t.Errorf("Expected eighteen months up to now, got %v", query)
+	}
+
+	if _, err := ticker.SharesHistory(ctx, end, start); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("Expected ErrInvalidPeriod for end before start, got %v", err)
+	}
+	spy, _ := NewTicker("SPY", WithClient(client))
+	if _, err := spy.SharesHistory(ctx, start, end); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without counts, got %v", err)
+	}
+	bad, _ := NewTicker("BAD", WithClient(client))
+	var qe *QueryError
+	if _, err := bad.SharesHistory(ctx, start, end); !errors.Is(err, ErrInvalidResponse) || !errors.As(err, &qe) {
+		t.Errorf("Expected ErrInvalidResponse for dates and counts of different lengths, got %v", err)
+	}
+}
+
+// TestRetryBackoff tests backoff calculation
+func TestRetryBackoff(t *testing.T) {
+	backoff := calculateBackoff(1*time.Second, 30*time.Second, 0)
+	if backoff != 1*time.Second {
+		t.Errorf("Expected 1s backoff, got %v", backoff)
+	}
+
+	// Test max limit
+	backoff = calculateBackoff(60*time.Second, 30*time.Second, 0)
+	if backoff != 30*time.Second {
+		t.Errorf("Expected 30s max backoff, got %v", backoff)
+	}
+}
+
+// TestRetryRequests tests that Get and Post retry retryable statuses
+func TestRetryRequests(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithRetry(RetryConfig{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		BackoffFactor:  2,
+		RetryOnStatus:  []int{http.StatusTooManyRequests},
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	if _, err := client.Get(context.Background(), srv.URL, nil); err != nil {
+		t.Fatalf("Expected Get to succeed after retries, got %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("Expected 3 attempts, got %d", n)
+	}
+
+	// The body is resent on each attempt
+	calls.Store(0)
+	body, err := client.Post(context.Background(), srv.URL, nil, map[string]string{"a": "b"})
+	if err != nil {
+		t.Fatalf("Expected Post to succeed after retries, got %v", err)
+	}
+	if string(body) != `{"a":"b"}` {
+		t.Errorf("Expected request body echoed, got %q", body)
+	}
+
+	// Exhausted retries surface the last status
+	calls.Store(-10)
+	if _, err := client.Get(context.Background(), srv.URL, nil); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+}
+
+// TestRetryContextCancel tests that waiting between retries stops with the context
+func TestRetryContextCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithRetry(RetryConfig{
+		MaxRetries:     5,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Minute,
+		BackoffFactor:  1,
+		RetryOnStatus:  []int{http.StatusServiceUnavailable},
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.Get(ctx, srv.URL, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected retry wait to stop with the context, took %v", elapsed)
+	}
+}
+
+// TestParseRetryAfter tests both Retry-After forms
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if d, ok := parseRetryAfter("5", now); !ok || d != 5*time.Second {
+		t.Errorf("Expected 5s, got %v %v", d, ok)
+	}
+	if d, ok := parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now); !ok || d != time.Minute {
+		t.Errorf("Expected 1m, got %v %v", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Error("Expected invalid Retry-After to be ignored")
+	}
+}
+
+// TestCircuitBreaker tests that sustained failures open an endpoint's
+// circuit until the cool-down passes
+func TestCircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v8/finance/chart") {
+			calls.Add(1)
+			if !healthy.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(
+		WithRetry(RetryConfig{MaxRetries: 0}),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, CoolDown: 50 * time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	chart := srv.URL + "/v8/finance/chart/AAPL"
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), chart, nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Expected request %d to reach the server and fail, got %v", i, err)
+		}
+	}
+	if state := client.CircuitState("chart"); state != CircuitOpen {
+		t.Errorf("Expected open circuit, got %s", state)
+	}
+
+	_, err = client.Get(context.Background(), chart, nil)
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, ErrCircuitOpen) || openErr.Endpoint != "chart" {
+		t.Fatalf("Expected CircuitOpenError for chart, got %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Expected open circuit to fail fast, server saw %d requests", n)
+	}
+
+	// Other endpoints are unaffected
+	if _, err := client.Get(context.Background(), srv.URL+"/v7/finance/quote", nil); err != nil {
+		t.Errorf("Expected quote request to succeed, got %v", err)
+	}
+
+	// After the cool-down a successful trial request closes the circuit
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.Get(context.Background(), chart, nil); err != nil {
+		t.Fatalf("Expected trial request to succeed, got %v", err)
+	}
+	if state := client.CircuitState("chart"); state != CircuitClosed {
+		t.Errorf("Expected closed circuit, got %s", state)
+	}
+}
+
+// TestRateLimiter tests rate limiter
+func TestRateLimiter(t *testing.T) {
+	rl := NewRateLimiter(10, 5) // 10 req/s, burst of 5
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// Should be able to make burst requests immediately
+	for i := 0; i < 5; i++ {
+		err := rl.Wait(ctx)
+		if err != nil {
+			t.Errorf("Expected no error on burst request %d, got %v", i, err)
+		}
+	}
+}
+
+// TestEndpointRateLimits tests that an endpoint's limit applies only to it
+func TestEndpointRateLimits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithEndpointRateLimits(map[string]Rate{"chart": {PerSecond: 20, Burst: 1}}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+	if client.EndpointRateLimiter("chart") == nil || client.EndpointRateLimiter("quote") != nil {
+		t.Fatal("Expected a limiter for chart only")
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(context.Background(), srv.URL+"/v7/finance/quote", nil); err != nil {
+			t.Fatalf("Quote request %d failed: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 80*time.Millisecond {
+		t.Errorf("Expected quote requests not to be limited, took %v", elapsed)
+	}
+
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(context.Background(), srv.URL+"/v8/finance/chart/AAPL", nil); err != nil {
+			t.Fatalf("Chart request %d failed: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected chart requests to be spaced by their limiter, took %v", elapsed)
+	}
+}
+
+// TestRateLimitedRequests tests that requests wait for the client's rate limiter
+func TestRateLimitedRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithRateLimiter(20, 1))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(context.Background(), srv.URL, nil); err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected requests to be spaced by the limiter, took %v", elapsed)
+	}
+
+	stream := NewStream(nil, WithStreamRateLimiter(client.RateLimiter()))
+	if stream.limiter != client.RateLimiter() {
+		t.Error("Expected stream to use the given rate limiter")
+	}
+}
+
+// TestCompression tests that compressed responses are decoded
+func TestCompression(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		var enc io.WriteCloser
+		switch {
+		case r.URL.Query().Get("encoding") == "deflate" && strings.Contains(r.Header.Get("Accept-Encoding"), "deflate"):
+			enc = zlib.NewWriter(&buf)
+			w.Header().Set("Content-Encoding", "deflate")
+		case strings.Contains(r.Header.Get("Accept-Encoding"), "gzip"):
+			enc = gzip.NewWriter(&buf)
+			w.Header().Set("Content-Encoding", "gzip")
+		default:
+			_, _ = w.Write([]byte(`{"plain":true}`))
+			return
+		}
+		_, _ = enc.Write([]byte(`{"compressed":true}`))
+		_ = enc.Close()
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithTransport(TransportConfig{MaxIdleConnsPerHost: 32, DisableHTTP2: true}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		data, err := client.Get(context.Background(), srv.URL, url.Values{"encoding": {encoding}})
+		if err != nil || string(data) != `{"compressed":true}` {
+			t.Errorf("Expected decoded %s response, got %q, %v", encoding, data, err)
+		}
+	}
+	transport, ok := client.httpClient.Transport.(*compressionTransport).next.(*http.Transport)
+	if !ok || transport.MaxIdleConnsPerHost != 32 || transport.ForceAttemptHTTP2 {
+		t.Errorf("Expected tuned transport, got %#v", client.httpClient.Transport)
+	}
+
+	client, err = NewClient(WithTransport(TransportConfig{DisableCompression: true}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+	// http.Transport still handles gzip on its own
+	if data, err := client.Get(context.Background(), srv.URL, url.Values{"encoding": {"deflate"}}); err != nil || string(data) != `{"compressed":true}` {
+		t.Errorf("Expected transparently decoded gzip, got %q, %v", data, err)
+	}
+
+	if _, err := NewClient(WithHTTPClient(&http.Client{Transport: RoundTripperFunc(nil)}), WithTransport(DefaultTransportConfig())); err == nil {
+		t.Error("Expected error tuning a custom transport")
+	}
+
+	base := &http.Transport{}
+	hc := &http.Client{Transport: base}
+	if _, err := NewClient(WithHTTPClient(hc), WithTransport(TransportConfig{MaxIdleConnsPerHost: 8})); err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if hc.Transport != base || base.MaxIdleConnsPerHost != 0 {
+		t.Errorf("Expected caller's client to be left unchanged, got %#v", hc.Transport)
+	}
+	prevDefault := http.DefaultClient.Transport
+	if _, err := NewClient(WithHTTPClient(http.DefaultClient)); err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if http.DefaultClient.Transport != prevDefault {
+		t.Error("Expected http.DefaultClient to be left unchanged")
+	}
+}
+
+// TestMiddleware tests that middleware and hooks wrap requests in order
+func TestMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Signature") != "signed" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	var status int
+	client, err := NewClient(
+		WithMiddleware(trace("outer"), trace("inner")),
+		WithRequestHook(func(req *http.Request) error {
+			req.Header.Set("X-Signature", "signed")
+			return nil
+		}),
+		WithResponseHook(func(resp *http.Response) error {
+			status = resp.StatusCode
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	if _, err := client.Get(context.Background(), srv.URL, nil); err != nil {
+		t.Fatalf("Expected signed request to succeed, got %v", err)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("Expected outer then inner middleware, got %v", order)
+	}
+	if status != http.StatusOK {
+		t.Errorf("Expected response hook to see 200, got %d", status)
+	}
+
+	errHook := errors.New("blocked")
+	blocked, err := NewClient(WithRequestHook(func(*http.Request) error { return errHook }))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	blocked.crumb = "test"
+	if _, err := blocked.Post(context.Background(), srv.URL, nil, nil); !errors.Is(err, errHook) {
+		t.Errorf("Expected hook error, got %v", err)
+	}
+
+	// The caller's client keeps its transport, so layers don't stack up
+	// across clients
+	hc := &http.Client{Transport: RoundTripperFunc(http.DefaultTransport.RoundTrip)}
+	base := hc.Transport
+	order = nil
+	for range 2 {
+		shared, err := NewClient(WithHTTPClient(hc), WithMiddleware(trace("shared")))
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		shared.crumb = "test"
+		if _, err := shared.Get(context.Background(), srv.URL, nil); err == nil {
+			t.Error("Expected unsigned request to be rejected")
+		}
+	}
+	if len(order) != 2 {
+		t.Errorf("Expected one middleware call per client, got %v", order)
+	}
+	if fmt.Sprintf("%p", hc.Transport) != fmt.Sprintf("%p", base) {
+		t.Error("Expected caller's client transport to be left unchanged")
+	}
+}
+
+// TestLogger tests debug logs of requests and cache use
+func TestLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClient(
+		WithLogger(logger),
+		WithCache(NewCache(CacheConfig{Type: CacheTypeMemory, DefaultTTL: time.Minute, MaxSize: 10})),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "secret-crumb"
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), srv.URL, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	logs := buf.String()
+	for _, want := range []string{"yfinance: request", "status=200", "cache miss", "cache hit"} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("Expected logs to contain %q, got:\n%s", want, logs)
+		}
+	}
+	if strings.Contains(logs, "secret-crumb") {
+		t.Error("Expected the crumb to be redacted")
+	}
+}
+
+// recordingTracerProvider records the names of started spans
+type recordingTracerProvider struct {
+	noop.TracerProvider
+	mu    sync.Mutex
+	names []string
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return recordingTracer{p: p}
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	p *recordingTracerProvider
+}
+
+func (t recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.p.mu.Lock()
+	t.p.names = append(t.p.names, name)
+	t.p.mu.Unlock()
+	return t.Tracer.Start(ctx, name, opts...)
+}
+
+// TestTracing tests that requests start spans on the configured provider
+func TestTracing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	tp := &recordingTracerProvider{}
+	client, err := NewClient(WithTracerProvider(tp))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	if _, err := client.Get(context.Background(), srv.URL+"/v8/finance/chart/AAPL", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Post(context.Background(), srv.URL+"/v1/finance/screener", nil, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	ticker, err := NewTicker("AAPL", WithClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := ticker.startSpan(context.Background(), "Quote")
+	span.End()
+
+	want := []string{"yfinance GET chart", "yfinance POST screener", "yfinance.Ticker.Quote"}
+	if len(tp.names) != len(want) {
+		t.Fatalf("Expected spans %v, got %v", want, tp.names)
+	}
+	for i := range want {
+		if tp.names[i] != want[i] {
+			t.Errorf("Expected span %q, got %q", want[i], tp.names[i])
+		}
+	}
+}
+
+// TestConfigure tests replacing the default client while it is in use
+func TestConfigure(t *testing.T) {
+	prev, err := DefaultClient()
+	if err != nil {
+		t.Fatalf("Failed to get default client: %v", err)
+	}
+	t.Cleanup(func() { SetDefaultClient(prev) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := NewTicker("AAPL"); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		if err := Configure(WithUserAgent("gotick-test"), WithRateLimiter(10, 1)); err != nil {
+			t.Fatalf("Configure failed: %v", err)
+		}
+	}
+	wg.Wait()
+
+	client, _ := DefaultClient()
+	if client.userAgent != "gotick-test" || client.RateLimiter() == nil {
+		t.Error("Expected Configure to install a client with its options")
+	}
+	if err := Configure(WithProxyURL("://bad")); err == nil {
+		t.Error("Expected error for an invalid proxy")
+	}
+	if current, _ := DefaultClient(); current != client {
+		t.Error("Expected a failed Configure to keep the default client")
+	}
+
+	SetDefaultClient(nil)
+	if fresh, err := DefaultClient(); err != nil || fresh == client || fresh.RateLimiter() != nil {
+		t.Errorf("Expected a new default client after reset, got %v", err)
+	}
+}
+
+// TestNewTicker tests ticker creation
+func TestNewTicker(t *testing.T) {
+	ticker, err := NewTicker("AAPL")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if ticker.Symbol != "AAPL" {
+		t.Errorf("Expected symbol AAPL, got %s", ticker.Symbol)
+	}
+}
+
+// TestNewTickerEmpty tests empty symbol
+func TestNewTickerEmpty(t *testing.T) {
+	_, err := NewTicker("")
+	if !errors.Is(err, ErrInvalidSymbol) {
+		t.Errorf("Expected ErrInvalidSymbol, got %v", err)
+	}
+}
+
+// TestNewTickers tests batch ticker creation
+func TestNewTickers(t *testing.T) {
+	tickers, err := NewTickers("AAPL", "GOOGL", "MSFT")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(tickers.Symbols()) != 3 {
+		t.Errorf("Expected 3 symbols, got %d", len(tickers.Symbols()))
+	}
+
+	_, ok := tickers.Ticker("AAPL")
+	if !ok {
+		t.Error("Expected to find AAPL ticker")
+	}
+}
+
+// TestPeriodConstants tests period constant values
+func TestPeriodConstants(t *testing.T) {
+	tests := []struct {
+		period Period
+		want   string
+	}{
+		{Period1d, "1d"},
+		{Period5d, "5d"},
+		{Period1mo, "1mo"},
+		{Period1y, "1y"},
+		{PeriodMax, "max"},
+	}
+
+	for _, tt := range tests {
+		if string(tt.period) != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, string(tt.period))
+		}
+	}
+}
+
+// TestIntervalConstants tests interval constant values
+func TestIntervalConstants(t *testing.T) {
+	tests := []struct {
+		interval Interval
+		want     string
+	}{
+		{Interval1m, "1m"},
+		{Interval5m, "5m"},
+		{Interval1h, "1h"},
+		{Interval1d, "1d"},
+		{Interval1wk, "1wk"},
+	}
+
+	for _, tt := range tests {
+		if string(tt.interval) != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, string(tt.interval))
+		}
+	}
+}
+
+// TestDefaultModules tests default modules helper
+func TestDefaultModules(t *testing.T) {
+	modules := DefaultModules()
+	if len(modules) == 0 {
+		t.Error("Expected non-empty default modules")
+	}
+}
+
+// TestFinancialModules tests financial modules helper
+func TestFinancialModules(t *testing.T) {
+	modules := FinancialModules()
+	if len(modules) != 6 {
+		t.Errorf("Expected 6 financial modules, got %d", len(modules))
+	}
+}
+
+// TestErrorTypes tests custom error types
+func TestErrorTypes(t *testing.T) {
+	apiErr := &APIError{
+		Code:        "test",
+		Description: "test error",
+		StatusCode:  400,
+	}
+
+	errStr := apiErr.Error()
+	if errStr == "" {
+		t.Error("Expected non-empty error string")
+	}
+
+	symbolErr := NewSymbolError("AAPL", ErrNotFound)
+	if symbolErr.Error() == "" {
+		t.Error("Expected non-empty symbol error string")
+	}
+}
+
+// TestTickerSymbolNormalization tests that ticker symbol is uppercased
+func TestTickerSymbolNormalization(t *testing.T) {
+	ticker, err := NewTicker("aapl")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if ticker.Symbol != "AAPL" {
+		t.Errorf("Expected symbol AAPL, got %s", ticker.Symbol)
+	}
+}
+
+// TestTickerWithCustomClient tests ticker with custom client option
+func TestTickerWithCustomClient(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Fatalf("Expected no error creating client, got %v", err)
+	}
+
+	ticker, err := NewTicker("MSFT", WithClient(client))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if ticker.Symbol != "MSFT" {
+		t.Errorf("Expected symbol MSFT, got %s", ticker.Symbol)
+	}
+}
+
+// TestClientEndpoints tests that Ticker methods resolve URLs through the client
+func TestClientEndpoints(t *testing.T) {
+	var paths []string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"quoteResponse":{"result":[{"symbol":"AAPL"}]}}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(
+		WithBaseURL(srv.URL+"/"),
+		WithEndpoints(Endpoints{Chart: srv.URL + "/mirror/chart"}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	endpoints := client.Endpoints()
+	if endpoints.Quote != srv.URL+"/v7/finance/quote" || endpoints.Crumb != srv.URL+"/v1/test/getcrumb" {
+		t.Errorf("Expected API endpoints on the base URL, got %+v", endpoints)
+	}
+	if endpoints.Cookie != CookieURL || endpoints.News != NewsURL {
+		t.Errorf("Expected non-API endpoints unchanged, got %+v", endpoints)
+	}
+
+	ticker, err := NewTicker("AAPL", WithClient(client))
+	if err != nil {
+		t.Fatalf("Failed to create ticker: %v", err)
+	}
+	if _, err := ticker.Quote(context.Background()); err != nil {
+		t.Fatalf("Quote failed: %v", err)
+	}
+	_, _ = ticker.History(context.Background(), HistoryParams{Period: Period1mo, Interval: Interval1d})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 2 || paths[0] != "/v7/finance/quote" || paths[1] != "/mirror/chart/AAPL" {
+		t.Errorf("Expected quote and chart requests to the server, got %v", paths)
+	}
+
+	for raw, want := range map[string]string{
+		srv.URL + "/mirror/chart/AAPL":              "chart",
+		srv.URL + "/v6/finance/quote/marketSummary": "marketSummary",
+		srv.URL + "/v7/finance/quote?symbols=AAPL":  "quote",
+		srv.URL + "/mirror/charts":                  "other",
+		"https://fc.yahoo.com":                      "cookie",
+	} {
+		u, _ := url.Parse(raw)
+		if got := endpoints.Name(u); got != want {
+			t.Errorf("Expected %s to be named %q, got %q", raw, want, got)
+		}
+	}
+
+	stream := NewStream(nil, WithStreamURL("ws://localhost/stream"))
+	if stream.url != "ws://localhost/stream" {
+		t.Errorf("Expected stream URL override, got %q", stream.url)
+	}
+}
+
+// TestStreamCreation tests WebSocket stream creation
+func TestStreamCreation(t *testing.T) {
+	stream := NewStream([]string{"AAPL", "GOOGL"})
+
+	symbols := stream.Symbols()
+	if len(symbols) != 2 {
+		t.Errorf("Expected 2 symbols, got %d", len(symbols))
+	}
+
+	// Should not be connected yet since Connect() wasn't called
+	if stream.IsConnected() {
+		t.Error("Expected stream to not be connected before Connect()")
+	}
+}
+
+// TestStreamSymbolManagement tests stream subscribe/unsubscribe before connection
+func TestStreamSymbolManagement(t *testing.T) {
+	stream := NewStream([]string{})
+
+	err := stream.Subscribe("AAPL", "GOOGL")
+	if err != nil {
+		t.Errorf("Expected no error on subscribe before connect, got %v", err)
+	}
+
+	if len(stream.Symbols()) != 2 {
+		t.Errorf("Expected 2 symbols, got %d", len(stream.Symbols()))
+	}
+}
+
+// TestOptionChainWithGreeks tests option chain Greeks calculation
+func TestOptionChainWithGreeks(t *testing.T) {
+	chain := &OptionChain{
+		Symbol:          "AAPL",
+		UnderlyingPrice: 150.0,
+		Calls: []Option{
+			{Strike: 150, ImpliedVolatility: 0.25, Expiration: time.Now().Add(30 * 24 * time.Hour).Unix()},
+		},
+		Puts: []Option{
+			{Strike: 150, ImpliedVolatility: 0.25, Expiration: time.Now().Add(30 * 24 * time.Hour).Unix()},
+		},
+	}
+
+	withGreeks := chain.WithGreeks(0.05)
+
+	if len(withGreeks.Calls) != 1 {
+		t.Errorf("Expected 1 call, got %d", len(withGreeks.Calls))
+	}
+
+	if len(withGreeks.Puts) != 1 {
+		t.Errorf("Expected 1 put, got %d", len(withGreeks.Puts))
+	}
+}
+
+// TestClientCache tests that Get serves cached responses until bypassed
+func TestClientCache(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithCache(NewCache(CacheConfig{Type: CacheTypeMemory, DefaultTTL: time.Minute, MaxSize: 10})))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	params := url.Values{"symbols": {"AAPL"}}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), srv.URL, params); err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected the second request to be cached, got %d requests", n)
+	}
+
+	if _, err := client.Get(BypassCache(context.Background()), srv.URL, params); err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Expected BypassCache to skip the cache, got %d requests", n)
+	}
+}
+
+// TestRequestCoalescing tests that identical concurrent requests share one call
+func TestRequestCoalescing(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		_, _ = w.Write([]byte(r.URL.Query().Get("symbols")))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithRetry(RetryConfig{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.crumb = "test"
+
+	params := url.Values{"symbols": {"AAPL"}}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if data, err := client.Get(context.Background(), srv.URL, params); err != nil || string(data) != "AAPL" {
+				t.Errorf("Expected shared response, got %q, %v", data, err)
+			}
+		}()
+	}
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", n)
+	}
+	if params.Has("crumb") {
+		t.Error("Expected the caller's params to be left unchanged")
+	}
+
+	// A canceled caller does not fail the others
+	calls.Store(0)
+	release = make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		_, err := client.Get(ctx, srv.URL, params)
+		leader <- err
+	}()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	follower := make(chan error, 1)
+	go func() {
+		_, err := client.Get(context.Background(), srv.URL, params)
+		follower <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the canceled caller to fail, got %v", err)
+	}
+	if err := <-follower; err != nil {
+		t.Errorf("Expected the other caller to succeed, got %v", err)
+	}
+}
+
+// TestCacheDataType tests per-endpoint data types and TTLs
+func TestCacheDataType(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	tests := []struct {
+		endpoint string
+		params   url.Values
+		want     DataType
+	}{
+		{QuoteURL, nil, DataQuote},
+		{ChartURL + "/AAPL", url.Values{"interval": {"1d"}}, DataHistory},
+		{ChartURL + "/AAPL", url.Values{"interval": {"5m"}}, DataQuote},
+		{ChartURL + "/AAPL", url.Values{"interval": {"1mo"}}, DataHistory},
+		{QuoteSummaryURL + "/AAPL", url.Values{"modules": {"assetProfile"}}, DataInfo},
+		{QuoteSummaryURL + "/AAPL", url.Values{"modules": {"assetProfile,price"}}, DataQuote},
+		{QuoteSummaryURL + "/AAPL", url.Values{"modules": {"insiderHolders,recommendationTrend"}}, DataAnalysis},
+		{SearchURL, url.Values{"newsCount": {"10"}}, DataNews},
+		{SearchURL, url.Values{"q": {"apple"}}, DataSearch},
+		{ScreenerURL, nil, DataOther},
+	}
+	for _, tt := range tests {
+		if got := client.cacheDataType(tt.endpoint, tt.params); got != tt.want {
+			t.Errorf("cacheDataType(%s, %v) = %v, want %v", tt.endpoint, tt.params, got, tt.want)
+		}
+	}
+
+	client, err = NewClient(WithCacheTTL(DataQuote, -1))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if client.cacheTTL(DataQuote) >= 0 || client.cacheTTL(DataHistory) != TTLHistory {
+		t.Errorf("Expected WithCacheTTL to override only quotes, got %v and %v", client.cacheTTL(DataQuote), client.cacheTTL(DataHistory))
+	}
+}
+
+// TestRedisCacheUnreachable tests that an unreachable Redis behaves as a miss
+func TestRedisCacheUnreachable(t *testing.T) {
+	if _, err := NewRedisCache(RedisCacheConfig{URL: "http://localhost"}); err == nil {
+		t.Error("Expected error for a non-Redis URL")
+	}
+
+	cache, err := NewRedisCache(RedisCacheConfig{Addr: "127.0.0.1:1", DialTimeout: 50 * time.Millisecond, Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cache.Close() }()
+
+	cache.Set("quote:key", []byte("data"), time.Minute)
+	if _, ok := cache.Get("quote:key"); ok {
+		t.Error("Expected a miss from an unreachable server")
+	}
+	if err := cache.Ping(context.Background()); err == nil {
+		t.Error("Expected Ping to fail")
+	}
+}
+
+// TestSessionPersistence tests that a saved session is restored without authenticating
+func TestSessionPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	client, err := NewClient(WithSessionFile(path))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	base, _ := url.Parse(BaseURL)
+	client.httpClient.Jar.SetCookies(base, []*http.Cookie{{Name: "A3", Value: "cookie", Domain: ".yahoo.com", Path: "/"}})
+	client.crumb = "saved-crumb"
+	client.sessionCreated = time.Now()
+	client.saveSession()
+
+	restored, err := NewClient(WithSessionFile(path))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := restored.ensureAuthenticated(context.Background()); err != nil {
+		t.Fatalf("Expected the saved session to be restored, got %v", err)
+	}
+	if restored.getCrumb() != "saved-crumb" {
+		t.Errorf("Expected restored crumb, got %q", restored.getCrumb())
+	}
+	query1, _ := url.Parse(Query1URL)
+	if cookies := restored.httpClient.Jar.Cookies(query1); len(cookies) != 1 || cookies[0].Value != "cookie" {
+		t.Errorf("Expected restored cookie for every host, got %v", cookies)
+	}
+
+	// Stale sessions are ignored
+	client.sessionCreated = time.Now().Add(-2 * DefaultSessionMaxAge)
+	client.saveSession()
+	stale, err := NewClient(WithSessionFile(path))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if stale.restoreSession() {
+		t.Error("Expected a stale session not to be restored")
+	}
+
+	// Cookies of another server keep its host
+	var sent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ck, err := r.Cookie("A3"); err == nil {
+			sent = ck.Value
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+	local, err := NewClient(WithRetry(RetryConfig{}), WithBaseURL(srv.URL), WithSessionFile(path))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	srvURL, _ := url.Parse(srv.URL)
+	local.httpClient.Jar.SetCookies(srvURL, []*http.Cookie{{Name: "A3", Value: "local", Path: "/"}})
+	local.crumb = "local-crumb"
+	local.sessionCreated = time.Now()
+	local.saveSession()
+
+	restored, err = NewClient(WithRetry(RetryConfig{}), WithBaseURL(srv.URL), WithSessionFile(path))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := restored.Get(context.Background(), srv.URL+"/v7/finance/quote", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if restored.getCrumb() != "local-crumb" || sent != "local" {
+		t.Errorf("Expected the restored cookie sent to the server, got crumb %q, cookie %q", restored.getCrumb(), sent)
+	}
+}
+
+// TestCacheKeyGeneration tests cache key generation
+func TestCacheKeyGeneration(t *testing.T) {
+	params := map[string]string{"symbol": "AAPL", "modules": "price"}
+	key := CacheKey("quote", params)
+
+	if key == "" {
+		t.Error("Expected non-empty cache key")
+	}
+
+	// Same inputs should produce same key
+	key2 := CacheKey("quote", params)
+	if key != key2 {
+		t.Errorf("Expected same cache key, got %s vs %s", key, key2)
+	}
+
+	// Different inputs should produce different keys
+	params2 := map[string]string{"symbol": "GOOGL", "modules": "price"}
+	key3 := CacheKey("quote", params2)
+	if key == key3 {
+		t.Error("Expected different cache keys for different symbols")
+	}
+}
+
+// TestCacheClear tests cache clear operation
+func TestCacheClear(t *testing.T) {
+	cache := NewCache(CacheConfig{
+		Type:       CacheTypeMemory,
+		DefaultTTL: 1 * time.Minute,
+		MaxSize:    100,
+	})
+
+	cache.Set("key1", []byte("data1"), 0)
+	cache.Set("key2", []byte("data2"), 0)
+
+	cache.Clear()
+
+	_, ok := cache.Get("key1")
+	if ok {
+		t.Error("Expected cache miss after clear")
+	}
+}
+
+// TestRequestError tests request error type
+func TestRequestError(t *testing.T) {
+	reqErr := &RequestError{
+		Endpoint: "/test",
+		Method:   "GET",
 		Err:      errors.New("connection failed"),
 	}
 
-	errStr := reqErr.Error()
-	if errStr == "" {
-		t.Error("Expected non-empty error string")
+	errStr := reqErr.Error()
+	if errStr == "" {
+		t.Error("Expected non-empty error string")
+	}
+
+	// Test Unwrap
+	unwrapped := reqErr.Unwrap()
+	if unwrapped == nil {
+		t.Error("Expected non-nil unwrapped error")
+	}
+}
+
+// TestQueryError tests that unusable responses carry their request details
+func TestQueryError(t *testing.T) {
+	page := "<html>" + strings.Repeat("maintenance ", 100) + "</html>"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Yahoo-Request-Id", "req-123")
+		switch r.URL.Query().Get("symbols") {
+		case "DOWN":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(page))
+		case "HTML":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(page))
+		default:
+			_, _ = w.Write([]byte(`{"quoteResponse":`))
+		}
+	})
+	quoteURL := client.Endpoints().Quote
+
+	_, err := client.Get(context.Background(), quoteURL, url.Values{"symbols": {"DOWN"}})
+	var qe *QueryError
+	if !errors.As(err, &qe) {
+		t.Fatalf("Expected QueryError, got %v", err)
+	}
+	if qe.StatusCode != http.StatusServiceUnavailable || qe.RequestID != "req-123" || qe.Params.Get("symbols") != "DOWN" {
+		t.Errorf("Unexpected QueryError %+v", qe)
+	}
+	if qe.Params.Has("crumb") || strings.Contains(err.Error(), "crumb") {
+		t.Error("Expected the crumb to be left out")
+	}
+	if len(qe.Body) > maxErrorBody+3 || !strings.HasPrefix(qe.Body, "<html>") {
+		t.Errorf("Expected truncated body, got %d bytes", len(qe.Body))
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected wrapped APIError, got %v", err)
+	}
+
+	_, err = client.Get(context.Background(), quoteURL, url.Values{"symbols": {"HTML"}})
+	if !errors.Is(err, ErrInvalidResponse) || !errors.As(err, &qe) || qe.StatusCode != http.StatusOK {
+		t.Errorf("Expected ErrInvalidResponse for an HTML page, got %v", err)
+	}
+
+	ticker, _ := NewTicker("BAD", WithClient(client))
+	_, err = ticker.Quote(context.Background())
+	if !errors.As(err, &qe) || qe.Body != `{"quoteResponse":` || qe.Endpoint != quoteURL {
+		t.Errorf("Expected QueryError with the malformed body, got %v", err)
+	}
+}
+
+// TestQuoteFallback tests that quotes are served without a crumb when
+// authentication fails
+func TestQuoteFallback(t *testing.T) {
+	var v6 atomic.Bool
+	var crumbs atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("crumb") {
+			t.Errorf("Expected no crumb, got %s", r.URL)
+		}
+		switch {
+		case r.URL.Path == "/v1/test/getcrumb":
+			crumbs.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/v6/finance/quote" && v6.Load():
+			_, _ = w.Write([]byte(`{"quoteResponse":{"result":[{"symbol":"AAPL","regularMarketPrice":190}]}}`))
+		case r.URL.Path == "/v8/finance/chart/AAPL":
+			_, _ = w.Write([]byte(`{"chart":{"result":[{"meta":{"symbol":"AAPL","currency":"USD",
+				"regularMarketPrice":189,"chartPreviousClose":180}}]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithRetry(RetryConfig{}), WithBaseURL(srv.URL), WithEndpoints(Endpoints{Cookie: srv.URL}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	quote, err := ticker.Quote(context.Background())
+	if err != nil || quote.RegularMarketPrice != 189 || quote.RegularMarketChange != 9 || quote.Currency != "USD" {
+		t.Errorf("Expected quote from chart metadata, got %+v, %v", quote, err)
+	}
+	quotes, err := QuoteMultipleWithClient(context.Background(), client, []string{"AAPL", "MISSING"})
+	if err != nil || len(quotes) != 1 {
+		t.Errorf("Expected unknown symbols to be left out, got %+v, %v", quotes, err)
+	}
+
+	v6.Store(true)
+	if quote, err := ticker.Quote(context.Background()); err != nil || quote.RegularMarketPrice != 190 {
+		t.Errorf("Expected quote from the v6 endpoint, got %+v, %v", quote, err)
+	}
+	if n := crumbs.Load(); n != 1 {
+		t.Errorf("Expected quotes to stop authenticating after it failed, got %d crumb requests", n)
+	}
+	if _, err := ticker.Info(context.Background()); !errors.Is(err, ErrAuthentication) {
+		t.Errorf("Expected other requests to still need authentication, got %v", err)
+	}
+
+	client.quoteAuthAt = time.Now().Add(-time.Second)
+	crumbs.Store(0)
+	if _, err := ticker.Quote(context.Background()); err != nil || crumbs.Load() != 1 {
+		t.Errorf("Expected authentication to be tried again after the delay, got %d crumb requests, %v", crumbs.Load(), err)
+	}
+}
+
+// TestFastInfo tests that FastInfo is derived from chart metadata and bars
+func TestFastInfo(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v8/finance/chart/AAPL":
+			_, _ = w.Write([]byte(`{"chart":{"result":[{"meta":{"symbol":"AAPL","currency":"USD","exchangeName":"NMS",
+				"instrumentType":"EQUITY","exchangeTimezoneName":"America/New_York","regularMarketPrice":12},
+				"timestamp":[1,2,3],"indicators":{"quote":[{"open":[8,9,10],"high":[9,13,11],"low":[7,8,6],
+				"close":[8,10,12],"volume":[1,2,3]}]}}]}}`))
+		case "/ws/fundamentals-timeseries/v1/finance/timeseries/AAPL":
+			_, _ = w.Write([]byte(`{"timeseries":{"result":[{"timestamp":[1,2],"shares_out":[90,100]}]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	info, err := ticker.FastInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := FastInfo{
+		Symbol: "AAPL", Currency: "USD", QuoteType: "EQUITY", Exchange: "NMS", Timezone: "America/New_York",
+		LastPrice: 12, PreviousClose: 10, YearHigh: 13, YearLow: 6, YearChange: 0.5,
+		FiftyDayAverage: 10, TwoHundredDayAverage: 10, Shares: 100, MarketCap: 1200,
+	}
+	if *info != want {
+		t.Errorf("Expected %+v, got %+v", want, *info)
+	}
+}
+
+// TestSustainability tests ESG score parsing and unrated securities
+func TestSustainability(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/SPY") {
+			_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{"esgScores":{"totalEsg":{"raw":16.8,"fmt":"16.8"},
+			"environmentScore":{"raw":0.6},"socialScore":{"raw":7.2},"governanceScore":{"raw":9},
+			"highestControversy":3,"relatedControversy":["Social Supply Chain Incidents"],"peerGroup":"Technology Hardware",
+			"peerCount":56,"esgPerformance":"UNDER_PERF","peerEsgScorePerformance":{"min":6.4,"avg":15.1,"max":28.4}}}]}}`))
+	})
+
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+	esg, err := ticker.Sustainability(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if esg.TotalESG != 16.8 || esg.GovernanceScore != 9 || esg.HighestControversy != 3 ||
+		esg.Peers.TotalESG.Avg != 15.1 || len(esg.RelatedControversies) != 1 {
+		t.Errorf("Unexpected ESG scores %+v", esg)
+	}
+
+	fund, _ := NewTicker("SPY", WithClient(client))
+	if _, err := fund.Sustainability(context.Background()); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData for an unrated security, got %v", err)
+	}
+}
+
+// TestEarningsDates tests that visualization rows are mapped by column
+func TestEarningsDates(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Size  int            `json:"size"`
+			Query map[string]any `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Size != 4 {
+			t.Errorf("Unexpected request body %+v, %v", body, err)
+		}
+		_, _ = w.Write([]byte(`{"finance":{"result":[{"documents":[{
+			"columns":[{"id":"ticker"},{"id":"startdatetime"},{"id":"timeZoneShortName"},{"id":"epsestimate"},{"id":"epsactual"},{"id":"epssurprisepct"}],
+			"rows":[["AAPL","2025-04-30T20:30:00Z","EDT",1.62,null,null],["AAPL","2025-01-30T21:30:00Z","EST",2.35,2.4,2.13]]}]}]}}`))
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	dates, err := ticker.EarningsDates(context.Background(), 4)
+	if err != nil || len(dates) != 2 {
+		t.Fatalf("Expected 2 earnings dates, got %+v, %v", dates, err)
+	}
+	if d := dates[0]; d.EPSEstimate == nil || *d.EPSEstimate != 1.62 || d.ReportedEPS != nil || d.Timezone != "EDT" {
+		t.Errorf("Unexpected upcoming earnings date %+v", d)
+	}
+	if d := dates[1]; d.ReportedEPS == nil || *d.ReportedEPS != 2.4 || *d.SurprisePercent != 2.13 || d.Date.Month() != time.January {
+		t.Errorf("Unexpected past earnings date %+v", d)
+	}
+}
+
+// TestOptionsAll tests that every expiration is fetched and grouped
+func TestOptionsAll(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		if date == "" {
+			date = "1700000000"
+		}
+		_, _ = fmt.Fprintf(w, `{"optionChain":{"result":[{"expirationDates":[1700000000,1700600000,1701200000],
+			"quote":{"regularMarketPrice":100},"options":[{"calls":[{"contractSymbol":"C%s"}],"puts":[]}]}]}}`, date)
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	expirations, err := ticker.OptionExpirations(context.Background())
+	if err != nil || len(expirations) != 3 || !expirations[1].Equal(time.Unix(1700600000, 0)) {
+		t.Errorf("Expected 3 expirations, got %v, %v", expirations, err)
+	}
+
+	all, err := ticker.OptionsAll(context.Background())
+	if err != nil || len(all.Expirations) != 3 || all.UnderlyingPrice != 100 {
+		t.Fatalf("Expected 3 chains, got %+v, %v", all, err)
+	}
+	for _, e := range all.Expirations {
+		if want := "C" + strconv.FormatInt(e.Expiration.Unix(), 10); len(e.Calls) != 1 || e.Calls[0].ContractSymbol != want {
+			t.Errorf("Expected %s for %s, got %+v", want, e.Expiration, e.Calls)
+		}
+	}
+}
+
+// TestHistoryChunks tests that long intraday ranges are fetched in chunks
+// and stitched without duplicates
+func TestHistoryChunks(t *testing.T) {
+	now := time.Now().Truncate(time.Minute)
+	day := 24 * time.Hour
+	if c := historyChunks(HistoryParams{Interval: Interval1d, Start: now.Add(-400 * day), End: now}, now); c != nil {
+		t.Errorf("Expected daily ranges not to be split, got %d chunks", len(c))
+	}
+	if c := historyChunks(HistoryParams{Interval: Interval1m, Start: now.Add(-2 * day), End: now}, now); c != nil {
+		t.Errorf("Expected a short range not to be split, got %d chunks", len(c))
+	}
+	chunks := historyChunks(HistoryParams{Interval: Interval1m, Start: now.Add(-90 * day), End: now}, now)
+	if len(chunks) != 5 || !chunks[0].start.Equal(now.Add(-29*day)) || !chunks[4].end.Equal(now) {
+		t.Fatalf("Expected 5 chunks within the lookback, got %+v", chunks)
+	}
+
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		p1, _ := strconv.ParseInt(r.URL.Query().Get("period1"), 10, 64)
+		p2, _ := strconv.ParseInt(r.URL.Query().Get("period2"), 10, 64)
+		// Each chunk includes the bar at its end, which starts the next chunk
+		_, _ = fmt.Fprintf(w, `{"chart":{"result":[{"meta":{"symbol":"AAPL"},"timestamp":[%d,%d],
+			"indicators":{"quote":[{"close":[1,2]}]}}]}}`, p1, p2)
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	chart, err := ticker.History(context.Background(), HistoryParams{Interval: Interval1m, Start: now.Add(-20 * day), End: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests.Load() != 3 || len(chart.Bars) != 4 {
+		t.Fatalf("Expected 3 requests and 4 bars, got %d and %d", requests.Load(), len(chart.Bars))
+	}
+	for i := 1; i < len(chart.Bars); i++ {
+		if !chart.Bars[i-1].Timestamp.Before(chart.Bars[i].Timestamp) {
+			t.Errorf("Expected ascending unique timestamps, got %v", chart.Bars)
+		}
+	}
+}
+
+// TestHistoryTimezone tests that bar timestamps are in the exchange's zone
+// unless another is requested
+func TestHistoryTimezone(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","timezone":"EDT",
+			"gmtoffset":-14400},"timestamp":[1700000000],"indicators":{"quote":[{"close":[1]}]}}]}}`))
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	chart, err := ticker.History(context.Background(), HistoryParams{})
+	if err != nil || chart.Bars[0].Timestamp.Location().String() != "America/New_York" {
+		t.Errorf("Expected exchange timezone, got %v, %v", chart.Bars[0].Timestamp, err)
+	}
+	chart, err = ticker.History(context.Background(), HistoryParams{Timezone: "UTC"})
+	if err != nil || chart.Bars[0].Timestamp.Location() != time.UTC || chart.Bars[0].Timestamp.Unix() != 1700000000 {
+		t.Errorf("Expected UTC timestamps, got %v, %v", chart.Bars[0].Timestamp, err)
+	}
+	if _, err := ticker.History(context.Background(), HistoryParams{Timezone: "Mars/Olympus"}); err == nil {
+		t.Error("Expected an error for an unknown timezone")
+	}
+}
+
+// TestAdjustBars tests auto- and back-adjusted prices
+func TestAdjustBars(t *testing.T) {
+	bar := Bar{Open: 10, High: 12, Low: 8, Close: 10, AdjClose: 5}
+
+	auto := []Bar{bar}
+	adjustBars(auto, true)
+	if want := (Bar{Open: 5, High: 6, Low: 4, Close: 5, AdjClose: 5}); auto[0] != want {
+		t.Errorf("Expected auto-adjusted %+v, got %+v", want, auto[0])
+	}
+
+	back := []Bar{bar, {}}
+	adjustBars(back, false)
+	if want := (Bar{Open: 5, High: 6, Low: 4, Close: 10, AdjClose: 5}); back[0] != want {
+		t.Errorf("Expected back-adjusted %+v, got %+v", want, back[0])
+	}
+	if back[1] != (Bar{}) {
+		t.Errorf("Expected a bar without a close to be left alone, got %+v", back[1])
+	}
+}
+
+// TestRepairBars tests that null, duplicate, 100x-off, and unadjusted bars
+// are repaired and reported
+func TestRepairBars(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	bar := func(d int, price, adj float64, volume int64) Bar {
+		return Bar{Timestamp: day(d), Open: price, High: price, Low: price, Close: price, AdjClose: adj, Volume: volume}
+	}
+	bars := []Bar{
+		bar(1, 10, 0, 100),
+		bar(2, 10, 9, 100),
+		{Timestamp: day(3)},
+		bar(4, 1000, 900, 100),
+		bar(5, 10, 9, 100),
+		{Timestamp: day(5).Add(20 * time.Hour), Open: 10, High: 10, Low: 10, Close: 10, AdjClose: 9},
+		bar(6, 10, 9, 100),
+	}
+
+	repaired, repairs := repairBars(bars, Interval1d)
+	if len(repaired) != 5 {
+		t.Fatalf("Expected 5 bars, got %+v", repaired)
+	}
+	if repaired[2].Close != 10 || repaired[2].AdjClose != 9 {
+		t.Errorf("Expected 100x bar to be rescaled, got %+v", repaired[2])
+	}
+	if repaired[0].AdjClose != 9 {
+		t.Errorf("Expected missing AdjClose from the next bar's ratio, got %v", repaired[0].AdjClose)
+	}
+	if repaired[3].Volume != 100 {
+		t.Errorf("Expected the duplicate with volume to be kept, got %+v", repaired[3])
+	}
+	kinds := make(map[RepairKind]int)
+	for _, r := range repairs {
+		kinds[r.Kind]++
+	}
+	want := map[RepairKind]int{RepairDroppedNull: 1, RepairDroppedDuplicate: 1, RepairUnitMixup: 1, RepairFilledAdjClose: 1}
+	if len(kinds) != len(want) {
+		t.Errorf("Expected %v, got %v", want, kinds)
+	}
+	for k, n := range want {
+		if kinds[k] != n {
+			t.Errorf("Expected %d %s repairs, got %d", n, k, kinds[k])
+		}
+	}
+}
+
+// TestTotalReturn tests that dividends are reinvested on their ex-date bar
+func TestTotalReturn(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 14, 30, 0, 0, time.UTC) }
+	bars := []Bar{
+		{Timestamp: day(1), Close: 10},
+		{Timestamp: day(2), Close: 9},
+		{Timestamp: day(3)},
+		{Timestamp: day(4), Close: 10},
+	}
+	dividends := []Dividend{
+		{Date: day(2), Amount: 1},
+		{Date: day(1).AddDate(0, -3, 0), Amount: 5},
+	}
+
+	points := totalReturn(bars, dividends)
+	if len(points) != 3 {
+		t.Fatalf("Expected 3 points, got %+v", points)
+	}
+	if points[1].Dividend != 1 || points[0].Dividend != 0 {
+		t.Errorf("Expected the dividend on the ex-date bar only, got %+v", points)
+	}
+	for i, want := range []float64{100, 100, 100 * 10.0 / 9} {
+		if math.Abs(points[i].Index-want) > 1e-9 {
+			t.Errorf("Point %d: expected index %v, got %v", i, want, points[i].Index)
+		}
+	}
+}
+
+// TestTickerCalendar tests that calendar events are parsed into times
+func TestTickerCalendar(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("modules") != ModuleCalendarEvents {
+			t.Errorf("Unexpected modules %q", r.URL.Query().Get("modules"))
+		}
+		_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{"calendarEvents":{
+			"earnings":{"earningsDate":[{"raw":1745443800,"fmt":"2025-04-23"},{"raw":1745875800,"fmt":"2025-04-28"}],
+				"isEarningsDateEstimate":true,"earningsAverage":{"raw":1.62},"revenueAverage":{"raw":94000000000}},
+			"exDividendDate":{"raw":1739145600,"fmt":"2025-02-10"},"dividendDate":{}}}]}}`))
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	cal, err := ticker.Calendar(context.Background())
+	if err != nil {
+		t.Fatalf("Calendar failed: %v", err)
+	}
+	if len(cal.EarningsDates) != 2 || !cal.EarningsEstimated || cal.EarningsAverage != 1.62 || cal.RevenueAverage != 94e9 {
+		t.Errorf("Unexpected earnings %+v", cal)
+	}
+	if cal.ExDividendDate != time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC) || !cal.DividendDate.IsZero() {
+		t.Errorf("Unexpected dividend dates %v, %v", cal.ExDividendDate, cal.DividendDate)
+	}
+}
+
+// TestEarnings tests that the earnings and financials charts are parsed
+func TestEarnings(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{"earnings":{
+			"earningsChart":{"quarterly":[{"date":"4Q2024","actual":{"raw":2.4},"estimate":{"raw":2.35}}],
+				"currentQuarterEstimate":{"raw":1.62},"currentQuarterEstimateDate":"1Q","currentQuarterEstimateYear":2025},
+			"financialsChart":{"yearly":[{"date":2024,"revenue":{"raw":391035000000},"earnings":{"raw":93736000000}}],
+				"quarterly":[{"date":"4Q2024","revenue":{"raw":124300000000},"earnings":{"raw":36330000000}}]},
+			"financialCurrency":"USD"}}]}}`))
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	earnings, err := ticker.Earnings(context.Background())
+	if err != nil {
+		t.Fatalf("Earnings failed: %v", err)
+	}
+	if earnings.Currency != "USD" || earnings.CurrentQuarter != "1Q2025" || earnings.CurrentQuarterEstimate != 1.62 {
+		t.Errorf("Unexpected summary %+v", earnings)
+	}
+	if len(earnings.Yearly) != 1 || earnings.Yearly[0] != (EarningsPeriod{Period: "2024", Revenue: 391035000000, Earnings: 93736000000}) {
+		t.Errorf("Unexpected yearly %+v", earnings.Yearly)
+	}
+	if len(earnings.Quarterly) != 1 || len(earnings.QuarterlyEPS) != 1 || earnings.QuarterlyEPS[0].Actual != 2.4 {
+		t.Errorf("Unexpected quarterly %+v, %+v", earnings.Quarterly, earnings.QuarterlyEPS)
+	}
+}
+
+// TestInfoKeepsAllModules tests that modules without a typed field can
+// still be decoded from Info
+func TestInfoKeepsAllModules(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/BAD") {
+			_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{"summaryDetail":{"currency":["USD"]}}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{
+			"quoteType":{"symbol":"SPY","quoteType":"ETF","exchange":"PCX"},
+			"esgScores":{"totalEsg":{"raw":16.8,"fmt":"16.8"}}}]}}`))
+	})
+	ticker, _ := NewTicker("SPY", WithClient(client))
+
+	info, err := ticker.Info(context.Background(), ModuleQuoteType, ModuleESGScores)
+	if err != nil {
+		t.Fatalf("Info failed: %v", err)
+	}
+	if info.QuoteType == nil || info.QuoteType.QuoteType != "ETF" || info.QuoteType.Exchange != "PCX" {
+		t.Errorf("Unexpected quote type %+v", info.QuoteType)
+	}
+
+	var esg struct {
+		TotalESG RawValue `json:"totalEsg"`
+	}
+	if err := info.Module(ModuleESGScores, &esg); err != nil || esg.TotalESG.Raw != 16.8 {
+		t.Errorf("Expected the esgScores module, got %+v, %v", esg, err)
+	}
+	if err := info.Module(ModuleEarnings, &esg); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData for a missing module, got %v", err)
+	}
+
+	bad, _ := NewTicker("BAD", WithClient(client))
+	if _, err := bad.Info(context.Background(), ModuleSummaryDetail); err == nil || !strings.Contains(err.Error(), "summaryDetail") {
+		t.Errorf("Expected an error decoding the summaryDetail module, got %v", err)
+	}
+}
+
+// TestRawValueShapes tests that every shape Yahoo uses for a value decodes,
+// and that missing values are not valid
+func TestRawValueShapes(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  float64
+		valid bool
+	}{
+		{`{"raw":1.5,"fmt":"1.50"}`, 1.5, true},
+		{`{"raw":0,"fmt":"0.00"}`, 0, true},
+		{`{}`, 0, false},
+		{`null`, 0, false},
+		{`42`, 42, true},
+		{`"12.5%"`, 12.5, true},
+		{`"N/A"`, 0, false},
+		{`{"raw":"3.25"}`, 3.25, true},
+	}
+	for _, tt := range tests {
+		var v RawValue
+		if err := json.Unmarshal([]byte(tt.in), &v); err != nil {
+			t.Errorf("%s: unexpected error %v", tt.in, err)
+			continue
+		}
+		if v.Raw != tt.want || v.Valid != tt.valid {
+			t.Errorf("%s: expected %v (valid %v), got %+v", tt.in, tt.want, tt.valid, v)
+		}
+	}
+
+	var detail SummaryDetail
+	data := []byte(`{"trailingPE":{"raw":31.2,"fmt":"31.20"},"forwardPE":{},"beta":"N/A","dayLow":"1,234.5","marketCap":{"raw":3019983503360},"currency":"USD"}`)
+	if err := json.Unmarshal(data, &detail); err != nil {
+		t.Fatalf("Failed to decode summary detail %s: %v", data, err)
+	}
+	if detail.TrailingPE != 31.2 || detail.ForwardPE != 0 || detail.DayLow != 1234.5 || detail.MarketCap != 3019983503360 || detail.Currency != "USD" {
+		t.Errorf("Unexpected summary detail %+v", detail)
+	}
+	if !detail.Has("trailingPE") || detail.Has("forwardPE") || detail.Has("beta") {
+		t.Errorf("Expected empty and unparseable values to be missing, got %+v", detail)
+	}
+
+	var earnings EarningsInfo
+	if err := json.Unmarshal([]byte(`{"earningsDate":[{"raw":1714680000,"fmt":"2024-05-02"}],"earningsAverage":{"raw":1.5}}`), &earnings); err != nil {
+		t.Fatalf("Failed to decode earnings info: %v", err)
+	}
+	if len(earnings.EarningsDate) != 1 || earnings.EarningsDate[0] != 1714680000 || earnings.EarningsAverage != 1.5 {
+		t.Errorf("Unexpected earnings info %+v", earnings)
+	}
+}
+
+// TestHasField tests that missing values are told apart from zeros
+func TestHasField(t *testing.T) {
+	var q Quote
+	if err := json.Unmarshal([]byte(`{"symbol":"BTC-USD","regularMarketPrice":65000,"dividendYield":0,"trailingPE":null}`), &q); err != nil {
+		t.Fatalf("Failed to decode quote: %v", err)
+	}
+	if !q.Has("dividendYield") || !q.Has("regularMarketPrice") {
+		t.Error("Expected the fields Yahoo sent, even zero, to be present")
+	}
+	if q.Has("trailingPE") || q.Has("forwardPE") {
+		t.Error("Expected null and missing fields to be absent")
+	}
+
+	built := quoteFromChart(&ChartMeta{Symbol: "AAPL", RegularMarketPrice: 200})
+	if !built.Has("regularMarketPrice") || built.Has("trailingPE") {
+		t.Errorf("Expected a chart quote to have just its non-zero fields")
+	}
+
+	var detail SummaryDetail
+	if err := json.Unmarshal([]byte(`{"forwardPE":{},"beta":{"raw":0}}`), &detail); err != nil {
+		t.Fatalf("Failed to decode summary detail: %v", err)
+	}
+	if detail.Has("forwardPE") || !detail.Has("beta") {
+		t.Errorf("Expected only beta to be present, got %+v", detail)
+	}
+}
+
+// TestNormalizeSymbol tests the common spellings of symbols
+func TestNormalizeSymbol(t *testing.T) {
+	tests := map[string]string{
+		" aapl ":      "AAPL",
+		"brk.b":       "BRK-B",
+		"BRK/B":       "BRK-B",
+		"VOD.L":       "VOD.L",
+		"SHOP.TO":     "SHOP.TO",
+		"RELIANCE.NS": "RELIANCE.NS",
+		"es=f":        "ES=F",
+		"EUR/USD":     "EURUSD=X",
+		"btc/usd":     "BTC-USD",
+		"ETH_USDT":    "ETH-USDT",
+		"^gspc":       "^GSPC",
+	}
+	for in, want := range tests {
+		if got := NormalizeSymbol(in); got != want {
+			t.Errorf("NormalizeSymbol(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestValidateSymbol tests that malformed symbols are rejected with a reason
+func TestValidateSymbol(t *testing.T) {
+	for _, symbol := range []string{"AAPL", "BRK-B", "VOD.L", "ES=F", "EURUSD=X", "^GSPC", "BTC-USD", "M&M.NS"} {
+		if err := ValidateSymbol(symbol); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", symbol, err)
+		}
+	}
+	for _, symbol := range []string{"", " AAPL", "AA PL", "A^B", "^", "-AAPL", "AAPL.", "ES=Q", "A=B=F", strings.Repeat("A", 40)} {
+		err := ValidateSymbol(symbol)
+		var invalid *InvalidSymbolError
+		if !errors.As(err, &invalid) || !errors.Is(err, ErrInvalidSymbol) || invalid.Reason == "" {
+			t.Errorf("Expected %q to be invalid with a reason, got %v", symbol, err)
+		}
+	}
+}
+
+// TestTickerVerify tests that unknown symbols fail verification
+func TestTickerVerify(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbols") == "AAPL" {
+			_, _ = w.Write([]byte(`{"quoteResponse":{"result":[{"symbol":"AAPL"}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"quoteResponse":{"result":[]}}`))
+	})
+
+	if _, err := NewTicker("AAPL", WithClient(client), WithVerify()); err != nil {
+		t.Errorf("Expected AAPL to verify, got %v", err)
+	}
+	var invalid *InvalidSymbolError
+	if _, err := NewTicker("NOPE", WithClient(client), WithVerify()); !errors.As(err, &invalid) || invalid.Reason != "not found" {
+		t.Errorf("Expected an unknown symbol to fail, got %v", err)
+	}
+}
+
+// TestQuoteType tests that the quoteType module is fetched and classified
+func TestQuoteType(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{"quoteType":{"symbol":"BTC-USD","quoteType":"CRYPTOCURRENCY",
+			"exchange":"CCC","firstTradeDateEpochUtc":1410912000,"timeZoneFullName":"UTC"}}]}}`))
+	})
+	ticker, _ := NewTicker("BTC-USD", WithClient(client))
+
+	qt, err := ticker.QuoteType(context.Background())
+	if err != nil {
+		t.Fatalf("QuoteType failed: %v", err)
+	}
+	if !qt.IsCrypto() || qt.IsETF() || qt.IsFund() || qt.Exchange != "CCC" {
+		t.Errorf("Unexpected quote type %+v", qt)
+	}
+	if want := time.Date(2014, 9, 17, 0, 0, 0, 0, time.UTC); !qt.FirstTraded().Equal(want) {
+		t.Errorf("Expected first trade %v, got %v", want, qt.FirstTraded())
+	}
+}
+
+// TestMarketCapHistory tests that a valuation timeseries is parsed, with
+// null padding dropped
+func TestMarketCapHistory(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if typ := r.URL.Query().Get("type"); typ != "trailingMarketCap" {
+			t.Errorf("Unexpected type %q", typ)
+		}
+		_, _ = w.Write([]byte(`{"timeseries":{"result":[{"meta":{"symbol":["AAPL"],"type":["trailingMarketCap"]},
+			"timestamp":[1711843200,1719705600],"trailingMarketCap":[null,
+			{"asOfDate":"2024-06-30","periodType":"TTM","reportedValue":{"raw":3.2e12,"fmt":"3.2T"}},
+			{"asOfDate":"2024-03-31","periodType":"TTM","reportedValue":{"raw":2.6e12,"fmt":"2.6T"}}]}],"error":null}}`))
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	caps, err := ticker.MarketCapHistory(context.Background(), time.Time{}, time.Time{})
+	if err != nil || len(caps) != 2 {
+		t.Fatalf("Expected 2 market caps, got %+v, %v", caps, err)
+	}
+	if caps[0].Value != 2.6e12 || caps[0].Date != time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC) || caps[1].Value != 3.2e12 {
+		t.Errorf("Unexpected market caps %+v", caps)
+	}
+}
+
+// TestFinancials tests timeseries values are keyed by metric, oldest first
+func TestFinancials(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if typ := r.URL.Query().Get("type"); typ != "quarterlyTotalRevenue,quarterlyNetIncome" {
+			t.Errorf("Unexpected type %q", typ)
+		}
+		_, _ = w.Write([]byte(`{"timeseries":{"result":[
+			{"meta":{"symbol":["AAPL"],"type":["quarterlyTotalRevenue"]},"quarterlyTotalRevenue":[
+				{"asOfDate":"2024-06-30","periodType":"3M","currencyCode":"USD","reportedValue":{"raw":8.58e10,"fmt":"85.78B"}},
+				{"asOfDate":"2024-03-31","periodType":"3M","currencyCode":"USD","reportedValue":{"raw":9.08e10,"fmt":"90.75B"}}]},
+			{"meta":{"symbol":["AAPL"],"type":["quarterlyNetIncome"]},"quarterlyNetIncome":[null,
+				{"asOfDate":"2024-06-30","periodType":"3M","currencyCode":"USD","reportedValue":{"raw":2.14e10}}]}],"error":null}}`))
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	financial, err := ticker.Financials(context.Background(), []string{"TotalRevenue", "NetIncome"}, "quarterly")
+	if err != nil {
+		t.Fatalf("Financials failed: %v", err)
+	}
+	revenue := financial.Data["TotalRevenue"]
+	if len(revenue) != 2 || revenue[0].AsOfDate != "2024-03-31" || revenue[0].Raw != 9.08e10 || revenue[1].Fmt != "85.78B" ||
+		revenue[1].PeriodType != "3M" || revenue[1].CurrencyCode != "USD" {
+		t.Errorf("Unexpected revenue %+v", revenue)
+	}
+	if income := financial.Data["NetIncome"]; len(income) != 1 || income[0].Raw != 2.14e10 {
+		t.Errorf("Unexpected net income %+v", income)
+	}
+	want := []int64{time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC).Unix()}
+	if !slices.Equal(financial.Timestamp, want) {
+		t.Errorf("Expected timestamps %v, got %v", want, financial.Timestamp)
+	}
+}
+
+// TestFinancialsCurrency tests statement values are converted at the last
+// close on or before each as-of date
+func TestFinancialsCurrency(t *testing.T) {
+	day := func(m, d int) int64 { return time.Date(2024, time.Month(m), d, 0, 0, 0, 0, time.UTC).Unix() }
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v8/finance/chart/EURUSD=X":
+			_, _ = fmt.Fprintf(w, `{"chart":{"result":[{"meta":{"symbol":"EURUSD=X","currency":"USD"},"timestamp":[%d,%d,%d],
+				"indicators":{"quote":[{"open":[1,1,1],"high":[1,1,1],"low":[1,1,1],"close":[1.08,1.1,1.2],"volume":[0,0,0]}]}}]}}`,
+				day(3, 28), day(6, 28), day(7, 1))
+		default:
+			_, _ = w.Write([]byte(`{"timeseries":{"result":[{"meta":{"type":["quarterlyTotalRevenue"]},"quarterlyTotalRevenue":[
+				{"asOfDate":"2024-03-31","periodType":"3M","currencyCode":"EUR","reportedValue":{"raw":100,"fmt":"100"}},
+				{"asOfDate":"2024-06-30","periodType":"3M","currencyCode":"EUR","reportedValue":{"raw":200,"fmt":"200"}}]}],"error":null}}`))
+		}
+	})
+	ticker, _ := NewTicker("ASML", WithClient(client))
+
+	financial, err := ticker.Financials(context.Background(), []string{"TotalRevenue"}, "quarterly", WithCurrency("usd"))
+	if err != nil {
+		t.Fatalf("Financials failed: %v", err)
+	}
+	revenue := financial.Data["TotalRevenue"]
+	if len(revenue) != 2 {
+		t.Fatalf("Expected 2 values, got %+v", revenue)
+	}
+	if v := revenue[0]; math.Abs(v.Raw-108) > 1e-9 || v.CurrencyCode != "USD" || v.ReportedCurrency != "EUR" || v.FXDate != "2024-03-28" || v.ReportedValue != 100 {
+		t.Errorf("Unexpected first value %+v", v)
+	}
+	if v := revenue[1]; math.Abs(v.Raw-220) > 1e-9 || v.FXRate != 1.1 || v.FXDate != "2024-06-28" {
+		t.Errorf("Unexpected second value %+v", v)
+	}
+}
+
+// TestRevenueSegments tests segment values are grouped by fiscal year
+func TestRevenueSegments(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"timeseries":{"result":[
+			{"meta":{"type":["annualRevenueBySegment"]},"annualRevenueBySegment":[
+				{"asOfDate":"2024-09-28","currencyCode":"USD","segment":"iPhone","reportedValue":{"raw":201}},
+				{"asOfDate":"2024-09-28","currencyCode":"USD","segment":"Services","reportedValue":{"raw":96}},
+				{"asOfDate":"2023-09-30","currencyCode":"USD","segment":"iPhone","reportedValue":{"raw":200}},
+				{"asOfDate":"2023-09-30","reportedValue":{"raw":1}}]},
+			{"meta":{"type":["annualRevenueByGeography"]},"annualRevenueByGeography":[
+				{"asOfDate":"2024-09-28","currencyCode":"USD","segmentName":"Americas","reportedValue":{"raw":167}}]}],"error":null}}`))
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	segments, err := ticker.RevenueSegments(context.Background())
+	if err != nil {
+		t.Fatalf("RevenueSegments failed: %v", err)
+	}
+	if len(segments) != 2 || len(segments[0].Business) != 1 || len(segments[0].Geographic) != 0 {
+		t.Fatalf("Unexpected segments %+v", segments)
+	}
+	latest := segments[1]
+	if latest.Business["iPhone"] != 201 || latest.Business["Services"] != 96 || latest.Geographic["Americas"] != 167 || latest.Currency != "USD" {
+		t.Errorf("Unexpected latest segments %+v", latest)
+	}
+}
+
+// TestHistorySessions tests that intraday bars are tagged with their
+// session from the chart's trading periods, and filtered by it
+func TestHistorySessions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"chart":{"result":[{"meta":{"symbol":"AAPL","exchangeTimezoneName":"America/New_York",
+			"tradingPeriods":{"pre":[[{"start":1700038800,"end":1700058600}]],"regular":[[{"start":1700058600,"end":1700082000}]],
+				"post":[[{"start":1700082000,"end":1700096400}]]}},
+			"timestamp":[1700040000,1700060000,1700085000],
+			"indicators":{"quote":[{"open":[1,2,3],"high":[1,2,3],"low":[1,2,3],"close":[1,2,3],"volume":[1,2,3]}]}}]}}`))
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	chart, err := ticker.History(context.Background(), HistoryParams{Period: Period1d, Interval: Interval5m, PrePost: true})
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	var got []MarketSession
+	for _, b := range chart.Bars {
+		got = append(got, b.Session)
+	}
+	if want := []MarketSession{PreMarket, RegularMarket, PostMarket}; !slices.Equal(got, want) {
+		t.Errorf("Expected sessions %v, got %v", want, got)
+	}
+
+	chart, err = ticker.History(context.Background(), HistoryParams{Period: Period1d, Interval: Interval5m, PrePost: true,
+		Sessions: []MarketSession{RegularMarket}})
+	if err != nil || len(chart.Bars) != 1 || chart.Bars[0].Close != 2 {
+		t.Errorf("Expected just the regular bar, got %+v, %v", chart, err)
+	}
+
+	if err := (HistoryParams{Sessions: []MarketSession{"lunch"}}).Validate(); err == nil {
+		t.Error("Expected an unknown session to be rejected")
+	}
+}
+
+// TestCompare tests that related tickers are compared side by side, with
+// failed peers reported as missing
+func TestCompare(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "recommendationsbysymbol"):
+			_, _ = w.Write([]byte(`{"finance":{"result":[{"symbol":"AAPL","recommendedSymbols":[
+				{"symbol":"MSFT","score":0.3},{"symbol":"BAD","score":0.1}]}],"error":null}}`))
+		case strings.HasSuffix(r.URL.Path, "/BAD"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found"}}}`))
+		default:
+			symbol := path.Base(r.URL.Path)
+			_, _ = fmt.Fprintf(w, `{"quoteSummary":{"result":[{"price":{"shortName":%q,"regularMarketPrice":{"raw":100}},
+				"summaryDetail":{"trailingPE":{"raw":30.5},"marketCap":{"raw":3000000000000}},
+				"financialData":{"profitMargins":{"raw":0.25}}}]}}`, symbol+" Inc")
+		}
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	related, err := ticker.RelatedTickers(context.Background())
+	if err != nil || len(related) != 2 || related[0] != (RelatedTicker{Symbol: "MSFT", Score: 0.3}) {
+		t.Fatalf("Unexpected related tickers %+v, %v", related, err)
+	}
+
+	cmp, err := ticker.Compare(context.Background())
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if len(cmp.Peers) != 2 || cmp.Peers[0].Symbol != "AAPL" || cmp.Peers[1].Symbol != "MSFT" {
+		t.Fatalf("Unexpected peers %+v", cmp.Peers)
+	}
+	if p := cmp.Peers[1]; p.Name != "MSFT Inc" || p.Price != 100 || p.TrailingPE != 30.5 || p.MarketCap != 3e12 || p.ProfitMargin != 0.25 {
+		t.Errorf("Unexpected peer stats %+v", p)
+	}
+	if !slices.Equal(cmp.Missing, []string{"BAD"}) {
+		t.Errorf("Expected BAD to be missing, got %v", cmp.Missing)
+	}
+}
+
+// TestAnalystActions tests that ratings, trend, and targets are combined
+func TestAnalystActions(t *testing.T) {
+	now := time.Now().Unix()
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"quoteSummary":{"result":[{
+			"upgradeDowngradeHistory":{"history":[
+				{"epochGradeDate":%d,"firm":"Old","toGrade":"Sell","fromGrade":"Hold","action":"down"},
+				{"epochGradeDate":%d,"firm":"A","toGrade":"Buy","fromGrade":"Hold","action":"up","currentPriceTarget":250,"priorPriceTarget":220},
+				{"epochGradeDate":%d,"firm":"B","toGrade":"Hold","action":"main","currentPriceTarget":200,"priorPriceTarget":210}]},
+			"recommendationTrend":{"trend":[{"period":"0m","strongBuy":2,"buy":1,"hold":1,"sell":0,"strongSell":0}]},
+			"financialData":{"targetMeanPrice":{"raw":230},"numberOfAnalystOpinions":{"raw":4},"recommendationKey":"buy"}}]}}`,
+			now-60*86400, now-5*86400, now-40*86400)
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	view, err := ticker.AnalystActions(context.Background())
+	if err != nil {
+		t.Fatalf("AnalystActions failed: %v", err)
+	}
+	if view.Consensus != 1.75 || view.ConsensusKey != "buy" || view.PriceTarget.Mean != 230 || view.PriceTarget.NumAnalysts != 4 {
+		t.Errorf("Unexpected consensus and targets %+v", view)
+	}
+	if len(view.Actions) != 3 || view.Actions[0].Firm != "A" || view.Actions[2].Firm != "Old" {
+		t.Errorf("Expected actions newest first, got %+v", view.Actions)
+	}
+	if want := (RevisionMomentum{Upgrades: 1, TargetRaises: 1, Net: 2}); view.Momentum30d != want {
+		t.Errorf("Expected 30-day momentum %+v, got %+v", want, view.Momentum30d)
+	}
+	if want := (RevisionMomentum{Upgrades: 1, Downgrades: 1, TargetRaises: 1, TargetCuts: 1, Net: 0}); view.Momentum90d != want {
+		t.Errorf("Expected 90-day momentum %+v, got %+v", want, view.Momentum90d)
+	}
+}
+
+// TestGroupHolders tests that holders are grouped by report date in range
+func TestGroupHolders(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	holders := []Holder{
+		{Holder: "A", Shares: 100, Value: 1000, DateReported: day(6, 30)},
+		{Holder: "B", Shares: 50, Value: 500, DateReported: day(3, 31)},
+		{Holder: "C", Shares: 25, Value: 250, DateReported: day(6, 30).Add(4 * time.Hour)},
+		{Holder: "D", Shares: 10, Value: 100, DateReported: day(12, 31)},
+	}
+
+	reports := groupHolders(holders, day(1, 1), day(9, 30))
+	if len(reports) != 2 {
+		t.Fatalf("Expected 2 reports, got %+v", reports)
+	}
+	if reports[0].Date != day(3, 31) || reports[0].Shares != 50 {
+		t.Errorf("Unexpected first report %+v", reports[0])
+	}
+	if reports[1].Date != day(6, 30) || len(reports[1].Holders) != 2 || reports[1].Shares != 125 || reports[1].Value != 1250 {
+		t.Errorf("Unexpected second report %+v", reports[1])
+	}
+	if all := groupHolders(holders, time.Time{}, time.Time{}); len(all) != 3 {
+		t.Errorf("Expected 3 reports without bounds, got %d", len(all))
+	}
+}
+
+// TestFundData tests that a fund's modules are combined into FundData
+func TestFundData(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{
+			"topHoldings":{"cashPosition":{"raw":0.01},"stockPosition":{"raw":0.99},"bondPosition":{},
+				"holdings":[{"symbol":"AAPL","holdingName":"Apple Inc","holdingPercent":{"raw":0.07}},
+					{"symbol":"MSFT","holdingName":"Microsoft Corp","holdingPercent":{"raw":0.06}}],
+				"sectorWeightings":[{"technology":{"raw":0.3}}],
+				"bondRatings":[{"aaa":{"raw":0}}],
+				"equityHoldings":{"priceToEarnings":{"raw":25.4},"priceToSales":{},"medianMarketCap":{"raw":250000},"priceToEarningsCat":{"raw":22.1}},
+				"bondHoldings":{"duration":{},"maturity":{}}},
+			"fundProfile":{"family":"Vanguard","categoryName":"Large Blend","legalType":"Exchange Traded Fund",
+				"feesExpensesInvestment":{"annualReportExpenseRatio":{"raw":0.0003}}},
+			"fundPerformance":{"trailingReturns":{"asOfDate":{"raw":1735603200},"ytd":{"raw":0.25},"threeYear":{"raw":0.09}}},
+			"defaultKeyStatistics":{"totalAssets":{"raw":500000000000}}}]}}`))
+	})
+	ticker, _ := NewTicker("VOO", WithClient(client))
+
+	fund, err := ticker.FundData(context.Background())
+	if err != nil {
+		t.Fatalf("FundData failed: %v", err)
+	}
+	o := fund.Overview
+	if o.FundFamily != "Vanguard" || o.ExpenseRatio != 0.0003 || o.YTDReturn != 0.25 || o.TrailingThreeYearReturns != 0.09 ||
+		o.TotalAssets != 5e11 || o.TotalHoldings != 2 || math.Abs(o.Top10HoldingsPercent-0.13) > 1e-9 {
+		t.Errorf("Unexpected overview %+v", o)
+	}
+	if len(fund.Holdings) != 2 || len(fund.SectorWeightings) != 1 || fund.BondRatings["aaa"] != 0 || len(fund.BondRatings) != 1 {
+		t.Errorf("Unexpected holdings %+v", fund)
+	}
+	if e := fund.EquityHoldings; e == nil || e.PriceToEarnings != 25.4 || e.MedianMarketCap != 250000 || e.Category == nil || e.Category.PriceToEarnings != 22.1 {
+		t.Errorf("Unexpected equity holdings %+v", fund.EquityHoldings)
+	}
+	if fund.BondHoldings != nil {
+		t.Errorf("Expected no bond holdings for a stock fund, got %+v", fund.BondHoldings)
+	}
+	if len(fund.AssetClasses) != 2 || fund.AssetClasses["stock"] != 0.99 {
+		t.Errorf("Unexpected asset classes %v", fund.AssetClasses)
+	}
+}
+
+// TestFundPerformance tests annual returns and risk statistics are paired
+// with their category benchmarks
+func TestFundPerformance(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"quoteSummary":{"result":[{"fundPerformance":{
+			"trailingReturns":{"ytd":{"raw":0.2}},
+			"annualTotalReturns":{
+				"returns":[{"year":"2022","annualValue":{"raw":-0.18}},{"year":"2023","annualValue":{"raw":0.26}},{"year":"2024","annualValue":{}}],
+				"returnsCat":[{"year":"2023","annualValue":{"raw":0.22}}]},
+			"riskOverviewStatistics":{
+				"riskStatistics":[{"year":"5y","alpha":{"raw":-0.02},"beta":{"raw":1},"sharpeRatio":{"raw":0.8},"stdDev":{"raw":18.1}}],
+				"riskStatisticsCat":[{"year":"5y","beta":{"raw":0.97}}]}}}]}}`))
+	})
+	ticker, _ := NewTicker("VOO", WithClient(client))
+
+	perf, err := ticker.FundPerformance(context.Background())
+	if err != nil {
+		t.Fatalf("FundPerformance failed: %v", err)
+	}
+	if perf.YTDReturn != 0.2 {
+		t.Errorf("Expected YTD return 0.2, got %v", perf.YTDReturn)
+	}
+	want := []FundAnnualReturn{{Year: 2023, Return: 0.26, CategoryReturn: 0.22}, {Year: 2022, Return: -0.18}}
+	if !slices.Equal(perf.AnnualReturns, want) {
+		t.Errorf("Expected annual returns %v, got %v", want, perf.AnnualReturns)
 	}
+	if len(perf.Risk) != 1 || perf.Risk[0].Period != "5y" || perf.Risk[0].SharpeRatio != 0.8 || perf.Risk[0].StdDev != 18.1 {
+		t.Errorf("Unexpected risk statistics %+v", perf.Risk)
+	}
+	if len(perf.CategoryRisk) != 1 || perf.CategoryRisk[0].Beta != 0.97 {
+		t.Errorf("Unexpected category risk statistics %+v", perf.CategoryRisk)
+	}
+}
 
-	// Test Unwrap
-	unwrapped := reqErr.Unwrap()
-	if unwrapped == nil {
-		t.Error("Expected non-nil unwrapped error")
+// TestFundOverlap tests shared holdings, weight overlap, and combined
+// sector exposure across funds
+func TestFundOverlap(t *testing.T) {
+	funds := map[string]string{
+		"SPY": `{"symbol":"AAPL","holdingName":"Apple","holdingPercent":{"raw":0.07}},{"symbol":"MSFT","holdingName":"Microsoft","holdingPercent":{"raw":0.06}},{"symbol":"XOM","holdingName":"Exxon","holdingPercent":{"raw":0.01}}`,
+		"QQQ": `{"symbol":"MSFT","holdingName":"Microsoft","holdingPercent":{"raw":0.08}},{"symbol":"AAPL","holdingName":"Apple","holdingPercent":{"raw":0.09}},{"symbol":"NVDA","holdingName":"Nvidia","holdingPercent":{"raw":0.07}}`,
+	}
+	sectors := map[string]string{"SPY": `{"technology":{"raw":0.3}},{"energy":{"raw":0.04}}`, "QQQ": `{"technology":{"raw":0.5}}`}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		symbol := path.Base(r.URL.Path)
+		_, _ = fmt.Fprintf(w, `{"quoteSummary":{"result":[{"topHoldings":{"holdings":[%s],"sectorWeightings":[%s]}}]}}`, funds[symbol], sectors[symbol])
+	})
+
+	if _, err := FundOverlapWithClient(context.Background(), client, "SPY"); err == nil {
+		t.Error("Expected an error for a single fund")
+	}
+	overlap, err := FundOverlapWithClient(context.Background(), client, "SPY", "QQQ")
+	if err != nil {
+		t.Fatalf("FundOverlap failed: %v", err)
+	}
+	if len(overlap.Common) != 2 || overlap.Common[0].Symbol != "AAPL" || overlap.Common[0].Overlap != 0.07 || overlap.Common[1].Weights["QQQ"] != 0.08 {
+		t.Errorf("Unexpected common holdings %+v", overlap.Common)
+	}
+	if math.Abs(overlap.WeightOverlap-0.13) > 1e-9 {
+		t.Errorf("Expected weight overlap 0.13, got %v", overlap.WeightOverlap)
+	}
+	if len(overlap.Sectors) != 2 || overlap.Sectors[0].Sector != "technology" || math.Abs(overlap.Sectors[0].Percent-0.4) > 1e-9 ||
+		math.Abs(overlap.Sectors[1].Percent-0.02) > 1e-9 {
+		t.Errorf("Unexpected sectors %+v", overlap.Sectors)
+	}
+}
+
+// TestRatios tests ratios are computed per fiscal year from statement
+// values and the market cap at year end
+func TestRatios(t *testing.T) {
+	point := func(date string, v float64) string {
+		return fmt.Sprintf(`{"asOfDate":%q,"periodType":"12M","reportedValue":{"raw":%g}}`, date, v)
+	}
+	values := map[string][]string{
+		"annualTotalRevenue":       {point("2023-09-30", 400), point("2024-09-30", 500)},
+		"annualGrossProfit":        {point("2024-09-30", 200)},
+		"annualOperatingIncome":    {point("2024-09-30", 150)},
+		"annualNetIncome":          {point("2023-09-30", 80), point("2024-09-30", 100)},
+		"annualPretaxIncome":       {point("2024-09-30", 125)},
+		"annualTaxProvision":       {point("2024-09-30", 25)},
+		"annualInvestedCapital":    {point("2024-09-30", 600)},
+		"annualStockholdersEquity": {point("2024-09-30", 400)},
+		"annualTotalDebt":          {point("2024-09-30", 300)},
+		"annualEBITDA":             {point("2024-09-30", 200)},
+		"annualFreeCashFlow":       {point("2024-09-30", 90)},
+		"annualCashDividendsPaid":  {point("2024-09-30", -15)},
+		"trailingMarketCap":        {point("2024-06-30", 2000), point("2024-09-30", 3000)},
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var results []string
+		for _, typ := range strings.Split(r.URL.Query().Get("type"), ",") {
+			results = append(results, fmt.Sprintf(`{"meta":{"type":[%q]},%q:[%s]}`, typ, typ, strings.Join(values[typ], ",")))
+		}
+		_, _ = fmt.Fprintf(w, `{"timeseries":{"result":[%s],"error":null}}`, strings.Join(results, ","))
+	})
+	ticker, _ := NewTicker("AAPL", WithClient(client))
+
+	ratios, err := ticker.Ratios(context.Background())
+	if err != nil {
+		t.Fatalf("Ratios failed: %v", err)
+	}
+	if len(ratios) != 2 || ratios[0].NetMargin != 0.2 || ratios[0].GrossMargin != 0 {
+		t.Fatalf("Unexpected ratios %+v", ratios)
+	}
+	want := FinancialRatios{
+		Date:            time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC),
+		GrossMargin:     0.4,
+		OperatingMargin: 0.3,
+		NetMargin:       0.2,
+		ROIC:            0.2,
+		ROE:             0.25,
+		DebtToEBITDA:    1.5,
+		FCFYield:        0.03,
+		PayoutRatio:     0.15,
+	}
+	if got := ratios[1]; got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+// TestStatementRows tests quoteSummary and timeseries keys both map to
+// typed statement rows
+func TestStatementRows(t *testing.T) {
+	period := FinancialStatementPeriod{Data: map[string]float64{"totalRevenue": 100, "netIncome": 20, "incomeBeforeTax": 25, "totalLiab": 70}}
+	if row := period.IncomeStatement(); row.TotalRevenue != 100 || row.NetIncome != 20 || row.PretaxIncome != 25 || row.EBITDA != 0 {
+		t.Errorf("Unexpected income row %+v", row)
+	}
+	if row := period.BalanceSheet(); row.TotalLiabilities != 70 {
+		t.Errorf("Unexpected balance sheet row %+v", row)
+	}
+
+	financial := &Financial{Data: map[string][]FinancialValue{
+		"FreeCashFlow":      {{Raw: 50, AsOfDate: "2023-12-31"}, {Raw: 60, AsOfDate: "2024-12-31"}},
+		"CashDividendsPaid": {{Raw: -10, AsOfDate: "2024-12-31", CurrencyCode: "USD"}},
+	}}
+	periods := financial.Periods()
+	if len(periods) != 2 || periods[0].EndDate != "2023-12-31" || !periods[1].Date.Equal(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected periods %+v", periods)
+	}
+	if row := periods[1].CashFlow(); row.FreeCashFlow != 60 || row.DividendsPaid != -10 || periods[1].Data["CashDividendsPaid"] != -10 {
+		t.Errorf("Unexpected cash flow row %+v", row)
+	}
+}
+
+// TestGetGrowthMetrics tests year-over-year growth and CAGR, including
+// the EPS and free cash flow fallbacks
+func TestGetGrowthMetrics(t *testing.T) {
+	year := func(y int) time.Time { return time.Date(y, 12, 31, 0, 0, 0, 0, time.UTC) }
+	fs := &FinancialStatement{Annual: []FinancialStatementPeriod{
+		{Date: year(2024), Data: map[string]float64{"totalRevenue": 121, "netIncome": 30, "DilutedAverageShares": 10, "dividendsPaid": -4}},
+		{Date: year(2022), Data: map[string]float64{"totalRevenue": 100, "DilutedEPS": 2, "FreeCashFlow": -5, "dividendsPaid": -2}},
+		{Date: year(2023), Data: map[string]float64{"totalRevenue": 110, "totalCashFromOperatingActivities": 20, "capitalExpenditures": -8}},
+	}}
+
+	growth := GetGrowthMetrics(fs)
+	revenue := growth.Revenue
+	if len(revenue.YoY) != 2 || math.Abs(revenue.YoY[0].Value-0.1) > 1e-9 || !revenue.YoY[1].Date.Equal(year(2024)) {
+		t.Errorf("Unexpected revenue growth %+v", revenue.YoY)
+	}
+	if math.Abs(revenue.Years-2) > 0.01 || math.Abs(revenue.CAGR-0.1) > 0.001 {
+		t.Errorf("Expected about 10%% CAGR over 2 years, got %v over %v", revenue.CAGR, revenue.Years)
+	}
+	if eps := growth.EPS; len(eps.YoY) != 0 || math.Abs(eps.CAGR-math.Sqrt(1.5)+1) > 0.001 {
+		t.Errorf("Unexpected EPS growth %+v", eps)
+	}
+	if fcf := growth.FreeCashFlow; len(fcf.YoY) != 1 || math.Abs(fcf.YoY[0].Value-3.4) > 1e-9 || fcf.CAGR != 0 {
+		t.Errorf("Expected growth from negative free cash flow without a CAGR, got %+v", fcf)
+	}
+	if div := growth.Dividends; len(div.YoY) != 0 || math.Abs(div.CAGR-math.Sqrt2+1) > 0.001 {
+		t.Errorf("Unexpected dividend growth %+v", div)
+	}
+}
+
+// TestOptionsParams tests option chains are narrowed by strike,
+// moneyness, side, open interest, and volume
+func TestOptionsParams(t *testing.T) {
+	chain := &OptionChain{
+		Symbol:          "SPY",
+		UnderlyingPrice: 100,
+		Strikes:         []float64{80, 95, 100, 105, 120},
+		Calls: []Option{
+			{Strike: 80, OpenInterest: 500, Volume: 10},
+			{Strike: 95, OpenInterest: 50, Volume: 10},
+			{Strike: 100, OpenInterest: 500, Volume: 0},
+			{Strike: 105, OpenInterest: 500, Volume: 10},
+		},
+		Puts: []Option{{Strike: 100, OpenInterest: 500, Volume: 10}, {Strike: 120, OpenInterest: 500, Volume: 10}},
+	}
+
+	filtered := OptionsParams{Moneyness: 0.1, MinOpenInterest: 100, MinVolume: 1}.Filter(chain)
+	if len(filtered.Calls) != 1 || filtered.Calls[0].Strike != 105 || len(filtered.Puts) != 1 || filtered.Puts[0].Strike != 100 {
+		t.Errorf("Unexpected contracts %+v, %+v", filtered.Calls, filtered.Puts)
+	}
+	if !slices.Equal(filtered.Strikes, []float64{95, 100, 105}) {
+		t.Errorf("Unexpected strikes %v", filtered.Strikes)
+	}
+
+	filtered = OptionsParams{MinStrike: 90, MaxStrike: 110, PutsOnly: true}.Filter(chain)
+	if len(filtered.Calls) != 0 || len(filtered.Puts) != 1 {
+		t.Errorf("Expected only the put at 100, got %+v, %+v", filtered.Calls, filtered.Puts)
+	}
+
+	for _, params := range []OptionsParams{{CallsOnly: true, PutsOnly: true}, {MinStrike: 110, MaxStrike: 90}, {Moneyness: -1}} {
+		if params.Validate() == nil {
+			t.Errorf("Expected %+v to be invalid", params)
+		}
+	}
+}
+
+// TestGreeksWithYield tests the dividend yield lowers call deltas and
+// reduces to plain Black-Scholes without one
+func TestGreeksWithYield(t *testing.T) {
+	plain := CalculateGreeks(100, 100, 0.05, 0.5, 0.2, true)
+	if g := CalculateGreeksWithYield(100, 100, 0.05, 0, 0.5, 0.2, true); *g != *plain {
+		t.Errorf("Expected zero yield to match Black-Scholes, got %+v and %+v", g, plain)
+	}
+	call := CalculateGreeksWithYield(100, 100, 0.05, 0.06, 0.5, 0.2, true)
+	put := CalculateGreeksWithYield(100, 100, 0.05, 0.06, 0.5, 0.2, false)
+	if call.Delta >= plain.Delta {
+		t.Errorf("Expected a yield to lower the call delta below %v, got %v", plain.Delta, call.Delta)
+	}
+	// Put-call parity of deltas: call - put = e^(-qt)
+	if math.Abs(call.Delta-put.Delta-math.Exp(-0.06*0.5)) > 1e-9 {
+		t.Errorf("Deltas %v and %v break parity", call.Delta, put.Delta)
+	}
+	// Put-call parity of prices: C - P = S e^(-qt) - K e^(-rt)
+	c, p := BlackScholesPrice(100, 100, 0.05, 0.06, 0.5, 0.2, true), BlackScholesPrice(100, 100, 0.05, 0.06, 0.5, 0.2, false)
+	if math.Abs(c-p-(100*math.Exp(-0.03)-100*math.Exp(-0.025))) > 1e-9 {
+		t.Errorf("Prices %v and %v break parity", c, p)
+	}
+}
+
+// TestAmericanPrice tests the binomial pricer against Black-Scholes and
+// the early exercise premium of puts
+func TestAmericanPrice(t *testing.T) {
+	// Without dividends an American call is worth the European one
+	european := BlackScholesPrice(100, 100, 0.05, 0, 1, 0.2, true)
+	if american := AmericanPrice(100, 100, 0.05, 0, 1, 0.2, true, 500); math.Abs(american-european) > 0.02 {
+		t.Errorf("Expected American call near %v, got %v", european, american)
+	}
+	// A deep in-the-money put is worth exercising at once
+	if put := AmericanPrice(50, 100, 0.05, 0, 1, 0.2, false, 0); math.Abs(put-50) > 1e-9 {
+		t.Errorf("Expected a deep put to be worth its intrinsic 50, got %v", put)
+	}
+	// Otherwise early exercise adds value over the European put
+	europeanPut := BlackScholesPrice(100, 100, 0.05, 0, 1, 0.2, false)
+	if put := AmericanPrice(100, 100, 0.05, 0, 1, 0.2, false, 500); math.Abs(put-6.09) > 0.01 {
+		t.Errorf("Expected about 6.09, above the European %v, got %v", europeanPut, put)
+	}
+	if AmericanPrice(110, 100, 0.05, 0.02, 0, 0.2, true, 100) != 10 {
+		t.Error("Expected intrinsic value at expiry")
+	}
+
+	bs := CalculateGreeks(100, 100, 0.05, 1, 0.2, true)
+	tree := AmericanGreeks(100, 100, 0.05, 0, 1, 0.2, true, 500)
+	for name, pair := range map[string][2]float64{
+		"delta": {bs.Delta, tree.Delta}, "gamma": {bs.Gamma, tree.Gamma}, "theta": {bs.Theta, tree.Theta},
+		"vega": {bs.Vega, tree.Vega}, "rho": {bs.Rho, tree.Rho},
+	} {
+		if math.Abs(pair[0]-pair[1]) > 0.01*math.Max(math.Abs(pair[0]), 0.1) {
+			t.Errorf("Tree %s %v too far from Black-Scholes %v", name, pair[1], pair[0])
+		}
+	}
+	if AmericanGreeks(100, 100, 0.05, 0, 0, 0.2, true, 0) != nil {
+		t.Error("Expected nil Greeks at expiry")
+	}
+}
+
+// TestChainAnalytics tests max pain, put/call ratios, and open interest
+// walls of a chain
+func TestChainAnalytics(t *testing.T) {
+	chain := &OptionChain{
+		Calls: []Option{
+			{Strike: 90, OpenInterest: 100, Volume: 50, Expiration: 1735862400},
+			{Strike: 100, OpenInterest: 300, Volume: 100},
+			{Strike: 110, OpenInterest: 500, Volume: 50},
+			{Strike: 120, OpenInterest: 200},
+		},
+		Puts: []Option{
+			{Strike: 90, OpenInterest: 400, Volume: 150},
+			{Strike: 100, OpenInterest: 200, Volume: 150},
+			{Strike: 110, OpenInterest: 100},
+		},
+	}
+
+	a := chain.Analytics()
+	// Payouts at 90: calls 0, puts 200*10 + 100*20 = 4000; at 100: calls 1000,
+	// puts 1000; at 110: calls 2000+3000, puts 0; at 120 more still
+	if a.MaxPain != 100 {
+		t.Errorf("Expected max pain at 100, got %v", a.MaxPain)
+	}
+	if a.CallVolume != 200 || a.PutVolume != 300 || a.PutCallVolumeRatio != 1.5 || a.PutCallOpenInterestRatio != 700.0/1100 {
+		t.Errorf("Unexpected totals %+v", a)
+	}
+	if len(a.CallWalls) != 3 || a.CallWalls[0] != (StrikeInterest{110, 500}) || a.CallWalls[2].Strike != 120 || a.PutWalls[0].Strike != 90 {
+		t.Errorf("Unexpected walls %+v, %+v", a.CallWalls, a.PutWalls)
+	}
+	if !a.Expiration.Equal(time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected expiration %v", a.Expiration)
+	}
+
+	if a := (&OptionChain{}).Analytics(); a.MaxPain != 0 || a.PutCallVolumeRatio != 0 || len(a.CallWalls) != 0 {
+		t.Errorf("Expected empty analytics for an empty chain, got %+v", a)
+	}
+}
+
+// TestTimeToExpiry tests time to expiry runs to the New York close of the
+// expiry day and follows the injected clock
+func TestTimeToExpiry(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone data: %v", err)
+	}
+	expiration := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC).Unix()
+	year := 365.25 * 24.0
+
+	if got := timeToExpiry(expiration, time.Date(2025, 1, 17, 10, 0, 0, 0, ny)); math.Abs(got-6/year) > 1e-12 {
+		t.Errorf("Expected 6 hours left on expiry day, got %v years", got)
+	}
+	if got := timeToExpiry(expiration, time.Date(2025, 1, 16, 16, 0, 0, 0, ny)); math.Abs(got-24/year) > 1e-12 {
+		t.Errorf("Expected one day left, got %v years", got)
+	}
+	if got := timeToExpiry(expiration, time.Date(2025, 1, 18, 0, 0, 0, 0, ny)); got != minTimeToExpiry {
+		t.Errorf("Expected the floor after expiry, got %v", got)
+	}
+
+	defer func(clock func() time.Time) { timeNow = clock }(timeNow)
+	timeNow = func() time.Time { return time.Date(2024, 1, 17, 16, 0, 0, 0, ny) }
+	opt := &Option{Strike: 100, Expiration: expiration, ImpliedVolatility: 0.2}
+	got := CalculateOptionGreeks(opt, 100, 0.05, true).Greeks
+	want := CalculateGreeks(100, 100, 0.05, 366*24/year, 0.2, true)
+	if *got != *want {
+		t.Errorf("Expected Greeks %+v a year out, got %+v", want, got)
+	}
+}
+
+// TestHistoricalVolatility tests the close-to-close, Parkinson, and
+// Garman-Klass estimators over a rolling window
+func TestHistoricalVolatility(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	// Closes alternate up and down 10%; every bar ranges 2% around its open
+	var bars []Bar
+	for i := range 6 {
+		c := 100.0
+		if i%2 == 1 {
+			c = 110
+		}
+		bars = append(bars, Bar{Timestamp: day(i + 1), Open: c, High: c * 1.01, Low: c / 1.01, Close: c})
+	}
+	bars = append(bars, Bar{Timestamp: day(10)}) // No prices, skipped
+
+	values, err := HistoricalVolatility(bars, VolatilityParams{Window: 4, PeriodsPerYear: 1})
+	if err != nil || len(values) != 2 {
+		t.Fatalf("Expected 2 windows of returns, got %+v, %v", values, err)
+	}
+	// Four returns of +-ln(1.1) have sample variance 4/3 ln(1.1)^2
+	if want := math.Sqrt(4.0/3) * math.Log(1.1); math.Abs(values[0].Value-want) > 1e-12 || !values[0].Date.Equal(day(5)) {
+		t.Errorf("Expected %v on day 5, got %+v", want, values[0])
+	}
+
+	parkinson, _ := HistoricalVolatility(bars, VolatilityParams{Method: Parkinson, Window: 6})
+	hl := math.Log(1.01 * 1.01)
+	if want := math.Sqrt(hl * hl / (4 * math.Ln2) * 252); len(parkinson) != 1 || math.Abs(parkinson[0].Value-want) > 1e-12 {
+		t.Errorf("Expected Parkinson %v, got %+v", want, parkinson)
+	}
+	gk, _ := (&ChartData{Bars: bars}).HistoricalVolatility(VolatilityParams{Method: GarmanKlass, Window: 3})
+	if want := math.Sqrt(hl * hl / 2 * 252); len(gk) != 4 || math.Abs(gk[0].Value-want) > 1e-12 {
+		t.Errorf("Expected Garman-Klass %v without open-to-close moves, got %+v", want, gk)
+	}
+
+	if _, err := HistoricalVolatility(bars, VolatilityParams{Method: "yang-zhang"}); err == nil {
+		t.Error("Expected an error for an unknown method")
+	}
+	if values, _ := HistoricalVolatility(bars[:3], VolatilityParams{}); len(values) != 0 {
+		t.Errorf("Expected no values for too few bars, got %+v", values)
+	}
+}
+
+// TestResample tests OHLCV aggregation into intraday, daily, weekly, and
+// monthly bars
+func TestResample(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone data: %v", err)
+	}
+	at := func(d, h, m int) time.Time { return time.Date(2024, 1, d, h, m, 0, 0, ny) }
+	bar := func(ts time.Time, session MarketSession, o, h, l, c float64, v int64) Bar {
+		return Bar{Timestamp: ts, Session: session, Open: o, High: h, Low: l, Close: c, AdjClose: c, Volume: v}
+	}
+	bars := []Bar{
+		bar(at(2, 9, 0), PreMarket, 9, 10, 9, 10, 1),
+		bar(at(2, 9, 30), RegularMarket, 10, 12, 10, 11, 10),
+		bar(at(2, 10, 0), RegularMarket, 11, 11, 8, 9, 20),
+		bar(at(2, 10, 30), RegularMarket, 9, 10, 9, 10, 30),
+		{Timestamp: at(2, 10, 45)},
+		bar(at(3, 9, 30), RegularMarket, 10, 15, 10, 14, 40),
+		bar(at(8, 9, 30), RegularMarket, 14, 14, 13, 13, 50),
+	}
+
+	hourly, err := Resample(bars, Interval1h)
+	if err != nil {
+		t.Fatalf("Resample failed: %v", err)
+	}
+	want := []Bar{
+		bar(at(2, 9, 0), PreMarket, 9, 10, 9, 10, 1),
+		bar(at(2, 9, 30), RegularMarket, 10, 12, 8, 9, 30),
+		bar(at(2, 10, 30), RegularMarket, 9, 10, 9, 10, 30),
+		bar(at(3, 9, 30), RegularMarket, 10, 15, 10, 14, 40),
+		bar(at(8, 9, 30), RegularMarket, 14, 14, 13, 13, 50),
+	}
+	if !slices.Equal(hourly, want) {
+		t.Errorf("Expected hourly bars\n%+v\ngot\n%+v", want, hourly)
+	}
+
+	daily, _ := Resample(bars, Interval1d)
+	if len(daily) != 3 || daily[0] != bar(at(2, 0, 0), "", 9, 12, 8, 10, 61) {
+		t.Errorf("Unexpected daily bars %+v", daily)
+	}
+	weekly, _ := Resample(bars, Interval1wk)
+	if len(weekly) != 2 || !weekly[0].Timestamp.Equal(at(1, 0, 0)) || weekly[0].Volume != 101 || weekly[1].Close != 13 {
+		t.Errorf("Unexpected weekly bars %+v", weekly)
+	}
+	quarterly, _ := Resample(bars, Interval3mo)
+	if len(quarterly) != 1 || quarterly[0].Open != 9 || quarterly[0].Close != 13 || quarterly[0].High != 15 {
+		t.Errorf("Unexpected quarterly bars %+v", quarterly)
+	}
+
+	if _, err := Resample(bars, Interval5d); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("Expected ErrInvalidInterval for 5d, got %v", err)
+	}
+}
+
+// TestPerformanceStats tests returns, drawdown, and risk-adjusted
+// statistics of a chart
+func TestPerformanceStats(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	chart := &ChartData{}
+	for i, c := range []float64{100, 110, 99, 88, 0, 121, 110} {
+		chart.Bars = append(chart.Bars, Bar{Timestamp: day(i + 1), Close: c, AdjClose: c})
+	}
+
+	returns := chart.Returns()
+	if len(returns) != 5 || math.Abs(returns[0].Value-0.1) > 1e-12 || math.Abs(returns[3].Value-0.375) > 1e-12 || !returns[3].Date.Equal(day(6)) {
+		t.Errorf("Unexpected returns %+v", returns)
+	}
+	if logs := chart.LogReturns(); math.Abs(logs[0].Value-math.Log(1.1)) > 1e-12 {
+		t.Errorf("Unexpected log returns %+v", logs)
+	}
+	if got := chart.CumulativeReturn(); math.Abs(got-0.1) > 1e-12 {
+		t.Errorf("Expected a 10%% cumulative return, got %v", got)
+	}
+
+	dd := chart.MaxDrawdown()
+	if math.Abs(dd.Depth-0.2) > 1e-12 || !dd.Peak.Equal(day(2)) || !dd.Trough.Equal(day(4)) || !dd.Recovery.Equal(day(6)) {
+		t.Errorf("Unexpected drawdown %+v", dd)
+	}
+
+	stats := chart.Stats(StatsParams{PeriodsPerYear: 5})
+	if math.Abs(stats.AnnualReturn-0.1) > 1e-12 || stats.MaxDrawdown != dd || !stats.Start.Equal(day(1)) || !stats.End.Equal(day(7)) {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	rs := []float64{0.1, -0.1, -1.0 / 9, 0.375, -1.0 / 11}
+	mean := (rs[0] + rs[1] + rs[2] + rs[3] + rs[4]) / 5
+	std := math.Sqrt(Variance(rs))
+	if math.Abs(stats.AnnualVolatility-std*math.Sqrt(5)) > 1e-12 || math.Abs(stats.Sharpe-mean/std*math.Sqrt(5)) > 1e-12 {
+		t.Errorf("Unexpected volatility %v or Sharpe %v", stats.AnnualVolatility, stats.Sharpe)
+	}
+	if stats.Sortino <= stats.Sharpe {
+		t.Errorf("Expected Sortino %v above Sharpe %v for upside-skewed returns", stats.Sortino, stats.Sharpe)
+	}
+
+	rolling := chart.RollingStats(3, StatsParams{})
+	if len(rolling) != 4 || math.Abs(rolling[0].TotalReturn+0.01) > 1e-12 || !rolling[3].End.Equal(day(7)) {
+		t.Errorf("Unexpected rolling stats %+v", rolling)
+	}
+}
+
+// TestStatsHelpers tests the return statistics shared with the portfolio
+// package
+func TestStatsHelpers(t *testing.T) {
+	bench := []float64{0.01, -0.02, 0.03, 0.005}
+	lev := []float64{0.02, -0.04, 0.06, 0.01}
+	if m := Mean(bench); math.Abs(m-0.00625) > 1e-12 {
+		t.Errorf("Expected mean 0.00625, got %v", m)
+	}
+	if v := Variance([]float64{1, 2, 3, 4}); math.Abs(v-5.0/3) > 1e-12 {
+		t.Errorf("Expected sample variance 5/3, got %v", v)
+	}
+	if b := Beta(lev, bench); math.Abs(b-2) > 1e-12 {
+		t.Errorf("Expected beta 2, got %v", b)
+	}
+	if b := Beta(lev, []float64{0.01, 0.01, 0.01, 0.01}); b != 0 {
+		t.Errorf("Expected zero beta against a constant benchmark, got %v", b)
+	}
+	if te := TrackingError(lev, bench, 252); math.Abs(te-math.Sqrt(Variance(bench)*252)) > 1e-12 {
+		t.Errorf("Expected tracking error of the excess returns, got %v", te)
+	}
+	if TrackingError(bench, bench, 252) != 0 {
+		t.Error("Expected no tracking error against itself")
+	}
+}
+
+// TestCorrelationAndBeta tests series are aligned by trading day before
+// correlations and betas are computed
+func TestCorrelationAndBeta(t *testing.T) {
+	chart := func(hour int, closes ...float64) *ChartData {
+		c := &ChartData{Meta: &ChartMeta{DataGranularity: "1d"}}
+		for i, close := range closes {
+			c.Bars = append(c.Bars, Bar{Timestamp: time.Date(2024, 1, i+1, hour, 0, 0, 0, time.UTC), Close: close})
+		}
+		return c
+	}
+	index := chart(14, 100, 110, 99, 108.9, 100)
+	series := map[string]*ChartData{
+		"^GSPC": index,
+		// Twice the index's returns, stamped at another hour
+		"LEV": chart(8, 100, 120, 96, 115.2, 115.2*(1+2*(100/108.9-1))),
+		// The opposite of the index's returns, with a missing day
+		"INV": chart(14, 100, 90, 99, 89.1, 0),
+	}
+
+	m, err := Correlation(series)
+	if err != nil {
+		t.Fatalf("Correlation failed: %v", err)
+	}
+	if !slices.Equal(m.Symbols, []string{"INV", "LEV", "^GSPC"}) || m.Observations != 3 {
+		t.Errorf("Unexpected matrix %+v", m)
+	}
+	if v, _ := m.Get("^GSPC", "LEV"); math.Abs(v-1) > 1e-9 {
+		t.Errorf("Expected LEV to track the index, got %v", v)
+	}
+	if v, _ := m.Get("INV", "^GSPC"); v > -0.9 {
+		t.Errorf("Expected INV to move against the index, got %v", v)
+	}
+	if _, ok := m.Get("INV", "AAPL"); ok {
+		t.Error("Expected no correlation for a missing symbol")
+	}
+
+	betas, err := Betas(series, "")
+	if err != nil {
+		t.Fatalf("Betas failed: %v", err)
+	}
+	if len(betas) != 2 || math.Abs(betas["LEV"]-2) > 1e-9 {
+		t.Errorf("Expected a beta of 2 for LEV, got %v", betas)
+	}
+	if _, err := Betas(series, "SPY"); err == nil {
+		t.Error("Expected an error for a missing benchmark")
+	}
+}
+
+// TestCandlePatterns tests pattern detection on hand-built candles
+func TestCandlePatterns(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	ohlc := [][4]float64{
+		{110, 111, 104, 105}, // 0 decline
+		{105, 106, 99, 100},  // 1 decline
+		{100, 101, 94, 95},   // 2 long bearish
+		{93, 93.5, 86, 93.2}, // 3 hammer gapping down, the star
+		{94, 102, 93.8, 101}, // 4 bullish, closes above bar 2's midpoint
+		{100.5, 106, 100.2, 105.5},
+		{104, 110, 103.8, 109.8}, // 6 third white soldier
+		{0, 0, 0, 0},             // 7 missing
+	}
+	bars := make([]Bar, len(ohlc))
+	for i, c := range ohlc {
+		bars[i] = Bar{Timestamp: day.AddDate(0, 0, i), Open: c[0], High: c[1], Low: c[2], Close: c[3]}
+	}
+	chart := &ChartData{Bars: bars}
+
+	type key struct {
+		pattern CandlePattern
+		index   int
+	}
+	found := map[key]PatternDirection{}
+	for _, e := range chart.Patterns() {
+		if !e.Date.Equal(bars[e.Index].Timestamp) {
+			t.Errorf("%s at %d dated %v", e.Pattern, e.Index, e.Date)
+		}
+		found[key{e.Pattern, e.Index}] = e.Direction
+	}
+	want := map[key]PatternDirection{
+		{Hammer, 3}:             Bullish,
+		{Doji, 3}:               Neutral,
+		{MorningStar, 4}:        Bullish,
+		{ThreeWhiteSoldiers, 6}: Bullish,
+	}
+	for k, dir := range want {
+		if found[k] != dir {
+			t.Errorf("%s at %d = %q, want %q", k.pattern, k.index, found[k], dir)
+		}
+	}
+	if len(found) != len(want) {
+		t.Errorf("found %v, want %v", found, want)
+	}
+
+	// The mirror image of a morning star
+	evening := []Bar{
+		{Open: 100, High: 106, Low: 99, Close: 105},
+		{Open: 106, High: 107, Low: 105.5, Close: 106.1},
+		{Open: 105, High: 105.2, Low: 98, Close: 99},
+	}
+	events := DetectPatterns(evening)
+	if !slices.ContainsFunc(events, func(e PatternEvent) bool { return e.Pattern == EveningStar && e.Direction == Bearish }) {
+		t.Errorf("evening star not found in %v", events)
+	}
+	if !slices.ContainsFunc(events, func(e PatternEvent) bool { return e.Pattern == Doji && e.Index == 1 }) {
+		t.Errorf("doji star not found in %v", events)
+	}
+
+	engulfing := []Bar{
+		{Open: 50, High: 52, Low: 49.5, Close: 51},
+		{Open: 51.5, High: 52, Low: 49, Close: 49.5},
+	}
+	if events := DetectPatterns(engulfing); len(events) != 1 || events[0].Pattern != BearishEngulfing || events[0].Direction != Bearish {
+		t.Errorf("engulfing = %v, want one bearish engulfing", events)
+	}
+}
+
+// TestJSONLWriter tests that each record is one line with type, symbol,
+// and time
+func TestJSONLWriter(t *testing.T) {
+	var buf strings.Builder
+	w := NewJSONLWriter(&buf)
+	ts := time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC)
+
+	res := &DownloadResult{Data: map[string]*ChartData{
+		"MSFT": {Interval: Interval1d, Bars: []Bar{{Timestamp: ts, Close: 405, Volume: 10}}},
+		"AAPL": {Symbol: "AAPL", Meta: &ChartMeta{DataGranularity: "5m"}, Bars: []Bar{{Timestamp: ts.In(time.FixedZone("EST", -5*3600)), Close: 180, Session: RegularMarket}}},
+	}}
+	if err := w.WriteDownload(res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.WriteQuotes(Quote{Symbol: "AAPL", RegularMarketPrice: 181, RegularMarketTime: ts.Unix()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.WriteStreamMessages(StreamMessage{ID: "BTC-USD", Price: 60000, Time: ts.UnixMilli()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := []struct{ typ, symbol, interval string }{
+		{RecordBar, "AAPL", "5m"},
+		{RecordBar, "MSFT", "1d"},
+		{RecordQuote, "AAPL", ""},
+		{RecordTick, "BTC-USD", ""},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d lines, got %d:\n%s", len(want), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("line %d: %v", i, err)
+		}
+		if rec["type"] != want[i].typ || rec["symbol"] != want[i].symbol || rec["time"] != "2024-03-01T14:30:00Z" {
+			t.Errorf("line %d: unexpected record %s", i, line)
+		}
+		if want[i].interval != "" && rec["interval"] != want[i].interval {
+			t.Errorf("line %d: expected interval %s, got %v", i, want[i].interval, rec["interval"])
+		}
+	}
+	if !strings.Contains(lines[0], `"session":"regular"`) || !strings.Contains(lines[2], `"regularMarketPrice":181`) || !strings.Contains(lines[3], `"price":60000`) {
+		t.Errorf("Expected record fields to be kept:\n%s", buf.String())
+	}
+}
+
+// TestPanel tests aligning charts on a common index with fills and joins
+func TestPanel(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	london, _ := time.LoadLocation("Europe/London")
+	day := func(loc *time.Location, d, hour int) time.Time { return time.Date(2024, 3, d, hour, 30, 0, 0, loc) }
+
+	res := &DownloadResult{Data: map[string]*ChartData{
+		"AAPL": {Interval: Interval1d, Bars: []Bar{
+			{Timestamp: day(ny, 4, 9), Open: 1, High: 2, Low: 1, Close: 2, AdjClose: 2, Volume: 10},
+			{Timestamp: day(ny, 5, 9), Open: 2, High: 3, Low: 2, Close: 3, AdjClose: 3, Volume: 20},
+			{Timestamp: day(ny, 6, 9), Open: 3, High: 4, Low: 3, Close: 4, AdjClose: 4, Volume: 30},
+		}},
+		// Opens at another time of day and misses the 5th
+		"VOD.L": {Meta: &ChartMeta{DataGranularity: "1d"}, Bars: []Bar{
+			{Timestamp: day(london, 4, 8), Open: 70, High: 71, Low: 69, Close: 70, AdjClose: 70, Volume: 5},
+			{Timestamp: day(london, 6, 8), Open: 72, High: 73, Low: 71, Close: 72, AdjClose: 72, Volume: 6},
+		}},
+	}}
+
+	p := res.Panel(PanelOptions{})
+	if !slices.Equal(p.Symbols, []string{"AAPL", "VOD.L"}) || p.Len() != 3 {
+		t.Fatalf("Unexpected panel shape: %v x %d", p.Symbols, p.Len())
+	}
+	if !p.Index[0].Equal(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the index to be trading dates, got %v", p.Index[0])
+	}
+	vod := p.Column("VOD.L", FieldClose)
+	if vod[0] != 70 || !math.IsNaN(vod[1]) || vod[2] != 72 {
+		t.Errorf("Unexpected unfilled closes %v", vod)
+	}
+	if p.Column("MSFT", FieldClose) != nil {
+		t.Error("Expected no column for an unknown symbol")
+	}
+
+	rows, cols, data := p.Matrix(FieldClose)
+	if rows != 3 || cols != 2 || data[0] != 2 || data[1] != 70 || data[4] != 4 {
+		t.Errorf("Unexpected matrix %dx%d %v", rows, cols, data)
+	}
+	if v := p.Values(FieldVolume); v[2][0] != 30 || v[2][1] != 6 {
+		t.Errorf("Unexpected volumes %v", v)
+	}
+
+	filled := NewPanel(res.Data, PanelOptions{Fill: FillForward})
+	if c := filled.Column("VOD.L", FieldClose); c[1] != 70 {
+		t.Errorf("Expected the gap to carry 70 forward, got %v", c)
+	}
+	if v := filled.Column("VOD.L", FieldVolume); v[1] != 0 {
+		t.Errorf("Expected zero volume on a filled row, got %v", v)
+	}
+
+	inner := NewPanel(res.Data, PanelOptions{Join: JoinInner})
+	if inner.Len() != 2 || !inner.Index[1].Equal(time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected only the shared days, got %v", inner.Index)
+	}
+
+	sliced := p.Slice(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), time.Time{})
+	if sliced.Len() != 2 || !slices.Equal(sliced.Column("AAPL", FieldClose), []float64{3, 4}) || p.Len() != 3 {
+		t.Errorf("Expected the rows from the 5th, got %v", sliced.Index)
+	}
+
+	var buf strings.Builder
+	if err := p.WriteCSV(&buf, FieldClose); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "time,AAPL,VOD.L\n2024-03-04T00:00:00Z,2,70\n2024-03-05T00:00:00Z,3,\n2024-03-06T00:00:00Z,4,72\n"
+	if buf.String() != want {
+		t.Errorf("Expected CSV:\n%s\ngot:\n%s", want, buf.String())
 	}
 }
 
@@ -458,3 +3614,75 @@ func TestBlackScholesPriceEdgeCases(t *testing.T) {
 		t.Errorf("Expected ITM put price 10, got %f", price)
 	}
 }
+
+// TestHistoryParamsValidate tests period, interval, and date range validation
+func TestHistoryParamsValidate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		params  HistoryParams
+		wantErr error
+	}{
+		{"empty", HistoryParams{}, nil},
+		{"valid", HistoryParams{Period: Period1y, Interval: Interval1d}, nil},
+		{"bad interval", HistoryParams{Interval: "7m"}, ErrInvalidInterval},
+		{"bad period", HistoryParams{Period: "3w"}, ErrInvalidPeriod},
+		{"valid range", HistoryParams{Start: now.AddDate(0, -1, 0), End: now}, nil},
+		{"reversed range", HistoryParams{Start: now, End: now.AddDate(0, -1, 0)}, ErrInvalidPeriod},
+	}
+
+	for _, tt := range tests {
+		err := tt.params.Validate()
+		if tt.wantErr == nil && err != nil {
+			t.Errorf("%s: expected no error, got %v", tt.name, err)
+		}
+		if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+// TestParseScreenQuery tests the compact screener query syntax
+func TestParseScreenQuery(t *testing.T) {
+	query, err := ParseScreenQuery("region=us and percentchange > 5, intradaymarketcap=1e9..5e9")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query["operator"] != "and" {
+		t.Errorf("Expected and operator, got %v", query["operator"])
+	}
+
+	operands := query["operands"].([]map[string]interface{})
+	if len(operands) != 3 {
+		t.Fatalf("Expected 3 operands, got %d", len(operands))
+	}
+
+	want := []struct {
+		operator string
+		args     []interface{}
+	}{
+		{"eq", []interface{}{"region", "us"}},
+		{"gt", []interface{}{"percentchange", 5.0}},
+		{"btwn", []interface{}{"intradaymarketcap", 1e9, 5e9}},
+	}
+	for i, w := range want {
+		if operands[i]["operator"] != w.operator {
+			t.Errorf("operand %d: expected %s, got %v", i, w.operator, operands[i]["operator"])
+		}
+		args := operands[i]["operands"].([]interface{})
+		if len(args) != len(w.args) {
+			t.Fatalf("operand %d: expected %d args, got %d", i, len(w.args), len(args))
+		}
+		for j := range args {
+			if args[j] != w.args[j] {
+				t.Errorf("operand %d arg %d: expected %v, got %v", i, j, w.args[j], args[j])
+			}
+		}
+	}
+
+	for _, bad := range []string{"", "percentchange", ">5", "region= and x>1"} {
+		if _, err := ParseScreenQuery(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package yfinancetest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// Record returns middleware that saves each successful response into dir,
+// named by endpoint and symbol, for LoadDir to serve later. Add it to a
+// live client to capture fixtures:
+//
+//	client, _ := yfinance.NewClient(yfinance.WithMiddleware(yfinancetest.Record("testdata")))
+//
+// Authentication responses are not saved, and a later response for the
+// same endpoint and symbol replaces an earlier one. Errors writing a
+// fixture fail the request.
+func Record(dir string) yfinance.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return yfinance.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil || resp.StatusCode != http.StatusOK {
+				return resp, err
+			}
+			endpoint := yfinance.EndpointName(req.URL)
+			if endpoint == "cookie" || endpoint == "crumb" {
+				return resp, nil
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return nil, fmt.Errorf("yfinancetest: %w", err)
+			}
+			path := filepath.Join(dir, fixtureName(endpoint, Symbol(req.URL)))
+			if err := os.WriteFile(path, body, 0o644); err != nil { //nolint:gosec // G306: fixtures are meant to be committed
+				return nil, fmt.Errorf("yfinancetest: %w", err)
+			}
+			return resp, nil
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Package yfinancetest provides a fake transport that serves canned Yahoo
+// Finance responses, so code using the yfinance package can be tested
+// without the network.
+//
+// A Transport answers quote, chart, quoteSummary, options, and screener
+// requests with built-in fixtures describing AAPL, and authenticates with a
+// fixed crumb:
+//
+//	tr := yfinancetest.NewTransport()
+//	client, _ := tr.Client()
+//	ticker, _ := yfinance.NewTicker("AAPL", yfinance.WithClient(client))
+//	quote, _ := ticker.Quote(ctx)
+//
+// Handle replaces a response for an endpoint or a single symbol, and Record
+// captures live responses into a directory that LoadDir serves later.
+package yfinancetest
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// Crumb is the crumb a Transport hands out when a client authenticates
+const Crumb = "yfinancetest-crumb"
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture is a canned response
+type Fixture struct {
+	Status int         // HTTP status; 0 means 200
+	Header http.Header // Extra response headers
+	Body   []byte
+}
+
+// Transport is an http.RoundTripper that serves fixtures by endpoint name,
+// as given by yfinance.EndpointName, and symbol. It is safe for concurrent
+// use.
+type Transport struct {
+	mu       sync.Mutex
+	fixtures map[string]Fixture
+	requests []*http.Request
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// NewTransport creates a transport serving the built-in fixtures for the
+// quote, chart, quoteSummary, options, and screener endpoints. They describe
+// AAPL but are served for any symbol until replaced with Handle.
+func NewTransport() *Transport {
+	t := &Transport{fixtures: make(map[string]Fixture)}
+	entries, _ := fixtures.ReadDir("fixtures")
+	for _, e := range entries {
+		body, _ := fixtures.ReadFile("fixtures/" + e.Name())
+		t.Handle(strings.TrimSuffix(e.Name(), ".json"), "", Fixture{Body: body})
+	}
+	return t
+}
+
+// Handle serves f for requests to endpoint, such as "chart" or "quote",
+// for symbol. An empty symbol serves requests for any symbol without a
+// fixture of its own.
+func (t *Transport) Handle(endpoint, symbol string, f Fixture) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fixtures[fixtureKey(endpoint, symbol)] = f
+}
+
+// HandleJSON serves body with status 200 for requests to endpoint for symbol
+func (t *Transport) HandleJSON(endpoint, symbol, body string) {
+	t.Handle(endpoint, symbol, Fixture{Body: []byte(body)})
+}
+
+// LoadDir serves every fixture in dir, as written by Record
+func (t *Transport) LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		endpoint, symbol, err := parseFixtureName(filepath.Base(path))
+		if err != nil {
+			return err
+		}
+		t.Handle(endpoint, symbol, Fixture{Body: body})
+	}
+	return nil
+}
+
+// Requests returns the requests served so far, including authentication
+func (t *Transport) Requests() []*http.Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*http.Request(nil), t.requests...)
+}
+
+// Client creates a yfinance client that sends its requests to t and does
+// not retry. opts are applied after the test defaults.
+func (t *Transport) Client(opts ...yfinance.ClientOption) (*yfinance.Client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	return yfinance.NewClient(append([]yfinance.ClientOption{
+		yfinance.WithHTTPClient(&http.Client{Transport: t, Jar: jar}),
+		yfinance.WithRetry(yfinance.RetryConfig{}),
+	}, opts...)...)
+}
+
+// RoundTrip implements http.RoundTripper. Requests without a fixture get a
+// 404 response.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := yfinance.EndpointName(req.URL)
+
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	f, ok := t.fixtures[fixtureKey(endpoint, Symbol(req.URL))]
+	if !ok {
+		f, ok = t.fixtures[fixtureKey(endpoint, "")]
+	}
+	t.mu.Unlock()
+
+	switch {
+	case ok:
+	case endpoint == "cookie":
+		f = Fixture{}
+	case endpoint == "crumb":
+		f = Fixture{Body: []byte(Crumb)}
+	default:
+		f = Fixture{
+			Status: http.StatusNotFound,
+			Body:   []byte(fmt.Sprintf(`{"code":"Not Found","description":"yfinancetest: no fixture for %s"}`, endpoint)),
+		}
+	}
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	return f.response(req), nil
+}
+
+// response builds the HTTP response serving f
+func (f Fixture) response(req *http.Request) *http.Response {
+	status := f.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	for k, v := range f.Header {
+		header[k] = v
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(string(f.Body))),
+		ContentLength: int64(len(f.Body)),
+		Request:       req,
+	}
+}
+
+// Symbol returns the symbol a request URL is for: the last path segment
+// for per-symbol endpoints such as chart, or the symbols parameter of a
+// quote request. It is empty for other requests.
+func Symbol(u *url.URL) string {
+	switch yfinance.EndpointName(u) {
+	case "chart", "quoteSummary", "options", "fundamentals":
+		if i := strings.LastIndex(u.Path, "/"); i >= 0 {
+			s, err := url.PathUnescape(u.Path[i+1:])
+			if err == nil {
+				return s
+			}
+		}
+	case "quote":
+		return u.Query().Get("symbols")
+	}
+	return ""
+}
+
+// fixtureKey identifies the fixture for endpoint and symbol
+func fixtureKey(endpoint, symbol string) string {
+	return endpoint + " " + strings.ToUpper(symbol)
+}
+
+// fixtureName is the file name of the fixture for endpoint and symbol
+func fixtureName(endpoint, symbol string) string {
+	if symbol == "" {
+		return endpoint + ".json"
+	}
+	return endpoint + "_" + url.PathEscape(strings.ToUpper(symbol)) + ".json"
+}
+
+// parseFixtureName is the inverse of fixtureName
+func parseFixtureName(name string) (endpoint, symbol string, err error) {
+	name = strings.TrimSuffix(name, ".json")
+	endpoint, escaped, found := strings.Cut(name, "_")
+	if !found {
+		return endpoint, "", nil
+	}
+	symbol, err = url.PathUnescape(escaped)
+	if err != nil {
+		return "", "", fmt.Errorf("yfinancetest: invalid fixture name %q: %w", name, err)
+	}
+	return endpoint, symbol, nil
+}
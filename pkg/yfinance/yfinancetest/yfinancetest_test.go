@@ -0,0 +1,96 @@
+package yfinancetest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/amjadjibon/gotick/pkg/yfinance"
+)
+
+// TestTransport tests that the built-in fixtures parse and overrides apply
+func TestTransport(t *testing.T) {
+	ctx := context.Background()
+	tr := NewTransport()
+	tr.HandleJSON("quote", "MSFT", `{"quoteResponse":{"result":[{"symbol":"MSFT","regularMarketPrice":374.58}]}}`)
+
+	client, err := tr.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticker, err := yfinance.NewTicker("AAPL", yfinance.WithClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quote, err := ticker.Quote(ctx)
+	if err != nil || quote.Symbol != "AAPL" || quote.RegularMarketPrice == 0 {
+		t.Errorf("Expected AAPL quote, got %+v, %v", quote, err)
+	}
+	history, err := ticker.History(ctx, yfinance.HistoryParams{Period: yfinance.Period5d})
+	if err != nil || len(history.Bars) != 5 {
+		t.Errorf("Expected 5 bars, got %v", err)
+	}
+	info, err := ticker.Info(ctx)
+	if err != nil || info.Price == nil || info.AssetProfile == nil {
+		t.Errorf("Expected price and profile, got %+v, %v", info, err)
+	}
+	chain, err := ticker.Options(ctx, "")
+	if err != nil || len(chain.Calls) != 2 || len(chain.Puts) != 2 {
+		t.Errorf("Expected 2 calls and 2 puts, got %v", err)
+	}
+	screen, err := yfinance.ScreenWithClient(ctx, client, yfinance.ScreenCriteria{})
+	if err != nil || len(screen.Quotes) != 2 {
+		t.Errorf("Expected 2 screener quotes, got %v", err)
+	}
+
+	msft, _ := yfinance.NewTicker("MSFT", yfinance.WithClient(client))
+	if quote, err := msft.Quote(ctx); err != nil || quote.Symbol != "MSFT" {
+		t.Errorf("Expected MSFT fixture, got %+v, %v", quote, err)
+	}
+
+	if _, err := yfinance.SearchWithClient(ctx, client, "apple"); !errors.Is(err, yfinance.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound without a fixture, got %v", err)
+	}
+	if n := len(tr.Requests()); n != 9 {
+		t.Errorf("Expected 9 requests including authentication, got %d", n)
+	}
+}
+
+// TestRecord tests that recorded responses are served by LoadDir
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	live := NewTransport()
+	live.HandleJSON("quote", "BRK-B", `{"quoteResponse":{"result":[{"symbol":"BRK-B","regularMarketPrice":357.57}]}}`)
+	client, err := live.Client(yfinance.WithMiddleware(Record(dir)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticker, _ := yfinance.NewTicker("BRK-B", yfinance.WithClient(client))
+	if _, err := ticker.Quote(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "quote_BRK-B.json")); err != nil {
+		t.Fatalf("Expected recorded fixture: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "crumb.json")); err == nil {
+		t.Error("Expected authentication responses not to be recorded")
+	}
+
+	replay := &Transport{fixtures: make(map[string]Fixture)}
+	if err := replay.LoadDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	client, err = replay.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticker, _ = yfinance.NewTicker("BRK-B", yfinance.WithClient(client))
+	if quote, err := ticker.Quote(ctx); err != nil || quote.RegularMarketPrice != 357.57 {
+		t.Errorf("Expected replayed quote, got %+v, %v", quote, err)
+	}
+}